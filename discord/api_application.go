@@ -0,0 +1,49 @@
+package discord
+
+import (
+	"context"
+)
+
+// Application command permission types.
+const (
+	CommandPermissionRole    = 1
+	CommandPermissionUser    = 2
+	CommandPermissionChannel = 3
+)
+
+// ApplicationCommandPermission allows or denies a command for a role, user or channel.
+type ApplicationCommandPermission struct {
+	ID         string `json:"id"`
+	Type       int    `json:"type"`
+	Permission bool   `json:"permission"`
+}
+
+// GuildApplicationCommandPermissions are the permission overrides of one command
+// in a guild. ID is the command ID, or the application ID for the defaults of
+// all its commands.
+type GuildApplicationCommandPermissions struct {
+	ID            string                         `json:"id"`
+	ApplicationID string                         `json:"application_id"`
+	GuildID       string                         `json:"guild_id"`
+	Permissions   []ApplicationCommandPermission `json:"permissions"`
+}
+
+type editCommandPermissionsParams struct {
+	Permissions []ApplicationCommandPermission `json:"permissions"`
+}
+
+func commandPermissionsPath(applicationID, guildID, commandID string) string {
+	return "/applications/" + applicationID + "/guilds/" + guildID + "/commands/" + commandID + "/permissions"
+}
+
+func (c *RestClient) GetApplicationCommandPermissions(ctx context.Context, applicationID, guildID, commandID string) (*GuildApplicationCommandPermissions, error) {
+	return call[*GuildApplicationCommandPermissions](ctx, c, "GET", commandPermissionsPath(applicationID, guildID, commandID), nil, nil, "")
+}
+
+// EditApplicationCommandPermissions replaces the permission overrides of a
+// command. Discord only accepts the Bearer token of a user who can manage the
+// guild and its roles, granted the applications.commands.permissions.update
+// scope, so it needs BearerToken; client credentials tokens are refused.
+func (c *RestClient) EditApplicationCommandPermissions(ctx context.Context, applicationID, guildID, commandID string, permissions []ApplicationCommandPermission) (*GuildApplicationCommandPermissions, error) {
+	return call[*GuildApplicationCommandPermissions](WithAuth(ctx, AuthBearer), c, "PUT", commandPermissionsPath(applicationID, guildID, commandID), nil, &editCommandPermissionsParams{Permissions: permissions}, "")
+}
@@ -0,0 +1,91 @@
+package discord
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// AutoModRule is a guild auto moderation rule. The shape of TriggerMetadata depends
+// on TriggerType.
+type AutoModRule struct {
+	ID              string                  `json:"id"`
+	GuildID         string                  `json:"guild_id"`
+	Name            string                  `json:"name"`
+	CreatorID       string                  `json:"creator_id"`
+	EventType       int                     `json:"event_type"`
+	TriggerType     int                     `json:"trigger_type"`
+	TriggerMetadata *AutoModTriggerMetadata `json:"trigger_metadata"`
+	Actions         []AutoModAction         `json:"actions"`
+	Enabled         bool                    `json:"enabled"`
+	ExemptRoles     []string                `json:"exempt_roles"`
+	ExemptChannels  []string                `json:"exempt_channels"`
+}
+
+type AutoModTriggerMetadata struct {
+	KeywordFilter                []string `json:"keyword_filter,omitempty"`
+	RegexPatterns                []string `json:"regex_patterns,omitempty"`
+	Presets                      []int    `json:"presets,omitempty"`
+	AllowList                    []string `json:"allow_list,omitempty"`
+	MentionTotalLimit            *int     `json:"mention_total_limit,omitempty"`
+	MentionRaidProtectionEnabled *bool    `json:"mention_raid_protection_enabled,omitempty"`
+}
+
+type AutoModAction struct {
+	Type     int                    `json:"type"`
+	Metadata *AutoModActionMetadata `json:"metadata,omitempty"`
+}
+
+type AutoModActionMetadata struct {
+	ChannelID       string `json:"channel_id,omitempty"`
+	DurationSeconds int    `json:"duration_seconds,omitempty"`
+	CustomMessage   string `json:"custom_message,omitempty"`
+}
+
+// AutoModRuleParams is the body of POST /guilds/{guild.id}/auto-moderation/rules and
+// PATCH /guilds/{guild.id}/auto-moderation/rules/{rule.id}. TriggerType can only be
+// set on create.
+type AutoModRuleParams struct {
+	Name            *string                 `json:"name,omitempty"`
+	EventType       *int                    `json:"event_type,omitempty"`
+	TriggerType     *int                    `json:"trigger_type,omitempty"`
+	TriggerMetadata *AutoModTriggerMetadata `json:"trigger_metadata,omitempty"`
+	Actions         *[]AutoModAction        `json:"actions,omitempty"`
+	Enabled         *bool                   `json:"enabled,omitempty"`
+	ExemptRoles     *[]string               `json:"exempt_roles,omitempty"`
+	ExemptChannels  *[]string               `json:"exempt_channels,omitempty"`
+}
+
+func (c *RestClient) ListAutoModRules(ctx context.Context, guildID string) ([]AutoModRule, error) {
+	return call[[]AutoModRule](ctx, c, "GET", "/guilds/"+guildID+"/auto-moderation/rules", nil, nil, "")
+}
+
+func (c *RestClient) GetAutoModRule(ctx context.Context, guildID, ruleID string) (*AutoModRule, error) {
+	return call[*AutoModRule](ctx, c, "GET", "/guilds/"+guildID+"/auto-moderation/rules/"+ruleID, nil, nil, "")
+}
+
+func (c *RestClient) CreateAutoModRule(ctx context.Context, guildID string, params *AutoModRuleParams, reason string) (*AutoModRule, error) {
+	return call[*AutoModRule](ctx, c, "POST", "/guilds/"+guildID+"/auto-moderation/rules", nil, params, reason)
+}
+
+func (c *RestClient) ModifyAutoModRule(ctx context.Context, guildID, ruleID string, params *AutoModRuleParams, reason string) (*AutoModRule, error) {
+	return call[*AutoModRule](ctx, c, "PATCH", "/guilds/"+guildID+"/auto-moderation/rules/"+ruleID, nil, params, reason)
+}
+
+// GetAutoModRuleJSON, CreateAutoModRuleJSON and ModifyAutoModRuleJSON are the rule
+// methods with the rule as raw JSON, for discord_automod_rule, which passes
+// payload_json through.
+func (c *RestClient) GetAutoModRuleJSON(ctx context.Context, guildID, ruleID string) (json.RawMessage, error) {
+	return call[json.RawMessage](ctx, c, "GET", "/guilds/"+guildID+"/auto-moderation/rules/"+ruleID, nil, nil, "")
+}
+
+func (c *RestClient) CreateAutoModRuleJSON(ctx context.Context, guildID string, params json.RawMessage, reason string) (json.RawMessage, error) {
+	return call[json.RawMessage](ctx, c, "POST", "/guilds/"+guildID+"/auto-moderation/rules", nil, params, reason)
+}
+
+func (c *RestClient) ModifyAutoModRuleJSON(ctx context.Context, guildID, ruleID string, params json.RawMessage, reason string) (json.RawMessage, error) {
+	return call[json.RawMessage](ctx, c, "PATCH", "/guilds/"+guildID+"/auto-moderation/rules/"+ruleID, nil, params, reason)
+}
+
+func (c *RestClient) DeleteAutoModRule(ctx context.Context, guildID, ruleID, reason string) error {
+	return c.DoJSONWithReason(ctx, "DELETE", "/guilds/"+guildID+"/auto-moderation/rules/"+ruleID, nil, nil, nil, reason)
+}
@@ -0,0 +1,164 @@
+package discord
+
+import (
+	"context"
+)
+
+// Channel is a guild channel or thread.
+type Channel struct {
+	ID                            string                `json:"id"`
+	Type                          uint                  `json:"type"`
+	GuildID                       string                `json:"guild_id"`
+	Position                      int                   `json:"position"`
+	PermissionOverwrites          []PermissionOverwrite `json:"permission_overwrites"`
+	Name                          string                `json:"name"`
+	Topic                         string                `json:"topic"`
+	NSFW                          bool                  `json:"nsfw"`
+	LastMessageID                 string                `json:"last_message_id"`
+	Bitrate                       int                   `json:"bitrate"`
+	UserLimit                     int                   `json:"user_limit"`
+	RateLimitPerUser              int                   `json:"rate_limit_per_user"`
+	OwnerID                       string                `json:"owner_id"`
+	ParentID                      string                `json:"parent_id"`
+	LastPinTimestamp              string                `json:"last_pin_timestamp"`
+	RTCRegion                     string                `json:"rtc_region"`
+	VideoQualityMode              int                   `json:"video_quality_mode"`
+	MessageCount                  int                   `json:"message_count"`
+	MemberCount                   int                   `json:"member_count"`
+	ThreadMetadata                *ThreadMetadata       `json:"thread_metadata"`
+	DefaultAutoArchiveDuration    int                   `json:"default_auto_archive_duration"`
+	Permissions                   string                `json:"permissions"`
+	Flags                         int                   `json:"flags"`
+	AvailableTags                 []ForumTag            `json:"available_tags"`
+	AppliedTags                   []string              `json:"applied_tags"`
+	DefaultReactionEmoji          *DefaultReaction      `json:"default_reaction_emoji"`
+	DefaultThreadRateLimitPerUser int                   `json:"default_thread_rate_limit_per_user"`
+	DefaultSortOrder              int                   `json:"default_sort_order"`
+	DefaultForumLayout            int                   `json:"default_forum_layout"`
+}
+
+// PermissionOverwrite is a role (type 0) or member (type 1) overwrite on a channel.
+// Allow and Deny are permission bit sets as decimal strings.
+type PermissionOverwrite struct {
+	ID    string `json:"id"`
+	Type  int    `json:"type"`
+	Allow string `json:"allow"`
+	Deny  string `json:"deny"`
+}
+
+// ForumTag is a tag that can be applied to threads in a forum or media channel.
+// Set at most one of EmojiID and EmojiName. Omit ID to create a new tag.
+type ForumTag struct {
+	ID        string `json:"id,omitempty"`
+	Name      string `json:"name"`
+	Moderated bool   `json:"moderated"`
+	EmojiID   string `json:"emoji_id,omitempty"`
+	EmojiName string `json:"emoji_name,omitempty"`
+}
+
+// DefaultReaction is the emoji shown on new posts in a forum or media channel.
+type DefaultReaction struct {
+	EmojiID   string `json:"emoji_id,omitempty"`
+	EmojiName string `json:"emoji_name,omitempty"`
+}
+
+// CreateChannelParams is the body of POST /guilds/{guild.id}/channels.
+type CreateChannelParams struct {
+	Name                          string                `json:"name"`
+	Type                          uint                  `json:"type"`
+	Topic                         *string               `json:"topic,omitempty"`
+	Bitrate                       *int                  `json:"bitrate,omitempty"`
+	UserLimit                     *int                  `json:"user_limit,omitempty"`
+	RateLimitPerUser              *int                  `json:"rate_limit_per_user,omitempty"`
+	Position                      *int                  `json:"position,omitempty"`
+	PermissionOverwrites          []PermissionOverwrite `json:"permission_overwrites,omitempty"`
+	ParentID                      *string               `json:"parent_id,omitempty"`
+	NSFW                          *bool                 `json:"nsfw,omitempty"`
+	RTCRegion                     *string               `json:"rtc_region,omitempty"`
+	VideoQualityMode              *int                  `json:"video_quality_mode,omitempty"`
+	DefaultAutoArchiveDuration    *int                  `json:"default_auto_archive_duration,omitempty"`
+	DefaultReactionEmoji          *DefaultReaction      `json:"default_reaction_emoji,omitempty"`
+	AvailableTags                 []ForumTag            `json:"available_tags,omitempty"`
+	DefaultSortOrder              *int                  `json:"default_sort_order,omitempty"`
+	DefaultForumLayout            *int                  `json:"default_forum_layout,omitempty"`
+	DefaultThreadRateLimitPerUser *int                  `json:"default_thread_rate_limit_per_user,omitempty"`
+}
+
+// ModifyChannelParams is the body of PATCH /channels/{channel.id}. Only set fields are
+// sent. The thread-only fields (Archived, AutoArchiveDuration, Locked, Invitable and
+// AppliedTags) apply when the channel is a thread.
+type ModifyChannelParams struct {
+	Name                          *string                   `json:"name,omitempty"`
+	Position                      *int                      `json:"position,omitempty"`
+	Topic                         *string                   `json:"topic,omitempty"`
+	NSFW                          *bool                     `json:"nsfw,omitempty"`
+	RateLimitPerUser              *int                      `json:"rate_limit_per_user,omitempty"`
+	Bitrate                       *int                      `json:"bitrate,omitempty"`
+	UserLimit                     *int                      `json:"user_limit,omitempty"`
+	PermissionOverwrites          *[]PermissionOverwrite    `json:"permission_overwrites,omitempty"`
+	ParentID                      Nullable[string]          `json:"parent_id,omitzero"`
+	RTCRegion                     Nullable[string]          `json:"rtc_region,omitzero"`
+	VideoQualityMode              *int                      `json:"video_quality_mode,omitempty"`
+	DefaultAutoArchiveDuration    *int                      `json:"default_auto_archive_duration,omitempty"`
+	Flags                         *int                      `json:"flags,omitempty"`
+	AvailableTags                 *[]ForumTag               `json:"available_tags,omitempty"`
+	DefaultReactionEmoji          Nullable[DefaultReaction] `json:"default_reaction_emoji,omitzero"`
+	DefaultThreadRateLimitPerUser *int                      `json:"default_thread_rate_limit_per_user,omitempty"`
+	DefaultSortOrder              *int                      `json:"default_sort_order,omitempty"`
+	DefaultForumLayout            *int                      `json:"default_forum_layout,omitempty"`
+
+	Archived            *bool     `json:"archived,omitempty"`
+	AutoArchiveDuration *int      `json:"auto_archive_duration,omitempty"`
+	Locked              *bool     `json:"locked,omitempty"`
+	Invitable           *bool     `json:"invitable,omitempty"`
+	AppliedTags         *[]string `json:"applied_tags,omitempty"`
+}
+
+// ChannelPosition is one entry of PATCH /guilds/{guild.id}/channels.
+type ChannelPosition struct {
+	ID              string           `json:"id"`
+	Position        *int             `json:"position,omitempty"`
+	LockPermissions *bool            `json:"lock_permissions,omitempty"`
+	ParentID        Nullable[string] `json:"parent_id,omitzero"`
+}
+
+// EditChannelPermissionsParams is the body of PUT /channels/{channel.id}/permissions/{overwrite.id}.
+type EditChannelPermissionsParams struct {
+	Allow string `json:"allow"`
+	Deny  string `json:"deny"`
+	Type  int    `json:"type"`
+}
+
+func (c *RestClient) GetChannel(ctx context.Context, channelID string) (*Channel, error) {
+	return call[*Channel](ctx, c, "GET", "/channels/"+channelID, nil, nil, "")
+}
+
+func (c *RestClient) CreateChannel(ctx context.Context, guildID string, params *CreateChannelParams, reason string) (*Channel, error) {
+	return call[*Channel](ctx, c, "POST", "/guilds/"+guildID+"/channels", nil, params, reason)
+}
+
+func (c *RestClient) ModifyChannel(ctx context.Context, channelID string, params *ModifyChannelParams, reason string) (*Channel, error) {
+	return call[*Channel](ctx, c, "PATCH", "/channels/"+channelID, nil, params, reason)
+}
+
+// DeleteChannel deletes a channel or thread.
+func (c *RestClient) DeleteChannel(ctx context.Context, channelID, reason string) error {
+	return c.DoJSONWithReason(ctx, "DELETE", "/channels/"+channelID, nil, nil, nil, reason)
+}
+
+// ListGuildChannels returns the guild's channels, excluding threads.
+func (c *RestClient) ListGuildChannels(ctx context.Context, guildID string) ([]Channel, error) {
+	return call[[]Channel](ctx, c, "GET", "/guilds/"+guildID+"/channels", nil, nil, "")
+}
+
+func (c *RestClient) ModifyGuildChannelPositions(ctx context.Context, guildID string, positions []ChannelPosition, reason string) error {
+	return c.DoJSONWithReason(ctx, "PATCH", "/guilds/"+guildID+"/channels", nil, positions, nil, reason)
+}
+
+func (c *RestClient) EditChannelPermissions(ctx context.Context, channelID, overwriteID string, params *EditChannelPermissionsParams, reason string) error {
+	return c.DoJSONWithReason(ctx, "PUT", "/channels/"+channelID+"/permissions/"+overwriteID, nil, params, nil, reason)
+}
+
+func (c *RestClient) DeleteChannelPermission(ctx context.Context, channelID, overwriteID, reason string) error {
+	return c.DoJSONWithReason(ctx, "DELETE", "/channels/"+channelID+"/permissions/"+overwriteID, nil, nil, nil, reason)
+}
@@ -0,0 +1,50 @@
+package discord
+
+import (
+	"context"
+)
+
+// Emoji is a custom guild emoji.
+type Emoji struct {
+	ID            string   `json:"id"`
+	Name          string   `json:"name"`
+	Roles         []string `json:"roles"`
+	User          *User    `json:"user"`
+	RequireColons bool     `json:"require_colons"`
+	Managed       bool     `json:"managed"`
+	Animated      bool     `json:"animated"`
+	Available     bool     `json:"available"`
+}
+
+// CreateEmojiParams is the body of POST /guilds/{guild.id}/emojis. Image is a data URI.
+type CreateEmojiParams struct {
+	Name  string   `json:"name"`
+	Image string   `json:"image"`
+	Roles []string `json:"roles"`
+}
+
+// ModifyEmojiParams is the body of PATCH /guilds/{guild.id}/emojis/{emoji.id}.
+type ModifyEmojiParams struct {
+	Name  *string   `json:"name,omitempty"`
+	Roles *[]string `json:"roles,omitempty"`
+}
+
+func (c *RestClient) ListGuildEmojis(ctx context.Context, guildID string) ([]Emoji, error) {
+	return call[[]Emoji](ctx, c, "GET", "/guilds/"+guildID+"/emojis", nil, nil, "")
+}
+
+func (c *RestClient) GetGuildEmoji(ctx context.Context, guildID, emojiID string) (*Emoji, error) {
+	return call[*Emoji](ctx, c, "GET", "/guilds/"+guildID+"/emojis/"+emojiID, nil, nil, "")
+}
+
+func (c *RestClient) CreateGuildEmoji(ctx context.Context, guildID string, params *CreateEmojiParams, reason string) (*Emoji, error) {
+	return call[*Emoji](ctx, c, "POST", "/guilds/"+guildID+"/emojis", nil, params, reason)
+}
+
+func (c *RestClient) ModifyGuildEmoji(ctx context.Context, guildID, emojiID string, params *ModifyEmojiParams, reason string) (*Emoji, error) {
+	return call[*Emoji](ctx, c, "PATCH", "/guilds/"+guildID+"/emojis/"+emojiID, nil, params, reason)
+}
+
+func (c *RestClient) DeleteGuildEmoji(ctx context.Context, guildID, emojiID, reason string) error {
+	return c.DoJSONWithReason(ctx, "DELETE", "/guilds/"+guildID+"/emojis/"+emojiID, nil, nil, nil, reason)
+}
@@ -0,0 +1,154 @@
+package discord
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// Guild is a Discord server. Empty IDs and hashes mean the value is null.
+type Guild struct {
+	ID                          string    `json:"id"`
+	Name                        string    `json:"name"`
+	Icon                        string    `json:"icon"`
+	Splash                      string    `json:"splash"`
+	DiscoverySplash             string    `json:"discovery_splash"`
+	OwnerID                     string    `json:"owner_id"`
+	Region                      string    `json:"region"`
+	AfkChannelID                string    `json:"afk_channel_id"`
+	AfkTimeout                  int       `json:"afk_timeout"`
+	WidgetEnabled               bool      `json:"widget_enabled"`
+	WidgetChannelID             string    `json:"widget_channel_id"`
+	VerificationLevel           int       `json:"verification_level"`
+	DefaultMessageNotifications int       `json:"default_message_notifications"`
+	ExplicitContentFilter       int       `json:"explicit_content_filter"`
+	Roles                       []Role    `json:"roles"`
+	Emojis                      []Emoji   `json:"emojis"`
+	Features                    []string  `json:"features"`
+	MFALevel                    int       `json:"mfa_level"`
+	SystemChannelID             string    `json:"system_channel_id"`
+	SystemChannelFlags          int       `json:"system_channel_flags"`
+	RulesChannelID              string    `json:"rules_channel_id"`
+	Description                 string    `json:"description"`
+	Banner                      string    `json:"banner"`
+	PremiumTier                 int       `json:"premium_tier"`
+	PreferredLocale             string    `json:"preferred_locale"`
+	PublicUpdatesChannelID      string    `json:"public_updates_channel_id"`
+	NSFWLevel                   int       `json:"nsfw_level"`
+	Stickers                    []Sticker `json:"stickers"`
+	SafetyAlertsChannelID       string    `json:"safety_alerts_channel_id"`
+}
+
+// ModifyGuildParams is the body of PATCH /guilds/{guild.id}. Only set fields are sent;
+// set a Nullable field to Null to clear it.
+type ModifyGuildParams struct {
+	Name                        *string          `json:"name,omitempty"`
+	VerificationLevel           *int             `json:"verification_level,omitempty"`
+	DefaultMessageNotifications *int             `json:"default_message_notifications,omitempty"`
+	ExplicitContentFilter       *int             `json:"explicit_content_filter,omitempty"`
+	AfkChannelID                Nullable[string] `json:"afk_channel_id,omitzero"`
+	AfkTimeout                  *int             `json:"afk_timeout,omitempty"`
+	Icon                        Nullable[string] `json:"icon,omitzero"`
+	OwnerID                     Nullable[string] `json:"owner_id,omitzero"`
+	Splash                      Nullable[string] `json:"splash,omitzero"`
+	DiscoverySplash             Nullable[string] `json:"discovery_splash,omitzero"`
+	Banner                      Nullable[string] `json:"banner,omitzero"`
+	SystemChannelID             Nullable[string] `json:"system_channel_id,omitzero"`
+	SystemChannelFlags          *int             `json:"system_channel_flags,omitempty"`
+	RulesChannelID              Nullable[string] `json:"rules_channel_id,omitzero"`
+	PublicUpdatesChannelID      Nullable[string] `json:"public_updates_channel_id,omitzero"`
+	PreferredLocale             *string          `json:"preferred_locale,omitempty"`
+	Features                    *[]string        `json:"features,omitempty"`
+	Description                 Nullable[string] `json:"description,omitzero"`
+	SafetyAlertsChannelID       Nullable[string] `json:"safety_alerts_channel_id,omitzero"`
+}
+
+func (c *RestClient) GetGuild(ctx context.Context, guildID string) (*Guild, error) {
+	return call[*Guild](ctx, c, "GET", "/guilds/"+guildID, nil, nil, "")
+}
+
+func (c *RestClient) ModifyGuild(ctx context.Context, guildID string, params *ModifyGuildParams, reason string) (*Guild, error) {
+	return call[*Guild](ctx, c, "PATCH", "/guilds/"+guildID, nil, params, reason)
+}
+
+// WelcomeScreen is a guild's welcome screen. Whether it is shown is the guild's
+// WELCOME_SCREEN_ENABLED feature, not a field of this object.
+type WelcomeScreen struct {
+	Description     string                 `json:"description"`
+	WelcomeChannels []WelcomeScreenChannel `json:"welcome_channels"`
+	Enabled         bool                   `json:"enabled"`
+}
+
+type WelcomeScreenChannel struct {
+	ChannelID   string `json:"channel_id"`
+	Description string `json:"description"`
+	EmojiID     string `json:"emoji_id,omitempty"`
+	EmojiName   string `json:"emoji_name,omitempty"`
+}
+
+// ModifyGuildWelcomeScreenParams is the body of PATCH /guilds/{guild.id}/welcome-screen.
+// Only set fields are sent.
+type ModifyGuildWelcomeScreenParams struct {
+	Enabled         *bool                   `json:"enabled,omitempty"`
+	WelcomeChannels *[]WelcomeScreenChannel `json:"welcome_channels,omitempty"`
+	Description     *string                 `json:"description,omitempty"`
+}
+
+func (c *RestClient) GetGuildWelcomeScreen(ctx context.Context, guildID string) (*WelcomeScreen, error) {
+	return call[*WelcomeScreen](ctx, c, "GET", "/guilds/"+guildID+"/welcome-screen", nil, nil, "")
+}
+
+func (c *RestClient) ModifyGuildWelcomeScreen(ctx context.Context, guildID string, params *ModifyGuildWelcomeScreenParams, reason string) (*WelcomeScreen, error) {
+	return call[*WelcomeScreen](ctx, c, "PATCH", "/guilds/"+guildID+"/welcome-screen", nil, params, reason)
+}
+
+// GuildWidgetSettings is the guild widget settings object. An empty ChannelID means
+// the widget has no invite channel.
+type GuildWidgetSettings struct {
+	Enabled   bool   `json:"enabled"`
+	ChannelID string `json:"channel_id"`
+}
+
+// ModifyGuildWidgetParams is the body of PATCH /guilds/{guild.id}/widget. Only set
+// fields are sent; set ChannelID to Null to clear it.
+type ModifyGuildWidgetParams struct {
+	Enabled   *bool            `json:"enabled,omitempty"`
+	ChannelID Nullable[string] `json:"channel_id,omitzero"`
+}
+
+func (c *RestClient) GetGuildWidgetSettings(ctx context.Context, guildID string) (*GuildWidgetSettings, error) {
+	return call[*GuildWidgetSettings](ctx, c, "GET", "/guilds/"+guildID+"/widget", nil, nil, "")
+}
+
+func (c *RestClient) ModifyGuildWidget(ctx context.Context, guildID string, params *ModifyGuildWidgetParams, reason string) (*GuildWidgetSettings, error) {
+	return call[*GuildWidgetSettings](ctx, c, "PATCH", "/guilds/"+guildID+"/widget", nil, params, reason)
+}
+
+// GetGuildJSON and ModifyGuildJSON are GetGuild and ModifyGuild with the guild as
+// raw JSON, for discord_guild_settings, which passes payload_json through.
+func (c *RestClient) GetGuildJSON(ctx context.Context, guildID string) (json.RawMessage, error) {
+	return call[json.RawMessage](ctx, c, "GET", "/guilds/"+guildID, nil, nil, "")
+}
+
+func (c *RestClient) ModifyGuildJSON(ctx context.Context, guildID string, params json.RawMessage, reason string) (json.RawMessage, error) {
+	return call[json.RawMessage](ctx, c, "PATCH", "/guilds/"+guildID, nil, params, reason)
+}
+
+// GetGuildOnboarding returns the guild's onboarding as raw JSON; prompts and their
+// options change shape over time, so discord_onboarding passes them through.
+func (c *RestClient) GetGuildOnboarding(ctx context.Context, guildID string) (json.RawMessage, error) {
+	return call[json.RawMessage](ctx, c, "GET", "/guilds/"+guildID+"/onboarding", nil, nil, "")
+}
+
+func (c *RestClient) ModifyGuildOnboarding(ctx context.Context, guildID string, params json.RawMessage, reason string) (json.RawMessage, error) {
+	return call[json.RawMessage](ctx, c, "PUT", "/guilds/"+guildID+"/onboarding", nil, params, reason)
+}
+
+// GetGuildMemberVerification returns the guild's membership screening form as raw
+// JSON. The route is not in Discord's published docs.
+func (c *RestClient) GetGuildMemberVerification(ctx context.Context, guildID string) (json.RawMessage, error) {
+	return call[json.RawMessage](ctx, c, "GET", "/guilds/"+guildID+"/member-verification", nil, nil, "")
+}
+
+func (c *RestClient) ModifyGuildMemberVerification(ctx context.Context, guildID string, params json.RawMessage, reason string) (json.RawMessage, error) {
+	return call[json.RawMessage](ctx, c, "PUT", "/guilds/"+guildID+"/member-verification", nil, params, reason)
+}
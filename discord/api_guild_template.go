@@ -0,0 +1,47 @@
+package discord
+
+import (
+	"context"
+)
+
+// GuildTemplate is a snapshot of a guild that new guilds can be created from.
+// IsDirty is true when the guild has changed since the template was last synced.
+type GuildTemplate struct {
+	Code          string `json:"code"`
+	Name          string `json:"name"`
+	Description   string `json:"description"`
+	UsageCount    int64  `json:"usage_count"`
+	CreatorID     string `json:"creator_id"`
+	CreatedAt     string `json:"created_at"`
+	UpdatedAt     string `json:"updated_at"`
+	SourceGuildID string `json:"source_guild_id"`
+	IsDirty       bool   `json:"is_dirty"`
+}
+
+// GuildTemplateParams is the body of POST /guilds/{guild.id}/templates and
+// PATCH /guilds/{guild.id}/templates/{template.code}. Only set fields are sent.
+type GuildTemplateParams struct {
+	Name        *string `json:"name,omitempty"`
+	Description *string `json:"description,omitempty"`
+}
+
+func (c *RestClient) GetGuildTemplates(ctx context.Context, guildID string) ([]GuildTemplate, error) {
+	return call[[]GuildTemplate](ctx, c, "GET", "/guilds/"+guildID+"/templates", nil, nil, "")
+}
+
+func (c *RestClient) CreateGuildTemplate(ctx context.Context, guildID string, params *GuildTemplateParams, reason string) (*GuildTemplate, error) {
+	return call[*GuildTemplate](ctx, c, "POST", "/guilds/"+guildID+"/templates", nil, params, reason)
+}
+
+// SyncGuildTemplate updates the template to the guild's current state.
+func (c *RestClient) SyncGuildTemplate(ctx context.Context, guildID, code, reason string) (*GuildTemplate, error) {
+	return call[*GuildTemplate](ctx, c, "PUT", "/guilds/"+guildID+"/templates/"+code, nil, nil, reason)
+}
+
+func (c *RestClient) ModifyGuildTemplate(ctx context.Context, guildID, code string, params *GuildTemplateParams, reason string) (*GuildTemplate, error) {
+	return call[*GuildTemplate](ctx, c, "PATCH", "/guilds/"+guildID+"/templates/"+code, nil, params, reason)
+}
+
+func (c *RestClient) DeleteGuildTemplate(ctx context.Context, guildID, code, reason string) error {
+	return c.DoJSONWithReason(ctx, "DELETE", "/guilds/"+guildID+"/templates/"+code, nil, nil, nil, reason)
+}
@@ -0,0 +1,37 @@
+package discord
+
+import (
+	"context"
+)
+
+// Invite is a channel invite. ExpiresAt is empty for an invite that never expires.
+type Invite struct {
+	Code      string `json:"code"`
+	Uses      int    `json:"uses"`
+	MaxUses   int    `json:"max_uses"`
+	MaxAge    int    `json:"max_age"`
+	Temporary bool   `json:"temporary"`
+	CreatedAt string `json:"created_at"`
+	ExpiresAt string `json:"expires_at"`
+}
+
+// CreateChannelInviteParams is the body of POST /channels/{channel.id}/invites. Only
+// set fields are sent; a MaxAge of 0 makes an invite that never expires.
+type CreateChannelInviteParams struct {
+	MaxAge    *int  `json:"max_age,omitempty"`
+	MaxUses   *int  `json:"max_uses,omitempty"`
+	Temporary *bool `json:"temporary,omitempty"`
+	Unique    *bool `json:"unique,omitempty"`
+}
+
+func (c *RestClient) CreateChannelInvite(ctx context.Context, channelID string, params *CreateChannelInviteParams, reason string) (*Invite, error) {
+	return call[*Invite](ctx, c, "POST", "/channels/"+channelID+"/invites", nil, params, reason)
+}
+
+func (c *RestClient) GetInvite(ctx context.Context, code string) (*Invite, error) {
+	return call[*Invite](ctx, c, "GET", "/invites/"+code, nil, nil, "")
+}
+
+func (c *RestClient) DeleteInvite(ctx context.Context, code, reason string) error {
+	return c.DoJSONWithReason(ctx, "DELETE", "/invites/"+code, nil, nil, nil, reason)
+}
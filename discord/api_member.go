@@ -0,0 +1,86 @@
+package discord
+
+import (
+	"context"
+)
+
+// User is a Discord user or bot account.
+type User struct {
+	ID            string `json:"id"`
+	Username      string `json:"username"`
+	Discriminator string `json:"discriminator"`
+	GlobalName    string `json:"global_name"`
+	Avatar        string `json:"avatar"`
+	Bot           bool   `json:"bot"`
+}
+
+// Member is a user's membership in a guild. Empty timestamps mean the value is null.
+type Member struct {
+	User                       User     `json:"user"`
+	Nick                       string   `json:"nick"`
+	Avatar                     string   `json:"avatar"`
+	Roles                      []string `json:"roles"`
+	JoinedAt                   string   `json:"joined_at"`
+	PremiumSince               string   `json:"premium_since"`
+	Deaf                       bool     `json:"deaf"`
+	Mute                       bool     `json:"mute"`
+	Flags                      int      `json:"flags"`
+	Pending                    bool     `json:"pending"`
+	CommunicationDisabledUntil string   `json:"communication_disabled_until"`
+}
+
+// ModifyMemberParams is the body of PATCH /guilds/{guild.id}/members/{user.id}.
+// Set Nick or CommunicationDisabledUntil to Null to clear them.
+type ModifyMemberParams struct {
+	Nick                       Nullable[string] `json:"nick,omitzero"`
+	Roles                      *[]string        `json:"roles,omitempty"`
+	Mute                       *bool            `json:"mute,omitempty"`
+	Deaf                       *bool            `json:"deaf,omitempty"`
+	ChannelID                  Nullable[string] `json:"channel_id,omitzero"`
+	CommunicationDisabledUntil Nullable[string] `json:"communication_disabled_until,omitzero"`
+	Flags                      *int             `json:"flags,omitempty"`
+}
+
+// Ban is a guild ban.
+type Ban struct {
+	Reason string `json:"reason"`
+	User   User   `json:"user"`
+}
+
+// CreateGuildBanParams is the body of PUT /guilds/{guild.id}/bans/{user.id}.
+type CreateGuildBanParams struct {
+	DeleteMessageSeconds *int `json:"delete_message_seconds,omitempty"`
+}
+
+// GetCurrentUser returns the user the token belongs to.
+func (c *RestClient) GetCurrentUser(ctx context.Context) (*User, error) {
+	return call[*User](ctx, c, "GET", "/users/@me", nil, nil, "")
+}
+
+func (c *RestClient) GetMember(ctx context.Context, guildID, userID string) (*Member, error) {
+	return call[*Member](ctx, c, "GET", "/guilds/"+guildID+"/members/"+userID, nil, nil, "")
+}
+
+func (c *RestClient) ModifyMember(ctx context.Context, guildID, userID string, params *ModifyMemberParams, reason string) error {
+	return c.DoJSONWithReason(ctx, "PATCH", "/guilds/"+guildID+"/members/"+userID, nil, params, nil, reason)
+}
+
+func (c *RestClient) AddMemberRole(ctx context.Context, guildID, userID, roleID, reason string) error {
+	return c.DoJSONWithReason(ctx, "PUT", "/guilds/"+guildID+"/members/"+userID+"/roles/"+roleID, nil, nil, nil, reason)
+}
+
+func (c *RestClient) RemoveMemberRole(ctx context.Context, guildID, userID, roleID, reason string) error {
+	return c.DoJSONWithReason(ctx, "DELETE", "/guilds/"+guildID+"/members/"+userID+"/roles/"+roleID, nil, nil, nil, reason)
+}
+
+func (c *RestClient) GetGuildBan(ctx context.Context, guildID, userID string) (*Ban, error) {
+	return call[*Ban](ctx, c, "GET", "/guilds/"+guildID+"/bans/"+userID, nil, nil, "")
+}
+
+func (c *RestClient) CreateGuildBan(ctx context.Context, guildID, userID string, params *CreateGuildBanParams, reason string) error {
+	return c.DoJSONWithReason(ctx, "PUT", "/guilds/"+guildID+"/bans/"+userID, nil, params, nil, reason)
+}
+
+func (c *RestClient) RemoveGuildBan(ctx context.Context, guildID, userID, reason string) error {
+	return c.DoJSONWithReason(ctx, "DELETE", "/guilds/"+guildID+"/bans/"+userID, nil, nil, nil, reason)
+}
@@ -0,0 +1,125 @@
+package discord
+
+import (
+	"context"
+)
+
+// Message is a message in a channel. EditedTimestamp is empty for unedited messages.
+type Message struct {
+	ID              string  `json:"id"`
+	ChannelID       string  `json:"channel_id"`
+	GuildID         string  `json:"guild_id"`
+	Author          User    `json:"author"`
+	Content         string  `json:"content"`
+	Timestamp       string  `json:"timestamp"`
+	EditedTimestamp string  `json:"edited_timestamp"`
+	TTS             bool    `json:"tts"`
+	MentionEveryone bool    `json:"mention_everyone"`
+	Embeds          []Embed `json:"embeds"`
+	Pinned          bool    `json:"pinned"`
+	WebhookID       string  `json:"webhook_id"`
+	Type            int     `json:"type"`
+	Flags           int     `json:"flags"`
+}
+
+// Embed is rich content attached to a message. The proxy URLs are set by Discord.
+type Embed struct {
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description,omitempty"`
+	URL         string `json:"url,omitempty"`
+	Timestamp   string `json:"timestamp,omitempty"`
+	Color       int    `json:"color,omitempty"`
+
+	Footer    *EmbedFooter    `json:"footer,omitempty"`
+	Image     *EmbedImage     `json:"image,omitempty"`
+	Thumbnail *EmbedThumbnail `json:"thumbnail,omitempty"`
+	Video     *EmbedVideo     `json:"video,omitempty"`
+	Provider  *EmbedProvider  `json:"provider,omitempty"`
+	Author    *EmbedAuthor    `json:"author,omitempty"`
+	Fields    []EmbedField    `json:"fields,omitempty"`
+}
+
+type EmbedFooter struct {
+	Text    string `json:"text,omitempty"`
+	IconURL string `json:"icon_url,omitempty"`
+}
+
+type EmbedImage struct {
+	URL      string `json:"url,omitempty"`
+	ProxyURL string `json:"proxy_url,omitempty"`
+	Height   int    `json:"height,omitempty"`
+	Width    int    `json:"width,omitempty"`
+}
+
+type EmbedThumbnail struct {
+	URL      string `json:"url,omitempty"`
+	ProxyURL string `json:"proxy_url,omitempty"`
+	Height   int    `json:"height,omitempty"`
+	Width    int    `json:"width,omitempty"`
+}
+
+type EmbedVideo struct {
+	URL    string `json:"url,omitempty"`
+	Height int    `json:"height,omitempty"`
+	Width  int    `json:"width,omitempty"`
+}
+
+type EmbedProvider struct {
+	Name string `json:"name,omitempty"`
+	URL  string `json:"url,omitempty"`
+}
+
+type EmbedAuthor struct {
+	Name         string `json:"name,omitempty"`
+	URL          string `json:"url,omitempty"`
+	IconURL      string `json:"icon_url,omitempty"`
+	ProxyIconURL string `json:"proxy_icon_url,omitempty"`
+}
+
+type EmbedField struct {
+	Name   string `json:"name,omitempty"`
+	Value  string `json:"value,omitempty"`
+	Inline bool   `json:"inline,omitempty"`
+}
+
+// CreateMessageParams is the body of POST /channels/{channel.id}/messages.
+// With Nonce and EnforceNonce set, Discord returns the original message instead of
+// posting a duplicate, which lets the client retry the create (see NewNonce).
+type CreateMessageParams struct {
+	Content      string  `json:"content,omitempty"`
+	TTS          bool    `json:"tts,omitempty"`
+	Embeds       []Embed `json:"embeds,omitempty"`
+	Nonce        string  `json:"nonce,omitempty"`
+	EnforceNonce bool    `json:"enforce_nonce,omitempty"`
+}
+
+// EditMessageParams is the body of PATCH /channels/{channel.id}/messages/{message.id}.
+// Point Embeds at an empty slice to remove all embeds.
+type EditMessageParams struct {
+	Content *string  `json:"content,omitempty"`
+	Embeds  *[]Embed `json:"embeds,omitempty"`
+}
+
+func (c *RestClient) GetMessage(ctx context.Context, channelID, messageID string) (*Message, error) {
+	return call[*Message](ctx, c, "GET", "/channels/"+channelID+"/messages/"+messageID, nil, nil, "")
+}
+
+func (c *RestClient) CreateMessage(ctx context.Context, channelID string, params *CreateMessageParams) (*Message, error) {
+	return call[*Message](ctx, c, "POST", "/channels/"+channelID+"/messages", nil, params, "")
+}
+
+func (c *RestClient) EditMessage(ctx context.Context, channelID, messageID string, params *EditMessageParams) (*Message, error) {
+	return call[*Message](ctx, c, "PATCH", "/channels/"+channelID+"/messages/"+messageID, nil, params, "")
+}
+
+func (c *RestClient) DeleteMessage(ctx context.Context, channelID, messageID, reason string) error {
+	return c.DoJSONWithReason(ctx, "DELETE", "/channels/"+channelID+"/messages/"+messageID, nil, nil, nil, reason)
+}
+
+func (c *RestClient) PinMessage(ctx context.Context, channelID, messageID, reason string) error {
+	return c.DoJSONWithReason(ctx, "PUT", "/channels/"+channelID+"/pins/"+messageID, nil, nil, nil, reason)
+}
+
+func (c *RestClient) UnpinMessage(ctx context.Context, channelID, messageID, reason string) error {
+	return c.DoJSONWithReason(ctx, "DELETE", "/channels/"+channelID+"/pins/"+messageID, nil, nil, nil, reason)
+}
@@ -0,0 +1,61 @@
+package discord
+
+import (
+	"context"
+)
+
+// Role is a guild role. Permissions is a bit set as a decimal string. The @everyone
+// role has the guild's ID.
+type Role struct {
+	ID           string `json:"id"`
+	Name         string `json:"name"`
+	Color        int    `json:"color"`
+	Hoist        bool   `json:"hoist"`
+	Icon         string `json:"icon"`
+	UnicodeEmoji string `json:"unicode_emoji"`
+	Position     int    `json:"position"`
+	Permissions  string `json:"permissions"`
+	Managed      bool   `json:"managed"`
+	Mentionable  bool   `json:"mentionable"`
+	Flags        int    `json:"flags"`
+}
+
+// RoleParams is the body of POST /guilds/{guild.id}/roles and
+// PATCH /guilds/{guild.id}/roles/{role.id}. Only set fields are sent, so false and
+// zero values can be sent explicitly.
+type RoleParams struct {
+	Name         *string          `json:"name,omitempty"`
+	Permissions  *string          `json:"permissions,omitempty"`
+	Color        *int             `json:"color,omitempty"`
+	Hoist        *bool            `json:"hoist,omitempty"`
+	Icon         Nullable[string] `json:"icon,omitzero"`
+	UnicodeEmoji Nullable[string] `json:"unicode_emoji,omitzero"`
+	Mentionable  *bool            `json:"mentionable,omitempty"`
+}
+
+// RolePosition is one entry of PATCH /guilds/{guild.id}/roles.
+type RolePosition struct {
+	ID       string `json:"id"`
+	Position *int   `json:"position,omitempty"`
+}
+
+func (c *RestClient) GetGuildRoles(ctx context.Context, guildID string) ([]Role, error) {
+	return call[[]Role](ctx, c, "GET", "/guilds/"+guildID+"/roles", nil, nil, "")
+}
+
+func (c *RestClient) CreateRole(ctx context.Context, guildID string, params *RoleParams, reason string) (*Role, error) {
+	return call[*Role](ctx, c, "POST", "/guilds/"+guildID+"/roles", nil, params, reason)
+}
+
+func (c *RestClient) ModifyRole(ctx context.Context, guildID, roleID string, params *RoleParams, reason string) (*Role, error) {
+	return call[*Role](ctx, c, "PATCH", "/guilds/"+guildID+"/roles/"+roleID, nil, params, reason)
+}
+
+// ModifyRolePositions moves the given roles and returns all roles of the guild.
+func (c *RestClient) ModifyRolePositions(ctx context.Context, guildID string, positions []RolePosition, reason string) ([]Role, error) {
+	return call[[]Role](ctx, c, "PATCH", "/guilds/"+guildID+"/roles", nil, positions, reason)
+}
+
+func (c *RestClient) DeleteRole(ctx context.Context, guildID, roleID, reason string) error {
+	return c.DoJSONWithReason(ctx, "DELETE", "/guilds/"+guildID+"/roles/"+roleID, nil, nil, nil, reason)
+}
@@ -0,0 +1,65 @@
+package discord
+
+import (
+	"context"
+)
+
+// ScheduledEvent is a guild scheduled event. ChannelID is set for stage and voice
+// events (entity_type 1 and 2); EntityMetadata.Location for external events (3).
+type ScheduledEvent struct {
+	ID                 string                        `json:"id"`
+	GuildID            string                        `json:"guild_id"`
+	ChannelID          string                        `json:"channel_id"`
+	CreatorID          string                        `json:"creator_id"`
+	Name               string                        `json:"name"`
+	Description        string                        `json:"description"`
+	ScheduledStartTime string                        `json:"scheduled_start_time"`
+	ScheduledEndTime   string                        `json:"scheduled_end_time"`
+	PrivacyLevel       int                           `json:"privacy_level"`
+	Status             int                           `json:"status"`
+	EntityType         int                           `json:"entity_type"`
+	EntityID           string                        `json:"entity_id"`
+	EntityMetadata     *ScheduledEventEntityMetadata `json:"entity_metadata"`
+	UserCount          int                           `json:"user_count"`
+	Image              string                        `json:"image"`
+}
+
+type ScheduledEventEntityMetadata struct {
+	Location string `json:"location,omitempty"`
+}
+
+// ScheduledEventParams is the body of POST /guilds/{guild.id}/scheduled-events and
+// PATCH /guilds/{guild.id}/scheduled-events/{event.id}. Only set fields are sent;
+// Status only applies to updates.
+type ScheduledEventParams struct {
+	ChannelID          *string                       `json:"channel_id,omitempty"`
+	EntityMetadata     *ScheduledEventEntityMetadata `json:"entity_metadata,omitempty"`
+	Name               *string                       `json:"name,omitempty"`
+	PrivacyLevel       *int                          `json:"privacy_level,omitempty"`
+	ScheduledStartTime *string                       `json:"scheduled_start_time,omitempty"`
+	ScheduledEndTime   *string                       `json:"scheduled_end_time,omitempty"`
+	Description        *string                       `json:"description,omitempty"`
+	EntityType         *int                          `json:"entity_type,omitempty"`
+	Status             *int                          `json:"status,omitempty"`
+	Image              *string                       `json:"image,omitempty"`
+}
+
+func (c *RestClient) ListScheduledEvents(ctx context.Context, guildID string) ([]ScheduledEvent, error) {
+	return call[[]ScheduledEvent](ctx, c, "GET", "/guilds/"+guildID+"/scheduled-events", nil, nil, "")
+}
+
+func (c *RestClient) GetScheduledEvent(ctx context.Context, guildID, eventID string) (*ScheduledEvent, error) {
+	return call[*ScheduledEvent](ctx, c, "GET", "/guilds/"+guildID+"/scheduled-events/"+eventID, nil, nil, "")
+}
+
+func (c *RestClient) CreateScheduledEvent(ctx context.Context, guildID string, params *ScheduledEventParams, reason string) (*ScheduledEvent, error) {
+	return call[*ScheduledEvent](ctx, c, "POST", "/guilds/"+guildID+"/scheduled-events", nil, params, reason)
+}
+
+func (c *RestClient) ModifyScheduledEvent(ctx context.Context, guildID, eventID string, params *ScheduledEventParams, reason string) (*ScheduledEvent, error) {
+	return call[*ScheduledEvent](ctx, c, "PATCH", "/guilds/"+guildID+"/scheduled-events/"+eventID, nil, params, reason)
+}
+
+func (c *RestClient) DeleteScheduledEvent(ctx context.Context, guildID, eventID, reason string) error {
+	return c.DoJSONWithReason(ctx, "DELETE", "/guilds/"+guildID+"/scheduled-events/"+eventID, nil, nil, nil, reason)
+}
@@ -0,0 +1,61 @@
+package discord
+
+import (
+	"context"
+)
+
+// SoundboardSound is a soundboard sound. GuildID is empty for Discord's default
+// sounds, and empty emoji fields mean the sound has no emoji.
+type SoundboardSound struct {
+	Name      string  `json:"name"`
+	SoundID   string  `json:"sound_id"`
+	Volume    float64 `json:"volume"`
+	EmojiID   string  `json:"emoji_id"`
+	EmojiName string  `json:"emoji_name"`
+	GuildID   string  `json:"guild_id"`
+	Available bool    `json:"available"`
+}
+
+// CreateGuildSoundboardSoundParams is the body of POST /guilds/{guild.id}/soundboard-sounds.
+// Sound is a data URI of MP3 or OGG audio.
+type CreateGuildSoundboardSoundParams struct {
+	Name      string   `json:"name"`
+	Sound     string   `json:"sound"`
+	Volume    *float64 `json:"volume,omitempty"`
+	EmojiID   *string  `json:"emoji_id,omitempty"`
+	EmojiName *string  `json:"emoji_name,omitempty"`
+}
+
+// ModifyGuildSoundboardSoundParams is the body of
+// PATCH /guilds/{guild.id}/soundboard-sounds/{sound.id}. Only set fields are sent;
+// set an emoji field to Null to clear it.
+type ModifyGuildSoundboardSoundParams struct {
+	Name      *string          `json:"name,omitempty"`
+	Volume    *float64         `json:"volume,omitempty"`
+	EmojiID   Nullable[string] `json:"emoji_id,omitzero"`
+	EmojiName Nullable[string] `json:"emoji_name,omitzero"`
+}
+
+func (c *RestClient) ListDefaultSoundboardSounds(ctx context.Context) ([]SoundboardSound, error) {
+	return call[[]SoundboardSound](ctx, c, "GET", "/soundboard-default-sounds", nil, nil, "")
+}
+
+func (c *RestClient) ListGuildSoundboardSounds(ctx context.Context, guildID string) ([]SoundboardSound, error) {
+	return call[[]SoundboardSound](ctx, c, "GET", "/guilds/"+guildID+"/soundboard-sounds", nil, nil, "")
+}
+
+func (c *RestClient) GetGuildSoundboardSound(ctx context.Context, guildID, soundID string) (*SoundboardSound, error) {
+	return call[*SoundboardSound](ctx, c, "GET", "/guilds/"+guildID+"/soundboard-sounds/"+soundID, nil, nil, "")
+}
+
+func (c *RestClient) CreateGuildSoundboardSound(ctx context.Context, guildID string, params *CreateGuildSoundboardSoundParams, reason string) (*SoundboardSound, error) {
+	return call[*SoundboardSound](ctx, c, "POST", "/guilds/"+guildID+"/soundboard-sounds", nil, params, reason)
+}
+
+func (c *RestClient) ModifyGuildSoundboardSound(ctx context.Context, guildID, soundID string, params *ModifyGuildSoundboardSoundParams, reason string) (*SoundboardSound, error) {
+	return call[*SoundboardSound](ctx, c, "PATCH", "/guilds/"+guildID+"/soundboard-sounds/"+soundID, nil, params, reason)
+}
+
+func (c *RestClient) DeleteGuildSoundboardSound(ctx context.Context, guildID, soundID, reason string) error {
+	return c.DoJSONWithReason(ctx, "DELETE", "/guilds/"+guildID+"/soundboard-sounds/"+soundID, nil, nil, nil, reason)
+}
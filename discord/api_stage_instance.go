@@ -0,0 +1,48 @@
+package discord
+
+import (
+	"context"
+)
+
+// StageInstance is a live stage in a stage channel. There is at most one per
+// channel, so it is addressed by the channel ID.
+type StageInstance struct {
+	ID                    string `json:"id"`
+	GuildID               string `json:"guild_id"`
+	ChannelID             string `json:"channel_id"`
+	Topic                 string `json:"topic"`
+	PrivacyLevel          int    `json:"privacy_level"`
+	GuildScheduledEventID string `json:"guild_scheduled_event_id"`
+}
+
+// CreateStageInstanceParams is the body of POST /stage-instances.
+type CreateStageInstanceParams struct {
+	ChannelID             string  `json:"channel_id"`
+	Topic                 string  `json:"topic"`
+	PrivacyLevel          *int    `json:"privacy_level,omitempty"`
+	SendStartNotification *bool   `json:"send_start_notification,omitempty"`
+	GuildScheduledEventID *string `json:"guild_scheduled_event_id,omitempty"`
+}
+
+// ModifyStageInstanceParams is the body of PATCH /stage-instances/{channel.id}. Only
+// set fields are sent.
+type ModifyStageInstanceParams struct {
+	Topic        *string `json:"topic,omitempty"`
+	PrivacyLevel *int    `json:"privacy_level,omitempty"`
+}
+
+func (c *RestClient) CreateStageInstance(ctx context.Context, params *CreateStageInstanceParams, reason string) (*StageInstance, error) {
+	return call[*StageInstance](ctx, c, "POST", "/stage-instances", nil, params, reason)
+}
+
+func (c *RestClient) GetStageInstance(ctx context.Context, channelID string) (*StageInstance, error) {
+	return call[*StageInstance](ctx, c, "GET", "/stage-instances/"+channelID, nil, nil, "")
+}
+
+func (c *RestClient) ModifyStageInstance(ctx context.Context, channelID string, params *ModifyStageInstanceParams, reason string) (*StageInstance, error) {
+	return call[*StageInstance](ctx, c, "PATCH", "/stage-instances/"+channelID, nil, params, reason)
+}
+
+func (c *RestClient) DeleteStageInstance(ctx context.Context, channelID, reason string) error {
+	return c.DoJSONWithReason(ctx, "DELETE", "/stage-instances/"+channelID, nil, nil, nil, reason)
+}
@@ -0,0 +1,66 @@
+package discord
+
+import (
+	"context"
+)
+
+// Sticker is a custom guild sticker. Tags is a comma separated list of related emoji
+// names.
+type Sticker struct {
+	ID          string `json:"id"`
+	GuildID     string `json:"guild_id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Tags        string `json:"tags"`
+	Type        int    `json:"type"`
+	FormatType  int    `json:"format_type"`
+	Available   bool   `json:"available"`
+	User        *User  `json:"user"`
+}
+
+// CreateStickerParams are the form fields and file of POST /guilds/{guild.id}/stickers.
+type CreateStickerParams struct {
+	Name        string
+	Description string
+	Tags        string
+
+	FileName string
+	File     []byte
+}
+
+// ModifyStickerParams is the body of PATCH /guilds/{guild.id}/stickers/{sticker.id}.
+type ModifyStickerParams struct {
+	Name        *string `json:"name,omitempty"`
+	Description *string `json:"description,omitempty"`
+	Tags        *string `json:"tags,omitempty"`
+}
+
+func (c *RestClient) ListGuildStickers(ctx context.Context, guildID string) ([]Sticker, error) {
+	return call[[]Sticker](ctx, c, "GET", "/guilds/"+guildID+"/stickers", nil, nil, "")
+}
+
+func (c *RestClient) GetGuildSticker(ctx context.Context, guildID, stickerID string) (*Sticker, error) {
+	return call[*Sticker](ctx, c, "GET", "/guilds/"+guildID+"/stickers/"+stickerID, nil, nil, "")
+}
+
+// CreateGuildSticker uploads a sticker as multipart/form-data.
+func (c *RestClient) CreateGuildSticker(ctx context.Context, guildID string, params *CreateStickerParams, reason string) (*Sticker, error) {
+	fields := map[string]string{
+		"name":        params.Name,
+		"description": params.Description,
+		"tags":        params.Tags,
+	}
+	var out Sticker
+	if err := c.DoMultipartWithReason(ctx, "POST", "/guilds/"+guildID+"/stickers", nil, fields, "file", params.FileName, params.File, &out, reason); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (c *RestClient) ModifyGuildSticker(ctx context.Context, guildID, stickerID string, params *ModifyStickerParams, reason string) (*Sticker, error) {
+	return call[*Sticker](ctx, c, "PATCH", "/guilds/"+guildID+"/stickers/"+stickerID, nil, params, reason)
+}
+
+func (c *RestClient) DeleteGuildSticker(ctx context.Context, guildID, stickerID, reason string) error {
+	return c.DoJSONWithReason(ctx, "DELETE", "/guilds/"+guildID+"/stickers/"+stickerID, nil, nil, nil, reason)
+}
@@ -0,0 +1,415 @@
+package discord
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+// apiRequest is what the test server saw for one call.
+type apiRequest struct {
+	Method string
+	Path   string
+	Query  url.Values
+	Reason string
+	Body   string
+}
+
+// newAPITestClient returns a client whose requests are recorded and answered with resp.
+func newAPITestClient(t *testing.T, resp string) (*RestClient, *[]apiRequest) {
+	t.Helper()
+
+	var seen []apiRequest
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		reason, _ := url.QueryUnescape(r.Header.Get("X-Audit-Log-Reason"))
+		seen = append(seen, apiRequest{
+			Method: r.Method,
+			Path:   r.URL.Path,
+			Query:  r.URL.Query(),
+			Reason: reason,
+			Body:   string(b),
+		})
+		if resp == "" {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, resp)
+	}))
+	t.Cleanup(s.Close)
+
+	c := NewRestClient("TOKEN", s.Client())
+	c.BaseURL = s.URL
+	return c, &seen
+}
+
+func mustMarshal(t *testing.T, v interface{}) string {
+	t.Helper()
+	b, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	return string(b)
+}
+
+func TestNullable_JSON(t *testing.T) {
+	type body struct {
+		A Nullable[string] `json:"a,omitzero"`
+		B Nullable[string] `json:"b,omitzero"`
+		C Nullable[string] `json:"c,omitzero"`
+		D Nullable[int]    `json:"d,omitzero"`
+	}
+
+	got := mustMarshal(t, body{B: Null[string](), C: Value("x"), D: Value(0)})
+	if want := `{"b":null,"c":"x","d":0}`; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+
+	if got := mustMarshal(t, body{A: NullIfEmpty(""), B: NullIfEmpty("1")}); got != `{"a":null,"b":"1"}` {
+		t.Fatalf("NullIfEmpty: got %s", got)
+	}
+
+	var decoded body
+	if err := json.Unmarshal([]byte(`{"a":null,"c":"y","d":3}`), &decoded); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if !decoded.A.IsNull() || !decoded.B.IsZero() {
+		t.Fatalf("unexpected null state: %#v", decoded)
+	}
+	if v, ok := decoded.C.Get(); !ok || v != "y" {
+		t.Fatalf("C = %q, %v", v, ok)
+	}
+	if v, ok := decoded.D.Get(); !ok || v != 3 {
+		t.Fatalf("D = %d, %v", v, ok)
+	}
+}
+
+func TestParams_SendExplicitZeroValues(t *testing.T) {
+	role := RoleParams{
+		Name:        Ptr("mods"),
+		Color:       Ptr(0),
+		Hoist:       Ptr(false),
+		Mentionable: Ptr(false),
+	}
+	if got, want := mustMarshal(t, role), `{"name":"mods","color":0,"hoist":false,"mentionable":false}`; got != want {
+		t.Fatalf("RoleParams: got %s, want %s", got, want)
+	}
+
+	noEmbeds := []Embed{}
+	edit := EditMessageParams{Embeds: &noEmbeds}
+	if got, want := mustMarshal(t, edit), `{"embeds":[]}`; got != want {
+		t.Fatalf("EditMessageParams: got %s, want %s", got, want)
+	}
+
+	pos := []ChannelPosition{{ID: "1", Position: Ptr(0)}, {ID: "2", Position: Ptr(1), ParentID: Null[string]()}}
+	if got, want := mustMarshal(t, pos), `[{"id":"1","position":0},{"id":"2","position":1,"parent_id":null}]`; got != want {
+		t.Fatalf("ChannelPosition: got %s, want %s", got, want)
+	}
+
+	modify := ModifyChannelParams{
+		Topic:                Ptr(""),
+		ParentID:             Null[string](),
+		DefaultReactionEmoji: Null[DefaultReaction](),
+	}
+	if got, want := mustMarshal(t, modify), `{"topic":"","parent_id":null,"default_reaction_emoji":null}`; got != want {
+		t.Fatalf("ModifyChannelParams: got %s, want %s", got, want)
+	}
+
+	if got := mustMarshal(t, ModifyGuildParams{}); got != `{}` {
+		t.Fatalf("empty ModifyGuildParams: got %s", got)
+	}
+}
+
+func TestAPI_RoutesAndReasons(t *testing.T) {
+	ctx := context.Background()
+
+	cases := []struct {
+		name   string
+		call   func(c *RestClient) error
+		method string
+		path   string
+		reason string
+		body   string
+	}{
+		{
+			name: "CreateChannel",
+			call: func(c *RestClient) error {
+				_, err := c.CreateChannel(ctx, "10", &CreateChannelParams{Name: "general", Type: 0, Position: Ptr(0)}, "setup")
+				return err
+			},
+			method: "POST", path: "/guilds/10/channels", reason: "setup",
+			body: `{"name":"general","type":0,"position":0}`,
+		},
+		{
+			name: "ModifyChannel",
+			call: func(c *RestClient) error {
+				_, err := c.ModifyChannel(ctx, "20", &ModifyChannelParams{RTCRegion: Null[string]()}, "")
+				return err
+			},
+			method: "PATCH", path: "/channels/20",
+			body: `{"rtc_region":null}`,
+		},
+		{
+			name:   "DeleteChannel",
+			call:   func(c *RestClient) error { return c.DeleteChannel(ctx, "20", "cleanup") },
+			method: "DELETE", path: "/channels/20", reason: "cleanup",
+		},
+		{
+			name: "EditChannelPermissions",
+			call: func(c *RestClient) error {
+				return c.EditChannelPermissions(ctx, "20", "30", &EditChannelPermissionsParams{Allow: "1024", Deny: "0", Type: 0}, "")
+			},
+			method: "PUT", path: "/channels/20/permissions/30",
+			body: `{"allow":"1024","deny":"0","type":0}`,
+		},
+		{
+			name: "ModifyRole",
+			call: func(c *RestClient) error {
+				_, err := c.ModifyRole(ctx, "10", "40", &RoleParams{Hoist: Ptr(false)}, "demote")
+				return err
+			},
+			method: "PATCH", path: "/guilds/10/roles/40", reason: "demote",
+			body: `{"hoist":false}`,
+		},
+		{
+			name: "ModifyMember",
+			call: func(c *RestClient) error {
+				return c.ModifyMember(ctx, "10", "50", &ModifyMemberParams{Nick: Null[string]()}, "")
+			},
+			method: "PATCH", path: "/guilds/10/members/50",
+			body: `{"nick":null}`,
+		},
+		{
+			name: "CreateGuildBan",
+			call: func(c *RestClient) error {
+				return c.CreateGuildBan(ctx, "10", "50", &CreateGuildBanParams{DeleteMessageSeconds: Ptr(3600)}, "spam")
+			},
+			method: "PUT", path: "/guilds/10/bans/50", reason: "spam",
+			body: `{"delete_message_seconds":3600}`,
+		},
+		{
+			name:   "AddThreadMember",
+			call:   func(c *RestClient) error { return c.AddThreadMember(ctx, "60", "@me", "") },
+			method: "PUT", path: "/channels/60/thread-members/@me",
+		},
+		{
+			name: "StartThreadFromMessage",
+			call: func(c *RestClient) error {
+				_, err := c.StartThreadFromMessage(ctx, "20", "70", &StartThreadParams{Name: "t"}, "")
+				return err
+			},
+			method: "POST", path: "/channels/20/messages/70/threads",
+			body: `{"name":"t"}`,
+		},
+		{
+			name:   "UnpinMessage",
+			call:   func(c *RestClient) error { return c.UnpinMessage(ctx, "20", "70", "") },
+			method: "DELETE", path: "/channels/20/pins/70",
+		},
+		{
+			name: "ModifyGuild",
+			call: func(c *RestClient) error {
+				_, err := c.ModifyGuild(ctx, "10", &ModifyGuildParams{SystemChannelID: Null[string]()}, "")
+				return err
+			},
+			method: "PATCH", path: "/guilds/10",
+			body: `{"system_channel_id":null}`,
+		},
+		{
+			name: "ModifyGuildOnboarding",
+			call: func(c *RestClient) error {
+				_, err := c.ModifyGuildOnboarding(ctx, "10", json.RawMessage(`{"enabled":false}`), "off")
+				return err
+			},
+			method: "PUT", path: "/guilds/10/onboarding", reason: "off",
+			body: `{"enabled":false}`,
+		},
+		{
+			name: "ModifyGuildMemberVerification",
+			call: func(c *RestClient) error {
+				_, err := c.ModifyGuildMemberVerification(ctx, "10", json.RawMessage(`{"enabled":true}`), "")
+				return err
+			},
+			method: "PUT", path: "/guilds/10/member-verification",
+			body: `{"enabled":true}`,
+		},
+		{
+			name: "ModifyGuildWelcomeScreen",
+			call: func(c *RestClient) error {
+				_, err := c.ModifyGuildWelcomeScreen(ctx, "10", &ModifyGuildWelcomeScreenParams{Enabled: Ptr(false), WelcomeChannels: &[]WelcomeScreenChannel{}}, "")
+				return err
+			},
+			method: "PATCH", path: "/guilds/10/welcome-screen",
+			body: `{"enabled":false,"welcome_channels":[]}`,
+		},
+		{
+			name: "ModifyGuildWidget",
+			call: func(c *RestClient) error {
+				_, err := c.ModifyGuildWidget(ctx, "10", &ModifyGuildWidgetParams{Enabled: Ptr(true), ChannelID: Null[string]()}, "widget")
+				return err
+			},
+			method: "PATCH", path: "/guilds/10/widget", reason: "widget",
+			body: `{"enabled":true,"channel_id":null}`,
+		},
+		{
+			name: "CreateChannelInvite",
+			call: func(c *RestClient) error {
+				_, err := c.CreateChannelInvite(ctx, "20", &CreateChannelInviteParams{MaxAge: Ptr(0)}, "")
+				return err
+			},
+			method: "POST", path: "/channels/20/invites",
+			body: `{"max_age":0}`,
+		},
+		{
+			name:   "DeleteInvite",
+			call:   func(c *RestClient) error { return c.DeleteInvite(ctx, "abc", "expired") },
+			method: "DELETE", path: "/invites/abc", reason: "expired",
+		},
+		{
+			name: "SyncGuildTemplate",
+			call: func(c *RestClient) error {
+				_, err := c.SyncGuildTemplate(ctx, "10", "abc", "sync")
+				return err
+			},
+			method: "PUT", path: "/guilds/10/templates/abc", reason: "sync",
+		},
+		{
+			name: "CreateStageInstance",
+			call: func(c *RestClient) error {
+				_, err := c.CreateStageInstance(ctx, &CreateStageInstanceParams{ChannelID: "20", Topic: "AMA", SendStartNotification: Ptr(false)}, "")
+				return err
+			},
+			method: "POST", path: "/stage-instances",
+			body: `{"channel_id":"20","topic":"AMA","send_start_notification":false}`,
+		},
+		{
+			name: "ModifyGuildSoundboardSound",
+			call: func(c *RestClient) error {
+				_, err := c.ModifyGuildSoundboardSound(ctx, "10", "90", &ModifyGuildSoundboardSoundParams{Volume: Ptr(0.5), EmojiName: Null[string]()}, "")
+				return err
+			},
+			method: "PATCH", path: "/guilds/10/soundboard-sounds/90",
+			body: `{"volume":0.5,"emoji_name":null}`,
+		},
+		{
+			name: "CreateAutoModRuleJSON",
+			call: func(c *RestClient) error {
+				_, err := c.CreateAutoModRuleJSON(ctx, "10", json.RawMessage(`{"name":"n","not_modeled":1}`), "")
+				return err
+			},
+			method: "POST", path: "/guilds/10/auto-moderation/rules",
+			body: `{"name":"n","not_modeled":1}`,
+		},
+		{
+			name:   "DeleteAutoModRule",
+			call:   func(c *RestClient) error { return c.DeleteAutoModRule(ctx, "10", "80", "") },
+			method: "DELETE", path: "/guilds/10/auto-moderation/rules/80",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			c, seen := newAPITestClient(t, `{}`)
+			if err := tc.call(c); err != nil {
+				t.Fatalf("call failed: %v", err)
+			}
+			if len(*seen) != 1 {
+				t.Fatalf("expected 1 request, got %d", len(*seen))
+			}
+			got := (*seen)[0]
+			if got.Method != tc.method || got.Path != tc.path {
+				t.Fatalf("route = %s %s, want %s %s", got.Method, got.Path, tc.method, tc.path)
+			}
+			if got.Reason != tc.reason {
+				t.Fatalf("reason = %q, want %q", got.Reason, tc.reason)
+			}
+			if strings.TrimSpace(got.Body) != tc.body {
+				t.Fatalf("body = %s, want %s", got.Body, tc.body)
+			}
+		})
+	}
+}
+
+func TestAPI_DecodesResponses(t *testing.T) {
+	c, _ := newAPITestClient(t, `{"id":"20","type":15,"guild_id":"10","parent_id":null,"available_tags":[{"id":"1","name":"bug","moderated":false,"emoji_id":null,"emoji_name":"🐛"}],"default_reaction_emoji":{"emoji_id":null,"emoji_name":"👍"}}`)
+
+	ch, err := c.GetChannel(context.Background(), "20")
+	if err != nil {
+		t.Fatalf("GetChannel: %v", err)
+	}
+	if ch.ID != "20" || ch.Type != 15 || ch.GuildID != "10" || ch.ParentID != "" {
+		t.Fatalf("unexpected channel: %#v", ch)
+	}
+	if len(ch.AvailableTags) != 1 || ch.AvailableTags[0].EmojiName != "🐛" {
+		t.Fatalf("unexpected tags: %#v", ch.AvailableTags)
+	}
+	if ch.DefaultReactionEmoji == nil || ch.DefaultReactionEmoji.EmojiName != "👍" {
+		t.Fatalf("unexpected default reaction: %#v", ch.DefaultReactionEmoji)
+	}
+}
+
+func TestWebhookExecuteURL(t *testing.T) {
+	c := NewRestClient("t", nil)
+	c.BaseURL = APIBaseURL("http://localhost:8080/discord/", 9)
+	if got, want := c.WebhookExecuteURL("1", "tok"), "http://localhost:8080/discord/v9/webhooks/1/tok"; got != want {
+		t.Fatalf("WebhookExecuteURL = %s, want %s", got, want)
+	}
+}
+
+func TestListThreadMembers_Query(t *testing.T) {
+	c, seen := newAPITestClient(t, `[{"id":"60","user_id":"50","join_timestamp":"2024-01-01T00:00:00Z","flags":0}]`)
+
+	members, err := c.ListThreadMembers(context.Background(), "60", &ListThreadMembersParams{WithMember: Ptr(true), After: "5", Limit: 10})
+	if err != nil {
+		t.Fatalf("ListThreadMembers: %v", err)
+	}
+	if len(members) != 1 || members[0].UserID != "50" {
+		t.Fatalf("unexpected members: %#v", members)
+	}
+
+	q := (*seen)[0].Query
+	if q.Get("with_member") != "true" || q.Get("after") != "5" || q.Get("limit") != "10" {
+		t.Fatalf("unexpected query: %v", q)
+	}
+
+	if _, err := c.ListThreadMembers(context.Background(), "60", nil); err != nil {
+		t.Fatalf("ListThreadMembers(nil): %v", err)
+	}
+	if len((*seen)[1].Query) != 0 {
+		t.Fatalf("expected no query for nil params, got %v", (*seen)[1].Query)
+	}
+}
+
+func TestCreateGuildSticker_Multipart(t *testing.T) {
+	c, seen := newAPITestClient(t, `{"id":"90","name":"wave"}`)
+
+	out, err := c.CreateGuildSticker(context.Background(), "10", &CreateStickerParams{
+		Name:        "wave",
+		Description: "hello",
+		Tags:        "wave",
+		FileName:    "wave.png",
+		File:        []byte("PNG"),
+	}, "")
+	if err != nil {
+		t.Fatalf("CreateGuildSticker: %v", err)
+	}
+	if out.ID != "90" {
+		t.Fatalf("unexpected sticker: %#v", out)
+	}
+
+	got := (*seen)[0]
+	if got.Method != "POST" || got.Path != "/guilds/10/stickers" {
+		t.Fatalf("route = %s %s", got.Method, got.Path)
+	}
+	for _, want := range []string{`name="name"`, `name="description"`, `name="tags"`, `name="file"; filename="wave.png"`, "PNG"} {
+		if !strings.Contains(got.Body, want) {
+			t.Fatalf("multipart body missing %q:\n%s", want, got.Body)
+		}
+	}
+}
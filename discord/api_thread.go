@@ -0,0 +1,101 @@
+package discord
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+)
+
+// ThreadMetadata holds the thread-only fields of a Channel.
+type ThreadMetadata struct {
+	Archived            bool   `json:"archived"`
+	AutoArchiveDuration int    `json:"auto_archive_duration"`
+	ArchiveTimestamp    string `json:"archive_timestamp"`
+	Locked              bool   `json:"locked"`
+	Invitable           bool   `json:"invitable"`
+	CreateTimestamp     string `json:"create_timestamp"`
+}
+
+// ThreadMember is a user that has joined a thread. Member is only set when listing
+// with WithMember.
+type ThreadMember struct {
+	ID            string  `json:"id"`
+	UserID        string  `json:"user_id"`
+	JoinTimestamp string  `json:"join_timestamp"`
+	Flags         int     `json:"flags"`
+	Member        *Member `json:"member"`
+}
+
+// StartThreadParams is the body of POST /channels/{channel.id}/threads and
+// POST /channels/{channel.id}/messages/{message.id}/threads.
+type StartThreadParams struct {
+	Name                string `json:"name"`
+	AutoArchiveDuration *int   `json:"auto_archive_duration,omitempty"`
+	RateLimitPerUser    *int   `json:"rate_limit_per_user,omitempty"`
+
+	// Type and Invitable apply to threads started without a message.
+	Type      *uint `json:"type,omitempty"`
+	Invitable *bool `json:"invitable,omitempty"`
+
+	// Message and AppliedTags apply to posts in forum and media channels.
+	Message     *ForumThreadMessageParams `json:"message,omitempty"`
+	AppliedTags []string                  `json:"applied_tags,omitempty"`
+}
+
+// ForumThreadMessageParams is the first message of a forum or media channel post.
+type ForumThreadMessageParams struct {
+	Content string  `json:"content,omitempty"`
+	Embeds  []Embed `json:"embeds,omitempty"`
+}
+
+// ListThreadMembersParams are the query parameters of GET /channels/{channel.id}/thread-members.
+type ListThreadMembersParams struct {
+	WithMember *bool
+	After      string
+	Limit      int
+}
+
+func (p *ListThreadMembersParams) query() url.Values {
+	q := url.Values{}
+	if p == nil {
+		return q
+	}
+	if p.WithMember != nil {
+		q.Set("with_member", strconv.FormatBool(*p.WithMember))
+	}
+	if p.After != "" {
+		q.Set("after", p.After)
+	}
+	if p.Limit > 0 {
+		q.Set("limit", strconv.Itoa(p.Limit))
+	}
+	return q
+}
+
+// StartThread starts a thread without a message, or a post in a forum or media channel.
+func (c *RestClient) StartThread(ctx context.Context, channelID string, params *StartThreadParams, reason string) (*Channel, error) {
+	return call[*Channel](ctx, c, "POST", "/channels/"+channelID+"/threads", nil, params, reason)
+}
+
+func (c *RestClient) StartThreadFromMessage(ctx context.Context, channelID, messageID string, params *StartThreadParams, reason string) (*Channel, error) {
+	return call[*Channel](ctx, c, "POST", "/channels/"+channelID+"/messages/"+messageID+"/threads", nil, params, reason)
+}
+
+// AddThreadMember adds a user to a thread. Use "@me" as userID to join with the bot.
+func (c *RestClient) AddThreadMember(ctx context.Context, threadID, userID, reason string) error {
+	return c.DoJSONWithReason(ctx, "PUT", "/channels/"+threadID+"/thread-members/"+userID, nil, nil, nil, reason)
+}
+
+// GetThreadMember returns a member of a thread. Use "@me" as userID for the bot.
+func (c *RestClient) GetThreadMember(ctx context.Context, threadID, userID string) (*ThreadMember, error) {
+	return call[*ThreadMember](ctx, c, "GET", "/channels/"+threadID+"/thread-members/"+userID, nil, nil, "")
+}
+
+// RemoveThreadMember removes a user from a thread. Use "@me" as userID to leave with the bot.
+func (c *RestClient) RemoveThreadMember(ctx context.Context, threadID, userID, reason string) error {
+	return c.DoJSONWithReason(ctx, "DELETE", "/channels/"+threadID+"/thread-members/"+userID, nil, nil, nil, reason)
+}
+
+func (c *RestClient) ListThreadMembers(ctx context.Context, threadID string, params *ListThreadMembersParams) ([]ThreadMember, error) {
+	return call[[]ThreadMember](ctx, c, "GET", "/channels/"+threadID+"/thread-members", params.query(), nil, "")
+}
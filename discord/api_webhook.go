@@ -0,0 +1,59 @@
+package discord
+
+import (
+	"context"
+)
+
+// Webhook is a channel webhook. Token and URL are only returned for incoming
+// webhooks the bot can manage.
+type Webhook struct {
+	ID            string `json:"id"`
+	Type          int    `json:"type"`
+	GuildID       string `json:"guild_id"`
+	ChannelID     string `json:"channel_id"`
+	User          *User  `json:"user"`
+	Name          string `json:"name"`
+	Avatar        string `json:"avatar"`
+	Token         string `json:"token"`
+	ApplicationID string `json:"application_id"`
+	URL           string `json:"url"`
+}
+
+// CreateWebhookParams is the body of POST /channels/{channel.id}/webhooks.
+type CreateWebhookParams struct {
+	Name   string  `json:"name"`
+	Avatar *string `json:"avatar,omitempty"`
+}
+
+// ModifyWebhookParams is the body of PATCH /webhooks/{webhook.id}.
+type ModifyWebhookParams struct {
+	Name      *string          `json:"name,omitempty"`
+	Avatar    Nullable[string] `json:"avatar,omitzero"`
+	ChannelID *string          `json:"channel_id,omitempty"`
+}
+
+func (c *RestClient) GetWebhook(ctx context.Context, webhookID string) (*Webhook, error) {
+	return call[*Webhook](ctx, c, "GET", "/webhooks/"+webhookID, nil, nil, "")
+}
+
+func (c *RestClient) GetChannelWebhooks(ctx context.Context, channelID string) ([]Webhook, error) {
+	return call[[]Webhook](ctx, c, "GET", "/channels/"+channelID+"/webhooks", nil, nil, "")
+}
+
+func (c *RestClient) CreateWebhook(ctx context.Context, channelID string, params *CreateWebhookParams, reason string) (*Webhook, error) {
+	return call[*Webhook](ctx, c, "POST", "/channels/"+channelID+"/webhooks", nil, params, reason)
+}
+
+func (c *RestClient) ModifyWebhook(ctx context.Context, webhookID string, params *ModifyWebhookParams, reason string) (*Webhook, error) {
+	return call[*Webhook](ctx, c, "PATCH", "/webhooks/"+webhookID, nil, params, reason)
+}
+
+// WebhookExecuteURL is the URL that executes an incoming webhook, below the
+// client's BaseURL.
+func (c *RestClient) WebhookExecuteURL(webhookID, token string) string {
+	return c.BaseURL + "/webhooks/" + webhookID + "/" + token
+}
+
+func (c *RestClient) DeleteWebhook(ctx context.Context, webhookID, reason string) error {
+	return c.DoJSONWithReason(ctx, "DELETE", "/webhooks/"+webhookID, nil, nil, nil, reason)
+}
@@ -0,0 +1,111 @@
+package discord
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"text/template"
+)
+
+const (
+	// WorkspaceEnv names the Terraform workspace for ReasonData.Workspace.
+	// Terraform does not pass the workspace to providers; CI sets this variable,
+	// and the terraform CLI reads it to select the workspace.
+	WorkspaceEnv = "TF_WORKSPACE"
+	// ChangeTicketEnv holds the change ticket for ReasonData.Ticket. It is the
+	// environment form of a change_ticket input variable.
+	ChangeTicketEnv = "TF_VAR_change_ticket"
+)
+
+// maxAuditLogReason is the longest X-Audit-Log-Reason Discord accepts.
+const maxAuditLogReason = 512
+
+// ReasonData is what a default audit log reason template can refer to.
+type ReasonData struct {
+	// ResourceType is the Terraform type of the resource being applied, e.g. discord_role.
+	ResourceType string
+	// Operation is create, read, update or delete.
+	Operation string
+	// Workspace is $TF_WORKSPACE, or "default".
+	Workspace string
+	// Ticket is $TF_VAR_change_ticket, or "".
+	Ticket string
+}
+
+// ReasonTemplate renders the audit log reason of requests that have none of
+// their own, from a text/template such as
+// "terraform {{.Operation}} {{.ResourceType}} ({{.Ticket}})".
+type ReasonTemplate struct {
+	tmpl *template.Template
+}
+
+// ParseReasonTemplate parses text and checks that it only refers to fields of
+// ReasonData.
+func ParseReasonTemplate(text string) (*ReasonTemplate, error) {
+	tmpl, err := template.New("reason").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, err
+	}
+	if err := tmpl.Execute(new(strings.Builder), ReasonData{}); err != nil {
+		return nil, err
+	}
+	return &ReasonTemplate{tmpl: tmpl}, nil
+}
+
+// Render returns the reason for a request made with ctx on one line, truncated
+// to the length Discord accepts.
+func (t *ReasonTemplate) Render(ctx context.Context) (string, error) {
+	op := operationFrom(ctx)
+	data := ReasonData{
+		ResourceType: op.resourceType,
+		Operation:    op.operation,
+		Workspace:    os.Getenv(WorkspaceEnv),
+		Ticket:       os.Getenv(ChangeTicketEnv),
+	}
+	if data.Workspace == "" {
+		data.Workspace = "default"
+	}
+	var b strings.Builder
+	if err := t.tmpl.Execute(&b, data); err != nil {
+		return "", fmt.Errorf("rendering default audit log reason: %w", err)
+	}
+	// Fields left empty would leave runs of spaces; audit log reasons are one line.
+	return truncateReason(strings.Join(strings.Fields(b.String()), " ")), nil
+}
+
+// truncateReason cuts reason to the maxAuditLogReason characters Discord
+// accepts.
+func truncateReason(reason string) string {
+	if r := []rune(reason); len(r) > maxAuditLogReason {
+		return string(r[:maxAuditLogReason])
+	}
+	return reason
+}
+
+type operationKey struct{}
+
+type operation struct {
+	resourceType string
+	operation    string
+}
+
+// WithOperation returns a context whose requests are made on behalf of the
+// given Terraform operation (create, read, update or delete) of resourceType.
+func WithOperation(ctx context.Context, resourceType, op string) context.Context {
+	return context.WithValue(ctx, operationKey{}, operation{resourceType: resourceType, operation: op})
+}
+
+func operationFrom(ctx context.Context) operation {
+	op, _ := ctx.Value(operationKey{}).(operation)
+	return op
+}
+
+// auditLogReason returns the reason to send with a request: the caller's, or
+// for writes without one, the rendered DefaultReason.
+func (c *RestClient) auditLogReason(ctx context.Context, method, reason string) (string, error) {
+	if reason != "" || c.DefaultReason == nil || method == "GET" {
+		return reason, nil
+	}
+	return c.DefaultReason.Render(ctx)
+}
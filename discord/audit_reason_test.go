@@ -0,0 +1,84 @@
+package discord
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestReasonTemplate_Render(t *testing.T) {
+	t.Setenv(WorkspaceEnv, "")
+	t.Setenv(ChangeTicketEnv, "CHG-42")
+
+	tmpl, err := ParseReasonTemplate("terraform {{.Operation}} {{.ResourceType}} in {{.Workspace}}{{with .Ticket}} ({{.}}){{end}}")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := tmpl.Render(WithOperation(context.Background(), "discord_role", "update"))
+	if err != nil || got != "terraform update discord_role in default (CHG-42)" {
+		t.Fatalf("Render = %q, %v", got, err)
+	}
+
+	t.Setenv(WorkspaceEnv, "prod")
+	t.Setenv(ChangeTicketEnv, "")
+	got, _ = tmpl.Render(context.Background())
+	if got != "terraform in prod" {
+		t.Fatalf("Render without an operation = %q", got)
+	}
+
+	long, _ := ParseReasonTemplate(strings.Repeat("é", 600))
+	if got, _ := long.Render(context.Background()); len([]rune(got)) != maxAuditLogReason {
+		t.Fatalf("expected the reason to be cut to %d characters, got %d", maxAuditLogReason, len([]rune(got)))
+	}
+
+	for _, text := range []string{"{{.Operator}}", "{{.Operation"} {
+		if _, err := ParseReasonTemplate(text); err == nil {
+			t.Errorf("ParseReasonTemplate(%q): expected an error", text)
+		}
+	}
+}
+
+func TestRestClient_DefaultReason(t *testing.T) {
+	t.Parallel()
+
+	var mu sync.Mutex
+	reasons := map[string]string{}
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		reason, _ := url.QueryUnescape(r.Header.Get("X-Audit-Log-Reason"))
+		mu.Lock()
+		reasons[r.Method] = reason
+		mu.Unlock()
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer s.Close()
+
+	c := NewRestClient("TOKEN", s.Client())
+	c.BaseURL = s.URL
+	c.DefaultReason, _ = ParseReasonTemplate("terraform {{.Operation}} {{.ResourceType}}")
+	ctx := WithOperation(context.Background(), "discord_ban", "delete")
+
+	if err := c.DoJSON(ctx, "GET", "/x", nil, nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.DoJSONWithReason(ctx, "DELETE", "/x", nil, nil, nil, ""); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.DoMultipartWithReason(ctx, "PATCH", "/x", nil, map[string]string{"a": "b"}, "", "", nil, nil, "spam"); err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]string{
+		"GET":    "",
+		"DELETE": "terraform delete discord_ban",
+		"PATCH":  "spam",
+	}
+	for method, reason := range want {
+		if reasons[method] != reason {
+			t.Errorf("%s reason = %q, want %q", method, reasons[method], reason)
+		}
+	}
+}
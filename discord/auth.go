@@ -0,0 +1,215 @@
+package discord
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// AuthScheme selects the Authorization header of a request.
+type AuthScheme int
+
+const (
+	// AuthBot sends the bot token: "Authorization: Bot <token>".
+	AuthBot AuthScheme = iota
+	// AuthBearer sends an OAuth2 access token: "Authorization: Bearer <token>",
+	// the configured user token or one from the client credentials grant. Some
+	// endpoints, such as application command permissions, only accept Bearer
+	// tokens.
+	AuthBearer
+)
+
+// DefaultOAuth2Scopes are requested by the client credentials grant when no
+// scopes are configured. A client credentials token acts for the application
+// itself, so it can update the application's commands but not edit their
+// permissions in a guild, which needs Config.BearerToken.
+var DefaultOAuth2Scopes = []string{"applications.commands.update"}
+
+// tokenExpiryMargin is how long before its expiry an access token is replaced,
+// so that it does not expire between being handed out and reaching Discord.
+const tokenExpiryMargin = time.Minute
+
+func (s AuthScheme) String() string {
+	if s == AuthBearer {
+		return "bearer"
+	}
+	return "bot"
+}
+
+// ParseAuthScheme parses "bot" or "bearer"; empty means AuthBot.
+func ParseAuthScheme(s string) (AuthScheme, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "", "bot":
+		return AuthBot, nil
+	case "bearer":
+		return AuthBearer, nil
+	}
+	return AuthBot, fmt.Errorf("unknown auth scheme %q: expected \"bot\" or \"bearer\"", s)
+}
+
+type authSchemeKey struct{}
+
+// WithAuth returns a context whose requests authenticate with scheme.
+func WithAuth(ctx context.Context, scheme AuthScheme) context.Context {
+	return context.WithValue(ctx, authSchemeKey{}, scheme)
+}
+
+func authSchemeFrom(ctx context.Context) AuthScheme {
+	s, _ := ctx.Value(authSchemeKey{}).(AuthScheme)
+	return s
+}
+
+// ClientCredentials exchanges an application's client ID and secret for Bearer
+// access tokens at TokenURL (POST /oauth2/token, grant_type=client_credentials).
+// The token is cached and replaced shortly before it expires, or when Discord
+// rejects it.
+type ClientCredentials struct {
+	ClientID string
+	Secret   string
+	Scopes   []string
+	// TokenURL is the token endpoint, e.g. https://discord.com/api/v10/oauth2/token.
+	TokenURL string
+	HTTP     *http.Client
+
+	now func() time.Time
+
+	mu      sync.Mutex
+	token   string
+	expires time.Time
+}
+
+// NewClientCredentials returns a token source for the client credentials grant.
+// Empty scopes use DefaultOAuth2Scopes.
+func NewClientCredentials(clientID, secret string, scopes []string, tokenURL string, httpClient *http.Client) *ClientCredentials {
+	if len(scopes) == 0 {
+		scopes = DefaultOAuth2Scopes
+	}
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return &ClientCredentials{
+		ClientID: clientID,
+		Secret:   secret,
+		Scopes:   scopes,
+		TokenURL: tokenURL,
+		HTTP:     httpClient,
+		now:      time.Now,
+	}
+}
+
+// oauth2TokenResponse is the body of a successful POST /oauth2/token.
+type oauth2TokenResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int    `json:"expires_in"`
+	Scope       string `json:"scope"`
+}
+
+// OAuth2Token is an access token from the client credentials grant.
+type OAuth2Token struct {
+	AccessToken string
+	// Scope is the space-separated scopes Discord granted.
+	Scope   string
+	Expires time.Time
+}
+
+// Token returns a valid access token, requesting a new one when needed.
+// Concurrent callers share one request.
+func (cc *ClientCredentials) Token(ctx context.Context) (string, error) {
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+	if cc.token != "" && cc.now().Add(tokenExpiryMargin).Before(cc.expires) {
+		return cc.token, nil
+	}
+
+	tok, err := cc.Exchange(ctx)
+	if err != nil {
+		return "", err
+	}
+	cc.token = tok.AccessToken
+	cc.expires = tok.Expires
+	return cc.token, nil
+}
+
+// Exchange requests a new access token. Unlike Token it neither uses nor
+// replaces the cached one, so the caller owns the token it gets.
+func (cc *ClientCredentials) Exchange(ctx context.Context) (*OAuth2Token, error) {
+	form := url.Values{}
+	form.Set("grant_type", "client_credentials")
+	form.Set("scope", strings.Join(cc.Scopes, " "))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, cc.TokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.SetBasicAuth(cc.ClientID, cc.Secret)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", userAgent())
+
+	res, err := cc.HTTP.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("requesting OAuth2 token: %w", err)
+	}
+	raw, _ := io.ReadAll(res.Body)
+	_ = res.Body.Close()
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		// Token endpoint errors are {"error": "...", "error_description": "..."}.
+		var e struct {
+			Error       string `json:"error"`
+			Description string `json:"error_description"`
+		}
+		_ = json.Unmarshal(raw, &e)
+		msg := strings.TrimSpace(e.Error + ": " + e.Description)
+		if e.Error == "" {
+			msg = http.StatusText(res.StatusCode)
+		}
+		return nil, fmt.Errorf("requesting OAuth2 token: HTTP %d: %s (check client_id, secret and oauth2_scopes)", res.StatusCode, msg)
+	}
+
+	var tok oauth2TokenResponse
+	if err := json.Unmarshal(raw, &tok); err != nil {
+		return nil, fmt.Errorf("decoding OAuth2 token response: %w", err)
+	}
+	if tok.AccessToken == "" || !strings.EqualFold(tok.TokenType, "Bearer") {
+		return nil, fmt.Errorf("OAuth2 token response has no Bearer access token")
+	}
+	return &OAuth2Token{
+		AccessToken: tok.AccessToken,
+		Scope:       tok.Scope,
+		Expires:     cc.now().Add(time.Duration(tok.ExpiresIn) * time.Second),
+	}, nil
+}
+
+// Invalidate drops token if it is the cached one, so that the next Token call
+// requests a new one.
+func (cc *ClientCredentials) Invalidate(token string) {
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+	if cc.token == token {
+		cc.token = ""
+	}
+}
+
+// authorization returns the Authorization header value for a request made with ctx.
+func (c *RestClient) authorization(ctx context.Context) (string, error) {
+	if authSchemeFrom(ctx) != AuthBearer {
+		return "Bot " + c.Token, nil
+	}
+	if c.BearerToken != "" {
+		return "Bearer " + c.BearerToken, nil
+	}
+	if c.Bearer == nil {
+		return "", fmt.Errorf("this request needs an OAuth2 Bearer token: set bearer_token, or client_id and secret, in the provider configuration")
+	}
+	tok, err := c.Bearer.Token(ctx)
+	if err != nil {
+		return "", err
+	}
+	return "Bearer " + tok, nil
+}
@@ -0,0 +1,207 @@
+package discord
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// tokenEndpoint is a stand-in for POST /oauth2/token and one API route that
+// records the Authorization header it gets.
+type tokenEndpoint struct {
+	issued  atomic.Int32
+	revoked atomic.Bool
+
+	mu    sync.Mutex
+	auths []string
+}
+
+func (e *tokenEndpoint) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	if r.URL.Path == "/api/v10/oauth2/token" {
+		id, secret, _ := r.BasicAuth()
+		_ = r.ParseForm()
+		if id != "app" || secret != "shh" || r.PostForm.Get("grant_type") != "client_credentials" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = io.WriteString(w, `{"error":"invalid_client","error_description":"bad credentials"}`)
+			return
+		}
+		n := e.issued.Add(1)
+		_, _ = fmt.Fprintf(w, `{"access_token":"tok%d","token_type":"Bearer","expires_in":3600,"scope":%q}`, n, r.PostForm.Get("scope"))
+		return
+	}
+	auth := r.Header.Get("Authorization")
+	e.mu.Lock()
+	e.auths = append(e.auths, auth)
+	e.mu.Unlock()
+	if auth == "Bearer tok1" && e.revoked.Load() {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = io.WriteString(w, `{"message":"401: Unauthorized","code":0}`)
+		return
+	}
+	_, _ = io.WriteString(w, `{}`)
+}
+
+func (e *tokenEndpoint) lastAuth() string {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.auths[len(e.auths)-1]
+}
+
+func newAuthTestClient(t *testing.T, clientID, secret string) (*RestClient, *tokenEndpoint) {
+	t.Helper()
+	e := &tokenEndpoint{}
+	s := httptest.NewServer(e)
+	t.Cleanup(s.Close)
+	ctx, err := (&Config{Token: "TOKEN", ClientID: clientID, Secret: secret, BaseURL: s.URL + "/api"}).Client()
+	if err != nil {
+		t.Fatalf("Client: %v", err)
+	}
+	ctx.Rest.MaxRetries = 0
+	return ctx.Rest, e
+}
+
+func TestAuth_PerRequestScheme(t *testing.T) {
+	t.Parallel()
+
+	c, e := newAuthTestClient(t, "app", "shh")
+	ctx := context.Background()
+
+	if err := c.DoJSON(ctx, "PUT", "/things/1", nil, nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	if got := e.lastAuth(); got != "Bot TOKEN" {
+		t.Fatalf("default auth = %q, want the bot token", got)
+	}
+
+	var wg sync.WaitGroup
+	for range 5 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := c.DoJSON(WithAuth(ctx, AuthBearer), "PUT", "/things/1", nil, nil, nil); err != nil {
+				t.Errorf("bearer request: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+	if got := e.lastAuth(); got != "Bearer tok1" {
+		t.Fatalf("bearer auth = %q, want Bearer tok1", got)
+	}
+	if n := e.issued.Load(); n != 1 {
+		t.Fatalf("expected one token to be shared, %d were issued", n)
+	}
+	if scopes := strings.Join(c.Bearer.Scopes, " "); scopes != "applications.commands.update" {
+		t.Fatalf("default scopes = %q", scopes)
+	}
+}
+
+func TestAuth_RefreshesTokens(t *testing.T) {
+	t.Parallel()
+
+	c, e := newAuthTestClient(t, "app", "shh")
+	ctx := WithAuth(context.Background(), AuthBearer)
+	now := time.Now()
+	c.Bearer.now = func() time.Time { return now }
+
+	if err := c.DoJSON(ctx, "PUT", "/things/1", nil, nil, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	// A revoked token is replaced once and the request repeated.
+	e.revoked.Store(true)
+	if err := c.DoJSON(ctx, "PUT", "/things/1", nil, nil, nil); err != nil {
+		t.Fatalf("expected the request to succeed with a new token: %v", err)
+	}
+	if got := e.lastAuth(); got != "Bearer tok2" {
+		t.Fatalf("auth after revocation = %q, want Bearer tok2", got)
+	}
+
+	// A token close to its expiry is replaced before use.
+	now = now.Add(time.Hour - 30*time.Second)
+	if err := c.DoJSON(ctx, "PUT", "/things/1", nil, nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	if got := e.lastAuth(); got != "Bearer tok3" {
+		t.Fatalf("auth near expiry = %q, want Bearer tok3", got)
+	}
+}
+
+func TestAuth_Exchange(t *testing.T) {
+	t.Parallel()
+
+	c, _ := newAuthTestClient(t, "app", "shh")
+	ctx := context.Background()
+	now := time.Now()
+	c.Bearer.now = func() time.Time { return now }
+
+	cached, err := c.Bearer.Token(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tok, err := c.Bearer.Exchange(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tok.AccessToken == cached || tok.Scope != "applications.commands.update" || !tok.Expires.Equal(now.Add(time.Hour)) {
+		t.Fatalf("Exchange = %+v, want a new token next to the cached %q", tok, cached)
+	}
+	// The exchanged token does not replace the cached one.
+	if got, _ := c.Bearer.Token(ctx); got != cached {
+		t.Fatalf("Token after Exchange = %q, want %q", got, cached)
+	}
+}
+
+func TestAuth_BearerToken(t *testing.T) {
+	t.Parallel()
+
+	c, e := newAuthTestClient(t, "app", "shh")
+	c.BearerToken = "user-token"
+	ctx := WithAuth(context.Background(), AuthBearer)
+
+	if err := c.DoJSON(ctx, "PUT", "/things/1", nil, nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	if got := e.lastAuth(); got != "Bearer user-token" {
+		t.Fatalf("bearer auth = %q, want the configured token", got)
+	}
+	if n := e.issued.Load(); n != 0 {
+		t.Fatalf("expected no client credentials exchange, %d tokens were issued", n)
+	}
+}
+
+func TestAuth_Errors(t *testing.T) {
+	t.Parallel()
+
+	if _, err := (&Config{Token: "TOKEN", ClientID: "app"}).Client(); err == nil {
+		t.Fatal("expected an error for a client ID without a secret")
+	}
+
+	c, _ := newAuthTestClient(t, "", "")
+	err := c.DoJSON(WithAuth(context.Background(), AuthBearer), "PUT", "/things/1", nil, nil, nil)
+	if err == nil || !strings.Contains(err.Error(), "set bearer_token, or client_id and secret") {
+		t.Fatalf("expected a missing credentials error, got %v", err)
+	}
+
+	c, _ = newAuthTestClient(t, "app", "wrong")
+	err = c.DoJSON(WithAuth(context.Background(), AuthBearer), "PUT", "/things/1", nil, nil, nil)
+	if err == nil || !strings.Contains(err.Error(), "invalid_client: bad credentials") {
+		t.Fatalf("expected the token endpoint's error, got %v", err)
+	}
+
+	for _, s := range []string{"", "bot", "Bearer"} {
+		if _, err := ParseAuthScheme(s); err != nil {
+			t.Errorf("ParseAuthScheme(%q): %v", s, err)
+		}
+	}
+	if _, err := ParseAuthScheme("basic"); err == nil {
+		t.Error("expected an error for an unknown scheme")
+	}
+}
@@ -1,24 +1,188 @@
 package discord
 
 import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
 	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultBaseURL is the Discord REST API root, without the version segment.
+	DefaultBaseURL = "https://discord.com/api"
+	// DefaultAPIVersion is the Discord REST API version this provider is written against.
+	DefaultAPIVersion = 10
 )
 
 type Config struct {
-	Token    string
+	Token string
+	// ClientID and Secret enable OAuth2 client credentials, for the endpoints
+	// that need a Bearer token. Both or neither must be set.
 	ClientID string
 	Secret   string
+	// OAuth2Scopes are requested with the client credentials. Empty uses DefaultOAuth2Scopes.
+	OAuth2Scopes []string
+	// BearerToken is a user's OAuth2 access token, sent instead of a client
+	// credentials token on the endpoints that need a Bearer token. Application
+	// command permissions only accept such a token.
+	BearerToken string
+
+	// DefaultServerID is the guild that guild-scoped resources and data sources
+	// use when they leave server_id unset.
+	DefaultServerID string
+	// DefaultAuditLogReason is a ReasonTemplate for the audit log reason of
+	// writes that have none of their own. Empty sends no reason.
+	DefaultAuditLogReason string
+	// ReadOnly refuses every request but GET, for plans with a token that could write.
+	ReadOnly bool
+
+	// MaxRetries is the number of retries for transient failures. Zero disables them.
+	MaxRetries int
+	// RetryMaxBackoff caps the backoff between transient retries. Zero uses DefaultRetryMaxBackoff.
+	RetryMaxBackoff time.Duration
+
+	// BaseURL is the API root without the version segment. Empty uses DefaultBaseURL.
+	BaseURL string
+	// APIVersion is appended to BaseURL as /v{APIVersion}. Zero uses DefaultAPIVersion.
+	APIVersion int
+	// ProxyURL routes all requests through this proxy. Empty uses the HTTP(S)_PROXY environment.
+	ProxyURL string
+	// RequestTimeout bounds a single HTTP attempt. Zero means no timeout.
+	RequestTimeout time.Duration
+	// CACertFile is a PEM bundle trusted in addition to the system roots.
+	CACertFile string
+	// RateLimitStateDir, when set, holds rate limit state shared with other provider
+	// processes using the same token.
+	RateLimitStateDir string
+	// MaxConcurrentRequests bounds in-flight HTTP requests. Zero means unlimited.
+	MaxConcurrentRequests int
+	// SerializeGuildMutations serializes conflicting guild mutations such as
+	// PATCH /guilds/{id}/roles and PATCH /guilds/{id}/channels per guild.
+	SerializeGuildMutations bool
+	// WrapTransport, if set, wraps the HTTP transport. Tests use it to record or
+	// replay traffic with a Recorder.
+	WrapTransport func(http.RoundTripper) http.RoundTripper
 }
 
 type Context struct {
 	Rest   *RestClient
 	Config *Config
+	// Cache holds the GET responses Rest shares between resources for the life
+	// of the provider process. Writes through Rest invalidate it.
+	Cache *ReadCache
+	// Positions merges the bulk role and channel position updates of a guild
+	// that resources submit concurrently.
+	Positions *PositionBatcher
 }
 
 func (c *Config) Client() (*Context, error) {
-	httpClient := &http.Client{Transport: http.DefaultTransport}
+	if (c.ClientID == "") != (c.Secret == "") {
+		return nil, fmt.Errorf("client_id and secret must be set together")
+	}
+	transport, err := c.transport()
+	if err != nil {
+		return nil, err
+	}
+	var rt http.RoundTripper = transport
+	if c.WrapTransport != nil {
+		rt = c.WrapTransport(rt)
+	}
+	if file := strings.TrimSpace(os.Getenv(FaultRulesEnv)); file != "" {
+		rules, err := LoadFaultRules(file)
+		if err != nil {
+			return nil, err
+		}
+		rt = NewFaultTransport(rules, rt)
+	}
+	httpClient := &http.Client{
+		Transport: rt,
+		Timeout:   c.RequestTimeout,
+	}
+
+	rest := NewRestClient(c.Token, httpClient)
+	rest.BaseURL = APIBaseURL(c.BaseURL, c.APIVersion)
+	rest.BearerToken = c.BearerToken
+	rest.MaxRetries = c.MaxRetries
+	rest.ReadOnly = c.ReadOnly
+	if c.RetryMaxBackoff > 0 {
+		rest.RetryMaxBackoff = c.RetryMaxBackoff
+	}
+	if c.MaxConcurrentRequests > 0 || c.SerializeGuildMutations {
+		rest.limiter = newConcurrencyLimiter(c.MaxConcurrentRequests, c.SerializeGuildMutations)
+	}
+	if dir := strings.TrimSpace(c.RateLimitStateDir); dir != "" {
+		shared, err := newSharedRateLimiter(dir, c.Token)
+		if err != nil {
+			return nil, err
+		}
+		rest.shared = shared
+	}
+	if c.ClientID != "" {
+		rest.Bearer = NewClientCredentials(c.ClientID, c.Secret, c.OAuth2Scopes, rest.BaseURL+"/oauth2/token", httpClient)
+	}
+	if c.DefaultAuditLogReason != "" {
+		tmpl, err := ParseReasonTemplate(c.DefaultAuditLogReason)
+		if err != nil {
+			return nil, fmt.Errorf("invalid default_audit_log_reason: %w", err)
+		}
+		rest.DefaultReason = tmpl
+	}
+	cache := NewReadCache(DefaultReadCacheTTL)
+	rest.cache = cache
 	return &Context{
-		Rest:   NewRestClient(c.Token, httpClient),
-		Config: c,
+		Rest:      rest,
+		Config:    c,
+		Cache:     cache,
+		Positions: NewPositionBatcher(DefaultPositionBatchWindow),
 	}, nil
 }
+
+func (c *Config) transport() (*http.Transport, error) {
+	t := http.DefaultTransport.(*http.Transport).Clone()
+
+	if p := strings.TrimSpace(c.ProxyURL); p != "" {
+		u, err := url.Parse(p)
+		if err != nil || u.Scheme == "" || u.Host == "" {
+			return nil, fmt.Errorf("invalid proxy_url %q: expected an absolute URL such as http://proxy:3128", p)
+		}
+		t.Proxy = http.ProxyURL(u)
+	}
+
+	if f := strings.TrimSpace(c.CACertFile); f != "" {
+		pem, err := os.ReadFile(f)
+		if err != nil {
+			return nil, fmt.Errorf("reading CA bundle: %w", err)
+		}
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("CA bundle %s contains no PEM certificates", f)
+		}
+		t.TLSClientConfig = &tls.Config{
+			RootCAs:    pool,
+			MinVersion: tls.VersionTLS12,
+		}
+	}
+
+	return t, nil
+}
+
+// APIBaseURL joins an API root and version into the URL prefix used for requests,
+// e.g. ("https://discord.com/api", 10) -> "https://discord.com/api/v10".
+func APIBaseURL(base string, version int) string {
+	base = strings.TrimSuffix(strings.TrimSpace(base), "/")
+	if base == "" {
+		base = DefaultBaseURL
+	}
+	if version <= 0 {
+		version = DefaultAPIVersion
+	}
+	return base + "/v" + strconv.Itoa(version)
+}
@@ -0,0 +1,144 @@
+package discord
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestAPIBaseURL(t *testing.T) {
+	cases := []struct {
+		base    string
+		version int
+		want    string
+	}{
+		{"", 0, "https://discord.com/api/v10"},
+		{"http://127.0.0.1:8080/api/", 0, "http://127.0.0.1:8080/api/v10"},
+		{"https://discord.com/api", 11, "https://discord.com/api/v11"},
+	}
+	for _, tc := range cases {
+		if got := APIBaseURL(tc.base, tc.version); got != tc.want {
+			t.Fatalf("APIBaseURL(%q, %d) = %q, want %q", tc.base, tc.version, got, tc.want)
+		}
+	}
+}
+
+func TestConfigClient_BaseURLAndVersion(t *testing.T) {
+	t.Parallel()
+
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/mock/v9/users/@me" {
+			t.Errorf("unexpected path %q", r.URL.Path)
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"id": "1"})
+	}))
+	defer s.Close()
+
+	cfg := &Config{Token: "TOKEN", BaseURL: s.URL + "/mock", APIVersion: 9}
+	client, err := cfg.Client()
+	if err != nil {
+		t.Fatalf("Client returned error: %v", err)
+	}
+	if err := client.Rest.DoJSON(context.Background(), "GET", "/users/@me", nil, nil, nil); err != nil {
+		t.Fatalf("DoJSON returned error: %v", err)
+	}
+}
+
+func TestConfigClient_CACertFile(t *testing.T) {
+	t.Parallel()
+
+	s := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"ok": true})
+	}))
+	defer s.Close()
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	block := &pem.Block{Type: "CERTIFICATE", Bytes: s.Certificate().Raw}
+	if err := os.WriteFile(caFile, pem.EncodeToMemory(block), 0o600); err != nil {
+		t.Fatalf("write CA file: %v", err)
+	}
+
+	// Without the bundle the self-signed test certificate is rejected.
+	plain, err := (&Config{Token: "TOKEN", BaseURL: s.URL, MaxRetries: 0}).Client()
+	if err != nil {
+		t.Fatalf("Client returned error: %v", err)
+	}
+	if err := plain.Rest.DoJSON(context.Background(), "GET", "/x", nil, nil, nil); err == nil {
+		t.Fatalf("expected TLS verification error without CA bundle")
+	}
+
+	trusted, err := (&Config{Token: "TOKEN", BaseURL: s.URL, CACertFile: caFile}).Client()
+	if err != nil {
+		t.Fatalf("Client returned error: %v", err)
+	}
+	if err := trusted.Rest.DoJSON(context.Background(), "GET", "/x", nil, nil, nil); err != nil {
+		t.Fatalf("DoJSON with CA bundle returned error: %v", err)
+	}
+}
+
+func TestConfigClient_InvalidCACertFile(t *testing.T) {
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	if err := os.WriteFile(caFile, []byte("not a certificate"), 0o600); err != nil {
+		t.Fatalf("write CA file: %v", err)
+	}
+	if _, err := (&Config{Token: "TOKEN", CACertFile: caFile}).Client(); err == nil {
+		t.Fatalf("expected error for CA bundle without certificates")
+	}
+	if _, err := (&Config{Token: "TOKEN", CACertFile: filepath.Join(t.TempDir(), "missing.pem")}).Client(); err == nil {
+		t.Fatalf("expected error for missing CA bundle")
+	}
+}
+
+func TestConfigClient_ProxyURL(t *testing.T) {
+	t.Parallel()
+
+	var proxied int32
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Requests through an HTTP proxy carry the absolute target URL.
+		if strings.HasPrefix(r.RequestURI, "http://discord.invalid/api/v10/") {
+			atomic.AddInt32(&proxied, 1)
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"ok": true})
+	}))
+	defer proxy.Close()
+
+	cfg := &Config{Token: "TOKEN", BaseURL: "http://discord.invalid/api", ProxyURL: proxy.URL}
+	client, err := cfg.Client()
+	if err != nil {
+		t.Fatalf("Client returned error: %v", err)
+	}
+	if err := client.Rest.DoJSON(context.Background(), "GET", "/users/@me", nil, nil, nil); err != nil {
+		t.Fatalf("DoJSON returned error: %v", err)
+	}
+	if atomic.LoadInt32(&proxied) != 1 {
+		t.Fatalf("expected request to go through the proxy")
+	}
+}
+
+func TestConfigClient_RequestTimeout(t *testing.T) {
+	t.Parallel()
+
+	release := make(chan struct{})
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer s.Close()
+	defer close(release)
+
+	cfg := &Config{Token: "TOKEN", BaseURL: s.URL, RequestTimeout: 50 * time.Millisecond}
+	client, err := cfg.Client()
+	if err != nil {
+		t.Fatalf("Client returned error: %v", err)
+	}
+	if err := client.Rest.DoJSON(context.Background(), "GET", "/x", nil, nil, nil); err == nil {
+		t.Fatalf("expected timeout error")
+	}
+}
@@ -0,0 +1,215 @@
+package discord
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// FaultRulesEnv names a JSON file of FaultRules. When set, Config.Client injects
+// the faults into every request the provider makes. It exists to test how
+// resources cope with Discord misbehaving mid-apply and must not be set otherwise.
+const FaultRulesEnv = "DISCORD_FAULT_RULES"
+
+// FaultRules is the content of a fault rules file:
+//
+//	{"rules": [
+//	  {"method": "POST", "path": "/guilds/*/channels", "status": 502, "after_send": true},
+//	  {"method": "GET", "path": "/channels/*", "status": 404, "created": true}
+//	]}
+type FaultRules struct {
+	Rules []FaultRule `json:"rules"`
+}
+
+// FaultRule replaces the response to matching requests with an error.
+//
+// Counters are kept per provider process, and Terraform starts one for every
+// plan, apply and refresh, so a rule that fires once fires once per command.
+type FaultRule struct {
+	// Method matches the HTTP method; empty matches any.
+	Method string `json:"method,omitempty"`
+	// Path is a path.Match pattern for the path below the API version, e.g.
+	// "/channels/*/messages". Empty matches any.
+	Path string `json:"path,omitempty"`
+	// Status is the status code to answer with.
+	Status int `json:"status"`
+	// Global and RetryAfter (seconds, default 0.1) shape a 429 response.
+	Global     bool    `json:"global,omitempty"`
+	RetryAfter float64 `json:"retry_after,omitempty"`
+	// AfterSend forwards the request and then discards Discord's response, so the
+	// request takes effect but the client sees the error, like a 502 from a proxy.
+	AfterSend bool `json:"after_send,omitempty"`
+	// Created limits the rule to requests for objects created through this
+	// transport, e.g. a 404 on the read that follows a create.
+	Created bool `json:"created,omitempty"`
+	// Skip lets the first Skip matching requests through.
+	Skip int `json:"skip,omitempty"`
+	// Times is how often the rule fires: zero means once, negative means always.
+	Times int `json:"times,omitempty"`
+}
+
+// LoadFaultRules reads and validates a fault rules file.
+func LoadFaultRules(file string) (*FaultRules, error) {
+	raw, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("reading fault rules: %w", err)
+	}
+	var rules FaultRules
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&rules); err != nil {
+		return nil, fmt.Errorf("parsing fault rules %s: %w", file, err)
+	}
+	for i, r := range rules.Rules {
+		if r.Status < 400 || r.Status > 599 {
+			return nil, fmt.Errorf("fault rule %d: status must be a 4xx or 5xx code, got %d", i, r.Status)
+		}
+		if _, err := path.Match(r.Path, "/"); err != nil {
+			return nil, fmt.Errorf("fault rule %d: invalid path pattern %q: %w", i, r.Path, err)
+		}
+	}
+	return &rules, nil
+}
+
+// NewFaultTransport returns an http.RoundTripper that applies rules to requests
+// sent through next. The first matching rule that still has firings left wins.
+func NewFaultTransport(rules *FaultRules, next http.RoundTripper) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	t := &faultTransport{next: next, created: map[string]bool{}}
+	if rules != nil {
+		t.rules = rules.Rules
+		t.seen = make([]int, len(rules.Rules))
+	}
+	return t
+}
+
+type faultTransport struct {
+	next  http.RoundTripper
+	rules []FaultRule
+
+	mu      sync.Mutex
+	seen    []int // matching requests per rule
+	created map[string]bool
+}
+
+var apiVersionPrefix = regexp.MustCompile(`^.*?/v\d+/`)
+
+func (t *faultTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	p := "/" + strings.TrimPrefix(apiVersionPrefix.ReplaceAllString(req.URL.Path, ""), "/")
+	rule := t.match(req.Method, p)
+
+	if rule != nil && !rule.AfterSend {
+		if req.Body != nil {
+			_ = req.Body.Close()
+		}
+		return faultResponse(req, rule), nil
+	}
+
+	res, err := t.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	if req.Method == http.MethodPost && res.StatusCode >= 200 && res.StatusCode <= 299 {
+		res.Body = t.noteCreated(res.Body)
+	}
+	if rule != nil {
+		_, _ = io.Copy(io.Discard, res.Body)
+		_ = res.Body.Close()
+		return faultResponse(req, rule), nil
+	}
+	return res, nil
+}
+
+func (t *faultTransport) match(method, p string) *FaultRule {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for i := range t.rules {
+		r := &t.rules[i]
+		if r.Method != "" && !strings.EqualFold(r.Method, method) {
+			continue
+		}
+		if r.Path != "" {
+			if ok, _ := path.Match(r.Path, p); !ok {
+				continue
+			}
+		}
+		if r.Created && !t.isCreated(p) {
+			continue
+		}
+		t.seen[i]++
+		n := t.seen[i] - r.Skip
+		if n <= 0 {
+			continue
+		}
+		if times := max(r.Times, 1); r.Times >= 0 && n > times {
+			continue
+		}
+		return r
+	}
+	return nil
+}
+
+func (t *faultTransport) isCreated(p string) bool {
+	for _, seg := range strings.Split(p, "/") {
+		if t.created[seg] {
+			return true
+		}
+	}
+	return false
+}
+
+// noteCreated remembers the ID in a create response.
+func (t *faultTransport) noteCreated(body io.ReadCloser) io.ReadCloser {
+	raw, err := io.ReadAll(body)
+	_ = body.Close()
+	var obj struct {
+		ID string `json:"id"`
+	}
+	if err == nil && json.Unmarshal(raw, &obj) == nil && obj.ID != "" {
+		t.mu.Lock()
+		t.created[obj.ID] = true
+		t.mu.Unlock()
+	}
+	return io.NopCloser(bytes.NewReader(raw))
+}
+
+func faultResponse(req *http.Request, r *FaultRule) *http.Response {
+	h := http.Header{}
+	h.Set("Content-Type", "application/json")
+	var body []byte
+	if r.Status == http.StatusTooManyRequests {
+		retryAfter := r.RetryAfter
+		if retryAfter <= 0 {
+			retryAfter = 0.1
+		}
+		body, _ = json.Marshal(discordRateLimit{Message: "You are being rate limited.", RetryAfter: retryAfter, Global: r.Global})
+		h.Set("Retry-After", strconv.FormatFloat(retryAfter, 'f', -1, 64))
+		if r.Global {
+			h.Set("X-RateLimit-Global", "true")
+			h.Set("X-RateLimit-Scope", "global")
+		}
+	} else {
+		body, _ = json.Marshal(map[string]any{"message": http.StatusText(r.Status), "code": 0})
+	}
+	return &http.Response{
+		Status:        strconv.Itoa(r.Status) + " " + http.StatusText(r.Status),
+		StatusCode:    r.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        h,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
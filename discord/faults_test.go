@@ -0,0 +1,101 @@
+package discord
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestLoadFaultRules(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		p := filepath.Join(dir, name)
+		if err := os.WriteFile(p, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		return p
+	}
+
+	rules, err := LoadFaultRules(write("ok.json", `{"rules":[{"method":"POST","path":"/guilds/*/channels","status":502,"after_send":true}]}`))
+	if err != nil {
+		t.Fatalf("LoadFaultRules: %v", err)
+	}
+	if len(rules.Rules) != 1 || !rules.Rules[0].AfterSend || rules.Rules[0].Status != 502 {
+		t.Fatalf("unexpected rules: %#v", rules)
+	}
+
+	for name, content := range map[string]string{
+		"status.json":  `{"rules":[{"status":200}]}`,
+		"pattern.json": `{"rules":[{"path":"/channels/[","status":404}]}`,
+		"unknown.json": `{"rules":[{"status":404,"after":true}]}`,
+	} {
+		if _, err := LoadFaultRules(write(name, content)); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestFaultTransport(t *testing.T) {
+	t.Parallel()
+
+	var posts, gets atomic.Int32
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodPost {
+			posts.Add(1)
+			_, _ = io.WriteString(w, `{"id":"111"}`)
+			return
+		}
+		gets.Add(1)
+		_, _ = io.WriteString(w, `{"id":"`+strings.TrimPrefix(r.URL.Path, "/api/v10/channels/")+`"}`)
+	}))
+	defer s.Close()
+
+	rules := &FaultRules{Rules: []FaultRule{
+		{Method: "POST", Path: "/guilds/*/channels", Status: 429, Global: true, RetryAfter: 0.01},
+		{Method: "POST", Path: "/guilds/*/channels", Status: 502, AfterSend: true},
+		{Method: "GET", Path: "/channels/*", Status: 404, Created: true, Skip: 1},
+	}}
+	c := NewRestClient("TOKEN", &http.Client{Transport: NewFaultTransport(rules, nil)})
+	c.BaseURL = APIBaseURL(s.URL, 10)
+	c.MaxRetries = 0
+	ctx := context.Background()
+
+	// The 429 never reaches the server and is retried; the 502 comes back after
+	// the create went through.
+	err := c.DoJSON(ctx, "POST", "/guilds/1/channels", nil, map[string]any{"name": "a"}, nil)
+	if !IsDiscordHTTPStatus(err, 502) {
+		t.Fatalf("expected a 502, got %v", err)
+	}
+	if n := posts.Load(); n != 1 {
+		t.Fatalf("expected the create to reach the server once, got %d", n)
+	}
+	if c.globalRL.cooldownUntil().IsZero() {
+		t.Fatal("expected the global 429 to set a cooldown")
+	}
+
+	// Only reads of the created object are failed, and only after the skip.
+	if err := c.DoJSON(ctx, "GET", "/channels/222", nil, nil, nil); err != nil {
+		t.Fatalf("GET of another object: %v", err)
+	}
+	if err := c.DoJSON(ctx, "GET", "/channels/111", nil, nil, nil); err != nil {
+		t.Fatalf("skipped GET: %v", err)
+	}
+	if err := c.DoJSON(ctx, "GET", "/channels/111", nil, nil, nil); !IsDiscordHTTPStatus(err, 404) {
+		t.Fatalf("expected a 404, got %v", err)
+	}
+	if err := c.DoJSON(ctx, "GET", "/channels/111", nil, nil, nil); err != nil {
+		t.Fatalf("rule should fire once: %v", err)
+	}
+	if n := gets.Load(); n != 3 {
+		t.Fatalf("expected 3 reads to reach the server, got %d", n)
+	}
+}
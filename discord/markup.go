@@ -0,0 +1,175 @@
+package discord
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Message markup: the text forms Discord renders as mentions, timestamps,
+// custom emoji and links.
+
+var (
+	markupSnowflake = regexp.MustCompile(`^[0-9]{17,20}$`)
+	// emojiName is the name rule for custom emoji: 2 to 32 letters, digits or
+	// underscores.
+	emojiName = regexp.MustCompile(`^[A-Za-z0-9_]{2,32}$`)
+	// oauth2Scope matches scope names such as bot or applications.commands.
+	oauth2Scope = regexp.MustCompile(`^[a-z][a-z0-9_]*(\.[a-z0-9_]+)*$`)
+	// markdownLineStart is what starts a heading, list item or block quote:
+	// the character to escape follows the leading spaces.
+	markdownLineStart = regexp.MustCompile(`(?m)^( *)(#|-|>|[0-9]+\.)`)
+)
+
+// TimestampStyles are the styles of timestamp markup: short and long time,
+// short and long date, short and long date with time, and relative.
+var TimestampStyles = []string{"t", "T", "d", "D", "f", "F", "R"}
+
+// OAuth2AuthorizeURL is the page that adds an application to a server.
+const OAuth2AuthorizeURL = "https://discord.com/oauth2/authorize"
+
+func checkSnowflake(what, id string) error {
+	if !markupSnowflake.MatchString(id) {
+		return fmt.Errorf("invalid %s %q: expected a Discord snowflake (17-20 digits)", what, id)
+	}
+	return nil
+}
+
+// MentionUser returns the mention of a user, <@id>.
+func MentionUser(id string) (string, error) {
+	if err := checkSnowflake("user ID", id); err != nil {
+		return "", err
+	}
+	return "<@" + id + ">", nil
+}
+
+// MentionRole returns the mention of a role, <@&id>.
+func MentionRole(id string) (string, error) {
+	if err := checkSnowflake("role ID", id); err != nil {
+		return "", err
+	}
+	return "<@&" + id + ">", nil
+}
+
+// MentionChannel returns the mention of a channel, <#id>.
+func MentionChannel(id string) (string, error) {
+	if err := checkSnowflake("channel ID", id); err != nil {
+		return "", err
+	}
+	return "<#" + id + ">", nil
+}
+
+// TimestampTag returns the markup that shows t in each reader's time zone,
+// <t:unix:style>. An empty style leaves it to Discord's default, f.
+func TimestampTag(t time.Time, style string) (string, error) {
+	unix := strconv.FormatInt(t.Unix(), 10)
+	if style == "" {
+		return "<t:" + unix + ">", nil
+	}
+	for _, s := range TimestampStyles {
+		if s == style {
+			return "<t:" + unix + ":" + style + ">", nil
+		}
+	}
+	return "", fmt.Errorf("invalid timestamp style %q: expected one of %s", style, strings.Join(TimestampStyles, ", "))
+}
+
+// CustomEmoji returns the markup of a server's custom emoji, <:name:id>, or
+// <a:name:id> when it is animated.
+func CustomEmoji(name, id string, animated bool) (string, error) {
+	if !emojiName.MatchString(name) {
+		return "", fmt.Errorf("invalid emoji name %q: expected 2-32 letters, digits or underscores", name)
+	}
+	if err := checkSnowflake("emoji ID", id); err != nil {
+		return "", err
+	}
+	prefix := "<:"
+	if animated {
+		prefix = "<a:"
+	}
+	return prefix + name + ":" + id + ">", nil
+}
+
+// MessageLink returns the link to a message. serverID is "@me" for messages
+// in direct messages.
+func MessageLink(serverID, channelID, messageID string) (string, error) {
+	if serverID != "@me" {
+		if err := checkSnowflake("server ID", serverID); err != nil {
+			return "", err
+		}
+	}
+	if err := checkSnowflake("channel ID", channelID); err != nil {
+		return "", err
+	}
+	if err := checkSnowflake("message ID", messageID); err != nil {
+		return "", err
+	}
+	return "https://discord.com/channels/" + serverID + "/" + channelID + "/" + messageID, nil
+}
+
+// markdownEscaper escapes the characters that are Markdown anywhere in a line.
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	"*", `\*`,
+	"_", `\_`,
+	"~", `\~`,
+	"`", "\\`",
+	"|", `\|`,
+	"[", `\[`,
+	"]", `\]`,
+)
+
+// EscapeMarkdown escapes text so that Discord shows it as is: bold, italics,
+// underline, strikethrough, spoilers, code, masked links, and headings, lists
+// and block quotes at the start of a line. Mentions are not Markdown and still
+// notify whoever they mention.
+func EscapeMarkdown(text string) string {
+	text = markdownEscaper.Replace(text)
+	return markdownLineStart.ReplaceAllStringFunc(text, func(m string) string {
+		// An ordered list item is escaped at its dot, everything else at its
+		// first character.
+		if strings.HasSuffix(m, ".") {
+			return m[:len(m)-1] + `\.`
+		}
+		i := len(m) - 1
+		return m[:i] + `\` + m[i:]
+	})
+}
+
+// OAuth2InviteURL returns the link that adds application clientID to a server
+// with scopes, e.g. bot and applications.commands. permissions are the bits
+// the bot role gets, "" for none. guildID preselects the server, "" for none.
+func OAuth2InviteURL(clientID, permissions string, scopes []string, guildID string) (string, error) {
+	if err := checkSnowflake("client ID", clientID); err != nil {
+		return "", err
+	}
+	if len(scopes) == 0 {
+		return "", errors.New("at least one scope is required, e.g. bot")
+	}
+	for _, s := range scopes {
+		if !oauth2Scope.MatchString(s) {
+			return "", fmt.Errorf("invalid scope %q: expected a name such as bot or applications.commands", s)
+		}
+	}
+	q := url.Values{}
+	q.Set("client_id", clientID)
+	q.Set("scope", strings.Join(scopes, " "))
+	if permissions != "" {
+		v, err := strconv.ParseUint(permissions, 0, 64)
+		if err != nil {
+			return "", fmt.Errorf("invalid permissions %q: expected a 64-bit integer string", permissions)
+		}
+		q.Set("permissions", strconv.FormatUint(v, 10))
+	}
+	if guildID != "" {
+		if err := checkSnowflake("guild ID", guildID); err != nil {
+			return "", err
+		}
+		q.Set("guild_id", guildID)
+	}
+	return OAuth2AuthorizeURL + "?" + q.Encode(), nil
+}
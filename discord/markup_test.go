@@ -0,0 +1,167 @@
+package discord
+
+import (
+	"testing"
+	"time"
+)
+
+const (
+	testUser    = "175928847299117063"
+	testChannel = "41771983423143937"
+	testMessage = "1234567890123456789"
+)
+
+func TestMentions(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		fn   func(string) (string, error)
+		id   string
+		want string
+	}{
+		{MentionUser, testUser, "<@175928847299117063>"},
+		{MentionRole, testUser, "<@&175928847299117063>"},
+		{MentionChannel, testChannel, "<#41771983423143937>"},
+	}
+	for _, tt := range tests {
+		got, err := tt.fn(tt.id)
+		if err != nil || got != tt.want {
+			t.Errorf("mention of %s = %q, %v; want %q", tt.id, got, err, tt.want)
+		}
+	}
+
+	for _, id := range []string{"", "123", "<@175928847299117063>", "17592884729911706x", "123456789012345678901"} {
+		if got, err := MentionUser(id); err == nil {
+			t.Errorf("MentionUser(%q) = %q, expected an error", id, got)
+		}
+	}
+}
+
+func TestTimestampTag(t *testing.T) {
+	t.Parallel()
+
+	ts := time.Date(2024, 1, 2, 3, 4, 5, 0, time.FixedZone("", 2*60*60))
+	tests := []struct {
+		style string
+		want  string
+	}{
+		{"", "<t:1704157445>"},
+		{"R", "<t:1704157445:R>"},
+		{"t", "<t:1704157445:t>"},
+		{"F", "<t:1704157445:F>"},
+	}
+	for _, tt := range tests {
+		got, err := TimestampTag(ts, tt.style)
+		if err != nil || got != tt.want {
+			t.Errorf("TimestampTag(%q) = %q, %v; want %q", tt.style, got, err, tt.want)
+		}
+	}
+
+	for _, style := range []string{"r", "x", "RR", ":R"} {
+		if _, err := TimestampTag(ts, style); err == nil {
+			t.Errorf("TimestampTag(%q): expected an error", style)
+		}
+	}
+}
+
+func TestCustomEmoji(t *testing.T) {
+	t.Parallel()
+
+	if got, err := CustomEmoji("party_blob", testUser, false); err != nil || got != "<:party_blob:175928847299117063>" {
+		t.Errorf("CustomEmoji = %q, %v", got, err)
+	}
+	if got, err := CustomEmoji("Wave2", testUser, true); err != nil || got != "<a:Wave2:175928847299117063>" {
+		t.Errorf("animated CustomEmoji = %q, %v", got, err)
+	}
+
+	tests := []struct{ name, id string }{
+		{"a", testUser},
+		{"with space", testUser},
+		{"colon:s", testUser},
+		{"abcdefghijklmnopqrstuvwxyz0123456", testUser},
+		{"party_blob", "123"},
+	}
+	for _, tt := range tests {
+		if got, err := CustomEmoji(tt.name, tt.id, false); err == nil {
+			t.Errorf("CustomEmoji(%q, %q) = %q, expected an error", tt.name, tt.id, got)
+		}
+	}
+}
+
+func TestMessageLink(t *testing.T) {
+	t.Parallel()
+
+	got, err := MessageLink(testUser, testChannel, testMessage)
+	if want := "https://discord.com/channels/175928847299117063/41771983423143937/1234567890123456789"; err != nil || got != want {
+		t.Errorf("MessageLink = %q, %v; want %q", got, err, want)
+	}
+	got, err = MessageLink("@me", testChannel, testMessage)
+	if want := "https://discord.com/channels/@me/41771983423143937/1234567890123456789"; err != nil || got != want {
+		t.Errorf("MessageLink in a DM = %q, %v; want %q", got, err, want)
+	}
+
+	for _, ids := range [][3]string{
+		{"", testChannel, testMessage},
+		{testUser, "@me", testMessage},
+		{testUser, testChannel, "abc"},
+	} {
+		if got, err := MessageLink(ids[0], ids[1], ids[2]); err == nil {
+			t.Errorf("MessageLink(%q) = %q, expected an error", ids, got)
+		}
+	}
+}
+
+func TestEscapeMarkdown(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct{ in, want string }{
+		{"plain text.", "plain text."},
+		{"**bold** and _it_", `\*\*bold\*\* and \_it\_`},
+		{"~~gone~~ ||spoiler||", `\~\~gone\~\~ \|\|spoiler\|\|`},
+		{"`code` [link](https://x)", "\\`code\\` \\[link\\](https://x)"},
+		{`C:\path`, `C:\\path`},
+		{"# Title\n## Sub", "\\# Title\n\\## Sub"},
+		{"- one\n  - two\n> quote", "\\- one\n  \\- two\n\\> quote"},
+		{"1. first\n10. tenth", "1\\. first\n10\\. tenth"},
+		{"a - b > c # d 1. e", "a - b > c # d 1. e"},
+		{"<@175928847299117063>", "<@175928847299117063>"},
+	}
+	for _, tt := range tests {
+		if got := EscapeMarkdown(tt.in); got != tt.want {
+			t.Errorf("EscapeMarkdown(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestOAuth2InviteURL(t *testing.T) {
+	t.Parallel()
+
+	got, err := OAuth2InviteURL(testUser, "0x800", []string{"bot", "applications.commands"}, testChannel)
+	want := "https://discord.com/oauth2/authorize?client_id=175928847299117063&guild_id=41771983423143937&permissions=2048&scope=bot+applications.commands"
+	if err != nil || got != want {
+		t.Errorf("OAuth2InviteURL = %q, %v; want %q", got, err, want)
+	}
+	got, err = OAuth2InviteURL(testUser, "", []string{"bot"}, "")
+	if want := "https://discord.com/oauth2/authorize?client_id=175928847299117063&scope=bot"; err != nil || got != want {
+		t.Errorf("OAuth2InviteURL without permissions or server = %q, %v; want %q", got, err, want)
+	}
+
+	tests := []struct {
+		client, permissions string
+		scopes              []string
+		guild               string
+	}{
+		{"app", "", []string{"bot"}, ""},
+		{testUser, "", nil, ""},
+		{testUser, "", []string{"bot applications.commands"}, ""},
+		{testUser, "", []string{"Bot"}, ""},
+		{testUser, "-1", []string{"bot"}, ""},
+		{testUser, "18446744073709551616", []string{"bot"}, ""},
+		{testUser, "", []string{"bot"}, "@me"},
+	}
+	for _, tt := range tests {
+		if got, err := OAuth2InviteURL(tt.client, tt.permissions, tt.scopes, tt.guild); err == nil {
+			t.Errorf("OAuth2InviteURL(%+v) = %q, expected an error", tt, got)
+		}
+	}
+}
@@ -0,0 +1,141 @@
+package discord
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultPositionBatchWindow is how long a position update waits for others of
+// the same guild before it is sent. Terraform applies independent resources
+// concurrently, so the updates of one apply arrive within a few milliseconds.
+const DefaultPositionBatchWindow = 200 * time.Millisecond
+
+// PositionBatcher coalesces the bulk position updates of a guild. Each of
+// PATCH /guilds/{id}/roles and PATCH /guilds/{id}/channels rewrites positions
+// for the whole guild, so when several resources send their own, whichever lands
+// last decides the order. Updates submitted within one window are merged into a
+// single request instead, and every submitter gets that request's result.
+//
+// Two updates that move the same role or channel differently fail together
+// without being sent.
+type PositionBatcher struct {
+	window time.Duration
+
+	mu      sync.Mutex
+	pending map[string]any // *positionBatch[T] by kind and guild
+}
+
+type positionBatch[T any] struct {
+	done    chan struct{}
+	entries []T
+	reasons []string
+	err     error
+}
+
+// NewPositionBatcher returns a batcher that waits window before sending.
+func NewPositionBatcher(window time.Duration) *PositionBatcher {
+	return &PositionBatcher{window: window, pending: map[string]any{}}
+}
+
+// ModifyRolePositions moves roles through c.ModifyRolePositions, together with
+// the other role moves of the guild submitted within the window.
+func (b *PositionBatcher) ModifyRolePositions(ctx context.Context, c *RestClient, guildID string, positions []RolePosition, reason string) error {
+	return submitPositions(ctx, b, "roles/"+guildID, positions, reason, func(p RolePosition) string { return p.ID },
+		func(ctx context.Context, all []RolePosition, reason string) error {
+			_, err := c.ModifyRolePositions(ctx, guildID, all, reason)
+			return err
+		})
+}
+
+// ModifyGuildChannelPositions moves channels through c.ModifyGuildChannelPositions,
+// together with the other channel moves of the guild submitted within the window.
+func (b *PositionBatcher) ModifyGuildChannelPositions(ctx context.Context, c *RestClient, guildID string, positions []ChannelPosition, reason string) error {
+	return submitPositions(ctx, b, "channels/"+guildID, positions, reason, func(p ChannelPosition) string { return p.ID },
+		func(ctx context.Context, all []ChannelPosition, reason string) error {
+			return c.ModifyGuildChannelPositions(ctx, guildID, all, reason)
+		})
+}
+
+func submitPositions[T any](ctx context.Context, b *PositionBatcher, key string, entries []T, reason string, id func(T) string, send func(context.Context, []T, string) error) error {
+	if b == nil {
+		return send(ctx, entries, reason)
+	}
+
+	b.mu.Lock()
+	batch, ok := b.pending[key].(*positionBatch[T])
+	if !ok {
+		batch = &positionBatch[T]{done: make(chan struct{})}
+		b.pending[key] = batch
+		// The batch outlives the first submitter's request, so it must not be
+		// cancelled with it.
+		go flushPositions(context.WithoutCancel(ctx), b, key, batch, id, send)
+	}
+	batch.entries = append(batch.entries, entries...)
+	if reason != "" {
+		batch.reasons = append(batch.reasons, reason)
+	}
+	b.mu.Unlock()
+
+	select {
+	case <-batch.done:
+		return batch.err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// flushPositions waits out the window, closes the batch to new entries and sends it.
+func flushPositions[T any](ctx context.Context, b *PositionBatcher, key string, batch *positionBatch[T], id func(T) string, send func(context.Context, []T, string) error) {
+	time.Sleep(b.window)
+
+	b.mu.Lock()
+	if b.pending[key] == batch {
+		delete(b.pending, key)
+	}
+	entries, reasons := batch.entries, batch.reasons
+	b.mu.Unlock()
+
+	merged, err := mergePositions(entries, id)
+	if err == nil {
+		err = send(ctx, merged, joinReasons(reasons))
+	}
+	batch.err = err
+	close(batch.done)
+}
+
+// mergePositions drops repeated entries and reports entries that move the same
+// object differently.
+func mergePositions[T any](entries []T, id func(T) string) ([]T, error) {
+	seen := map[string]T{}
+	out := make([]T, 0, len(entries))
+	for _, e := range entries {
+		prev, ok := seen[id(e)]
+		if !ok {
+			seen[id(e)] = e
+			out = append(out, e)
+			continue
+		}
+		if !reflect.DeepEqual(prev, e) {
+			return nil, fmt.Errorf("conflicting position updates for %s in the same apply: each role or channel may only be ordered by one resource", id(e))
+		}
+	}
+	return out, nil
+}
+
+// joinReasons combines the distinct audit log reasons of a batch, cut to the
+// length Discord accepts.
+func joinReasons(reasons []string) string {
+	var out []string
+	seen := map[string]bool{}
+	for _, r := range reasons {
+		if r != "" && !seen[r] {
+			seen[r] = true
+			out = append(out, r)
+		}
+	}
+	return truncateReason(strings.Join(out, "; "))
+}
@@ -0,0 +1,153 @@
+package discord
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+type positionRequest struct {
+	path   string
+	reason string
+	body   []map[string]any
+}
+
+func newPositionTestClient(t *testing.T) (*RestClient, func() []positionRequest) {
+	t.Helper()
+	var mu sync.Mutex
+	var reqs []positionRequest
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body []map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		reason, _ := url.QueryUnescape(r.Header.Get("X-Audit-Log-Reason"))
+		mu.Lock()
+		reqs = append(reqs, positionRequest{path: r.URL.Path, reason: reason, body: body})
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		if strings.HasSuffix(r.URL.Path, "/roles") {
+			_, _ = w.Write([]byte(`[]`))
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(s.Close)
+	c := NewRestClient("TOKEN", s.Client())
+	c.BaseURL = s.URL
+	c.MaxRetries = 0
+	return c, func() []positionRequest {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]positionRequest(nil), reqs...)
+	}
+}
+
+func TestPositionBatcher_MergesConcurrentUpdates(t *testing.T) {
+	t.Parallel()
+
+	c, requests := newPositionTestClient(t)
+	b := NewPositionBatcher(50 * time.Millisecond)
+	ctx := context.Background()
+
+	var wg sync.WaitGroup
+	submit := func(f func() error) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := f(); err != nil {
+				t.Errorf("submit: %v", err)
+			}
+		}()
+	}
+	submit(func() error {
+		return b.ModifyRolePositions(ctx, c, "1", []RolePosition{{ID: "10", Position: Ptr(1)}}, "first")
+	})
+	submit(func() error {
+		return b.ModifyRolePositions(ctx, c, "1", []RolePosition{{ID: "11", Position: Ptr(2)}, {ID: "10", Position: Ptr(1)}}, "second")
+	})
+	submit(func() error {
+		return b.ModifyRolePositions(ctx, c, "2", []RolePosition{{ID: "20", Position: Ptr(1)}}, "")
+	})
+	submit(func() error {
+		return b.ModifyGuildChannelPositions(ctx, c, "1", []ChannelPosition{{ID: "30", Position: Ptr(0)}}, "")
+	})
+	wg.Wait()
+
+	byPath := map[string][]positionRequest{}
+	for _, r := range requests() {
+		byPath[r.path] = append(byPath[r.path], r)
+	}
+	if len(byPath) != 3 {
+		t.Fatalf("expected one request per guild and kind, got %v", byPath)
+	}
+	roles := byPath["/guilds/1/roles"]
+	if len(roles) != 1 {
+		t.Fatalf("expected the role moves of guild 1 to be merged, got %d requests", len(roles))
+	}
+	if got := len(roles[0].body); got != 2 {
+		t.Fatalf("expected 2 distinct entries, got %v", roles[0].body)
+	}
+	if r := roles[0].reason; r != "first; second" && r != "second; first" {
+		t.Fatalf("unexpected audit log reason %q", r)
+	}
+}
+
+func TestPositionBatcher_RejectsConflicts(t *testing.T) {
+	t.Parallel()
+
+	c, requests := newPositionTestClient(t)
+	b := NewPositionBatcher(50 * time.Millisecond)
+	ctx := context.Background()
+
+	errs := make([]error, 2)
+	var wg sync.WaitGroup
+	for i := range errs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = b.ModifyRolePositions(ctx, c, "1", []RolePosition{{ID: "10", Position: Ptr(i + 1)}}, "")
+		}()
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err == nil || !strings.Contains(err.Error(), "conflicting position updates for 10") {
+			t.Fatalf("expected a conflict error, got %v", err)
+		}
+	}
+	if n := len(requests()); n != 0 {
+		t.Fatalf("conflicting updates must not be sent, got %d requests", n)
+	}
+}
+
+func TestPositionBatcher_SeparateWindows(t *testing.T) {
+	t.Parallel()
+
+	c, requests := newPositionTestClient(t)
+	b := NewPositionBatcher(10 * time.Millisecond)
+	ctx := context.Background()
+
+	for i := range 2 {
+		if err := b.ModifyGuildChannelPositions(ctx, c, "1", []ChannelPosition{{ID: "30", Position: Ptr(i)}}, ""); err != nil {
+			t.Fatalf("submit %d: %v", i, err)
+		}
+	}
+	if n := len(requests()); n != 2 {
+		t.Fatalf("expected updates in separate windows to be sent separately, got %d requests", n)
+	}
+}
+
+func TestJoinReasons(t *testing.T) {
+	if got := joinReasons([]string{"a", "", "b", "a"}); got != "a; b" {
+		t.Fatalf("joinReasons = %q, want %q", got, "a; b")
+	}
+	long := []string{strings.Repeat("x", 300), strings.Repeat("y", 300)}
+	if got := joinReasons(long); len([]rune(got)) != maxAuditLogReason {
+		t.Fatalf("expected the merged reason to be cut to %d characters, got %d", maxAuditLogReason, len([]rune(got)))
+	}
+}
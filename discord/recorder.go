@@ -0,0 +1,335 @@
+package discord
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RecorderMode selects whether a Recorder captures live traffic or serves a cassette.
+type RecorderMode int
+
+const (
+	// RecorderReplay serves responses from an existing cassette and never touches the network.
+	RecorderReplay RecorderMode = iota
+	// RecorderRecord forwards requests and captures the interactions for Save.
+	RecorderRecord
+)
+
+// Cassette is the on-disk form of recorded Discord traffic.
+type Cassette struct {
+	// RecordedAt lets tests derive the same timestamps when the cassette is replayed.
+	RecordedAt   time.Time     `json:"recorded_at"`
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Interaction is one request and the response Discord sent for it. Bodies that are
+// JSON are stored as JSON; anything else, such as multipart uploads, is not kept.
+type Interaction struct {
+	Method      string            `json:"method"`
+	Path        string            `json:"path"`
+	Query       string            `json:"query,omitempty"`
+	RequestBody json.RawMessage   `json:"request_body,omitempty"`
+	Status      int               `json:"status"`
+	Headers     map[string]string `json:"headers,omitempty"`
+	Response    json.RawMessage   `json:"response,omitempty"`
+}
+
+// recordedHeaders are the response headers kept in a cassette. Rate limit headers
+// are left out so that replays never wait.
+var recordedHeaders = []string{"Content-Type"}
+
+// Recorder captures Discord HTTP interactions to a JSON cassette and replays them.
+// Plug it into Config.WrapTransport with Transport.
+//
+// Recording scrubs secrets when the cassette is saved: values passed to Redact,
+// every token field in a response (webhook, interaction and OAuth2 tokens) and
+// the IDs of users, which are replaced with stable fake snowflakes so that
+// references between objects still line up. The Authorization header is never recorded.
+//
+// Replay matches requests on method, path and query. Each interaction is served
+// once, in recorded order among requests to the same URL, so a GET after an update
+// sees the updated object. Rate limited (429) responses are not recorded; the
+// retry that follows them is.
+type Recorder struct {
+	path string
+	mode RecorderMode
+
+	mu       sync.Mutex
+	cassette Cassette
+	used     []bool
+	redact   map[string]string
+}
+
+// NewRecorder returns a recorder for the cassette at path. In replay mode the
+// cassette must exist.
+func NewRecorder(path string, mode RecorderMode) (*Recorder, error) {
+	r := &Recorder{path: path, mode: mode, redact: map[string]string{}}
+	if mode == RecorderRecord {
+		r.cassette.RecordedAt = time.Now().UTC().Truncate(time.Second)
+		return r, nil
+	}
+
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading cassette: %w", err)
+	}
+	if err := json.Unmarshal(raw, &r.cassette); err != nil {
+		return nil, fmt.Errorf("parsing cassette %s: %w", path, err)
+	}
+	r.used = make([]bool, len(r.cassette.Interactions))
+	return r, nil
+}
+
+// RecordedAt is when the cassette was recorded, or when recording started.
+func (r *Recorder) RecordedAt() time.Time {
+	return r.cassette.RecordedAt
+}
+
+// Redact replaces secret with placeholder wherever it appears in the saved
+// cassette, e.g. a bot token or the ID of the test guild.
+func (r *Recorder) Redact(secret, placeholder string) {
+	if secret == "" {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.redact[secret] = placeholder
+}
+
+// Transport returns an http.RoundTripper that records through next or replays the
+// cassette. next is unused in replay mode.
+func (r *Recorder) Transport(next http.RoundTripper) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return recorderTransport{r: r, next: next}
+}
+
+type recorderTransport struct {
+	r    *Recorder
+	next http.RoundTripper
+}
+
+func (t recorderTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.r.mode == RecorderReplay {
+		return t.r.replay(req)
+	}
+	return t.r.record(req, t.next)
+}
+
+func (r *Recorder) record(req *http.Request, next http.RoundTripper) (*http.Response, error) {
+	var reqBody []byte
+	if req.Body != nil {
+		b, err := io.ReadAll(req.Body)
+		_ = req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		reqBody = b
+		req.Body = io.NopCloser(bytes.NewReader(b))
+	}
+
+	resp, err := next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	respBody, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return resp, nil
+	}
+
+	in := Interaction{
+		Method: req.Method,
+		Path:   req.URL.Path,
+		Query:  req.URL.RawQuery,
+		Status: resp.StatusCode,
+	}
+	if json.Valid(reqBody) {
+		in.RequestBody = reqBody
+	}
+	if json.Valid(respBody) {
+		in.Response = respBody
+	}
+	for _, h := range recordedHeaders {
+		if v := resp.Header.Get(h); v != "" {
+			if in.Headers == nil {
+				in.Headers = map[string]string{}
+			}
+			in.Headers[h] = v
+		}
+	}
+
+	r.mu.Lock()
+	r.cassette.Interactions = append(r.cassette.Interactions, in)
+	r.mu.Unlock()
+	return resp, nil
+}
+
+func (r *Recorder) replay(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		_, _ = io.Copy(io.Discard, req.Body)
+		_ = req.Body.Close()
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i, in := range r.cassette.Interactions {
+		if r.used[i] || in.Method != req.Method || in.Path != req.URL.Path || in.Query != req.URL.RawQuery {
+			continue
+		}
+		r.used[i] = true
+
+		h := http.Header{}
+		for k, v := range in.Headers {
+			h.Set(k, v)
+		}
+		return &http.Response{
+			Status:        strconv.Itoa(in.Status) + " " + http.StatusText(in.Status),
+			StatusCode:    in.Status,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        h,
+			Body:          io.NopCloser(bytes.NewReader(in.Response)),
+			ContentLength: int64(len(in.Response)),
+			Request:       req,
+		}, nil
+	}
+	return nil, fmt.Errorf("cassette %s has no unused interaction for %s %s; re-record it", filepath.Base(r.path), req.Method, req.URL.RequestURI())
+}
+
+// Save writes the scrubbed cassette in record mode. It does nothing in replay mode.
+func (r *Recorder) Save() error {
+	if r.mode != RecorderRecord {
+		return nil
+	}
+	r.mu.Lock()
+	c := Cassette{RecordedAt: r.cassette.RecordedAt, Interactions: append([]Interaction(nil), r.cassette.Interactions...)}
+	replacements := r.replacements()
+	r.mu.Unlock()
+
+	for i := range c.Interactions {
+		in := &c.Interactions[i]
+		in.Path = replacements.Replace(in.Path)
+		in.Query = replacements.Replace(in.Query)
+		if in.RequestBody != nil {
+			in.RequestBody = json.RawMessage(replacements.Replace(string(in.RequestBody)))
+		}
+		if in.Response != nil {
+			in.Response = json.RawMessage(replacements.Replace(string(in.Response)))
+		}
+	}
+
+	raw, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(r.path, append(raw, '\n'), 0o644)
+}
+
+// replacements builds the scrubber for the recorded interactions. Longer secrets
+// are replaced first so that a value containing another is not split.
+func (r *Recorder) replacements() *strings.Replacer {
+	pairs := map[string]string{}
+	for k, v := range r.redact {
+		pairs[k] = v
+	}
+
+	var tokens, users []string
+	seen := map[string]bool{}
+	for _, in := range r.cassette.Interactions {
+		var v any
+		if in.Response == nil || json.Unmarshal(in.Response, &v) != nil {
+			continue
+		}
+		collectSecrets(v, func(kind, s string) {
+			if s == "" || seen[s] {
+				return
+			}
+			if _, ok := pairs[s]; ok {
+				return
+			}
+			seen[s] = true
+			if kind == "token" {
+				tokens = append(tokens, s)
+			} else {
+				users = append(users, s)
+			}
+		})
+	}
+	for i, s := range tokens {
+		pairs[s] = fmt.Sprintf("REDACTED_TOKEN_%d", i+1)
+	}
+	for i, s := range users {
+		// 18-digit snowflakes from 2015, well before any real account.
+		pairs[s] = strconv.FormatInt(100000000000000000+int64(i+1), 10)
+	}
+
+	keys := make([]string, 0, len(pairs))
+	for k := range pairs {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if len(keys[i]) != len(keys[j]) {
+			return len(keys[i]) > len(keys[j])
+		}
+		return keys[i] < keys[j]
+	})
+	args := make([]string, 0, 2*len(keys))
+	for _, k := range keys {
+		args = append(args, k, pairs[k])
+	}
+	return strings.NewReplacer(args...)
+}
+
+// userIDFields hold a user ID directly.
+var userIDFields = map[string]bool{"owner_id": true, "creator_id": true, "user_id": true}
+
+// tokenFields hold a secret token.
+var tokenFields = map[string]bool{"token": true, "access_token": true, "refresh_token": true}
+
+// collectSecrets walks a decoded JSON value and reports token fields and user IDs.
+// A user is any object with a username.
+func collectSecrets(v any, report func(kind, s string)) {
+	switch x := v.(type) {
+	case map[string]any:
+		if _, ok := x["username"]; ok {
+			if id, ok := x["id"].(string); ok {
+				report("user", id)
+			}
+		}
+		for k, child := range x {
+			switch s, isString := child.(string); {
+			case isString && tokenFields[k]:
+				report("token", s)
+			case isString && userIDFields[k]:
+				report("user", s)
+			default:
+				collectSecrets(child, report)
+			}
+		}
+	case []any:
+		for _, child := range x {
+			collectSecrets(child, report)
+		}
+	}
+}
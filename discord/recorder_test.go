@@ -0,0 +1,113 @@
+package discord
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRecorder_RecordScrubsAndReplays(t *testing.T) {
+	t.Parallel()
+
+	const (
+		botToken = "bot-secret"
+		guildID  = "123456789012345678"
+		userID   = "987654321098765432"
+		whToken  = "webhook-secret"
+	)
+
+	var calls, limited int
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/guilds/"+guildID+"/webhooks" && limited == 0 {
+			limited++
+			w.WriteHeader(http.StatusTooManyRequests)
+			_, _ = io.WriteString(w, `{"message":"You are being rate limited.","retry_after":0.01,"global":false}`)
+			return
+		}
+		calls++
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method {
+		case http.MethodPost:
+			_, _ = io.WriteString(w, `{"id":"1","name":"hook","token":"`+whToken+`","user":{"id":"`+userID+`","username":"bot"}}`)
+		default:
+			_, _ = io.WriteString(w, `[{"id":"1","name":"hook","token":"`+whToken+`","user":{"id":"`+userID+`","username":"bot"}}]`)
+		}
+	}))
+	defer s.Close()
+
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	rec, err := NewRecorder(path, RecorderRecord)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec.Redact(botToken, "REDACTED")
+	rec.Redact(guildID, "200000000000000000")
+
+	c := NewRestClient(botToken, &http.Client{Transport: rec.Transport(nil)})
+	c.BaseURL = s.URL
+	var created map[string]any
+	if err := c.DoJSON(context.Background(), "POST", "/guilds/"+guildID+"/webhooks", nil, map[string]any{"name": "hook"}, &created); err != nil {
+		t.Fatalf("POST: %v", err)
+	}
+	if created["token"] != whToken {
+		t.Fatalf("recording must not change live responses, got %#v", created)
+	}
+	if err := c.DoJSON(context.Background(), "GET", "/guilds/"+guildID+"/webhooks", nil, nil, nil); err != nil {
+		t.Fatalf("GET: %v", err)
+	}
+	if err := rec.Save(); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, secret := range []string{botToken, guildID, userID, whToken} {
+		if strings.Contains(string(raw), secret) {
+			t.Fatalf("cassette contains %q:\n%s", secret, raw)
+		}
+	}
+	if n := strings.Count(string(raw), `"method"`); n != 2 {
+		t.Fatalf("expected the 429 to be dropped and 2 interactions kept, got %d:\n%s", n, raw)
+	}
+
+	replay, err := NewRecorder(path, RecorderReplay)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !replay.RecordedAt().Equal(rec.RecordedAt()) {
+		t.Fatalf("recorded_at: got %s want %s", replay.RecordedAt(), rec.RecordedAt())
+	}
+	rc := NewRestClient("REDACTED", &http.Client{Transport: replay.Transport(nil)})
+	rc.BaseURL = "http://replay.invalid"
+	rc.MaxRetries = 0
+	var got map[string]any
+	if err := rc.DoJSON(context.Background(), "POST", "/guilds/200000000000000000/webhooks", nil, map[string]any{"name": "hook"}, &got); err != nil {
+		t.Fatalf("replayed POST: %v", err)
+	}
+	if got["token"] != "REDACTED_TOKEN_1" || got["user"].(map[string]any)["id"] != "100000000000000001" {
+		t.Fatalf("unexpected replayed response: %#v", got)
+	}
+	var list []map[string]any
+	if err := rc.DoJSON(context.Background(), "GET", "/guilds/200000000000000000/webhooks", nil, nil, &list); err != nil {
+		t.Fatalf("replayed GET: %v", err)
+	}
+	if len(list) != 1 || list[0]["token"] != "REDACTED_TOKEN_1" {
+		t.Fatalf("unexpected replayed list: %#v", list)
+	}
+
+	// Every interaction is served once.
+	err = rc.DoJSON(context.Background(), "GET", "/guilds/200000000000000000/webhooks", nil, nil, nil)
+	if err == nil || !strings.Contains(err.Error(), "no unused interaction") {
+		t.Fatalf("expected an exhausted cassette error, got %v", err)
+	}
+	if calls != 2 {
+		t.Fatalf("replay must not reach the server, got %d calls", calls)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -12,15 +13,19 @@ import (
 	"strings"
 	"sync"
 	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 // Discord API error response shape.
 // Example:
 //
-//	{"message":"Missing Access","code":50001}
+//	{"message":"Invalid Form Body","code":50035,"errors":{"name":{"_errors":[{"code":"BASE_TYPE_REQUIRED","message":"This field is required"}]}}}
 type discordAPIError struct {
-	Message string `json:"message"`
-	Code    int    `json:"code"`
+	Message string          `json:"message"`
+	Code    int             `json:"code"`
+	Errors  json.RawMessage `json:"errors"`
 }
 
 // Discord rate limit response shape.
@@ -40,29 +45,45 @@ type DiscordHTTPError struct {
 	Code       int
 	Message    string
 	Raw        string
+
+	// Errors holds the per-field errors from Discord's nested "errors" object, if any.
+	Errors []DiscordFieldError
 }
 
 func (e *DiscordHTTPError) Error() string {
 	if e == nil {
 		return "discord http error <nil>"
 	}
-	if e.Message != "" {
-		if e.Code != 0 {
-			return fmt.Sprintf("discord api error %s %s: http %d (code %d) %s", e.Method, e.Path, e.StatusCode, e.Code, e.Message)
-		}
-		return fmt.Sprintf("discord api error %s %s: http %d %s", e.Method, e.Path, e.StatusCode, e.Message)
-	}
-	if e.Raw != "" {
+	var msg string
+	switch {
+	case e.Message != "" && e.Code != 0:
+		msg = fmt.Sprintf("discord api error %s %s: http %d (code %d) %s", e.Method, e.Path, e.StatusCode, e.Code, e.Message)
+	case e.Message != "":
+		msg = fmt.Sprintf("discord api error %s %s: http %d %s", e.Method, e.Path, e.StatusCode, e.Message)
+	case e.Raw != "":
 		return fmt.Sprintf("discord api error %s %s: http %d: %s", e.Method, e.Path, e.StatusCode, strings.TrimSpace(e.Raw))
+	default:
+		return fmt.Sprintf("discord api error %s %s: http %d", e.Method, e.Path, e.StatusCode)
+	}
+
+	var b strings.Builder
+	b.WriteString(msg)
+	for _, fe := range e.Errors {
+		b.WriteString("\n  ")
+		b.WriteString(fe.String())
 	}
-	return fmt.Sprintf("discord api error %s %s: http %d", e.Method, e.Path, e.StatusCode)
+	if h := e.Hint(); h != "" {
+		b.WriteString("\nHint: ")
+		b.WriteString(h)
+	}
+	return b.String()
 }
 
 func IsDiscordHTTPStatus(err error, status int) bool {
 	if err == nil {
 		return false
 	}
-	if e, ok := err.(*DiscordHTTPError); ok {
+	if e, ok := AsDiscordHTTPError(err); ok {
 		return e.StatusCode == status
 	}
 	return false
@@ -74,18 +95,65 @@ type RestClient struct {
 	HTTP      *http.Client
 	UserAgent string
 
+	// MaxRetries is the number of retries for transient failures (5xx, connection resets).
+	// Zero disables these retries; 429 responses are always retried.
+	MaxRetries int
+	// RetryMaxBackoff caps the exponential backoff between transient retries.
+	RetryMaxBackoff time.Duration
+
 	// globalRL gates requests when Discord responds with a global rate limit.
 	// Discord global limits apply across routes, so we must coordinate across concurrent requests.
 	globalRL *globalRateLimiter
+
+	// buckets tracks per-route limits from the X-RateLimit-* response headers.
+	buckets *bucketLimiter
+
+	// shared, when set, coordinates the global cooldown and buckets with other
+	// provider processes using the same token (see rate_limit_state_dir).
+	shared *sharedRateLimiter
+
+	// limiter bounds concurrent requests (max_concurrent_requests) and serializes
+	// conflicting guild mutations when enabled. Nil means no limits.
+	limiter *concurrencyLimiter
+
+	// cache, when set, serves repeated GETs and is invalidated by writes.
+	cache *ReadCache
+
+	// BearerToken, when set, is the OAuth2 access token of requests made with
+	// WithAuth(ctx, AuthBearer). Otherwise Bearer provides them.
+	BearerToken string
+	// Bearer, when set, provides the OAuth2 access tokens for requests made with
+	// WithAuth(ctx, AuthBearer).
+	Bearer *ClientCredentials
+
+	// DefaultReason, when set, renders the audit log reason of writes that have
+	// none of their own.
+	DefaultReason *ReasonTemplate
+
+	// ReadOnly makes every request but GET fail with ErrReadOnly before it is
+	// sent, so that refresh and plan cannot change anything on Discord.
+	ReadOnly bool
 }
 
+// ErrReadOnly is returned for requests that would change something when the
+// client is read-only.
+var ErrReadOnly = errors.New("the provider is read-only (read_only = true)")
+
+// maxRateLimitAttempts bounds how many 429 responses a single call waits out.
+const maxRateLimitAttempts = 10
+
 func NewRestClient(token string, httpClient *http.Client) *RestClient {
 	c := &RestClient{
-		BaseURL:   "https://discord.com/api/v10",
+		BaseURL:   APIBaseURL(DefaultBaseURL, DefaultAPIVersion),
 		Token:     token,
 		HTTP:      httpClient,
 		UserAgent: userAgent(),
-		globalRL:  &globalRateLimiter{},
+
+		MaxRetries:      DefaultMaxRetries,
+		RetryMaxBackoff: DefaultRetryMaxBackoff,
+
+		globalRL: &globalRateLimiter{},
+		buckets:  newBucketLimiter(),
 	}
 	if c.HTTP == nil {
 		c.HTTP = http.DefaultClient
@@ -108,15 +176,9 @@ func (g *globalRateLimiter) wait(ctx context.Context) error {
 			return nil
 		}
 
-		d := time.Until(until)
-		t := time.NewTimer(d)
-		select {
-		case <-ctx.Done():
-			t.Stop()
-			return ctx.Err()
-		case <-t.C:
-			// Loop in case another goroutine extends the window.
-			continue
+		// Loop afterwards in case another goroutine extends the window.
+		if err := sleepTraced(ctx, "discord.global_rate_limit_wait", time.Until(until)); err != nil {
+			return err
 		}
 	}
 }
@@ -127,148 +189,269 @@ func (g *globalRateLimiter) setCooldown(d time.Duration) {
 		d = 0
 	}
 	d += 150 * time.Millisecond
+	g.extend(time.Now().Add(d))
+}
 
+// extend moves the cooldown end to until if that is later than the current one.
+func (g *globalRateLimiter) extend(until time.Time) {
 	g.mu.Lock()
 	defer g.mu.Unlock()
 
-	until := time.Now().Add(d)
 	if until.After(g.until) {
 		g.until = until
 	}
 }
 
+// cooldownUntil returns the end of the current global cooldown (zero if none).
+func (g *globalRateLimiter) cooldownUntil() time.Time {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.until
+}
+
 func (c *RestClient) DoJSON(ctx context.Context, method, path string, query url.Values, in interface{}, out interface{}) error {
-	return c.doJSON(ctx, method, path, query, in, out, "")
+	return c.do(ctx, method, path, query, jsonBody{v: in}, out, "")
 }
 
 // DoJSONWithReason is the same as DoJSON but sets the X-Audit-Log-Reason header when provided.
 // Discord expects this header value URL-encoded.
 func (c *RestClient) DoJSONWithReason(ctx context.Context, method, path string, query url.Values, in interface{}, out interface{}, reason string) error {
-	return c.doJSON(ctx, method, path, query, in, out, reason)
+	return c.do(ctx, method, path, query, jsonBody{v: in}, out, reason)
 }
 
-func (c *RestClient) doJSON(ctx context.Context, method, path string, query url.Values, in interface{}, out interface{}, reason string) error {
-	var bodyBytes []byte
-	if in != nil {
-		b, err := json.Marshal(in)
-		if err != nil {
-			return err
+// do is the single request-execution core shared by the JSON and multipart entry points.
+// It serves GETs from the read cache, if any, and invalidates it on writes; send owns
+// URL building, rate limits, transient retries, audit log reasons and error decoding.
+// The body encoder only decides what bytes are sent.
+func (c *RestClient) do(ctx context.Context, method, path string, query url.Values, body requestBody, out interface{}, reason string) error {
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+	if c.ReadOnly && method != http.MethodGet {
+		return fmt.Errorf("%w: refusing %s %s. Only GET requests are sent in this mode; unset read_only to apply changes", ErrReadOnly, method, path)
+	}
+	reason, err := c.auditLogReason(ctx, method, reason)
+	if err != nil {
+		return err
+	}
+
+	var raw []byte
+	switch {
+	case c.cache == nil || (method == http.MethodGet && FreshReads(ctx)):
+		raw, err = c.send(ctx, method, path, query, body, reason)
+	case method == http.MethodGet:
+		key := path
+		if query != nil {
+			key += "?" + query.Encode()
 		}
-		bodyBytes = b
+		if authSchemeFrom(ctx) == AuthBearer {
+			// What a request may see depends on who makes it.
+			key = "bearer " + key
+		}
+		raw, err = c.cache.get(ctx, path, key, func() ([]byte, error) {
+			return c.send(ctx, method, path, query, body, reason)
+		})
+	default:
+		raw, err = c.send(ctx, method, path, query, body, reason)
+		// Even a failed write may have been applied.
+		c.cache.invalidate(path)
+	}
+	if err != nil || out == nil || len(raw) == 0 {
+		return err
 	}
+	return json.Unmarshal(raw, out)
+}
 
-	u, err := url.Parse(c.BaseURL)
+// send performs one logical request, with rate limit waits and retries, and
+// returns the body of the successful response.
+func (c *RestClient) send(ctx context.Context, method, path string, query url.Values, body requestBody, reason string) ([]byte, error) {
+	enc, err := body.encode()
 	if err != nil {
-		return err
+		return nil, err
 	}
-	if !strings.HasPrefix(path, "/") {
-		path = "/" + path
+
+	u, err := url.Parse(c.BaseURL)
+	if err != nil {
+		return nil, err
 	}
 	u.Path = strings.TrimSuffix(u.Path, "/") + path
 	if query != nil {
 		u.RawQuery = query.Encode()
 	}
 
-	// Retry loop for rate limits (429).
-	for attempt := 0; attempt < 10; attempt++ {
+	if c.Token != "" {
+		// Belt and braces on top of the targeted redaction in logAttempt.
+		ctx = tflog.MaskAllFieldValuesStrings(ctx, c.Token)
+		ctx = tflog.MaskMessageStrings(ctx, c.Token)
+	}
+
+	unlockGuild, err := c.limiter.lockGuild(ctx, method, path)
+	if err != nil {
+		return nil, err
+	}
+	defer unlockGuild()
+
+	// Retry loop for rate limits (429) and transient failures.
+	retries := 0
+	reauthorized := false
+	for attempt := 0; attempt < maxRateLimitAttempts; {
 		// Global limits apply across all routes; coordinate across concurrent requests.
-		if c.globalRL != nil {
-			if err := c.globalRL.wait(ctx); err != nil {
-				return err
-			}
+		if err := c.waitGlobal(ctx); err != nil {
+			return nil, err
+		}
+		// Per-route buckets; wait ahead of time instead of running into a 429.
+		if err := c.waitBucket(ctx, method, path); err != nil {
+			return nil, err
 		}
 
 		var reqBody io.Reader
-		if bodyBytes != nil {
-			reqBody = bytes.NewReader(bodyBytes)
+		if enc.data != nil {
+			reqBody = bytes.NewReader(enc.data)
 		}
 		req, err := http.NewRequestWithContext(ctx, method, u.String(), reqBody)
 		if err != nil {
-			return err
+			return nil, err
+		}
+		auth, err := c.authorization(ctx)
+		if err != nil {
+			return nil, err
 		}
-		req.Header.Set("Authorization", "Bot "+c.Token)
+		req.Header.Set("Authorization", auth)
 		req.Header.Set("User-Agent", c.UserAgent)
 		req.Header.Set("Accept", "application/json")
-		if in != nil {
-			req.Header.Set("Content-Type", "application/json")
+		if enc.contentType != "" {
+			req.Header.Set("Content-Type", enc.contentType)
 		}
 		if reason != "" {
 			// Must be URL-encoded; Discord decodes it for audit log entries.
 			req.Header.Set("X-Audit-Log-Reason", url.QueryEscape(reason))
 		}
 
+		release, err := c.limiter.acquire(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		_, span := startAttemptSpan(ctx, method, path, retries)
+		start := time.Now()
 		res, err := c.HTTP.Do(req)
 		if err != nil {
-			return err
+			release()
+			endAttemptSpan(span, 0, "", err)
+			logAttempt(ctx, req, enc, nil, nil, retries, time.Since(start), err)
+			if c.shouldRetry(method, enc.payload, retries, 0, err) {
+				if err := c.sleepBackoff(ctx, retries); err != nil {
+					return nil, err
+				}
+				retries++
+				continue
+			}
+			return nil, err
 		}
 
 		// Discord often returns useful JSON for errors; read it once.
 		raw, _ := io.ReadAll(res.Body)
 		_ = res.Body.Close()
+		release()
+		endAttemptSpan(span, res.StatusCode, res.Header.Get("X-RateLimit-Bucket"), nil)
+		logAttempt(ctx, req, enc, res, raw, retries, time.Since(start), nil)
+
+		c.updateBucket(ctx, method, path, res.Header)
 
 		if res.StatusCode == http.StatusTooManyRequests {
-			var rl discordRateLimit
-			if err := json.Unmarshal(raw, &rl); err != nil || rl.RetryAfter <= 0 {
-				// Fallback to headers, which are seconds (may be float).
-				ra := res.Header.Get("Retry-After")
-				if ra == "" {
-					ra = res.Header.Get("X-RateLimit-Reset-After")
-				}
-				if ra != "" {
-					if f, ferr := strconv.ParseFloat(ra, 64); ferr == nil {
-						rl.RetryAfter = f
-					}
-				}
-				if rl.RetryAfter <= 0 {
-					rl.RetryAfter = 1.0
-				}
-			}
-			sleep := time.Duration(rl.RetryAfter*1000.0) * time.Millisecond
+			sleep, global := parseRateLimit(raw, res.Header)
 
 			// Discord can respond with a global limit; coordinate it.
-			if rl.Global || strings.EqualFold(res.Header.Get("X-RateLimit-Global"), "true") {
-				if c.globalRL != nil {
-					c.globalRL.setCooldown(sleep)
-				}
+			if global {
+				c.setGlobalCooldown(ctx, sleep)
 			}
 
-			t := time.NewTimer(sleep)
-			select {
-			case <-ctx.Done():
-				t.Stop()
-				return ctx.Err()
-			case <-t.C:
-				continue
+			tflog.Debug(ctx, "Discord API rate limited", map[string]interface{}{
+				"method":   method,
+				"path":     redactPath(path),
+				"bucket":   res.Header.Get("X-RateLimit-Bucket"),
+				"global":   global,
+				"retry_ms": sleep.Milliseconds(),
+			})
+
+			attempt++
+			if err := sleepTraced(ctx, "discord.rate_limit_wait", sleep,
+				attribute.String("discord.rate_limit.bucket", res.Header.Get("X-RateLimit-Bucket")),
+				attribute.Bool("discord.rate_limit.global", global),
+			); err != nil {
+				return nil, err
 			}
+			continue
 		}
 
-		if res.StatusCode < 200 || res.StatusCode > 299 {
-			var apiErr discordAPIError
-			if err := json.Unmarshal(raw, &apiErr); err == nil && apiErr.Message != "" {
-				return &DiscordHTTPError{
-					Method:     method,
-					Path:       path,
-					StatusCode: res.StatusCode,
-					Code:       apiErr.Code,
-					Message:    apiErr.Message,
-				}
-			}
-			return &DiscordHTTPError{
-				Method:     method,
-				Path:       path,
-				StatusCode: res.StatusCode,
-				Raw:        string(raw),
+		// Access tokens can be revoked or expire early; get a new one once.
+		if res.StatusCode == http.StatusUnauthorized && c.BearerToken == "" && c.Bearer != nil && strings.HasPrefix(auth, "Bearer ") && !reauthorized {
+			c.Bearer.Invalidate(strings.TrimPrefix(auth, "Bearer "))
+			reauthorized = true
+			continue
+		}
+
+		// Transient server errors; only retried when replaying cannot create duplicates.
+		if c.shouldRetry(method, enc.payload, retries, res.StatusCode, nil) {
+			if err := c.sleepBackoff(ctx, retries); err != nil {
+				return nil, err
 			}
+			retries++
+			continue
 		}
 
-		if out == nil {
-			return nil
+		if res.StatusCode < 200 || res.StatusCode > 299 {
+			return nil, decodeHTTPError(method, path, res.StatusCode, raw)
 		}
-		if len(raw) == 0 || res.StatusCode == http.StatusNoContent {
-			return nil
+		return raw, nil
+	}
+
+	return nil, &DiscordHTTPError{
+		Method:     method,
+		Path:       path,
+		StatusCode: http.StatusTooManyRequests,
+		Message:    "exceeded rate limit retry attempts",
+	}
+}
+
+// parseRateLimit returns how long to wait after a 429 and whether the limit is global.
+func parseRateLimit(raw []byte, h http.Header) (time.Duration, bool) {
+	var rl discordRateLimit
+	if err := json.Unmarshal(raw, &rl); err != nil || rl.RetryAfter <= 0 {
+		// Fallback to headers, which are seconds (may be float).
+		ra := h.Get("Retry-After")
+		if ra == "" {
+			ra = h.Get("X-RateLimit-Reset-After")
+		}
+		if ra != "" {
+			if f, ferr := strconv.ParseFloat(ra, 64); ferr == nil {
+				rl.RetryAfter = f
+			}
+		}
+		if rl.RetryAfter <= 0 {
+			rl.RetryAfter = 1.0
 		}
-		return json.Unmarshal(raw, out)
 	}
+	sleep := time.Duration(rl.RetryAfter*1000.0) * time.Millisecond
+	return sleep, rl.Global || strings.EqualFold(h.Get("X-RateLimit-Global"), "true")
+}
 
-	return fmt.Errorf("discord api error %s %s: exceeded rate limit retry attempts", method, path)
+func decodeHTTPError(method, path string, status int, raw []byte) error {
+	var apiErr discordAPIError
+	if err := json.Unmarshal(raw, &apiErr); err == nil && apiErr.Message != "" {
+		return &DiscordHTTPError{
+			Method:     method,
+			Path:       path,
+			StatusCode: status,
+			Code:       apiErr.Code,
+			Message:    apiErr.Message,
+			Errors:     parseFieldErrors(apiErr.Errors),
+		}
+	}
+	return &DiscordHTTPError{
+		Method:     method,
+		Path:       path,
+		StatusCode: status,
+		Raw:        string(raw),
+	}
 }
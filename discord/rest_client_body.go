@@ -0,0 +1,144 @@
+package discord
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"mime/multipart"
+	"net/textproto"
+	"strings"
+)
+
+// requestBody encodes a request body for the shared request pipeline.
+// It is encoded once per call and the bytes are reused across retries.
+type requestBody interface {
+	encode() (encodedBody, error)
+}
+
+type encodedBody struct {
+	data        []byte
+	contentType string
+	// payload is the JSON part of the body, used to detect POSTs Discord deduplicates.
+	payload []byte
+}
+
+// jsonBody sends v as application/json. A nil v sends no body.
+type jsonBody struct {
+	v interface{}
+}
+
+func (b jsonBody) encode() (encodedBody, error) {
+	if b.v == nil {
+		return encodedBody{}, nil
+	}
+	data, err := json.Marshal(b.v)
+	if err != nil {
+		return encodedBody{}, err
+	}
+	return encodedBody{data: data, contentType: "application/json", payload: data}, nil
+}
+
+// multipartBody sends plain form fields plus at most one file (e.g. sticker and soundboard uploads).
+type multipartBody struct {
+	fields    map[string]string
+	fileField string
+	fileName  string
+	fileBytes []byte
+}
+
+func (b multipartBody) encode() (encodedBody, error) {
+	var buf bytes.Buffer
+	w := multipart.NewWriter(&buf)
+
+	for k, v := range b.fields {
+		if err := w.WriteField(k, v); err != nil {
+			return encodedBody{}, err
+		}
+	}
+	if b.fileField != "" {
+		fw, err := w.CreateFormFile(b.fileField, b.fileName)
+		if err != nil {
+			return encodedBody{}, err
+		}
+		if _, err := fw.Write(b.fileBytes); err != nil {
+			return encodedBody{}, err
+		}
+	}
+	if err := w.Close(); err != nil {
+		return encodedBody{}, err
+	}
+
+	enc := encodedBody{data: buf.Bytes(), contentType: w.FormDataContentType()}
+	if p, ok := b.fields["payload_json"]; ok {
+		enc.payload = []byte(p)
+	}
+	return enc, nil
+}
+
+// MultipartFile is one attachment sent as files[n] by DoMultipartFilesWithReason.
+type MultipartFile struct {
+	Name        string
+	ContentType string
+	Data        []byte
+}
+
+// multipartFilesBody sends a JSON payload as payload_json plus N files as files[0..N-1],
+// the format Discord uses for message and webhook attachments.
+type multipartFilesBody struct {
+	payload interface{}
+	files   []MultipartFile
+}
+
+func (b multipartFilesBody) encode() (encodedBody, error) {
+	var buf bytes.Buffer
+	w := multipart.NewWriter(&buf)
+
+	var payload []byte
+	if b.payload != nil {
+		p, err := json.Marshal(b.payload)
+		if err != nil {
+			return encodedBody{}, err
+		}
+		payload = p
+
+		h := make(textproto.MIMEHeader)
+		h.Set("Content-Disposition", `form-data; name="payload_json"`)
+		h.Set("Content-Type", "application/json")
+		pw, err := w.CreatePart(h)
+		if err != nil {
+			return encodedBody{}, err
+		}
+		if _, err := pw.Write(payload); err != nil {
+			return encodedBody{}, err
+		}
+	}
+
+	for i, f := range b.files {
+		ct := f.ContentType
+		if ct == "" {
+			ct = "application/octet-stream"
+		}
+		h := make(textproto.MIMEHeader)
+		h.Set("Content-Disposition", fmt.Sprintf(`form-data; name="files[%d]"; filename="%s"`, i, escapeQuotes(f.Name)))
+		h.Set("Content-Type", ct)
+		fw, err := w.CreatePart(h)
+		if err != nil {
+			return encodedBody{}, err
+		}
+		if _, err := fw.Write(f.Data); err != nil {
+			return encodedBody{}, err
+		}
+	}
+	if err := w.Close(); err != nil {
+		return encodedBody{}, err
+	}
+
+	return encodedBody{data: buf.Bytes(), contentType: w.FormDataContentType(), payload: payload}, nil
+}
+
+var quoteEscaper = strings.NewReplacer("\\", "\\\\", `"`, "\\\"")
+
+// escapeQuotes matches mime/multipart's escaping of form-data parameter values.
+func escapeQuotes(s string) string {
+	return quoteEscaper.Replace(s)
+}
@@ -0,0 +1,190 @@
+package discord
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// bucketLimiter tracks Discord per-route rate limit buckets so we can wait before
+// sending into an exhausted bucket instead of waiting for a 429.
+//
+// Discord identifies a bucket by the opaque X-RateLimit-Bucket hash, and the limit
+// is applied per bucket and "major parameter" (guild, channel or webhook). Several
+// routes can share one bucket hash, so we keep two maps:
+//
+//	route (method + templated path) -> bucket hash
+//	bucket hash + major parameter   -> remaining/reset state
+type bucketLimiter struct {
+	mu      sync.Mutex
+	routes  map[string]string
+	buckets map[string]*bucketState
+}
+
+type bucketState struct {
+	limit     int
+	remaining int
+	resetAt   time.Time
+}
+
+func newBucketLimiter() *bucketLimiter {
+	return &bucketLimiter{
+		routes:  map[string]string{},
+		buckets: map[string]*bucketState{},
+	}
+}
+
+// wait blocks until the bucket for method+path has capacity, then reserves one request.
+// Routes without a known bucket are not delayed.
+func (b *bucketLimiter) wait(ctx context.Context, method, path string) error {
+	for {
+		b.mu.Lock()
+		d, hash := b.reserveLocked(method, path, time.Now())
+		b.mu.Unlock()
+		if d <= 0 {
+			return nil
+		}
+
+		// Loop afterwards in case another response moved the reset window.
+		if err := sleepTraced(ctx, "discord.bucket_wait", d, attribute.String("discord.rate_limit.bucket", hash)); err != nil {
+			return err
+		}
+	}
+}
+
+// reserveLocked reserves one request in the bucket for method+path, or returns how long
+// to wait before trying again along with the bucket hash. b.mu must be held.
+func (b *bucketLimiter) reserveLocked(method, path string, now time.Time) (time.Duration, string) {
+	tmpl, major := rateLimitRoute(path)
+	hash := b.routes[method+" "+tmpl]
+	st := b.buckets[hash+":"+major]
+	if hash == "" || st == nil {
+		return 0, hash
+	}
+	if !now.Before(st.resetAt) {
+		// The window has elapsed; the next response refreshes the state.
+		return 0, hash
+	}
+	if st.remaining > 0 {
+		st.remaining--
+		return 0, hash
+	}
+	return st.resetAt.Sub(now), hash
+}
+
+// update records the bucket state from the X-RateLimit-* response headers.
+func (b *bucketLimiter) update(method, path string, h http.Header) {
+	obs, ok := parseBucketHeaders(h, time.Now())
+	if !ok {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.updateLocked(method, path, obs)
+}
+
+// bucketObservation is the bucket state reported by one response.
+type bucketObservation struct {
+	hash      string
+	limit     int
+	remaining int
+	resetAt   time.Time
+}
+
+func parseBucketHeaders(h http.Header, now time.Time) (bucketObservation, bool) {
+	hash := h.Get("X-RateLimit-Bucket")
+	if hash == "" {
+		return bucketObservation{}, false
+	}
+	remaining, err := strconv.Atoi(h.Get("X-RateLimit-Remaining"))
+	if err != nil {
+		return bucketObservation{}, false
+	}
+	resetAfter, err := strconv.ParseFloat(h.Get("X-RateLimit-Reset-After"), 64)
+	if err != nil {
+		return bucketObservation{}, false
+	}
+	limit, _ := strconv.Atoi(h.Get("X-RateLimit-Limit"))
+	return bucketObservation{
+		hash:      hash,
+		limit:     limit,
+		remaining: remaining,
+		resetAt:   now.Add(time.Duration(resetAfter * float64(time.Second))),
+	}, true
+}
+
+// updateLocked stores one bucket observation. b.mu must be held.
+func (b *bucketLimiter) updateLocked(method, path string, obs bucketObservation) {
+	tmpl, major := rateLimitRoute(path)
+	b.routes[method+" "+tmpl] = obs.hash
+	key := obs.hash + ":" + major
+	st := b.buckets[key]
+	if st == nil {
+		st = &bucketState{}
+		b.buckets[key] = st
+	}
+	st.limit = obs.limit
+	st.remaining = obs.remaining
+	st.resetAt = obs.resetAt
+}
+
+// rateLimitRoute splits an API path into a route template and its major parameter.
+//
+// Major parameters (guild, channel and webhook IDs, plus the webhook token) are kept
+// out of the template because Discord limits them independently. Other snowflakes
+// and reaction emoji are replaced with placeholders so e.g. every message in a
+// channel maps to the same route.
+//
+//	/channels/123/messages/456 -> ("/channels/:major/messages/:id", "123")
+//	/webhooks/1/abc            -> ("/webhooks/:major", "1/abc")
+func rateLimitRoute(path string) (string, string) {
+	if i := strings.IndexByte(path, '?'); i >= 0 {
+		path = path[:i]
+	}
+	segs := strings.Split(strings.Trim(path, "/"), "/")
+
+	var major string
+	start := 0
+	if len(segs) >= 2 {
+		switch segs[0] {
+		case "guilds", "channels", "webhooks":
+			major = segs[1]
+			segs[1] = ":major"
+			start = 2
+			if segs[0] == "webhooks" && len(segs) >= 3 && !isSnowflakeSegment(segs[2]) && segs[2] != "messages" {
+				major += "/" + segs[2]
+				segs = append(segs[:2], segs[3:]...)
+			}
+		}
+	}
+
+	for i := start; i < len(segs); i++ {
+		if i > 0 && segs[i-1] == "reactions" {
+			// Everything after /reactions/ (emoji, @me, user ID) shares one route.
+			segs = append(segs[:i], ":reaction")
+			break
+		}
+		if isSnowflakeSegment(segs[i]) {
+			segs[i] = ":id"
+		}
+	}
+
+	return "/" + strings.Join(segs, "/"), major
+}
+
+func isSnowflakeSegment(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,117 @@
+package discord
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRateLimitRoute(t *testing.T) {
+	cases := []struct {
+		path      string
+		wantRoute string
+		wantMajor string
+	}{
+		{"/guilds/111/roles", "/guilds/:major/roles", "111"},
+		{"/guilds/111/roles/222", "/guilds/:major/roles/:id", "111"},
+		{"/channels/333/messages/444", "/channels/:major/messages/:id", "333"},
+		{"/channels/333/messages/444/reactions/%F0%9F%91%8D/@me", "/channels/:major/messages/:id/reactions/:reaction", "333"},
+		{"/webhooks/555/tok-en", "/webhooks/:major", "555/tok-en"},
+		{"/webhooks/555/tok-en/messages/666", "/webhooks/:major/messages/:id", "555/tok-en"},
+		{"/webhooks/555", "/webhooks/:major", "555"},
+		{"/users/@me", "/users/@me", ""},
+		{"/stickers/777", "/stickers/:id", ""},
+	}
+	for _, tc := range cases {
+		route, major := rateLimitRoute(tc.path)
+		if route != tc.wantRoute || major != tc.wantMajor {
+			t.Fatalf("rateLimitRoute(%q) = (%q, %q), want (%q, %q)", tc.path, route, major, tc.wantRoute, tc.wantMajor)
+		}
+	}
+}
+
+func TestRestClient_DoJSON_WaitsForExhaustedBucket(t *testing.T) {
+	t.Parallel()
+
+	var calls int32
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.Header().Set("X-RateLimit-Bucket", "roles-bucket")
+		w.Header().Set("X-RateLimit-Limit", "1")
+		w.Header().Set("X-RateLimit-Remaining", "0")
+		w.Header().Set("X-RateLimit-Reset-After", "0.25")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"ok": true})
+	}))
+	defer s.Close()
+
+	c := NewRestClient("TOKEN", s.Client())
+	c.BaseURL = s.URL
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	var out map[string]interface{}
+	if err := c.DoJSON(ctx, "GET", "/guilds/111/roles", nil, nil, &out); err != nil {
+		t.Fatalf("DoJSON returned error: %v", err)
+	}
+
+	// A different major parameter has its own bucket and must not be delayed.
+	start := time.Now()
+	if err := c.DoJSON(ctx, "GET", "/guilds/222/roles", nil, nil, &out); err != nil {
+		t.Fatalf("DoJSON returned error: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 150*time.Millisecond {
+		t.Fatalf("expected other guild not to be delayed, elapsed=%s", elapsed)
+	}
+
+	// The same route is now exhausted; the client should wait for the reset without a 429.
+	start = time.Now()
+	if err := c.DoJSON(ctx, "GET", "/guilds/111/roles", nil, nil, &out); err != nil {
+		t.Fatalf("DoJSON returned error: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 150*time.Millisecond {
+		t.Fatalf("expected exhausted bucket to delay the request, elapsed=%s", elapsed)
+	}
+	if got := atomic.LoadInt32(&calls); got != 3 {
+		t.Fatalf("expected 3 calls, got %d", got)
+	}
+}
+
+func TestRestClient_DoMultipartWithReason_SharesBucketWithJSON(t *testing.T) {
+	t.Parallel()
+
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Bucket", "stickers-bucket")
+		w.Header().Set("X-RateLimit-Remaining", "0")
+		w.Header().Set("X-RateLimit-Reset-After", "0.25")
+		if strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data;") {
+			_ = r.ParseMultipartForm(1 << 20)
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"ok": true})
+	}))
+	defer s.Close()
+
+	c := NewRestClient("TOKEN", s.Client())
+	c.BaseURL = s.URL
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	var out map[string]interface{}
+	if err := c.DoJSON(ctx, "POST", "/guilds/111/stickers", nil, map[string]interface{}{"name": "x"}, &out); err != nil {
+		t.Fatalf("DoJSON returned error: %v", err)
+	}
+
+	start := time.Now()
+	if err := c.DoMultipartWithReason(ctx, "POST", "/guilds/111/stickers", nil, map[string]string{"name": "x"}, "file", "x.png", []byte("png"), &out, ""); err != nil {
+		t.Fatalf("DoMultipartWithReason returned error: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 150*time.Millisecond {
+		t.Fatalf("expected multipart request to wait for the exhausted bucket, elapsed=%s", elapsed)
+	}
+}
@@ -0,0 +1,180 @@
+package discord
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultReadCacheTTL bounds how long a cached GET response is served. A provider
+// process lives for one plan or apply, so this mostly guards long applies.
+const DefaultReadCacheTTL = 30 * time.Second
+
+// ReadCache is a short-lived cache of GET responses shared by all resources of a
+// provider instance. Concurrent GETs of the same URL share one request, so a
+// refresh of 300 channels reads /guilds/{id}/channels once instead of 300 times.
+//
+// Any other request invalidates what it may have changed: everything cached for
+// the guild it touches, or everything when the guild is not known from the path
+// or from cached objects. Error responses are never cached, and each caller
+// decodes its own copy of the response.
+type ReadCache struct {
+	ttl time.Duration
+	now func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]*cacheEntry
+	// channelGuild maps channel IDs seen in cached responses to their guild, so
+	// that /channels/{id} requests can be attributed to a guild.
+	channelGuild map[string]string
+	// gen and guildGen are bumped by invalidations. A fetch that overlapped one
+	// is returned to its waiters but not stored.
+	gen      uint64
+	guildGen map[string]uint64
+}
+
+type cacheEntry struct {
+	done    chan struct{}
+	guild   string
+	expires time.Time
+	raw     []byte
+	err     error
+}
+
+type freshReadsKey struct{}
+
+// WithFreshReads returns a context whose GETs bypass the read cache, for reads
+// that must observe a write that just happened.
+func WithFreshReads(ctx context.Context) context.Context {
+	return context.WithValue(ctx, freshReadsKey{}, true)
+}
+
+// FreshReads reports whether ctx was made by WithFreshReads.
+func FreshReads(ctx context.Context) bool {
+	v, _ := ctx.Value(freshReadsKey{}).(bool)
+	return v
+}
+
+// NewReadCache returns an empty cache whose entries live for ttl.
+func NewReadCache(ttl time.Duration) *ReadCache {
+	return &ReadCache{
+		ttl:          ttl,
+		now:          time.Now,
+		entries:      map[string]*cacheEntry{},
+		channelGuild: map[string]string{},
+		guildGen:     map[string]uint64{},
+	}
+}
+
+// get returns the cached response for key or fetches it, sharing the fetch with
+// concurrent callers.
+func (c *ReadCache) get(ctx context.Context, path, key string, fetch func() ([]byte, error)) ([]byte, error) {
+	c.mu.Lock()
+	if e, ok := c.entries[key]; ok {
+		select {
+		case <-e.done:
+			if c.now().Before(e.expires) {
+				c.mu.Unlock()
+				return e.raw, nil
+			}
+			delete(c.entries, key)
+		default:
+			c.mu.Unlock()
+			select {
+			case <-e.done:
+				return e.raw, e.err
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+		}
+	}
+	guild := c.guildOfLocked(path)
+	e := &cacheEntry{done: make(chan struct{}), guild: guild}
+	c.entries[key] = e
+	gen, guildGen := c.gen, c.guildGen[guild]
+	c.mu.Unlock()
+
+	e.raw, e.err = fetch()
+
+	c.mu.Lock()
+	if e.err != nil || gen != c.gen || guildGen != c.guildGen[guild] {
+		if c.entries[key] == e {
+			delete(c.entries, key)
+		}
+	} else {
+		e.expires = c.now().Add(c.ttl)
+		c.learnChannelsLocked(guild, e.raw)
+	}
+	c.mu.Unlock()
+	close(e.done)
+	return e.raw, e.err
+}
+
+// invalidate drops what a request to path may have changed.
+func (c *ReadCache) invalidate(path string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	guild := c.guildOfLocked(path)
+	if guild == "" {
+		c.gen++
+		clear(c.entries)
+		return
+	}
+	c.guildGen[guild]++
+	for k, e := range c.entries {
+		if e.guild == guild || e.guild == "" {
+			delete(c.entries, k)
+		}
+	}
+}
+
+// Invalidate drops everything cached for a guild.
+func (c *ReadCache) Invalidate(guildID string) {
+	c.invalidate("/guilds/" + guildID)
+}
+
+// guildOfLocked attributes a path to a guild, or returns "" when it can't.
+func (c *ReadCache) guildOfLocked(path string) string {
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	if len(parts) < 2 {
+		return ""
+	}
+	switch parts[0] {
+	case "guilds":
+		return parts[1]
+	case "channels":
+		return c.channelGuild[parts[1]]
+	}
+	return ""
+}
+
+// learnChannelsLocked records the guild of every channel in a cached response:
+// a channel, a list of channels, or anything else with id and guild_id.
+func (c *ReadCache) learnChannelsLocked(guild string, raw []byte) {
+	type channelRef struct {
+		ID      string `json:"id"`
+		GuildID string `json:"guild_id"`
+	}
+	var refs []channelRef
+	if len(raw) > 0 && raw[0] == '[' {
+		_ = json.Unmarshal(raw, &refs)
+	} else {
+		var one channelRef
+		if json.Unmarshal(raw, &one) == nil {
+			refs = append(refs, one)
+		}
+	}
+	for _, r := range refs {
+		if r.ID == "" {
+			continue
+		}
+		if r.GuildID == "" {
+			r.GuildID = guild
+		}
+		if r.GuildID != "" {
+			c.channelGuild[r.ID] = r.GuildID
+		}
+	}
+}
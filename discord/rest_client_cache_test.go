@@ -0,0 +1,174 @@
+package discord
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newCacheTestClient(t *testing.T, h http.HandlerFunc) *RestClient {
+	t.Helper()
+	s := httptest.NewServer(h)
+	t.Cleanup(s.Close)
+	c := NewRestClient("TOKEN", s.Client())
+	c.BaseURL = s.URL
+	c.MaxRetries = 0
+	c.cache = NewReadCache(time.Minute)
+	return c
+}
+
+func TestReadCache_SharesConcurrentGets(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	release := make(chan struct{})
+	c := newCacheTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		<-release
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, `[{"id":"10","guild_id":"1","name":"general"}]`)
+	})
+
+	ctx := context.Background()
+	var wg sync.WaitGroup
+	got := make([][]Channel, 20)
+	for i := range got {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := c.DoJSON(ctx, "GET", "/guilds/1/channels", nil, nil, &got[i]); err != nil {
+				t.Errorf("GET: %v", err)
+			}
+		}()
+	}
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if n := calls.Load(); n != 1 {
+		t.Fatalf("expected one request, got %d", n)
+	}
+	got[0][0].Name = "changed"
+	if got[1][0].Name != "general" {
+		t.Fatal("callers must not share decoded values")
+	}
+
+	if err := c.DoJSON(ctx, "GET", "/guilds/1/channels", nil, nil, nil); err != nil {
+		t.Fatalf("cached GET: %v", err)
+	}
+	if err := c.DoJSON(WithFreshReads(ctx), "GET", "/guilds/1/channels", nil, nil, nil); err != nil {
+		t.Fatalf("fresh GET: %v", err)
+	}
+	if n := calls.Load(); n != 2 {
+		t.Fatalf("expected only the fresh read to reach the server, got %d requests", n)
+	}
+}
+
+func TestReadCache_WritesInvalidateTheirGuild(t *testing.T) {
+	t.Parallel()
+
+	gets := map[string]int{}
+	var mu sync.Mutex
+	c := newCacheTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		mu.Lock()
+		gets[r.URL.Path]++
+		mu.Unlock()
+		switch r.URL.Path {
+		case "/guilds/1/channels":
+			_, _ = io.WriteString(w, `[{"id":"10","guild_id":"1"}]`)
+		default:
+			_, _ = io.WriteString(w, `[]`)
+		}
+	})
+
+	ctx := context.Background()
+	read := func(path string) {
+		t.Helper()
+		if err := c.DoJSON(ctx, "GET", path, nil, nil, nil); err != nil {
+			t.Fatalf("GET %s: %v", path, err)
+		}
+	}
+	read("/guilds/1/channels")
+	read("/guilds/1/roles")
+	read("/guilds/2/roles")
+
+	// The channel is known to belong to guild 1 from the cached list.
+	if err := c.DoJSON(ctx, "PATCH", "/channels/10", nil, map[string]any{"name": "x"}, nil); err != nil {
+		t.Fatalf("PATCH: %v", err)
+	}
+	read("/guilds/1/channels")
+	read("/guilds/1/roles")
+	read("/guilds/2/roles")
+
+	mu.Lock()
+	defer mu.Unlock()
+	if gets["/guilds/1/channels"] != 2 || gets["/guilds/1/roles"] != 2 {
+		t.Fatalf("expected guild 1 to be refetched, got %v", gets)
+	}
+	if gets["/guilds/2/roles"] != 1 {
+		t.Fatalf("expected guild 2 to stay cached, got %v", gets)
+	}
+}
+
+func TestReadCache_DoesNotCacheErrors(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	c := newCacheTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if calls.Add(1) == 1 {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = io.WriteString(w, `{"message":"Unknown Channel","code":10003}`)
+			return
+		}
+		_, _ = io.WriteString(w, `{"id":"10"}`)
+	})
+
+	ctx := context.Background()
+	if err := c.DoJSON(ctx, "GET", "/channels/10", nil, nil, nil); !IsDiscordHTTPStatus(err, http.StatusNotFound) {
+		t.Fatalf("expected a 404, got %v", err)
+	}
+	if err := c.DoJSON(ctx, "GET", "/channels/10", nil, nil, nil); err != nil {
+		t.Fatalf("expected the retry to reach the server: %v", err)
+	}
+	if n := calls.Load(); n != 2 {
+		t.Fatalf("expected 2 requests, got %d", n)
+	}
+}
+
+func TestReadCache_Expires(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	c := newCacheTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, `[]`)
+	})
+	now := time.Now()
+	c.cache.now = func() time.Time { return now }
+
+	ctx := context.Background()
+	for range 2 {
+		if err := c.DoJSON(ctx, "GET", "/guilds/1/roles", nil, nil, nil); err != nil {
+			t.Fatalf("GET: %v", err)
+		}
+	}
+	now = now.Add(2 * time.Minute)
+	if err := c.DoJSON(ctx, "GET", "/guilds/1/roles", nil, nil, nil); err != nil {
+		t.Fatalf("GET: %v", err)
+	}
+	if n := calls.Load(); n != 2 {
+		t.Fatalf("expected the expired entry to be refetched, got %d requests", n)
+	}
+}
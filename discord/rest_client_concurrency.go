@@ -0,0 +1,95 @@
+package discord
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// concurrencyLimiter bounds in-flight requests and optionally serializes mutations
+// that conflict when issued concurrently for the same guild.
+type concurrencyLimiter struct {
+	// sem holds one token per in-flight HTTP attempt; nil means unlimited.
+	sem chan struct{}
+
+	// serializeGuilds enables the per-guild locks below.
+	serializeGuilds bool
+	mu              sync.Mutex
+	guilds          map[string]chan struct{}
+}
+
+func newConcurrencyLimiter(maxConcurrent int, serializeGuilds bool) *concurrencyLimiter {
+	l := &concurrencyLimiter{
+		serializeGuilds: serializeGuilds,
+		guilds:          map[string]chan struct{}{},
+	}
+	if maxConcurrent > 0 {
+		l.sem = make(chan struct{}, maxConcurrent)
+	}
+	return l
+}
+
+// acquire takes one in-flight slot. The returned release must be called once.
+func (l *concurrencyLimiter) acquire(ctx context.Context) (func(), error) {
+	if l == nil || l.sem == nil {
+		return func() {}, nil
+	}
+	select {
+	case l.sem <- struct{}{}:
+		return func() { <-l.sem }, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// lockGuild serializes calls to conflicting guild mutation endpoints, e.g. bulk
+// position updates, for the duration of the whole call including retries.
+// Other requests are not affected.
+func (l *concurrencyLimiter) lockGuild(ctx context.Context, method, path string) (func(), error) {
+	if l == nil || !l.serializeGuilds {
+		return func() {}, nil
+	}
+	guildID, ok := serializedGuildMutation(method, path)
+	if !ok {
+		return func() {}, nil
+	}
+
+	l.mu.Lock()
+	ch := l.guilds[guildID]
+	if ch == nil {
+		ch = make(chan struct{}, 1)
+		l.guilds[guildID] = ch
+	}
+	l.mu.Unlock()
+
+	select {
+	case ch <- struct{}{}:
+		return func() { <-ch }, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// serializedGuildMutations are guild sub-resources whose bulk PATCH endpoints
+// rewrite positions for the whole guild, so concurrent calls race each other.
+var serializedGuildMutations = map[string]bool{
+	"roles":    true,
+	"channels": true,
+}
+
+// serializedGuildMutation returns the guild ID when method+path is a mutation that
+// must be serialized per guild, e.g. PATCH /guilds/{id}/roles.
+func serializedGuildMutation(method, path string) (string, bool) {
+	if method != http.MethodPatch {
+		return "", false
+	}
+	if i := strings.IndexByte(path, '?'); i >= 0 {
+		path = path[:i]
+	}
+	segs := strings.Split(strings.Trim(path, "/"), "/")
+	if len(segs) != 3 || segs[0] != "guilds" || segs[1] == "" || !serializedGuildMutations[segs[2]] {
+		return "", false
+	}
+	return segs[1], true
+}
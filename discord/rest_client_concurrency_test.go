@@ -0,0 +1,115 @@
+package discord
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestSerializedGuildMutation(t *testing.T) {
+	cases := []struct {
+		method, path string
+		wantGuild    string
+		wantOK       bool
+	}{
+		{"PATCH", "/guilds/1/roles", "1", true},
+		{"PATCH", "/guilds/1/channels", "1", true},
+		{"POST", "/guilds/1/roles", "", false},
+		{"PATCH", "/guilds/1/roles/2", "", false},
+		{"PATCH", "/guilds/1", "", false},
+		{"PATCH", "/channels/1", "", false},
+	}
+	for _, tc := range cases {
+		guild, ok := serializedGuildMutation(tc.method, tc.path)
+		if guild != tc.wantGuild || ok != tc.wantOK {
+			t.Fatalf("serializedGuildMutation(%q, %q) = (%q, %v), want (%q, %v)", tc.method, tc.path, guild, ok, tc.wantGuild, tc.wantOK)
+		}
+	}
+}
+
+// inFlightServer records the highest number of concurrent requests it served.
+func inFlightServer(t *testing.T) (*httptest.Server, *int32) {
+	t.Helper()
+	var cur, peak int32
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&cur, 1)
+		for {
+			p := atomic.LoadInt32(&peak)
+			if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+				break
+			}
+		}
+		time.Sleep(50 * time.Millisecond)
+		atomic.AddInt32(&cur, -1)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(s.Close)
+	return s, &peak
+}
+
+func runConcurrently(n int, fn func(i int)) {
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			fn(i)
+		}(i)
+	}
+	wg.Wait()
+}
+
+func TestRestClient_MaxConcurrentRequests(t *testing.T) {
+	t.Parallel()
+
+	s, peak := inFlightServer(t)
+	c := NewRestClient("TOKEN", s.Client())
+	c.BaseURL = s.URL
+	c.limiter = newConcurrencyLimiter(2, false)
+
+	runConcurrently(8, func(int) {
+		if err := c.DoJSON(context.Background(), "GET", "/users/@me", nil, nil, nil); err != nil {
+			t.Errorf("DoJSON returned error: %v", err)
+		}
+	})
+	if got := atomic.LoadInt32(peak); got > 2 {
+		t.Fatalf("expected at most 2 requests in flight, saw %d", got)
+	}
+}
+
+func TestRestClient_SerializesGuildPositionUpdates(t *testing.T) {
+	t.Parallel()
+
+	s, peak := inFlightServer(t)
+	c := NewRestClient("TOKEN", s.Client())
+	c.BaseURL = s.URL
+	c.limiter = newConcurrencyLimiter(0, true)
+
+	runConcurrently(4, func(int) {
+		if err := c.DoJSON(context.Background(), "PATCH", "/guilds/1/roles", nil, []interface{}{}, nil); err != nil {
+			t.Errorf("DoJSON returned error: %v", err)
+		}
+	})
+	if got := atomic.LoadInt32(peak); got != 1 {
+		t.Fatalf("expected position updates for one guild to be serialized, saw %d in flight", got)
+	}
+}
+
+func TestConcurrencyLimiter_AcquireHonoursContext(t *testing.T) {
+	l := newConcurrencyLimiter(1, false)
+	release, err := l.acquire(context.Background())
+	if err != nil {
+		t.Fatalf("acquire: %v", err)
+	}
+	defer release()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := l.acquire(ctx); err == nil {
+		t.Fatalf("expected acquire to fail once the context is done")
+	}
+}
@@ -0,0 +1,136 @@
+package discord
+
+import (
+	"encoding/json"
+	"errors"
+	"sort"
+	"strings"
+)
+
+// DiscordFieldError is one leaf of Discord's nested "errors" object.
+// Example body:
+//
+//	{"code":50035,"message":"Invalid Form Body","errors":{"name":{"_errors":[{"code":"BASE_TYPE_MAX_LENGTH","message":"Must be 100 or fewer in length."}]}}}
+//
+// yields {Field: "name", Code: "BASE_TYPE_MAX_LENGTH", Message: "Must be 100 or fewer in length."}.
+type DiscordFieldError struct {
+	// Field is the dotted JSON path of the offending request field, e.g. "embeds.0.title".
+	Field   string
+	Code    string
+	Message string
+}
+
+type discordFieldErrorLeaf struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// parseFieldErrors flattens Discord's nested error tree into field errors, sorted by field.
+func parseFieldErrors(raw json.RawMessage) []DiscordFieldError {
+	if len(raw) == 0 {
+		return nil
+	}
+	var out []DiscordFieldError
+	walkFieldErrors(raw, "", &out)
+	sort.SliceStable(out, func(i, j int) bool { return out[i].Field < out[j].Field })
+	return out
+}
+
+func walkFieldErrors(raw json.RawMessage, prefix string, out *[]DiscordFieldError) {
+	var node map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &node); err != nil {
+		return
+	}
+	for k, v := range node {
+		if k == "_errors" {
+			var leaves []discordFieldErrorLeaf
+			if err := json.Unmarshal(v, &leaves); err != nil {
+				continue
+			}
+			for _, l := range leaves {
+				*out = append(*out, DiscordFieldError{Field: prefix, Code: l.Code, Message: l.Message})
+			}
+			continue
+		}
+		field := k
+		if prefix != "" {
+			field = prefix + "." + k
+		}
+		walkFieldErrors(v, field, out)
+	}
+}
+
+// errorHints maps well-known Discord JSON error codes to actionable advice.
+// See https://discord.com/developers/docs/topics/opcodes-and-status-codes#json.
+var errorHints = map[int]string{
+	10003: "The channel does not exist or was deleted outside Terraform.",
+	10004: "The server does not exist or the bot is not a member of it. Check server_id.",
+	10008: "The message does not exist or was deleted outside Terraform.",
+	10011: "The role does not exist or was deleted outside Terraform.",
+	10013: "The user does not exist.",
+	10014: "The emoji does not exist or was deleted outside Terraform.",
+	10015: "The webhook does not exist or was deleted outside Terraform.",
+	10060: "The sticker does not exist or was deleted outside Terraform.",
+	10070: "The scheduled event does not exist or was deleted outside Terraform.",
+	20028: "The channel write rate limit was hit; slow down changes to this channel.",
+	30005: "The server has reached the maximum number of roles (250). Delete unused roles first.",
+	30007: "The channel has reached the maximum number of webhooks (15).",
+	30008: "The server has reached its emoji limit; the limit depends on the boost tier.",
+	30013: "The server has reached the maximum number of channels (500).",
+	30016: "The maximum number of invites (1000) has been reached.",
+	30018: "The server has reached the maximum number of animated emojis for its boost tier.",
+	30039: "The server has reached its sticker limit; the limit depends on the boost tier.",
+	40001: "The bot token is invalid or was revoked. Check the provider token.",
+	50001: "The bot cannot see this object. Make sure the bot is in the server and has View Channel access where needed.",
+	50013: "The bot lacks a permission for this action, or is trying to manage a role or member at or above its highest role. Grant the permission or move the bot's role higher.",
+	50024: "This action is not supported for this channel type.",
+	50028: "The role is invalid, e.g. @everyone or a role managed by an integration.",
+	50045: "The uploaded file exceeds the maximum size.",
+	50046: "The uploaded asset is invalid, e.g. wrong image format or dimensions.",
+	50101: "The request requires the server to be a Community server.",
+	50109: "The request body is not valid JSON.",
+}
+
+// ErrorHint returns actionable advice for a Discord JSON error code, or "".
+func ErrorHint(code int) string {
+	return errorHints[code]
+}
+
+// Hint returns actionable advice for this error's JSON error code, or "".
+func (e *DiscordHTTPError) Hint() string {
+	if e == nil {
+		return ""
+	}
+	return ErrorHint(e.Code)
+}
+
+// String renders a field error as "field: message (CODE)".
+func (fe DiscordFieldError) String() string {
+	var b strings.Builder
+	if fe.Field != "" {
+		b.WriteString(fe.Field)
+		b.WriteString(": ")
+	}
+	b.WriteString(fe.Message)
+	if fe.Code != "" {
+		b.WriteString(" (")
+		b.WriteString(fe.Code)
+		b.WriteString(")")
+	}
+	return b.String()
+}
+
+// AsDiscordHTTPError returns the *DiscordHTTPError in err's chain, if any.
+func AsDiscordHTTPError(err error) (*DiscordHTTPError, bool) {
+	var e *DiscordHTTPError
+	if errors.As(err, &e) && e != nil {
+		return e, true
+	}
+	return nil, false
+}
+
+// IsDiscordErrorCode reports whether err is a Discord API error with the given JSON error code.
+func IsDiscordErrorCode(err error, code int) bool {
+	e, ok := AsDiscordHTTPError(err)
+	return ok && e.Code == code
+}
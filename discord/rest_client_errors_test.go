@@ -0,0 +1,90 @@
+package discord
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseFieldErrors(t *testing.T) {
+	raw := []byte(`{
+		"name": {"_errors": [{"code": "BASE_TYPE_MAX_LENGTH", "message": "Must be 100 or fewer in length."}]},
+		"embeds": {"0": {"title": {"_errors": [{"code": "BASE_TYPE_REQUIRED", "message": "This field is required"}]}}},
+		"_errors": [{"code": "TOP", "message": "Top-level problem"}]
+	}`)
+
+	got := parseFieldErrors(raw)
+	want := []DiscordFieldError{
+		{Field: "", Code: "TOP", Message: "Top-level problem"},
+		{Field: "embeds.0.title", Code: "BASE_TYPE_REQUIRED", Message: "This field is required"},
+		{Field: "name", Code: "BASE_TYPE_MAX_LENGTH", Message: "Must be 100 or fewer in length."},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("parseFieldErrors mismatch:\ngot  %#v\nwant %#v", got, want)
+	}
+
+	if parseFieldErrors(nil) != nil {
+		t.Fatalf("expected nil for empty errors")
+	}
+}
+
+func TestRestClient_DoJSON_DecodesFieldErrorsAndHint(t *testing.T) {
+	t.Parallel()
+
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = io.WriteString(w, `{"code":50035,"message":"Invalid Form Body","errors":{"name":{"_errors":[{"code":"BASE_TYPE_MAX_LENGTH","message":"Must be 100 or fewer in length."}]}}}`)
+	}))
+	defer s.Close()
+
+	c := NewRestClient("TOKEN", s.Client())
+	c.BaseURL = s.URL
+
+	err := c.DoJSON(context.Background(), "POST", "/guilds/1/roles", nil, map[string]interface{}{"name": "x"}, nil)
+	httpErr, ok := AsDiscordHTTPError(err)
+	if !ok {
+		t.Fatalf("expected *DiscordHTTPError, got %T", err)
+	}
+	if len(httpErr.Errors) != 1 || httpErr.Errors[0].Field != "name" || httpErr.Errors[0].Code != "BASE_TYPE_MAX_LENGTH" {
+		t.Fatalf("unexpected field errors: %#v", httpErr.Errors)
+	}
+	if !strings.Contains(err.Error(), "name: Must be 100 or fewer in length. (BASE_TYPE_MAX_LENGTH)") {
+		t.Fatalf("expected field error in message, got %q", err.Error())
+	}
+}
+
+func TestDiscordHTTPError_Hint(t *testing.T) {
+	err := &DiscordHTTPError{Method: "POST", Path: "/guilds/1/roles", StatusCode: 403, Code: 50013, Message: "Missing Permissions"}
+	if err.Hint() == "" {
+		t.Fatalf("expected a hint for 50013")
+	}
+	if !strings.Contains(err.Error(), "Hint: "+err.Hint()) {
+		t.Fatalf("expected hint in error message, got %q", err.Error())
+	}
+
+	unknown := &DiscordHTTPError{StatusCode: 400, Code: 1, Message: "Something"}
+	if strings.Contains(unknown.Error(), "Hint:") {
+		t.Fatalf("did not expect a hint for an unknown code, got %q", unknown.Error())
+	}
+}
+
+func TestIsDiscordErrorCode(t *testing.T) {
+	err := fmt.Errorf("wrapped: %w", &DiscordHTTPError{StatusCode: 400, Code: 30005})
+	if !IsDiscordErrorCode(err, 30005) {
+		t.Fatalf("expected wrapped error to match code 30005")
+	}
+	if IsDiscordErrorCode(err, 50013) {
+		t.Fatalf("did not expect code 50013 to match")
+	}
+	if !IsDiscordHTTPStatus(err, 400) {
+		t.Fatalf("expected wrapped error to match status 400")
+	}
+	if IsDiscordErrorCode(nil, 30005) {
+		t.Fatalf("expected false for nil")
+	}
+}
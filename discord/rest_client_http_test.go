@@ -3,6 +3,7 @@ package discord
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"io"
 	"mime"
 	"net/http"
@@ -260,3 +261,164 @@ func TestRestClient_DoMultipartWithReason_EmptyFileFieldStillValidMultipart(t *t
 		t.Fatalf("DoMultipartWithReason returned error: %v", err)
 	}
 }
+
+func TestRestClient_DoMultipartFilesWithReason_SendsPayloadAndFiles(t *testing.T) {
+	t.Parallel()
+
+	files := []MultipartFile{
+		{Name: "a.txt", ContentType: "text/plain", Data: []byte("first")},
+		{Name: "b.bin", Data: []byte("second")},
+	}
+
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("X-Audit-Log-Reason"); got != "because" {
+			t.Errorf("X-Audit-Log-Reason mismatch: got %q", got)
+		}
+		mr, err := r.MultipartReader()
+		if err != nil {
+			t.Errorf("MultipartReader error: %v", err)
+			return
+		}
+
+		var payload string
+		seen := map[string]string{}
+		seenTypes := map[string]string{}
+		for {
+			part, err := mr.NextPart()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				t.Errorf("NextPart error: %v", err)
+				return
+			}
+			b, _ := io.ReadAll(part)
+			if part.FormName() == "payload_json" {
+				payload = string(b)
+				seenTypes["payload_json"] = part.Header.Get("Content-Type")
+			} else {
+				seen[part.FormName()+"|"+part.FileName()] = string(b)
+				seenTypes[part.FormName()] = part.Header.Get("Content-Type")
+			}
+			_ = part.Close()
+		}
+
+		if payload != `{"attachments":[{"id":0},{"id":1}],"content":"hi"}` {
+			t.Errorf("unexpected payload_json %q", payload)
+		}
+		if seenTypes["payload_json"] != "application/json" {
+			t.Errorf("unexpected payload_json content type %q", seenTypes["payload_json"])
+		}
+		if seen["files[0]|a.txt"] != "first" || seen["files[1]|b.bin"] != "second" {
+			t.Errorf("unexpected files: %#v", seen)
+		}
+		if seenTypes["files[0]"] != "text/plain" || seenTypes["files[1]"] != "application/octet-stream" {
+			t.Errorf("unexpected file content types: %#v", seenTypes)
+		}
+
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"ok": true})
+	}))
+	defer s.Close()
+
+	c := NewRestClient("TOKEN", s.Client())
+	c.BaseURL = s.URL
+
+	payload := map[string]interface{}{
+		"content":     "hi",
+		"attachments": []map[string]interface{}{{"id": 0}, {"id": 1}},
+	}
+	var out map[string]interface{}
+	if err := c.DoMultipartFilesWithReason(context.Background(), "POST", "/channels/1/messages", nil, payload, files, &out, "because"); err != nil {
+		t.Fatalf("DoMultipartFilesWithReason returned error: %v", err)
+	}
+	if v, ok := out["ok"].(bool); !ok || !v {
+		t.Fatalf("unexpected response: %#v", out)
+	}
+}
+
+func TestRestClient_DoMultipartWithReason_RetriesTransientFailureWithSameBody(t *testing.T) {
+	t.Parallel()
+
+	var calls int32
+	var bodies []string
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(b))
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"ok": true})
+	}))
+	defer s.Close()
+
+	c := NewRestClient("TOKEN", s.Client())
+	c.BaseURL = s.URL
+	c.RetryMaxBackoff = 10 * time.Millisecond
+
+	if err := c.DoMultipartWithReason(context.Background(), "PATCH", "/x", nil, map[string]string{"name": "x"}, "file", "x.bin", []byte("abc"), nil, ""); err != nil {
+		t.Fatalf("DoMultipartWithReason returned error: %v", err)
+	}
+	if len(bodies) != 2 || bodies[0] != bodies[1] {
+		t.Fatalf("expected the same multipart body to be replayed, got %d bodies", len(bodies))
+	}
+}
+
+func TestRestClient_DoJSON_DecodesErrorIdenticallyToMultipart(t *testing.T) {
+	t.Parallel()
+
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = io.WriteString(w, `{"message":"Missing Permissions","code":50013}`)
+	}))
+	defer s.Close()
+
+	c := NewRestClient("TOKEN", s.Client())
+	c.BaseURL = s.URL
+
+	jsonErr := c.DoJSON(context.Background(), "POST", "/x", nil, map[string]interface{}{}, nil)
+	mpErr := c.DoMultipartWithReason(context.Background(), "POST", "/x", nil, nil, "", "", nil, nil, "")
+	for _, err := range []error{jsonErr, mpErr} {
+		httpErr, ok := err.(*DiscordHTTPError)
+		if !ok {
+			t.Fatalf("expected *DiscordHTTPError, got %T (%v)", err, err)
+		}
+		if httpErr.StatusCode != http.StatusForbidden || httpErr.Code != 50013 || httpErr.Message != "Missing Permissions" {
+			t.Fatalf("unexpected error: %#v", httpErr)
+		}
+	}
+}
+
+func TestRestClient_ReadOnly_RefusesWrites(t *testing.T) {
+	t.Parallel()
+
+	var writes atomic.Int32
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			writes.Add(1)
+		}
+		_, _ = io.WriteString(w, `{}`)
+	}))
+	defer s.Close()
+
+	c := NewRestClient("TOKEN", s.Client())
+	c.BaseURL = s.URL
+	c.ReadOnly = true
+	ctx := context.Background()
+
+	if err := c.DoJSON(ctx, "GET", "/guilds/1", nil, nil, nil); err != nil {
+		t.Fatalf("GET: %v", err)
+	}
+	for _, method := range []string{"POST", "PATCH", "PUT", "DELETE"} {
+		err := c.DoJSONWithReason(ctx, method, "/guilds/1/roles", nil, map[string]any{}, nil, "")
+		if !errors.Is(err, ErrReadOnly) || !strings.Contains(err.Error(), method+" /guilds/1/roles") {
+			t.Errorf("%s: expected ErrReadOnly naming the request, got %v", method, err)
+		}
+	}
+	if err := c.DoMultipartWithReason(ctx, "POST", "/guilds/1/emojis", nil, nil, "image", "a.png", []byte("x"), nil, ""); !errors.Is(err, ErrReadOnly) {
+		t.Errorf("multipart POST: expected ErrReadOnly, got %v", err)
+	}
+	if n := writes.Load(); n != 0 {
+		t.Fatalf("%d writes reached the server", n)
+	}
+}
@@ -0,0 +1,139 @@
+package discord
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const redacted = "[REDACTED]"
+
+// sensitiveBodyKeys are JSON keys whose values are never logged. Webhook objects carry
+// their execution token in "token"; OAuth2 responses carry access and refresh tokens.
+var sensitiveBodyKeys = map[string]bool{
+	"token":         true,
+	"access_token":  true,
+	"refresh_token": true,
+	"client_secret": true,
+}
+
+// tokenPathPrefixes are routes whose segment after the id is a secret token,
+// e.g. /webhooks/{webhook.id}/{webhook.token} and /interactions/{id}/{token}/callback.
+var tokenPathPrefixes = []string{"webhooks", "interactions"}
+
+// redactPath replaces token segments in a request path.
+func redactPath(p string) string {
+	parts := strings.Split(p, "/")
+	for i := 0; i+2 < len(parts); i++ {
+		for _, prefix := range tokenPathPrefixes {
+			if parts[i] == prefix && parts[i+2] != "" {
+				parts[i+2] = redacted
+			}
+		}
+	}
+	return strings.Join(parts, "/")
+}
+
+// redactError returns err with the request URL of a transport error
+// (*url.Error, "Get \"https://...\": dial tcp ...") reduced to its redacted
+// path, so webhook and interaction tokens stay out of logs and traces.
+func redactError(err error) error {
+	var ue *url.Error
+	if err == nil || !errors.As(err, &ue) || ue.URL == "" {
+		return err
+	}
+	safe := redacted
+	if u, perr := url.Parse(ue.URL); perr == nil {
+		safe = redactPath(u.Path)
+	}
+	return errors.New(strings.ReplaceAll(err.Error(), ue.URL, safe))
+}
+
+// redactHeaders copies h with credentials masked.
+func redactHeaders(h http.Header) map[string]string {
+	out := make(map[string]string, len(h))
+	for k, v := range h {
+		if strings.EqualFold(k, "Authorization") {
+			out[k] = redacted
+			continue
+		}
+		out[k] = strings.Join(v, ", ")
+	}
+	return out
+}
+
+// redactBody renders a body for TRACE logs. JSON bodies have sensitive keys masked;
+// other bodies (multipart uploads) are summarized rather than dumped.
+func redactBody(data []byte, contentType string) string {
+	if len(data) == 0 {
+		return ""
+	}
+	if contentType != "" && !strings.HasPrefix(contentType, "application/json") {
+		return fmt.Sprintf("<%d bytes %s>", len(data), strings.SplitN(contentType, ";", 2)[0])
+	}
+	var v interface{}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return fmt.Sprintf("<%d bytes, not JSON>", len(data))
+	}
+	redactJSON(v)
+	out, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("<%d bytes>", len(data))
+	}
+	return string(out)
+}
+
+func redactJSON(v interface{}) {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		for k, child := range t {
+			if sensitiveBodyKeys[k] {
+				t[k] = redacted
+				continue
+			}
+			redactJSON(child)
+		}
+	case []interface{}:
+		for _, child := range t {
+			redactJSON(child)
+		}
+	}
+}
+
+// logAttempt records one HTTP attempt. Method, path, status, bucket, retry count and
+// duration go to DEBUG; headers and bodies go to TRACE.
+func logAttempt(ctx context.Context, req *http.Request, enc encodedBody, res *http.Response, raw []byte, retries int, took time.Duration, err error) {
+	fields := map[string]interface{}{
+		"method":      req.Method,
+		"path":        redactPath(req.URL.Path),
+		"retries":     retries,
+		"duration_ms": took.Milliseconds(),
+	}
+	if err != nil {
+		fields["error"] = redactError(err).Error()
+		tflog.Debug(ctx, "Discord API request failed", fields)
+		return
+	}
+	fields["status"] = res.StatusCode
+	if b := res.Header.Get("X-RateLimit-Bucket"); b != "" {
+		fields["bucket"] = b
+	}
+	tflog.Debug(ctx, "Discord API request", fields)
+
+	tflog.Trace(ctx, "Discord API request detail", map[string]interface{}{
+		"method":           req.Method,
+		"path":             redactPath(req.URL.Path),
+		"request_headers":  redactHeaders(req.Header),
+		"request_body":     redactBody(enc.data, enc.contentType),
+		"status":           res.StatusCode,
+		"response_headers": redactHeaders(res.Header),
+		"response_body":    redactBody(raw, res.Header.Get("Content-Type")),
+	})
+}
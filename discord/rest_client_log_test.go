@@ -0,0 +1,119 @@
+package discord
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+)
+
+func TestRedactPath(t *testing.T) {
+	cases := map[string]string{
+		"/webhooks/123":                "/webhooks/123",
+		"/webhooks/123/abc":            "/webhooks/123/[REDACTED]",
+		"/webhooks/123/abc/messages/9": "/webhooks/123/[REDACTED]/messages/9",
+		"/interactions/1/tok/callback": "/interactions/1/[REDACTED]/callback",
+		"/channels/1/webhooks":         "/channels/1/webhooks",
+		"/guilds/1/roles/2":            "/guilds/1/roles/2",
+	}
+	for in, want := range cases {
+		if got := redactPath(in); got != want {
+			t.Errorf("redactPath(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestRedactBody(t *testing.T) {
+	got := redactBody([]byte(`{"id":"1","token":"secret","nested":[{"access_token":"x"}]}`), "application/json")
+	if strings.Contains(got, "secret") || strings.Contains(got, `"x"`) {
+		t.Fatalf("expected tokens to be redacted, got %s", got)
+	}
+	if !strings.Contains(got, `"id":"1"`) {
+		t.Fatalf("expected other fields to be kept, got %s", got)
+	}
+
+	got = redactBody([]byte("binary"), "multipart/form-data; boundary=x")
+	if got != "<6 bytes multipart/form-data>" {
+		t.Fatalf("unexpected multipart summary %q", got)
+	}
+}
+
+func TestRestClient_LogsRequestsWithoutSecrets(t *testing.T) {
+	t.Parallel()
+
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-RateLimit-Bucket", "abcd")
+		_, _ = io.WriteString(w, `{"id":"123","token":"webhook-secret"}`)
+	}))
+	defer s.Close()
+
+	c := NewRestClient("BOT-TOKEN", s.Client())
+	c.BaseURL = s.URL
+
+	var buf bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &buf)
+
+	if err := c.DoJSON(ctx, "GET", "/webhooks/123/webhook-secret", nil, nil, nil); err != nil {
+		t.Fatalf("DoJSON returned error: %v", err)
+	}
+
+	logs := buf.String()
+	for _, secret := range []string{"BOT-TOKEN", "webhook-secret"} {
+		if strings.Contains(logs, secret) {
+			t.Fatalf("logs leaked %q:\n%s", secret, logs)
+		}
+	}
+
+	entries, err := tflogtest.MultilineJSONDecode(strings.NewReader(logs))
+	if err != nil {
+		t.Fatalf("decode logs: %v", err)
+	}
+	if len(entries) == 0 {
+		t.Fatalf("expected log entries")
+	}
+
+	var sawDebug bool
+	for _, e := range entries {
+		if e["@message"] == "Discord API request" {
+			sawDebug = true
+			if e["status"] != float64(200) || e["bucket"] != "abcd" || e["method"] != "GET" {
+				t.Fatalf("unexpected debug entry: %v", e)
+			}
+		}
+	}
+	if !sawDebug {
+		t.Fatalf("expected a debug entry, got %v", entries)
+	}
+}
+
+func TestRestClient_LogsTransportErrorsWithoutSecrets(t *testing.T) {
+	t.Parallel()
+
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	s.Close()
+
+	c := NewRestClient("BOT-TOKEN", s.Client())
+	c.BaseURL = s.URL
+	c.MaxRetries = 0
+
+	var buf bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &buf)
+
+	if err := c.DoJSON(ctx, "POST", "/webhooks/123/webhook-secret", nil, nil, nil); err == nil {
+		t.Fatal("expected a connection error")
+	}
+
+	logs := buf.String()
+	if strings.Contains(logs, "webhook-secret") {
+		t.Fatalf("logs leaked the webhook token:\n%s", logs)
+	}
+	if !strings.Contains(logs, "/webhooks/123/[REDACTED]") {
+		t.Fatalf("expected the redacted path in the error, got:\n%s", logs)
+	}
+}
@@ -0,0 +1,138 @@
+package discord
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+)
+
+const (
+	// DefaultMaxRetries is the number of retries for transient failures (5xx, connection resets).
+	DefaultMaxRetries = 3
+	// DefaultRetryMaxBackoff caps the exponential backoff between transient retries.
+	DefaultRetryMaxBackoff = 30 * time.Second
+
+	retryBaseBackoff = 500 * time.Millisecond
+)
+
+// shouldRetry reports whether a failed attempt may be retried. Exactly one of status
+// (non-zero) or err is set. body is the encoded request body, used to detect POSTs
+// that Discord deduplicates for us.
+func (c *RestClient) shouldRetry(method string, body []byte, retries int, status int, err error) bool {
+	if retries >= c.MaxRetries {
+		return false
+	}
+
+	if err != nil {
+		if !isTransientNetError(err) {
+			return false
+		}
+		// The request never reached Discord, so replaying it cannot duplicate anything.
+		if isDialError(err) {
+			return true
+		}
+		return isRetrySafe(method, body)
+	}
+
+	switch status {
+	case http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return isRetrySafe(method, body)
+	}
+	return false
+}
+
+// sleepBackoff waits for the backoff of the given retry using exponential growth with jitter.
+func (c *RestClient) sleepBackoff(ctx context.Context, retries int) error {
+	t := time.NewTimer(retryBackoff(retries, c.RetryMaxBackoff))
+	select {
+	case <-ctx.Done():
+		t.Stop()
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+// retryBackoff returns a duration in [d/2, d) where d = base * 2^retries, capped at max.
+func retryBackoff(retries int, max time.Duration) time.Duration {
+	if max <= 0 {
+		max = DefaultRetryMaxBackoff
+	}
+	d := retryBaseBackoff
+	for i := 0; i < retries && d < max; i++ {
+		d *= 2
+	}
+	if d > max {
+		d = max
+	}
+	half := d / 2
+	if half <= 0 {
+		return d
+	}
+	return half + rand.N(half)
+}
+
+// isRetrySafe reports whether replaying the request cannot create a duplicate.
+// GET/PUT/DELETE/PATCH are idempotent. POST is only safe when Discord can detect
+// the duplicate itself, e.g. message creates with a nonce and enforce_nonce.
+func isRetrySafe(method string, body []byte) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete, http.MethodPatch:
+		return true
+	case http.MethodPost:
+		return isNonceEnforced(body)
+	}
+	return false
+}
+
+func isNonceEnforced(body []byte) bool {
+	if len(body) == 0 {
+		return false
+	}
+	var v struct {
+		Nonce        json.RawMessage `json:"nonce"`
+		EnforceNonce bool            `json:"enforce_nonce"`
+	}
+	if err := json.Unmarshal(body, &v); err != nil {
+		return false
+	}
+	return v.EnforceNonce && len(v.Nonce) > 0 && string(v.Nonce) != "null" && string(v.Nonce) != `""`
+}
+
+// IsAmbiguousWriteError reports whether a failed write may still have been applied:
+// a 5xx, or a connection that broke after the request was sent. A create that
+// fails this way can leave an object behind that the caller doesn't know about.
+func IsAmbiguousWriteError(err error) bool {
+	var httpErr *DiscordHTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.StatusCode >= 500
+	}
+	return isTransientNetError(err) && !isDialError(err)
+}
+
+func isTransientNetError(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNREFUSED) || errors.Is(err, syscall.EPIPE) {
+		return true
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+	var opErr *net.OpError
+	return errors.As(err, &opErr)
+}
+
+func isDialError(err error) bool {
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
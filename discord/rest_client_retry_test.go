@@ -0,0 +1,183 @@
+package discord
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newRetryTestClient(t *testing.T, h http.HandlerFunc) *RestClient {
+	t.Helper()
+	s := httptest.NewServer(h)
+	t.Cleanup(s.Close)
+
+	c := NewRestClient("TOKEN", s.Client())
+	c.BaseURL = s.URL
+	c.RetryMaxBackoff = 10 * time.Millisecond
+	return c
+}
+
+func TestRestClient_DoJSON_RetriesTransient5xx(t *testing.T) {
+	t.Parallel()
+
+	var calls int32
+	c := newRetryTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) < 3 {
+			w.WriteHeader(http.StatusBadGateway)
+			_, _ = io.WriteString(w, "<html>bad gateway</html>")
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"ok": true})
+	})
+
+	var out map[string]interface{}
+	if err := c.DoJSON(context.Background(), "PATCH", "/guilds/1", nil, map[string]interface{}{"name": "x"}, &out); err != nil {
+		t.Fatalf("DoJSON returned error: %v", err)
+	}
+	if got := atomic.LoadInt32(&calls); got != 3 {
+		t.Fatalf("expected 3 calls, got %d", got)
+	}
+}
+
+func TestRestClient_DoJSON_GivesUpAfterMaxRetries(t *testing.T) {
+	t.Parallel()
+
+	var calls int32
+	c := newRetryTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+	c.MaxRetries = 2
+
+	err := c.DoJSON(context.Background(), "GET", "/guilds/1", nil, nil, nil)
+	if !IsDiscordHTTPStatus(err, http.StatusServiceUnavailable) {
+		t.Fatalf("expected 503 error, got %v", err)
+	}
+	if got := atomic.LoadInt32(&calls); got != 3 {
+		t.Fatalf("expected 1 call + 2 retries, got %d", got)
+	}
+}
+
+func TestRestClient_DoJSON_DoesNotRetryPlainPOST(t *testing.T) {
+	t.Parallel()
+
+	var calls int32
+	c := newRetryTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusBadGateway)
+	})
+
+	err := c.DoJSON(context.Background(), "POST", "/guilds/1/roles", nil, map[string]interface{}{"name": "x"}, nil)
+	if !IsDiscordHTTPStatus(err, http.StatusBadGateway) {
+		t.Fatalf("expected 502 error, got %v", err)
+	}
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Fatalf("expected POST not to be retried, got %d calls", got)
+	}
+}
+
+func TestRestClient_DoJSON_RetriesPOSTWithEnforcedNonce(t *testing.T) {
+	t.Parallel()
+
+	var calls int32
+	c := newRetryTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"id": "1"})
+	})
+
+	body := map[string]interface{}{"content": "hi", "nonce": "123", "enforce_nonce": true}
+	if err := c.DoJSON(context.Background(), "POST", "/channels/1/messages", nil, body, nil); err != nil {
+		t.Fatalf("DoJSON returned error: %v", err)
+	}
+	if got := atomic.LoadInt32(&calls); got != 2 {
+		t.Fatalf("expected 2 calls, got %d", got)
+	}
+}
+
+func TestRestClient_DoJSON_RetriesConnectionReset(t *testing.T) {
+	t.Parallel()
+
+	var calls int32
+	c := newRetryTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			// Drop the connection without a response.
+			conn, _, err := w.(http.Hijacker).Hijack()
+			if err != nil {
+				t.Errorf("hijack: %v", err)
+				return
+			}
+			_ = conn.Close()
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"ok": true})
+	})
+
+	if err := c.DoJSON(context.Background(), "GET", "/guilds/1", nil, nil, nil); err != nil {
+		t.Fatalf("DoJSON returned error: %v", err)
+	}
+	if got := atomic.LoadInt32(&calls); got != 2 {
+		t.Fatalf("expected 2 calls, got %d", got)
+	}
+}
+
+func TestRestClient_DoJSON_MaxRetriesZeroDisablesRetries(t *testing.T) {
+	t.Parallel()
+
+	var calls int32
+	c := newRetryTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusBadGateway)
+	})
+	c.MaxRetries = 0
+
+	if err := c.DoJSON(context.Background(), "GET", "/guilds/1", nil, nil, nil); err == nil {
+		t.Fatalf("expected error")
+	}
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Fatalf("expected 1 call, got %d", got)
+	}
+}
+
+func TestRetryBackoff(t *testing.T) {
+	for retries := 0; retries < 10; retries++ {
+		d := retryBackoff(retries, 4*time.Second)
+		want := retryBaseBackoff << retries
+		if want > 4*time.Second {
+			want = 4 * time.Second
+		}
+		if d < want/2 || d >= want {
+			t.Fatalf("retryBackoff(%d) = %s, want in [%s, %s)", retries, d, want/2, want)
+		}
+	}
+}
+
+func TestIsRetrySafe(t *testing.T) {
+	cases := []struct {
+		method string
+		body   string
+		want   bool
+	}{
+		{"GET", "", true},
+		{"PUT", `{}`, true},
+		{"PATCH", `{"name":"x"}`, true},
+		{"DELETE", "", true},
+		{"POST", `{"name":"x"}`, false},
+		{"POST", `{"nonce":"1"}`, false},
+		{"POST", `{"nonce":"1","enforce_nonce":true}`, true},
+		{"POST", `{"nonce":"","enforce_nonce":true}`, false},
+		{"POST", "", false},
+	}
+	for _, tc := range cases {
+		if got := isRetrySafe(tc.method, []byte(tc.body)); got != tc.want {
+			t.Fatalf("isRetrySafe(%s, %s) = %v, want %v", tc.method, tc.body, got, tc.want)
+		}
+	}
+}
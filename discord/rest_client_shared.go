@@ -0,0 +1,210 @@
+package discord
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// sharedRateLimiter shares the global cooldown and bucket state between provider
+// processes on one machine that use the same bot token, e.g. parallel Terraform
+// workspaces in CI. The state lives in one JSON file per token that is read and
+// rewritten under an exclusive file lock for every reservation and update.
+//
+// The file is named after a hash of the token; the token itself is never written.
+type sharedRateLimiter struct {
+	path string
+}
+
+func newSharedRateLimiter(dir, token string) (*sharedRateLimiter, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("creating rate limit state directory: %w", err)
+	}
+	sum := sha256.Sum256([]byte(token))
+	return &sharedRateLimiter{
+		path: filepath.Join(dir, "discord-ratelimit-"+hex.EncodeToString(sum[:8])+".json"),
+	}, nil
+}
+
+// sharedRateLimitFile is the on-disk state.
+type sharedRateLimitFile struct {
+	GlobalUntil time.Time                     `json:"global_until"`
+	Routes      map[string]string             `json:"routes"`
+	Buckets     map[string]sharedBucketRecord `json:"buckets"`
+}
+
+type sharedBucketRecord struct {
+	Limit     int       `json:"limit"`
+	Remaining int       `json:"remaining"`
+	ResetAt   time.Time `json:"reset_at"`
+}
+
+// withState locks the state file, loads it into a bucketLimiter, runs fn and writes
+// the result back. Expired buckets are dropped on write.
+func (s *sharedRateLimiter) withState(fn func(global *time.Time, b *bucketLimiter)) error {
+	f, err := os.OpenFile(s.path, os.O_RDWR|os.O_CREATE, 0o600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if err := lockFile(f); err != nil {
+		return fmt.Errorf("locking %s: %w", s.path, err)
+	}
+	defer func() { _ = unlockFile(f) }()
+
+	raw, err := io.ReadAll(f)
+	if err != nil {
+		return err
+	}
+	var st sharedRateLimitFile
+	// A torn or foreign file only loses rate limit hints; start over.
+	_ = json.Unmarshal(raw, &st)
+
+	b := newBucketLimiter()
+	for k, v := range st.Routes {
+		b.routes[k] = v
+	}
+	for k, v := range st.Buckets {
+		b.buckets[k] = &bucketState{limit: v.Limit, remaining: v.Remaining, resetAt: v.ResetAt}
+	}
+
+	global := st.GlobalUntil
+	fn(&global, b)
+
+	now := time.Now()
+	out := sharedRateLimitFile{
+		Routes:  b.routes,
+		Buckets: make(map[string]sharedBucketRecord, len(b.buckets)),
+	}
+	if global.After(now) {
+		out.GlobalUntil = global
+	}
+	for k, v := range b.buckets {
+		if v.resetAt.After(now) {
+			out.Buckets[k] = sharedBucketRecord{Limit: v.limit, Remaining: v.remaining, ResetAt: v.resetAt}
+		}
+	}
+	data, err := json.Marshal(out)
+	if err != nil {
+		return err
+	}
+	if err := f.Truncate(0); err != nil {
+		return err
+	}
+	_, err = f.WriteAt(data, 0)
+	return err
+}
+
+// globalUntil returns the shared global cooldown end.
+func (s *sharedRateLimiter) globalUntil() (time.Time, error) {
+	var until time.Time
+	err := s.withState(func(global *time.Time, _ *bucketLimiter) { until = *global })
+	return until, err
+}
+
+// extendGlobal moves the shared global cooldown end to until if that is later.
+func (s *sharedRateLimiter) extendGlobal(until time.Time) error {
+	return s.withState(func(global *time.Time, _ *bucketLimiter) {
+		if until.After(*global) {
+			*global = until
+		}
+	})
+}
+
+// reserve is bucketLimiter.reserveLocked against the shared state.
+func (s *sharedRateLimiter) reserve(method, path string) (time.Duration, string, error) {
+	var (
+		d    time.Duration
+		hash string
+	)
+	err := s.withState(func(_ *time.Time, b *bucketLimiter) {
+		d, hash = b.reserveLocked(method, path, time.Now())
+	})
+	return d, hash, err
+}
+
+// update is bucketLimiter.update against the shared state.
+func (s *sharedRateLimiter) update(method, path string, h http.Header) error {
+	obs, ok := parseBucketHeaders(h, time.Now())
+	if !ok {
+		return nil
+	}
+	return s.withState(func(_ *time.Time, b *bucketLimiter) {
+		b.updateLocked(method, path, obs)
+	})
+}
+
+// The helpers below route the request pipeline through the shared state when
+// rate_limit_state_dir is set. Failures to use the state file are logged and the
+// process-local limiters take over, so a broken directory never fails an apply.
+
+func (c *RestClient) waitGlobal(ctx context.Context) error {
+	if c.shared != nil {
+		until, err := c.shared.globalUntil()
+		if err != nil {
+			tflog.Warn(ctx, "Shared rate limit state unavailable", map[string]interface{}{"error": err.Error()})
+		} else if c.globalRL != nil {
+			c.globalRL.extend(until)
+		}
+	}
+	if c.globalRL == nil {
+		return nil
+	}
+	return c.globalRL.wait(ctx)
+}
+
+func (c *RestClient) setGlobalCooldown(ctx context.Context, d time.Duration) {
+	if c.globalRL == nil {
+		return
+	}
+	c.globalRL.setCooldown(d)
+	if c.shared != nil {
+		if err := c.shared.extendGlobal(c.globalRL.cooldownUntil()); err != nil {
+			tflog.Warn(ctx, "Shared rate limit state unavailable", map[string]interface{}{"error": err.Error()})
+		}
+	}
+}
+
+func (c *RestClient) waitBucket(ctx context.Context, method, path string) error {
+	if c.shared != nil {
+		for {
+			d, hash, err := c.shared.reserve(method, path)
+			if err != nil {
+				tflog.Warn(ctx, "Shared rate limit state unavailable", map[string]interface{}{"error": err.Error()})
+				break
+			}
+			if d <= 0 {
+				return nil
+			}
+			if err := sleepTraced(ctx, "discord.bucket_wait", d, attribute.String("discord.rate_limit.bucket", hash)); err != nil {
+				return err
+			}
+		}
+	}
+	if c.buckets == nil {
+		return nil
+	}
+	return c.buckets.wait(ctx, method, path)
+}
+
+func (c *RestClient) updateBucket(ctx context.Context, method, path string, h http.Header) {
+	if c.buckets != nil {
+		c.buckets.update(method, path, h)
+	}
+	if c.shared != nil {
+		if err := c.shared.update(method, path, h); err != nil {
+			tflog.Warn(ctx, "Shared rate limit state unavailable", map[string]interface{}{"error": err.Error()})
+		}
+	}
+}
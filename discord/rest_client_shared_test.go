@@ -0,0 +1,125 @@
+package discord
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newSharedTestClient(t *testing.T, url, dir string, httpClient *http.Client) *RestClient {
+	t.Helper()
+	c, err := (&Config{Token: "TOKEN", BaseURL: url, RateLimitStateDir: dir}).Client()
+	if err != nil {
+		t.Fatalf("Client returned error: %v", err)
+	}
+	c.Rest.BaseURL = url
+	c.Rest.HTTP = httpClient
+	return c.Rest
+}
+
+func TestSharedRateLimiter_BucketStateIsSharedBetweenClients(t *testing.T) {
+	t.Parallel()
+
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Bucket", "roles-bucket")
+		w.Header().Set("X-RateLimit-Limit", "1")
+		w.Header().Set("X-RateLimit-Remaining", "0")
+		w.Header().Set("X-RateLimit-Reset-After", "0.3")
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer s.Close()
+
+	dir := t.TempDir()
+	a := newSharedTestClient(t, s.URL, dir, s.Client())
+	b := newSharedTestClient(t, s.URL, dir, s.Client())
+
+	ctx := context.Background()
+	if err := a.DoJSON(ctx, "GET", "/guilds/1/roles", nil, nil, nil); err != nil {
+		t.Fatalf("first request: %v", err)
+	}
+
+	// b has never seen this route; only the shared state can make it wait.
+	start := time.Now()
+	if err := b.DoJSON(ctx, "GET", "/guilds/1/roles", nil, nil, nil); err != nil {
+		t.Fatalf("second request: %v", err)
+	}
+	if waited := time.Since(start); waited < 200*time.Millisecond {
+		t.Fatalf("expected the second client to wait for the shared bucket, waited %s", waited)
+	}
+}
+
+func TestSharedRateLimiter_GlobalCooldownIsSharedBetweenClients(t *testing.T) {
+	t.Parallel()
+
+	var calls int32
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusTooManyRequests)
+			_, _ = w.Write([]byte(`{"message":"You are being rate limited.","retry_after":0.3,"global":true}`))
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer s.Close()
+
+	dir := t.TempDir()
+	a := newSharedTestClient(t, s.URL, dir, s.Client())
+	b := newSharedTestClient(t, s.URL, dir, s.Client())
+
+	done := make(chan error, 1)
+	go func() { done <- a.DoJSON(context.Background(), "GET", "/users/@me", nil, nil, nil) }()
+
+	// Wait until a has recorded the global cooldown.
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		until, err := b.shared.globalUntil()
+		if err != nil {
+			t.Fatalf("globalUntil: %v", err)
+		}
+		if !until.IsZero() {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("global cooldown was never shared")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	start := time.Now()
+	if err := b.DoJSON(context.Background(), "GET", "/channels/2", nil, nil, nil); err != nil {
+		t.Fatalf("second client: %v", err)
+	}
+	if waited := time.Since(start); waited < 100*time.Millisecond {
+		t.Fatalf("expected the second client to honour the shared global cooldown, waited %s", waited)
+	}
+	if err := <-done; err != nil {
+		t.Fatalf("first client: %v", err)
+	}
+}
+
+func TestSharedRateLimiter_DoesNotStoreToken(t *testing.T) {
+	dir := t.TempDir()
+	sh, err := newSharedRateLimiter(dir, "SECRET-TOKEN")
+	if err != nil {
+		t.Fatalf("newSharedRateLimiter: %v", err)
+	}
+	if err := sh.extendGlobal(time.Now().Add(time.Minute)); err != nil {
+		t.Fatalf("extendGlobal: %v", err)
+	}
+	if strings.Contains(sh.path, "SECRET-TOKEN") {
+		t.Fatalf("state file name contains the token: %s", sh.path)
+	}
+	data, err := os.ReadFile(sh.path)
+	if err != nil {
+		t.Fatalf("reading state: %v", err)
+	}
+	if strings.Contains(string(data), "SECRET-TOKEN") {
+		t.Fatalf("state file contains the token: %s", data)
+	}
+}
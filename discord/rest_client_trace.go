@@ -0,0 +1,59 @@
+package discord
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracer is the OpenTelemetry no-op tracer unless the provider installed an SDK
+// tracer provider (see internal/telemetry).
+var tracer = otel.Tracer("github.com/45ck/terraform-provider-discord/discord")
+
+// startAttemptSpan starts the span for one HTTP attempt.
+func startAttemptSpan(ctx context.Context, method, path string, retries int) (context.Context, trace.Span) {
+	return tracer.Start(ctx, "discord.http "+method, trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(
+		attribute.String("http.request.method", method),
+		attribute.String("url.path", redactPath(path)),
+		attribute.Int("discord.retries", retries),
+	))
+}
+
+// endAttemptSpan records the outcome of an HTTP attempt and ends its span.
+func endAttemptSpan(span trace.Span, status int, bucket string, err error) {
+	if status != 0 {
+		span.SetAttributes(attribute.Int("http.response.status_code", status))
+	}
+	if bucket != "" {
+		span.SetAttributes(attribute.String("discord.rate_limit.bucket", bucket))
+	}
+	switch {
+	case err != nil:
+		err = redactError(err)
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	case status >= 400:
+		span.SetStatus(codes.Error, "")
+	}
+	span.End()
+}
+
+// sleepTraced waits d (or until ctx is done) inside a span named name.
+func sleepTraced(ctx context.Context, name string, d time.Duration, attrs ...attribute.KeyValue) error {
+	_, span := tracer.Start(ctx, name, trace.WithAttributes(append(attrs, attribute.Int64("discord.wait_ms", d.Milliseconds()))...))
+	defer span.End()
+
+	t := time.NewTimer(d)
+	select {
+	case <-ctx.Done():
+		t.Stop()
+		span.SetStatus(codes.Error, ctx.Err().Error())
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
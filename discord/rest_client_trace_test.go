@@ -0,0 +1,115 @@
+package discord
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+var (
+	traceOnce     sync.Once
+	traceProvider *sdktrace.TracerProvider
+	traceExporter *tracetest.InMemoryExporter
+)
+
+// testTracerProvider installs one global tracer provider for the package's
+// tests and clears its recorded spans. The global otel tracer binds to the
+// first provider it is given, so tests cannot each install their own.
+func testTracerProvider() (*sdktrace.TracerProvider, *tracetest.InMemoryExporter) {
+	traceOnce.Do(func() {
+		traceExporter = tracetest.NewInMemoryExporter()
+		traceProvider = sdktrace.NewTracerProvider(sdktrace.WithSyncer(traceExporter))
+		otel.SetTracerProvider(traceProvider)
+	})
+	traceExporter.Reset()
+	return traceProvider, traceExporter
+}
+
+func TestRestClient_TracesAttemptsAndRateLimitWaits(t *testing.T) {
+	tp, exp := testTracerProvider()
+
+	var calls int32
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusTooManyRequests)
+			_, _ = w.Write([]byte(`{"message":"You are being rate limited.","retry_after":0.01,"global":false}`))
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer s.Close()
+
+	c := NewRestClient("TOKEN", s.Client())
+	c.BaseURL = s.URL
+
+	ctx, parent := tp.Tracer("test").Start(context.Background(), "parent")
+	if err := c.DoJSON(ctx, "DELETE", "/webhooks/1/secret", nil, nil, nil); err != nil {
+		t.Fatalf("DoJSON returned error: %v", err)
+	}
+	parent.End()
+
+	var attempts, waits int
+	for _, sp := range exp.GetSpans() {
+		if sp.Name == "parent" {
+			continue
+		}
+		if sp.Parent.SpanID() != parent.SpanContext().SpanID() {
+			t.Fatalf("span %q is not a child of the caller's span", sp.Name)
+		}
+		switch sp.Name {
+		case "discord.http DELETE":
+			attempts++
+			for _, a := range sp.Attributes {
+				if a.Key == "url.path" && a.Value.AsString() != "/webhooks/1/[REDACTED]" {
+					t.Fatalf("expected redacted url.path, got %q", a.Value.AsString())
+				}
+			}
+		case "discord.rate_limit_wait":
+			waits++
+		}
+	}
+	if attempts != 2 || waits != 1 {
+		t.Fatalf("expected 2 attempt spans and 1 wait span, got %d and %d", attempts, waits)
+	}
+}
+
+func TestRestClient_TracesTransportErrorsWithoutSecrets(t *testing.T) {
+	_, exp := testTracerProvider()
+
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	s.Close()
+
+	c := NewRestClient("TOKEN", s.Client())
+	c.BaseURL = s.URL
+	c.MaxRetries = 0
+
+	if err := c.DoJSON(context.Background(), "POST", "/webhooks/1/secret", nil, nil, nil); err == nil {
+		t.Fatal("expected a connection error")
+	}
+
+	spans := exp.GetSpans()
+	if len(spans) == 0 {
+		t.Fatal("expected an attempt span")
+	}
+	for _, sp := range spans {
+		if strings.Contains(sp.Status.Description, "secret") {
+			t.Fatalf("span status leaked the webhook token: %q", sp.Status.Description)
+		}
+		for _, ev := range sp.Events {
+			for _, a := range ev.Attributes {
+				if strings.Contains(a.Value.Emit(), "secret") {
+					t.Fatalf("span event %q leaked the webhook token: %s", ev.Name, a.Value.Emit())
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package discord
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// TokenEnv is the environment variable the provider reads the bot token from
+// when token is not configured.
+const TokenEnv = "DISCORD_TOKEN"
+
+// TokenCommandTimeout bounds how long a token command may run.
+const TokenCommandTimeout = 30 * time.Second
+
+// tokenFormat matches a bot token: three base64url segments (the user ID, a
+// timestamp and an HMAC) joined by dots.
+var tokenFormat = regexp.MustCompile(`^[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+$`)
+
+// ValidateToken reports tokens that can't be bot tokens, such as ones pasted
+// with the "Bot " prefix or a client secret.
+func ValidateToken(token string) error {
+	if strings.HasPrefix(token, "Bot ") || strings.HasPrefix(token, "Bearer ") {
+		return errors.New(`the token must not include the "Bot " or "Bearer " prefix; the provider adds it`)
+	}
+	if !tokenFormat.MatchString(token) {
+		return errors.New("the token is not a bot token: expected three dot-separated segments, as shown on the Bot page of the Discord developer portal")
+	}
+	return nil
+}
+
+// ReadTokenFile returns the content of file without surrounding whitespace.
+func ReadTokenFile(file string) (string, error) {
+	raw, err := os.ReadFile(file)
+	if err != nil {
+		return "", fmt.Errorf("reading token_file: %w", err)
+	}
+	tok := strings.TrimSpace(string(raw))
+	if tok == "" {
+		return "", fmt.Errorf("token_file %s is empty", file)
+	}
+	return tok, nil
+}
+
+// RunTokenCommand runs argv, without a shell, and returns its standard output
+// without surrounding whitespace. It fails when the command fails, prints
+// nothing or runs longer than TokenCommandTimeout.
+func RunTokenCommand(ctx context.Context, argv []string) (string, error) {
+	if len(argv) == 0 || argv[0] == "" {
+		return "", errors.New("token_command is empty")
+	}
+	ctx, cancel := context.WithTimeout(ctx, TokenCommandTimeout)
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, argv[0], argv[1:]...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return "", fmt.Errorf("token_command %s did not finish within %s", argv[0], TokenCommandTimeout)
+		}
+		msg := strings.TrimSpace(stderr.String())
+		if len(msg) > 500 {
+			msg = msg[:500] + "..."
+		}
+		if msg != "" {
+			return "", fmt.Errorf("token_command %s: %w: %s", argv[0], err, msg)
+		}
+		return "", fmt.Errorf("token_command %s: %w", argv[0], err)
+	}
+	tok := strings.TrimSpace(stdout.String())
+	if tok == "" {
+		return "", fmt.Errorf("token_command %s printed nothing", argv[0])
+	}
+	return tok, nil
+}
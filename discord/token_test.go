@@ -0,0 +1,85 @@
+package discord
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestTokenCommandHelper is the command RunTokenCommand runs in the tests below.
+func TestTokenCommandHelper(t *testing.T) {
+	switch os.Getenv("DISCORD_TEST_TOKEN_COMMAND") {
+	case "":
+		return
+	case "print":
+		fmt.Println("  MTIz.NDU2.Nzg5  ")
+	case "fail":
+		fmt.Fprintln(os.Stderr, "vault is sealed")
+		os.Exit(2)
+	}
+	os.Exit(0)
+}
+
+func TestRunTokenCommand(t *testing.T) {
+	argv := []string{os.Args[0], "-test.run=^TestTokenCommandHelper$"}
+	ctx := context.Background()
+
+	t.Setenv("DISCORD_TEST_TOKEN_COMMAND", "print")
+	tok, err := RunTokenCommand(ctx, argv)
+	if err != nil || tok != "MTIz.NDU2.Nzg5" {
+		t.Fatalf("RunTokenCommand = %q, %v", tok, err)
+	}
+
+	t.Setenv("DISCORD_TEST_TOKEN_COMMAND", "fail")
+	if _, err := RunTokenCommand(ctx, argv); err == nil || !strings.Contains(err.Error(), "vault is sealed") {
+		t.Fatalf("expected the command's stderr in the error, got %v", err)
+	}
+
+	t.Setenv("DISCORD_TEST_TOKEN_COMMAND", "silent")
+	if _, err := RunTokenCommand(ctx, argv); err == nil || !strings.Contains(err.Error(), "printed nothing") {
+		t.Fatalf("expected an empty output error, got %v", err)
+	}
+
+	if _, err := RunTokenCommand(ctx, nil); err == nil {
+		t.Fatal("expected an error for an empty command")
+	}
+}
+
+func TestReadTokenFile(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	file := filepath.Join(dir, "token")
+	if err := os.WriteFile(file, []byte("MTIz.NDU2.Nzg5\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if tok, err := ReadTokenFile(file); err != nil || tok != "MTIz.NDU2.Nzg5" {
+		t.Fatalf("ReadTokenFile = %q, %v", tok, err)
+	}
+
+	empty := filepath.Join(dir, "empty")
+	if err := os.WriteFile(empty, []byte(" \n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	for _, f := range []string{empty, filepath.Join(dir, "missing")} {
+		if _, err := ReadTokenFile(f); err == nil {
+			t.Errorf("ReadTokenFile(%s): expected an error", f)
+		}
+	}
+}
+
+func TestValidateToken(t *testing.T) {
+	t.Parallel()
+
+	if err := ValidateToken("MTIzNDU2Nzg5MDEyMzQ1Njc4.GaBcDe.abc_DEF-123"); err != nil {
+		t.Fatalf("valid token: %v", err)
+	}
+	for _, tok := range []string{"", "Bot MTIz.NDU2.Nzg5", "client-secret", "MTIz.NDU2", "MTIz..Nzg5", "MTIz.NDU2.Nzg5 "} {
+		if err := ValidateToken(tok); err == nil {
+			t.Errorf("ValidateToken(%q): expected an error", tok)
+		}
+	}
+}
@@ -2,9 +2,24 @@ package discord
 
 import (
 	"fmt"
+	"math/rand/v2"
+	"strconv"
 	"strings"
+	"time"
 )
 
+// DiscordEpoch is the first second of 2015, the zero point of snowflake timestamps.
+const DiscordEpoch = 1420070400000
+
+// SnowflakeTime returns the creation time encoded in a snowflake ID.
+func SnowflakeTime(id string) (time.Time, bool) {
+	v, err := strconv.ParseUint(id, 10, 64)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return time.UnixMilli(int64(v>>22) + DiscordEpoch).UTC(), true
+}
+
 func parseTwoIds(id string) (string, string, error) {
 	parts := strings.SplitN(id, ":", 2)
 	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
@@ -12,3 +27,10 @@ func parseTwoIds(id string) (string, string, error) {
 	}
 	return parts[0], parts[1], nil
 }
+
+// NewNonce returns a random message nonce (at most 25 characters).
+// Sent together with enforce_nonce, Discord returns the original message instead of
+// creating a duplicate, which makes message creates safe to retry.
+func NewNonce() string {
+	return strconv.FormatUint(rand.Uint64(), 10)
+}
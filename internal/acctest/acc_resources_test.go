@@ -1,6 +1,3 @@
-//go:build acctest
-// +build acctest
-
 package acctest
 
 import (
@@ -9,15 +6,17 @@ import (
 	"strings"
 	"testing"
 
-	"github.com/45ck/terraform-provider-discord/internal/fw"
-	"github.com/hashicorp/terraform-plugin-framework/providerserver"
-	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
-	ptacctest "github.com/hashicorp/terraform-plugin-testing/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 )
 
+// testAccToken returns the bot token, or a placeholder when replaying. Recorded
+// cassettes never contain the real token.
 func testAccToken(t *testing.T) string {
 	t.Helper()
+	rec := testAccRecorder(t)
+	if testAccMode(t) == accReplay {
+		return replayToken
+	}
 	if os.Getenv("TF_ACC") == "" {
 		t.Skip("TF_ACC not set")
 	}
@@ -25,11 +24,19 @@ func testAccToken(t *testing.T) string {
 	if v == "" {
 		t.Fatal("DISCORD_TOKEN must be set for acceptance tests")
 	}
+	if rec != nil {
+		rec.Redact(v, replayToken)
+	}
 	return v
 }
 
+// testAccGuildID returns the test guild, or a placeholder when replaying.
 func testAccGuildID(t *testing.T) string {
 	t.Helper()
+	rec := testAccRecorder(t)
+	if testAccMode(t) == accReplay {
+		return replayGuildID
+	}
 	v := os.Getenv("DISCORD_GUILD_ID")
 	if v == "" {
 		v = os.Getenv("DISCORD_SERVER_ID")
@@ -37,20 +44,17 @@ func testAccGuildID(t *testing.T) string {
 	if v == "" {
 		t.Fatal("DISCORD_GUILD_ID (or legacy DISCORD_SERVER_ID) must be set for acceptance tests")
 	}
-	return v
-}
-
-func testAccProtoV6ProviderFactories() map[string]func() (tfprotov6.ProviderServer, error) {
-	return map[string]func() (tfprotov6.ProviderServer, error){
-		"discord": providerserver.NewProtocol6WithError(fw.New("acctest")()),
+	if rec != nil {
+		rec.Redact(v, replayGuildID)
 	}
+	return v
 }
 
 func TestAccChannelAndMessage_Basic(t *testing.T) {
 	token := testAccToken(t)
 	guildID := testAccGuildID(t)
 
-	suffix := ptacctest.RandStringFromCharSet(8, "abcdefghijklmnopqrstuvwxyz0123456789")
+	suffix := testAccSuffix(t)
 	channelName := fmt.Sprintf("tf-acc-%s", strings.ToLower(suffix))
 
 	cfg1 := fmt.Sprintf(`
@@ -91,8 +95,7 @@ resource "discord_message" "msg" {
 }
 `, token, guildID, channelName)
 
-	resource.Test(t, resource.TestCase{
-		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories(),
+	testAccTest(t, resource.TestCase{
 		Steps: []resource.TestStep{
 			{
 				Config: cfg1,
@@ -111,4 +114,3 @@ resource "discord_message" "msg" {
 		},
 	})
 }
-
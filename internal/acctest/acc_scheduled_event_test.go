@@ -1,6 +1,3 @@
-//go:build acctest
-// +build acctest
-
 package acctest
 
 import (
@@ -9,7 +6,6 @@ import (
 	"testing"
 	"time"
 
-	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 )
 
@@ -17,10 +13,10 @@ func TestAccScheduledEvent_VoiceBasic(t *testing.T) {
 	token := testAccToken(t)
 	guildID := testAccGuildID(t)
 
-	suffix := acctest.RandStringFromCharSet(8, "abcdefghijklmnopqrstuvwxyz0123456789")
+	suffix := testAccSuffix(t)
 	voiceName := fmt.Sprintf("tf-acc-voice-%s", strings.ToLower(suffix))
 	eventName := fmt.Sprintf("tf-acc-event-%s", strings.ToLower(suffix))
-	start := time.Now().UTC().Add(2 * time.Hour).Format(time.RFC3339)
+	start := testAccNow(t).Add(2 * time.Hour).Format(time.RFC3339)
 
 	cfg1 := fmt.Sprintf(`
 provider "discord" {
@@ -64,8 +60,7 @@ resource "discord_scheduled_event" "ev" {
 }
 `, token, guildID, voiceName, guildID, eventName, start)
 
-	resource.Test(t, resource.TestCase{
-		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories(),
+	testAccTest(t, resource.TestCase{
 		Steps: []resource.TestStep{
 			{
 				Config: cfg1,
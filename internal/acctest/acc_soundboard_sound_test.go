@@ -1,32 +1,22 @@
-//go:build acctest
-// +build acctest
-
 package acctest
 
 import (
 	"fmt"
-	"os"
 	"strings"
 	"testing"
 
-	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 )
 
 func TestAccSoundboardSound_Basic(t *testing.T) {
-	if os.Getenv("DISCORD_ENABLE_SOUNDBOARD_TEST") == "" {
-		t.Skip("DISCORD_ENABLE_SOUNDBOARD_TEST not set")
-	}
+	testAccOptIn(t, "DISCORD_ENABLE_SOUNDBOARD_TEST")
 
 	token := testAccToken(t)
 	guildID := testAccGuildID(t)
 
-	filePath := os.Getenv("DISCORD_SOUNDBOARD_FILE_PATH")
-	if filePath == "" {
-		t.Skip("DISCORD_SOUNDBOARD_FILE_PATH not set")
-	}
+	filePath := testAccFile(t, "DISCORD_SOUNDBOARD_FILE_PATH", "sound.mp3")
 
-	suffix := acctest.RandStringFromCharSet(8, "abcdefghijklmnopqrstuvwxyz0123456789")
+	suffix := testAccSuffix(t)
 	name1 := fmt.Sprintf("tf-acc-sound-%s", strings.ToLower(suffix))
 	name2 := fmt.Sprintf("tf-acc-sound2-%s", strings.ToLower(suffix))
 
@@ -58,8 +48,7 @@ resource "discord_soundboard_sound" "s" {
 }
 `, token, guildID, name2, filePath)
 
-	resource.Test(t, resource.TestCase{
-		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories(),
+	testAccTest(t, resource.TestCase{
 		Steps: []resource.TestStep{
 			{
 				Config: cfg1,
@@ -1,27 +1,20 @@
-//go:build acctest
-// +build acctest
-
 package acctest
 
 import (
 	"fmt"
-	"os"
 	"strings"
 	"testing"
 
-	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 )
 
 func TestAccStageInstance_Basic(t *testing.T) {
-	if os.Getenv("DISCORD_ENABLE_STAGE_INSTANCE_TEST") == "" {
-		t.Skip("DISCORD_ENABLE_STAGE_INSTANCE_TEST not set")
-	}
+	testAccOptIn(t, "DISCORD_ENABLE_STAGE_INSTANCE_TEST")
 
 	token := testAccToken(t)
 	guildID := testAccGuildID(t)
 
-	suffix := acctest.RandStringFromCharSet(8, "abcdefghijklmnopqrstuvwxyz0123456789")
+	suffix := testAccSuffix(t)
 	stageName := fmt.Sprintf("tf-acc-stage-%s", strings.ToLower(suffix))
 
 	cfg1 := fmt.Sprintf(`
@@ -58,8 +51,7 @@ resource "discord_stage_instance" "si" {
 }
 `, token, guildID, stageName)
 
-	resource.Test(t, resource.TestCase{
-		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories(),
+	testAccTest(t, resource.TestCase{
 		Steps: []resource.TestStep{
 			{
 				Config: cfg1,
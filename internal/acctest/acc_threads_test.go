@@ -1,6 +1,3 @@
-//go:build acctest
-// +build acctest
-
 package acctest
 
 import (
@@ -8,7 +5,6 @@ import (
 	"strings"
 	"testing"
 
-	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 )
 
@@ -16,7 +12,7 @@ func TestAccThreadAndMember_Basic(t *testing.T) {
 	token := testAccToken(t)
 	guildID := testAccGuildID(t)
 
-	suffix := acctest.RandStringFromCharSet(8, "abcdefghijklmnopqrstuvwxyz0123456789")
+	suffix := testAccSuffix(t)
 	channelName := fmt.Sprintf("tf-acc-threads-%s", strings.ToLower(suffix))
 	threadName := fmt.Sprintf("tf-acc-thread-%s", strings.ToLower(suffix))
 
@@ -43,8 +39,7 @@ resource "discord_thread_member" "me" {
 }
 `, token, guildID, channelName, threadName)
 
-	resource.Test(t, resource.TestCase{
-		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories(),
+	testAccTest(t, resource.TestCase{
 		Steps: []resource.TestStep{
 			{
 				Config: cfg,
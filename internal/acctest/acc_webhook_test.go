@@ -1,15 +1,10 @@
-//go:build acctest
-// +build acctest
-
 package acctest
 
 import (
 	"fmt"
-	"os"
 	"strings"
 	"testing"
 
-	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 )
 
@@ -17,7 +12,7 @@ func TestAccWebhook_Basic(t *testing.T) {
 	token := testAccToken(t)
 	guildID := testAccGuildID(t)
 
-	suffix := acctest.RandStringFromCharSet(8, "abcdefghijklmnopqrstuvwxyz0123456789")
+	suffix := testAccSuffix(t)
 	channelName := fmt.Sprintf("tf-acc-webhook-%s", strings.ToLower(suffix))
 	webhookName1 := fmt.Sprintf("tf-acc-webhook-%s", strings.ToLower(suffix))
 	webhookName2 := fmt.Sprintf("tf-acc-webhook2-%s", strings.ToLower(suffix))
@@ -57,8 +52,7 @@ resource "discord_webhook" "wh" {
 `, token, guildID, channelName, webhookName2)
 
 	// Keep this lightweight: in some Discord responses the token/url may be omitted.
-	resource.Test(t, resource.TestCase{
-		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories(),
+	testAccTest(t, resource.TestCase{
 		Steps: []resource.TestStep{
 			{
 				Config: cfg1,
@@ -80,12 +74,9 @@ func TestAccSticker_Basic(t *testing.T) {
 	token := testAccToken(t)
 	guildID := testAccGuildID(t)
 
-	filePath := os.Getenv("DISCORD_STICKER_FILE_PATH")
-	if filePath == "" {
-		t.Skip("DISCORD_STICKER_FILE_PATH not set")
-	}
+	filePath := testAccFile(t, "DISCORD_STICKER_FILE_PATH", "sticker.png")
 
-	suffix := acctest.RandStringFromCharSet(8, "abcdefghijklmnopqrstuvwxyz0123456789")
+	suffix := testAccSuffix(t)
 	name := fmt.Sprintf("tf-acc-sticker-%s", strings.ToLower(suffix))
 
 	cfg := fmt.Sprintf(`
@@ -102,8 +93,7 @@ resource "discord_sticker" "s" {
 }
 `, token, guildID, name, filePath)
 
-	resource.Test(t, resource.TestCase{
-		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories(),
+	testAccTest(t, resource.TestCase{
 		Steps: []resource.TestStep{
 			{
 				Config: cfg,
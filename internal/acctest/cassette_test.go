@@ -0,0 +1,188 @@
+package acctest
+
+import (
+	"fmt"
+	"hash/fnv"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/45ck/terraform-provider-discord/discord"
+	"github.com/45ck/terraform-provider-discord/internal/fw"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	ptacctest "github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+// Acceptance tests can replay cassettes recorded against Discord from
+// testdata/cassettes, offline and without secrets; a test without one is
+// skipped. DISCORD_CASSETTE_MODE selects the mode:
+//
+//   - replay: serve recorded responses (the default without TF_ACC)
+//   - record: run against Discord and rewrite the cassettes (needs TF_ACC)
+//   - live:   run against Discord without cassettes (the default with TF_ACC)
+const cassetteModeEnv = "DISCORD_CASSETTE_MODE"
+
+// Placeholders stand in for the credentials scrubbed from cassettes.
+const (
+	replayToken   = "cmVwbGF5.cmVwbGF5.replay-token"
+	replayGuildID = "200000000000000000"
+)
+
+type accMode int
+
+const (
+	accReplay accMode = iota
+	accRecord
+	accLive
+)
+
+func testAccMode(t *testing.T) accMode {
+	t.Helper()
+	switch v := strings.ToLower(strings.TrimSpace(os.Getenv(cassetteModeEnv))); v {
+	case "":
+		if os.Getenv("TF_ACC") != "" {
+			return accLive
+		}
+		return accReplay
+	case "replay":
+		return accReplay
+	case "record":
+		return accRecord
+	case "live":
+		return accLive
+	default:
+		t.Fatalf("%s must be replay, record or live, got %q", cassetteModeEnv, v)
+		return accLive
+	}
+}
+
+var recorders sync.Map // test name -> *discord.Recorder
+
+func cassettePath(t *testing.T) string {
+	return filepath.Join("testdata", "cassettes", strings.ReplaceAll(t.Name(), "/", "_")+".json")
+}
+
+// testAccRecorder returns the test's recorder, or nil when running live. In
+// replay mode a test without a cassette is skipped; in record mode the cassette
+// is written when the test passes.
+func testAccRecorder(t *testing.T) *discord.Recorder {
+	t.Helper()
+	if v, ok := recorders.Load(t.Name()); ok {
+		return v.(*discord.Recorder)
+	}
+
+	var rec *discord.Recorder
+	switch testAccMode(t) {
+	case accLive:
+		return nil
+	case accReplay:
+		if _, err := os.Stat(cassettePath(t)); os.IsNotExist(err) {
+			t.Skipf("no cassette at %s; record one with %s=record", cassettePath(t), cassetteModeEnv)
+		}
+		r, err := discord.NewRecorder(cassettePath(t), discord.RecorderReplay)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rec = r
+	case accRecord:
+		if os.Getenv("TF_ACC") == "" {
+			t.Fatalf("%s=record runs against Discord and needs TF_ACC", cassetteModeEnv)
+		}
+		r, err := discord.NewRecorder(cassettePath(t), discord.RecorderRecord)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rec = r
+		t.Cleanup(func() {
+			if t.Failed() {
+				return
+			}
+			if err := rec.Save(); err != nil {
+				t.Errorf("saving cassette: %s", err)
+			}
+		})
+	}
+	recorders.Store(t.Name(), rec)
+	t.Cleanup(func() { recorders.Delete(t.Name()) })
+	return rec
+}
+
+// testAccSuffix returns a random name suffix, or one derived from the test name
+// when cassettes are involved so that recorded names match on replay.
+func testAccSuffix(t *testing.T) string {
+	t.Helper()
+	if testAccRecorder(t) == nil {
+		return strings.ToLower(ptacctest.RandStringFromCharSet(8, "abcdefghijklmnopqrstuvwxyz0123456789"))
+	}
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(t.Name()))
+	return fmt.Sprintf("%08s", strconv.FormatUint(uint64(h.Sum32()), 36))
+}
+
+// testAccNow is the current time, or the time the cassette was recorded.
+func testAccNow(t *testing.T) time.Time {
+	t.Helper()
+	if rec := testAccRecorder(t); rec != nil {
+		return rec.RecordedAt()
+	}
+	return time.Now().UTC()
+}
+
+// testAccOptIn skips a test that needs guild features most test guilds lack,
+// unless env is set. Replays always run.
+func testAccOptIn(t *testing.T, env string) {
+	t.Helper()
+	if testAccMode(t) != accReplay && os.Getenv(env) == "" {
+		t.Skipf("%s not set", env)
+	}
+}
+
+// testAccFile returns the file named by env, or the stand-in in testdata when
+// replaying. The stand-in's content is never sent anywhere.
+func testAccFile(t *testing.T, env, standIn string) string {
+	t.Helper()
+	if testAccMode(t) == accReplay {
+		return filepath.Join("testdata", standIn)
+	}
+	v := os.Getenv(env)
+	if v == "" {
+		t.Skipf("%s not set", env)
+	}
+	return v
+}
+
+func testAccProtoV6ProviderFactories(t *testing.T) map[string]func() (tfprotov6.ProviderServer, error) {
+	var wrap func(http.RoundTripper) http.RoundTripper
+	if rec := testAccRecorder(t); rec != nil {
+		wrap = rec.Transport
+	}
+	return map[string]func() (tfprotov6.ProviderServer, error){
+		"discord": providerserver.NewProtocol6WithError(fw.NewWithTransport("acctest", wrap)()),
+	}
+}
+
+// testAccTest runs tc against Discord or its cassette. Replays run without TF_ACC
+// but still need a terraform CLI, and skip when there is none rather than
+// downloading one.
+func testAccTest(t *testing.T, tc resource.TestCase) {
+	t.Helper()
+	tc.ProtoV6ProviderFactories = testAccProtoV6ProviderFactories(t)
+	if testAccMode(t) != accReplay {
+		resource.Test(t, tc)
+		return
+	}
+	if os.Getenv("TF_ACC_TERRAFORM_PATH") == "" {
+		if _, err := exec.LookPath("terraform"); err != nil {
+			t.Skip("terraform CLI not found; install it or set TF_ACC_TERRAFORM_PATH")
+		}
+	}
+	resource.UnitTest(t, tc)
+}
@@ -0,0 +1,249 @@
+package fakediscord
+
+import (
+	"io"
+	"net/http"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// emojiName is the character set Discord accepts for custom emoji names.
+var emojiName = regexp.MustCompile(`^[A-Za-z0-9_]{2,32}$`)
+
+func (s *Server) assetRoutes() {
+	s.handle("GET /guilds/{guild}/emojis", s.withGuild(s.listEmojis))
+	s.handle("POST /guilds/{guild}/emojis", s.withGuild(s.createEmoji))
+	s.handle("GET /guilds/{guild}/emojis/{emoji}", s.withGuild(s.getEmoji))
+	s.handle("PATCH /guilds/{guild}/emojis/{emoji}", s.withGuild(s.modifyEmoji))
+	s.handle("DELETE /guilds/{guild}/emojis/{emoji}", s.withGuild(s.deleteEmoji))
+
+	s.handle("GET /guilds/{guild}/stickers", s.withGuild(s.listStickers))
+	s.handle("POST /guilds/{guild}/stickers", s.withGuild(s.createSticker))
+	s.handle("GET /guilds/{guild}/stickers/{sticker}", s.withGuild(s.getSticker))
+	s.handle("PATCH /guilds/{guild}/stickers/{sticker}", s.withGuild(s.modifySticker))
+	s.handle("DELETE /guilds/{guild}/stickers/{sticker}", s.withGuild(s.deleteSticker))
+}
+
+func (s *Server) validateEmoji(g *guild, in object) formErrors {
+	var errs formErrors
+	if v, ok := in["name"]; ok && v != nil && !emojiName.MatchString(str(v)) {
+		errs.add("name", "BASE_TYPE_BAD_LENGTH", "Must be between 2 and 32 in length and contain only alphanumeric characters and underscores.")
+	}
+	if v, ok := in["roles"]; ok && v != nil {
+		roles, _ := v.([]any)
+		for i, id := range roles {
+			if _, found := g.roles[str(id)]; !found {
+				errs.add("roles."+fmtInt(i), "ROLE_INVALID", "Unknown role "+str(id))
+			}
+		}
+	}
+	return errs
+}
+
+func (s *Server) listEmojis(w http.ResponseWriter, r *http.Request, g *guild) {
+	writeJSON(w, http.StatusOK, valuesByID(g.emojis))
+}
+
+func (s *Server) createEmoji(w http.ResponseWriter, r *http.Request, g *guild) {
+	in, ok := decodeBody(w, r)
+	if !ok {
+		return
+	}
+	errs := s.validateEmoji(g, in)
+	if _, ok := in["name"]; !ok {
+		errs.required("name")
+	}
+	if _, ok := in["image"]; !ok {
+		errs.required("image")
+	}
+	checkImage(&errs, in, "image")
+	if errs.write(w) {
+		return
+	}
+	if len(g.emojis) >= 50 {
+		writeError(w, http.StatusBadRequest, 30008, "Maximum number of emojis reached (50)")
+		return
+	}
+
+	roles, _ := in["roles"].([]any)
+	if roles == nil {
+		roles = []any{}
+	}
+	e := object{
+		"id":             s.ids.next(),
+		"name":           in["name"],
+		"roles":          roles,
+		"user":           cloneObject(s.botUser),
+		"require_colons": true,
+		"managed":        false,
+		"animated":       strings.HasPrefix(str(in["image"]), "data:image/gif"),
+		"available":      true,
+	}
+	g.emojis[str(e["id"])] = e
+	writeJSON(w, http.StatusCreated, e)
+}
+
+// emoji resolves the {emoji} path value or answers 10014 Unknown Emoji.
+func (s *Server) emoji(w http.ResponseWriter, r *http.Request, g *guild) (object, bool) {
+	e, ok := g.emojis[r.PathValue("emoji")]
+	if !ok {
+		writeNotFound(w, 10014, "Emoji")
+	}
+	return e, ok
+}
+
+func (s *Server) getEmoji(w http.ResponseWriter, r *http.Request, g *guild) {
+	if e, ok := s.emoji(w, r, g); ok {
+		writeJSON(w, http.StatusOK, e)
+	}
+}
+
+func (s *Server) modifyEmoji(w http.ResponseWriter, r *http.Request, g *guild) {
+	e, ok := s.emoji(w, r, g)
+	if !ok {
+		return
+	}
+	in, ok := decodeBody(w, r)
+	if !ok {
+		return
+	}
+	if errs := s.validateEmoji(g, in); errs.write(w) {
+		return
+	}
+	merge(e, in, "name")
+	if v, ok := in["roles"]; ok {
+		if v == nil {
+			v = []any{}
+		}
+		e["roles"] = v
+	}
+	writeJSON(w, http.StatusOK, e)
+}
+
+func (s *Server) deleteEmoji(w http.ResponseWriter, r *http.Request, g *guild) {
+	if e, ok := s.emoji(w, r, g); ok {
+		delete(g.emojis, str(e["id"]))
+		writeNoContent(w)
+	}
+}
+
+// Sticker format types, see https://discord.com/developers/docs/resources/sticker#sticker-object-sticker-format-types.
+var stickerFormats = map[string]int{".png": 1, ".apng": 2, ".json": 3, ".gif": 4}
+
+func validateSticker(in object, create bool) formErrors {
+	var errs formErrors
+	if create {
+		errs.requiredLength(in, "name", 2, 30)
+		errs.requiredLength(in, "tags", 1, 200)
+	} else {
+		errs.length(in, "name", 2, 30)
+		errs.length(in, "tags", 1, 200)
+	}
+	if d := str(in["description"]); d != "" {
+		errs.length(in, "description", 2, 100)
+	}
+	return errs
+}
+
+func (s *Server) listStickers(w http.ResponseWriter, r *http.Request, g *guild) {
+	writeJSON(w, http.StatusOK, valuesByID(g.stickers))
+}
+
+// createSticker takes multipart/form-data with name, description and tags fields
+// and the image in a file part.
+func (s *Server) createSticker(w http.ResponseWriter, r *http.Request, g *guild) {
+	if err := r.ParseMultipartForm(8 << 20); err != nil {
+		writeError(w, http.StatusBadRequest, 50035, "Invalid Form Body")
+		return
+	}
+	in := object{}
+	for _, k := range []string{"name", "description", "tags"} {
+		if vs, ok := r.MultipartForm.Value[k]; ok && len(vs) > 0 {
+			in[k] = vs[0]
+		}
+	}
+	errs := validateSticker(in, true)
+
+	format := 0
+	files := r.MultipartForm.File["file"]
+	if len(files) == 0 {
+		errs.required("file")
+	} else {
+		format = stickerFormats[strings.ToLower(path.Ext(files[0].Filename))]
+		if format == 0 {
+			errs.add("file", "STICKER_FILE_INVALID", "Invalid file type")
+		} else if f, err := files[0].Open(); err == nil {
+			data, _ := io.ReadAll(f)
+			_ = f.Close()
+			if len(data) == 0 || len(data) > 512*1024 {
+				errs.add("file", "STICKER_MAXIMUM_FILESIZE_EXCEEDED", "Sticker file must be under 512 KiB")
+			}
+		}
+	}
+	if errs.write(w) {
+		return
+	}
+	if len(g.stickers) >= 5 {
+		writeError(w, http.StatusBadRequest, 30039, "Maximum number of stickers reached (5)")
+		return
+	}
+
+	st := object{
+		"id":          s.ids.next(),
+		"guild_id":    g.obj["id"],
+		"name":        in["name"],
+		"description": in["description"],
+		"tags":        in["tags"],
+		"type":        2,
+		"format_type": format,
+		"available":   true,
+		"user":        cloneObject(s.botUser),
+	}
+	if str(st["description"]) == "" {
+		st["description"] = nil
+	}
+	g.stickers[str(st["id"])] = st
+	writeJSON(w, http.StatusCreated, st)
+}
+
+// sticker resolves the {sticker} path value or answers 10060 Unknown Sticker.
+func (s *Server) sticker(w http.ResponseWriter, r *http.Request, g *guild) (object, bool) {
+	st, ok := g.stickers[r.PathValue("sticker")]
+	if !ok {
+		writeNotFound(w, 10060, "Sticker")
+	}
+	return st, ok
+}
+
+func (s *Server) getSticker(w http.ResponseWriter, r *http.Request, g *guild) {
+	if st, ok := s.sticker(w, r, g); ok {
+		writeJSON(w, http.StatusOK, st)
+	}
+}
+
+func (s *Server) modifySticker(w http.ResponseWriter, r *http.Request, g *guild) {
+	st, ok := s.sticker(w, r, g)
+	if !ok {
+		return
+	}
+	in, ok := decodeBody(w, r)
+	if !ok {
+		return
+	}
+	if errs := validateSticker(in, false); errs.write(w) {
+		return
+	}
+	merge(st, in, "name", "tags", "description")
+	if str(st["description"]) == "" {
+		st["description"] = nil
+	}
+	writeJSON(w, http.StatusOK, st)
+}
+
+func (s *Server) deleteSticker(w http.ResponseWriter, r *http.Request, g *guild) {
+	if st, ok := s.sticker(w, r, g); ok {
+		delete(g.stickers, str(st["id"]))
+		writeNoContent(w)
+	}
+}
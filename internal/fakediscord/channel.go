@@ -0,0 +1,401 @@
+package fakediscord
+
+import (
+	"net/http"
+	"strings"
+)
+
+// Channel types, see https://discord.com/developers/docs/resources/channel#channel-object-channel-types.
+const (
+	typeText         = 0
+	typeVoice        = 2
+	typeCategory     = 4
+	typeAnnouncement = 5
+	typeAnnThread    = 10
+	typePublicThread = 11
+	typePrivThread   = 12
+	typeStage        = 13
+	typeForum        = 15
+	typeMedia        = 16
+)
+
+func isThread(ch object) bool {
+	switch num(ch["type"]) {
+	case typeAnnThread, typePublicThread, typePrivThread:
+		return true
+	}
+	return false
+}
+
+func isForum(typ int) bool {
+	return typ == typeForum || typ == typeMedia
+}
+
+func isVoice(typ int) bool {
+	return typ == typeVoice || typ == typeStage
+}
+
+// hasTextName reports whether Discord normalizes the channel's name like a text
+// channel: lower case, with spaces replaced by dashes.
+func hasTextName(typ int) bool {
+	return typ == typeText || typ == typeAnnouncement || isForum(typ)
+}
+
+func (s *Server) channelRoutes() {
+	s.handle("GET /guilds/{guild}/channels", s.withGuild(s.listChannels))
+	s.handle("POST /guilds/{guild}/channels", s.withGuild(s.createChannel))
+	s.handle("PATCH /guilds/{guild}/channels", s.withGuild(s.modifyChannelPositions))
+
+	s.handle("GET /channels/{channel}", s.withChannel(s.getChannel))
+	s.handle("PATCH /channels/{channel}", s.withChannel(s.modifyChannel))
+	s.handle("DELETE /channels/{channel}", s.withChannel(s.deleteChannel))
+
+	s.handle("PUT /channels/{channel}/permissions/{overwrite}", s.withChannel(s.editOverwrite))
+	s.handle("DELETE /channels/{channel}/permissions/{overwrite}", s.withChannel(s.deleteOverwrite))
+}
+
+type channelHandler func(w http.ResponseWriter, r *http.Request, ch object)
+
+// withChannel resolves the {channel} path value or answers 10003 Unknown Channel.
+func (s *Server) withChannel(h channelHandler) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ch, ok := s.channels[r.PathValue("channel")]
+		if !ok {
+			writeNotFound(w, 10003, "Channel")
+			return
+		}
+		h(w, r, ch)
+	}
+}
+
+// newChannel stores a guild channel built from a validated create body.
+func (s *Server) newChannel(guildID string, in object) object {
+	typ := num(in["type"])
+	ch := object{
+		"id":                    s.ids.next(),
+		"type":                  typ,
+		"guild_id":              guildID,
+		"name":                  in["name"],
+		"position":              s.nextPosition(guildID, in["parent_id"]),
+		"permission_overwrites": []any{},
+		"parent_id":             nil,
+		"nsfw":                  false,
+		"flags":                 0,
+	}
+	switch {
+	case typ == typeText || typ == typeAnnouncement:
+		ch["topic"] = nil
+		ch["rate_limit_per_user"] = 0
+		ch["last_message_id"] = nil
+	case isVoice(typ):
+		ch["bitrate"] = 64000
+		ch["user_limit"] = 0
+		ch["rtc_region"] = nil
+		ch["rate_limit_per_user"] = 0
+		ch["last_message_id"] = nil
+	case isForum(typ):
+		ch["topic"] = nil
+		ch["rate_limit_per_user"] = 0
+		ch["last_message_id"] = nil
+		ch["available_tags"] = []any{}
+		ch["default_reaction_emoji"] = nil
+		ch["default_thread_rate_limit_per_user"] = 0
+		ch["default_sort_order"] = nil
+		ch["default_forum_layout"] = 0
+	}
+	s.applyChannel(ch, in)
+	s.channels[str(ch["id"])] = ch
+	return ch
+}
+
+// nextPosition returns the position for a new channel: below its siblings.
+func (s *Server) nextPosition(guildID string, parentID any) int {
+	n := 0
+	for _, ch := range s.channels {
+		if str(ch["guild_id"]) == guildID && !isThread(ch) && str(ch["parent_id"]) == str(parentID) {
+			n++
+		}
+	}
+	return n
+}
+
+// applyChannel copies the writable fields of a validated create or modify body.
+func (s *Server) applyChannel(ch, in object) {
+	merge(ch, in,
+		"name", "position", "topic", "nsfw", "rate_limit_per_user", "bitrate", "user_limit",
+		"parent_id", "rtc_region", "video_quality_mode", "default_auto_archive_duration",
+		"default_reaction_emoji", "default_thread_rate_limit_per_user", "default_sort_order",
+		"default_forum_layout", "flags",
+	)
+	if hasTextName(num(ch["type"])) {
+		ch["name"] = strings.ReplaceAll(strings.ToLower(str(ch["name"])), " ", "-")
+	}
+	if v, ok := in["permission_overwrites"]; ok && v != nil {
+		ch["permission_overwrites"] = v
+	}
+	if v, ok := in["available_tags"]; ok && v != nil {
+		tags, _ := v.([]any)
+		out := make([]any, 0, len(tags))
+		for _, t := range tags {
+			tag := object{"id": nil, "name": "", "moderated": false, "emoji_id": nil, "emoji_name": nil}
+			merge(tag, t.(object), "id", "name", "moderated", "emoji_id", "emoji_name")
+			if str(tag["id"]) == "" {
+				tag["id"] = s.ids.next()
+			}
+			out = append(out, tag)
+		}
+		ch["available_tags"] = out
+	}
+}
+
+// validateChannel checks a guild channel create (ch == nil) or modify body.
+func (s *Server) validateChannel(guildID string, in, ch object) formErrors {
+	var errs formErrors
+	typ := num(in["type"])
+	if ch == nil {
+		errs.requiredLength(in, "name", 1, 100)
+		errs.oneOf(in, "type", typeText, typeVoice, typeCategory, typeAnnouncement, typeStage, typeForum, typeMedia)
+	} else {
+		typ = num(ch["type"])
+		errs.length(in, "name", 1, 100)
+		if v, ok := in["type"]; ok && num(v) != typ {
+			errs.add("type", "CHANNEL_TYPE_INVALID", "Channel type cannot be changed")
+		}
+	}
+	if isForum(typ) {
+		errs.length(in, "topic", 0, 4096)
+	} else {
+		errs.length(in, "topic", 0, 1024)
+	}
+	errs.integer(in, "position", 0, 1<<31-1)
+	errs.boolean(in, "nsfw")
+	errs.integer(in, "rate_limit_per_user", 0, 21600)
+	errs.integer(in, "default_thread_rate_limit_per_user", 0, 21600)
+	errs.oneOf(in, "default_auto_archive_duration", 60, 1440, 4320, 10080)
+	errs.oneOf(in, "video_quality_mode", 1, 2)
+	errs.oneOf(in, "default_sort_order", 0, 1)
+	errs.oneOf(in, "default_forum_layout", 0, 1, 2)
+	if isVoice(typ) {
+		errs.integer(in, "bitrate", 8000, 96000)
+		errs.integer(in, "user_limit", 0, 99)
+	}
+
+	if v, ok := in["parent_id"]; ok && v != nil {
+		parent, found := s.channels[str(v)]
+		switch {
+		case typ == typeCategory:
+			errs.add("parent_id", "CHANNEL_PARENT_INVALID", "Categories cannot have a parent")
+		case !found || str(parent["guild_id"]) != guildID || num(parent["type"]) != typeCategory:
+			errs.add("parent_id", "CHANNEL_PARENT_INVALID", "Category does not exist")
+		}
+	}
+	if v, ok := in["available_tags"]; ok && v != nil {
+		tags, isList := v.([]any)
+		if !isList || len(tags) > 20 {
+			errs.add("available_tags", "BASE_TYPE_MAX_LENGTH", "Must be 20 or fewer in length.")
+		}
+		for i, t := range tags {
+			tag, _ := t.(object)
+			te := errs.at("available_tags." + fmtInt(i))
+			te.requiredLength(tag, "name", 1, 20)
+			if tag["emoji_id"] != nil && tag["emoji_name"] != nil {
+				te.add("emoji_id", "TAG_EMOJI_INVALID", "Only one of emoji_id and emoji_name can be set")
+			}
+		}
+	}
+	if v, ok := in["default_reaction_emoji"].(object); ok {
+		if v["emoji_id"] != nil && v["emoji_name"] != nil {
+			errs.add("default_reaction_emoji.emoji_id", "EMOJI_INVALID", "Only one of emoji_id and emoji_name can be set")
+		}
+	}
+	return errs
+}
+
+func (s *Server) listChannels(w http.ResponseWriter, r *http.Request, g *guild) {
+	var out []object
+	for _, ch := range s.channels {
+		if str(ch["guild_id"]) == str(g.obj["id"]) && !isThread(ch) {
+			out = append(out, ch)
+		}
+	}
+	writeJSON(w, http.StatusOK, valuesByID(indexByID(out)))
+}
+
+func (s *Server) createChannel(w http.ResponseWriter, r *http.Request, g *guild) {
+	in, ok := decodeBody(w, r)
+	if !ok {
+		return
+	}
+	if _, ok := in["type"]; !ok {
+		in["type"] = float64(typeText)
+	}
+	if errs := s.validateChannel(str(g.obj["id"]), in, nil); errs.write(w) {
+		return
+	}
+	writeJSON(w, http.StatusCreated, s.newChannel(str(g.obj["id"]), in))
+}
+
+func (s *Server) modifyChannelPositions(w http.ResponseWriter, r *http.Request, g *guild) {
+	var in []object
+	if !decodeArray(w, r, &in) {
+		return
+	}
+	var errs formErrors
+	for i, p := range in {
+		ch, ok := s.channels[str(p["id"])]
+		if !ok || str(ch["guild_id"]) != str(g.obj["id"]) || isThread(ch) {
+			errs.add(fmtInt(i)+".id", "CHANNEL_INVALID", "Unknown channel "+str(p["id"]))
+			continue
+		}
+		errs.integer(p, "position", 0, 1<<31-1)
+	}
+	if errs.write(w) {
+		return
+	}
+	for _, p := range in {
+		ch := s.channels[str(p["id"])]
+		if v, ok := p["position"]; ok && v != nil {
+			ch["position"] = num(v)
+		}
+		if v, ok := p["parent_id"]; ok {
+			ch["parent_id"] = v
+		}
+	}
+	writeNoContent(w)
+}
+
+func (s *Server) getChannel(w http.ResponseWriter, r *http.Request, ch object) {
+	writeJSON(w, http.StatusOK, ch)
+}
+
+func (s *Server) modifyChannel(w http.ResponseWriter, r *http.Request, ch object) {
+	in, ok := decodeBody(w, r)
+	if !ok {
+		return
+	}
+	if isThread(ch) {
+		s.modifyThread(w, ch, in)
+		return
+	}
+	if errs := s.validateChannel(str(ch["guild_id"]), in, ch); errs.write(w) {
+		return
+	}
+	s.applyChannel(ch, in)
+	writeJSON(w, http.StatusOK, ch)
+}
+
+func (s *Server) deleteChannel(w http.ResponseWriter, r *http.Request, ch object) {
+	s.removeChannel(ch)
+	writeJSON(w, http.StatusOK, ch)
+}
+
+// removeChannel deletes a channel with its threads, messages, webhooks, invites
+// and stage instance, and moves the children of a deleted category to the top
+// level.
+func (s *Server) removeChannel(ch object) {
+	id := str(ch["id"])
+	delete(s.channels, id)
+	delete(s.threadMems, id)
+	for _, other := range s.channels {
+		if str(other["parent_id"]) != id {
+			continue
+		}
+		if isThread(other) {
+			s.removeChannel(other)
+		} else {
+			other["parent_id"] = nil
+		}
+	}
+	for mid, m := range s.messages {
+		if str(m["channel_id"]) == id {
+			delete(s.messages, mid)
+		}
+	}
+	for wid, wh := range s.webhooks {
+		if str(wh["channel_id"]) == id {
+			delete(s.webhooks, wid)
+		}
+	}
+	for code, inv := range s.invites {
+		if str(inv["channel_id"]) == id {
+			delete(s.invites, code)
+		}
+	}
+	delete(s.stages, id)
+}
+
+func (s *Server) editOverwrite(w http.ResponseWriter, r *http.Request, ch object) {
+	in, ok := decodeBody(w, r)
+	if !ok {
+		return
+	}
+	var errs formErrors
+	if _, ok := in["type"]; !ok {
+		errs.required("type")
+	}
+	errs.oneOf(in, "type", 0, 1)
+	errs.permissions(in, "allow")
+	errs.permissions(in, "deny")
+	if errs.write(w) {
+		return
+	}
+
+	id := r.PathValue("overwrite")
+	g := s.guilds[str(ch["guild_id"])]
+	if num(in["type"]) == 0 {
+		if _, ok := g.roles[id]; !ok {
+			writeNotFound(w, 10011, "Role")
+			return
+		}
+	} else if _, ok := g.members[id]; !ok {
+		writeNotFound(w, 10007, "Member")
+		return
+	}
+
+	ow := object{"id": id, "type": num(in["type"]), "allow": "0", "deny": "0"}
+	merge(ow, in, "allow", "deny")
+	list, _ := ch["permission_overwrites"].([]any)
+	out := []any{}
+	replaced := false
+	for _, v := range list {
+		if str(v.(object)["id"]) == id {
+			out = append(out, ow)
+			replaced = true
+			continue
+		}
+		out = append(out, v)
+	}
+	if !replaced {
+		out = append(out, ow)
+	}
+	ch["permission_overwrites"] = out
+	writeNoContent(w)
+}
+
+func (s *Server) deleteOverwrite(w http.ResponseWriter, r *http.Request, ch object) {
+	id := r.PathValue("overwrite")
+	list, _ := ch["permission_overwrites"].([]any)
+	out := []any{}
+	found := false
+	for _, v := range list {
+		if str(v.(object)["id"]) == id {
+			found = true
+			continue
+		}
+		out = append(out, v)
+	}
+	if !found {
+		writeNotFound(w, 10009, "Overwrite")
+		return
+	}
+	ch["permission_overwrites"] = out
+	writeNoContent(w)
+}
+
+func indexByID(list []object) map[string]object {
+	out := make(map[string]object, len(list))
+	for _, o := range list {
+		out[str(o["id"])] = o
+	}
+	return out
+}
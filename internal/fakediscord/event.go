@@ -0,0 +1,437 @@
+package fakediscord
+
+import (
+	"net/http"
+	"regexp"
+	"time"
+)
+
+// Scheduled event entity types and statuses.
+const (
+	entityStage    = 1
+	entityVoice    = 2
+	entityExternal = 3
+
+	eventScheduled = 1
+	eventActive    = 2
+	eventCompleted = 3
+	eventCanceled  = 4
+)
+
+// Auto moderation trigger types.
+const (
+	triggerKeyword       = 1
+	triggerSpam          = 3
+	triggerKeywordPreset = 4
+	triggerMentionSpam   = 5
+	triggerMemberProfile = 6
+)
+
+func (s *Server) eventRoutes() {
+	s.handle("GET /guilds/{guild}/scheduled-events", s.withGuild(s.listEvents))
+	s.handle("POST /guilds/{guild}/scheduled-events", s.withGuild(s.createEvent))
+	s.handle("GET /guilds/{guild}/scheduled-events/{event}", s.withGuild(s.getEvent))
+	s.handle("PATCH /guilds/{guild}/scheduled-events/{event}", s.withGuild(s.modifyEvent))
+	s.handle("DELETE /guilds/{guild}/scheduled-events/{event}", s.withGuild(s.deleteEvent))
+
+	s.handle("GET /guilds/{guild}/auto-moderation/rules", s.withGuild(s.listAutoModRules))
+	s.handle("POST /guilds/{guild}/auto-moderation/rules", s.withGuild(s.createAutoModRule))
+	s.handle("GET /guilds/{guild}/auto-moderation/rules/{rule}", s.withGuild(s.getAutoModRule))
+	s.handle("PATCH /guilds/{guild}/auto-moderation/rules/{rule}", s.withGuild(s.modifyAutoModRule))
+	s.handle("DELETE /guilds/{guild}/auto-moderation/rules/{rule}", s.withGuild(s.deleteAutoModRule))
+}
+
+// validateEvent checks an event body against the merged result ev, which is the
+// event as it would be stored after the create or modify.
+func (s *Server) validateEvent(g *guild, in, ev object, create bool) formErrors {
+	var errs formErrors
+	if create {
+		errs.requiredLength(in, "name", 1, 100)
+		if _, ok := in["scheduled_start_time"]; !ok {
+			errs.required("scheduled_start_time")
+		}
+		if _, ok := in["entity_type"]; !ok {
+			errs.required("entity_type")
+		}
+		if _, ok := in["privacy_level"]; !ok {
+			errs.required("privacy_level")
+		}
+	} else {
+		errs.length(in, "name", 1, 100)
+	}
+	errs.length(in, "description", 0, 1000)
+	errs.oneOf(in, "privacy_level", 2)
+	errs.oneOf(in, "entity_type", entityStage, entityVoice, entityExternal)
+	errs.oneOf(in, "status", eventScheduled, eventActive, eventCompleted, eventCanceled)
+	checkImage(&errs, in, "image")
+	start, startOK := errs.timestamp(in, "scheduled_start_time")
+	errs.timestamp(in, "scheduled_end_time")
+	if len(errs.tree) > 0 {
+		return errs
+	}
+
+	if create && startOK && start.Before(time.Now()) {
+		errs.add("scheduled_start_time", "GUILD_SCHEDULED_EVENT_SCHEDULED_START_TIME_IN_PAST", "Cannot schedule event in the past.")
+	}
+	if ev["scheduled_end_time"] != nil {
+		start, _ = time.Parse(time.RFC3339, str(ev["scheduled_start_time"]))
+		end, _ := time.Parse(time.RFC3339, str(ev["scheduled_end_time"]))
+		if !end.After(start) {
+			errs.add("scheduled_end_time", "GUILD_SCHEDULED_EVENT_END_BEFORE_START", "Cannot end event before it starts.")
+		}
+	}
+
+	switch num(ev["entity_type"]) {
+	case entityExternal:
+		meta, _ := ev["entity_metadata"].(object)
+		if str(meta["location"]) == "" {
+			errs.add("entity_metadata.location", "BASE_TYPE_REQUIRED", "This field is required")
+		}
+		if ev["scheduled_end_time"] == nil {
+			errs.required("scheduled_end_time")
+		}
+		if ev["channel_id"] != nil {
+			errs.add("channel_id", "GUILD_SCHEDULED_EVENT_CHANNEL_INVALID", "External events cannot have a channel")
+		}
+	case entityStage, entityVoice:
+		want := typeVoice
+		if num(ev["entity_type"]) == entityStage {
+			want = typeStage
+		}
+		ch, found := s.channels[str(ev["channel_id"])]
+		if ev["channel_id"] == nil {
+			errs.required("channel_id")
+		} else if !found || str(ch["guild_id"]) != str(g.obj["id"]) || num(ch["type"]) != want {
+			errs.add("channel_id", "GUILD_SCHEDULED_EVENT_CHANNEL_INVALID", "Invalid channel")
+		}
+	}
+	return errs
+}
+
+// normalizeTimestamp formats an accepted timestamp as Discord echoes it.
+func normalizeTimestamp(v any) any {
+	if v == nil {
+		return nil
+	}
+	t, err := time.Parse(time.RFC3339, str(v))
+	if err != nil {
+		return v
+	}
+	return t.UTC().Format("2006-01-02T15:04:05.000000+00:00")
+}
+
+func (s *Server) listEvents(w http.ResponseWriter, r *http.Request, g *guild) {
+	writeJSON(w, http.StatusOK, valuesByID(g.events))
+}
+
+var eventFields = []string{
+	"name", "description", "channel_id", "entity_metadata", "scheduled_start_time",
+	"scheduled_end_time", "privacy_level", "entity_type", "status",
+}
+
+func (s *Server) createEvent(w http.ResponseWriter, r *http.Request, g *guild) {
+	in, ok := decodeBody(w, r)
+	if !ok {
+		return
+	}
+	ev := object{
+		"id":                   s.ids.next(),
+		"guild_id":             g.obj["id"],
+		"channel_id":           nil,
+		"creator_id":           s.botUser["id"],
+		"creator":              cloneObject(s.botUser),
+		"name":                 nil,
+		"description":          nil,
+		"scheduled_start_time": nil,
+		"scheduled_end_time":   nil,
+		"privacy_level":        2,
+		"status":               eventScheduled,
+		"entity_type":          nil,
+		"entity_id":            nil,
+		"entity_metadata":      nil,
+		"user_count":           0,
+		"image":                imageHash(in["image"]),
+	}
+	merge(ev, in, eventFields...)
+	ev["status"] = eventScheduled
+	if errs := s.validateEvent(g, in, ev, true); errs.write(w) {
+		return
+	}
+	ev["scheduled_start_time"] = normalizeTimestamp(ev["scheduled_start_time"])
+	ev["scheduled_end_time"] = normalizeTimestamp(ev["scheduled_end_time"])
+	g.events[str(ev["id"])] = ev
+	writeJSON(w, http.StatusOK, ev)
+}
+
+// event resolves the {event} path value or answers 10070 Unknown Guild Scheduled Event.
+func (s *Server) event(w http.ResponseWriter, r *http.Request, g *guild) (object, bool) {
+	ev, ok := g.events[r.PathValue("event")]
+	if !ok {
+		writeNotFound(w, 10070, "Guild Scheduled Event")
+	}
+	return ev, ok
+}
+
+func (s *Server) getEvent(w http.ResponseWriter, r *http.Request, g *guild) {
+	if ev, ok := s.event(w, r, g); ok {
+		writeJSON(w, http.StatusOK, ev)
+	}
+}
+
+// eventTransitions are the status changes Discord allows.
+var eventTransitions = map[int][]int{
+	eventScheduled: {eventActive, eventCanceled},
+	eventActive:    {eventCompleted},
+}
+
+func (s *Server) modifyEvent(w http.ResponseWriter, r *http.Request, g *guild) {
+	ev, ok := s.event(w, r, g)
+	if !ok {
+		return
+	}
+	in, ok := decodeBody(w, r)
+	if !ok {
+		return
+	}
+	// Switching to an external event clears the channel, as Discord does.
+	if num(in["entity_type"]) == entityExternal {
+		if _, ok := in["channel_id"]; !ok {
+			in["channel_id"] = nil
+		}
+	}
+	next := cloneObject(ev)
+	merge(next, in, eventFields...)
+	if errs := s.validateEvent(g, in, next, false); errs.write(w) {
+		return
+	}
+	if v, ok := in["status"]; ok && num(v) != num(ev["status"]) {
+		allowed := false
+		for _, to := range eventTransitions[num(ev["status"])] {
+			allowed = allowed || to == num(v)
+		}
+		if !allowed {
+			writeError(w, http.StatusBadRequest, 180000, "Invalid status transition")
+			return
+		}
+	}
+
+	merge(ev, next, eventFields...)
+	ev["scheduled_start_time"] = normalizeTimestamp(ev["scheduled_start_time"])
+	ev["scheduled_end_time"] = normalizeTimestamp(ev["scheduled_end_time"])
+	if v, ok := in["image"]; ok {
+		ev["image"] = imageHash(v)
+	}
+	writeJSON(w, http.StatusOK, ev)
+}
+
+func (s *Server) deleteEvent(w http.ResponseWriter, r *http.Request, g *guild) {
+	if ev, ok := s.event(w, r, g); ok {
+		delete(g.events, str(ev["id"]))
+		writeNoContent(w)
+	}
+}
+
+// validateAutoModRule checks a rule body against the merged result rule.
+func (s *Server) validateAutoModRule(g *guild, in, rule object, create bool) formErrors {
+	var errs formErrors
+	if create {
+		errs.requiredLength(in, "name", 1, 100)
+		for _, f := range []string{"event_type", "trigger_type", "actions"} {
+			if _, ok := in[f]; !ok {
+				errs.required(f)
+			}
+		}
+	} else {
+		errs.length(in, "name", 1, 100)
+		if _, ok := in["trigger_type"]; ok {
+			errs.add("trigger_type", "AUTO_MODERATION_TRIGGER_TYPE_IMMUTABLE", "Trigger type cannot be changed")
+		}
+	}
+	errs.oneOf(in, "event_type", 1, 2)
+	errs.oneOf(in, "trigger_type", triggerKeyword, triggerSpam, triggerKeywordPreset, triggerMentionSpam, triggerMemberProfile)
+	errs.boolean(in, "enabled")
+
+	trigger := num(rule["trigger_type"])
+	if actions, ok := in["actions"].([]any); ok {
+		if len(actions) == 0 {
+			errs.add("actions", "BASE_TYPE_MIN_LENGTH", "Must be 1 or more in length.")
+		}
+		for i, raw := range actions {
+			a, _ := raw.(object)
+			ae := errs.at("actions." + fmtInt(i))
+			ae.oneOf(a, "type", 1, 2, 3, 4)
+			meta, _ := a["metadata"].(object)
+			switch num(a["type"]) {
+			case 2:
+				ch, found := s.channels[str(meta["channel_id"])]
+				if !found || str(ch["guild_id"]) != str(g.obj["id"]) {
+					ae.add("metadata.channel_id", "AUTO_MODERATION_ACTION_METADATA_INVALID", "Invalid alert channel")
+				}
+			case 3:
+				if trigger != triggerKeyword && trigger != triggerMentionSpam {
+					ae.add("type", "AUTO_MODERATION_ACTION_INVALID", "Timeout is not allowed for this trigger type")
+				}
+				ae.at("metadata").integer(meta, "duration_seconds", 1, 2419200)
+			}
+			if meta != nil {
+				ae.at("metadata").length(meta, "custom_message", 0, 150)
+			}
+		}
+	}
+
+	meta, _ := rule["trigger_metadata"].(object)
+	me := errs.at("trigger_metadata")
+	switch trigger {
+	case triggerKeyword, triggerMemberProfile:
+		checkStrings(me, meta, "keyword_filter", 1000, 60)
+		checkStrings(me, meta, "allow_list", 100, 60)
+		if patterns, ok := meta["regex_patterns"].([]any); ok {
+			if len(patterns) > 10 {
+				me.add("regex_patterns", "BASE_TYPE_MAX_LENGTH", "Must be 10 or fewer in length.")
+			}
+			for i, p := range patterns {
+				if _, err := regexp.Compile(str(p)); err != nil || len(str(p)) > 260 {
+					me.add("regex_patterns."+fmtInt(i), "AUTO_MODERATION_INVALID_REGEX", "Invalid regex pattern")
+				}
+			}
+		}
+	case triggerKeywordPreset:
+		if presets, ok := meta["presets"].([]any); ok {
+			for i, p := range presets {
+				me.oneOf(object{fmtInt(i): p}, fmtInt(i), 1, 2, 3)
+			}
+		}
+		checkStrings(me, meta, "allow_list", 1000, 60)
+	case triggerMentionSpam:
+		me.integer(meta, "mention_total_limit", 1, 50)
+	}
+	return errs
+}
+
+// checkStrings validates a list of strings with a maximum count and item length.
+func checkStrings(errs *formErrors, in object, field string, maxItems, maxLen int) {
+	items, ok := in[field].([]any)
+	if !ok {
+		return
+	}
+	if len(items) > maxItems {
+		errs.add(field, "BASE_TYPE_MAX_LENGTH", "Must be "+fmtInt(maxItems)+" or fewer in length.")
+	}
+	for i, v := range items {
+		errs.at(field).length(object{fmtInt(i): v}, fmtInt(i), 1, maxLen)
+	}
+}
+
+func (s *Server) listAutoModRules(w http.ResponseWriter, r *http.Request, g *guild) {
+	writeJSON(w, http.StatusOK, valuesByID(g.automod))
+}
+
+var autoModFields = []string{
+	"name", "event_type", "trigger_type", "trigger_metadata", "actions", "enabled", "exempt_roles", "exempt_channels",
+}
+
+func (s *Server) createAutoModRule(w http.ResponseWriter, r *http.Request, g *guild) {
+	in, ok := decodeBody(w, r)
+	if !ok {
+		return
+	}
+	rule := object{
+		"id":               s.ids.next(),
+		"guild_id":         g.obj["id"],
+		"creator_id":       s.botUser["id"],
+		"trigger_metadata": object{},
+		"enabled":          false,
+		"exempt_roles":     []any{},
+		"exempt_channels":  []any{},
+	}
+	merge(rule, in, autoModFields...)
+	if errs := s.validateAutoModRule(g, in, rule, true); errs.write(w) {
+		return
+	}
+	switch trigger := num(rule["trigger_type"]); trigger {
+	case triggerSpam, triggerKeywordPreset, triggerMentionSpam:
+		for _, other := range g.automod {
+			if num(other["trigger_type"]) == trigger {
+				writeError(w, http.StatusBadRequest, 30036, "Maximum number of rules of this trigger type reached")
+				return
+			}
+		}
+	}
+	normalizeAutoModRule(rule)
+	g.automod[str(rule["id"])] = rule
+	writeJSON(w, http.StatusOK, rule)
+}
+
+// normalizeAutoModRule fills the trigger metadata keys Discord always returns for
+// the rule's trigger type.
+func normalizeAutoModRule(rule object) {
+	meta, _ := rule["trigger_metadata"].(object)
+	if meta == nil {
+		meta = object{}
+	}
+	defaults := object{}
+	switch num(rule["trigger_type"]) {
+	case triggerKeyword, triggerMemberProfile:
+		defaults = object{"keyword_filter": []any{}, "regex_patterns": []any{}, "allow_list": []any{}}
+	case triggerKeywordPreset:
+		defaults = object{"presets": []any{}, "allow_list": []any{}}
+	case triggerMentionSpam:
+		defaults = object{"mention_total_limit": 0, "mention_raid_protection_enabled": false}
+	}
+	for k, v := range defaults {
+		if meta[k] == nil {
+			meta[k] = v
+		}
+	}
+	rule["trigger_metadata"] = meta
+	for _, k := range []string{"exempt_roles", "exempt_channels"} {
+		if rule[k] == nil {
+			rule[k] = []any{}
+		}
+	}
+}
+
+// autoModRule resolves the {rule} path value or answers 10066 Unknown Auto Moderation Rule.
+func (s *Server) autoModRule(w http.ResponseWriter, r *http.Request, g *guild) (object, bool) {
+	rule, ok := g.automod[r.PathValue("rule")]
+	if !ok {
+		writeNotFound(w, 10066, "Auto Moderation Rule")
+	}
+	return rule, ok
+}
+
+func (s *Server) getAutoModRule(w http.ResponseWriter, r *http.Request, g *guild) {
+	if rule, ok := s.autoModRule(w, r, g); ok {
+		writeJSON(w, http.StatusOK, rule)
+	}
+}
+
+func (s *Server) modifyAutoModRule(w http.ResponseWriter, r *http.Request, g *guild) {
+	rule, ok := s.autoModRule(w, r, g)
+	if !ok {
+		return
+	}
+	in, ok := decodeBody(w, r)
+	if !ok {
+		return
+	}
+	if v, ok := in["trigger_type"]; ok && num(v) == num(rule["trigger_type"]) {
+		// Resending the current trigger type is accepted; only a change is rejected.
+		delete(in, "trigger_type")
+	}
+	next := cloneObject(rule)
+	merge(next, in, autoModFields...)
+	if errs := s.validateAutoModRule(g, in, next, false); errs.write(w) {
+		return
+	}
+	normalizeAutoModRule(next)
+	merge(rule, next, autoModFields...)
+	writeJSON(w, http.StatusOK, rule)
+}
+
+func (s *Server) deleteAutoModRule(w http.ResponseWriter, r *http.Request, g *guild) {
+	if rule, ok := s.autoModRule(w, r, g); ok {
+		delete(g.automod, str(rule["id"]))
+		writeNoContent(w)
+	}
+}
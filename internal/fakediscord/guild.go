@@ -0,0 +1,617 @@
+package fakediscord
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+// defaultEveryonePermissions is what Discord grants @everyone in a new guild.
+const defaultEveryonePermissions = "2248473465835073"
+
+// guild holds a guild and the objects scoped to it.
+type guild struct {
+	obj      object
+	roles    map[string]object
+	members  map[string]object
+	bans     map[string]object
+	emojis   map[string]object
+	stickers map[string]object
+	events   map[string]object
+	automod  map[string]object
+
+	templates    map[string]object
+	sounds       map[string]object
+	welcome      object
+	onboarding   object
+	verification object
+	commandPerms map[string]object
+}
+
+// AddGuild creates a guild the bot is a member of and returns its ID. Like a new
+// Discord guild it has an @everyone role, a managed role for the bot, a human
+// owner, and a "general" text channel that is the system channel.
+func (s *Server) AddGuild(name string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	owner := s.newUser(strings.ToLower(name)+"-owner", false)
+	id := s.ids.next()
+	g := &guild{
+		obj: object{
+			"id":                            id,
+			"name":                          name,
+			"icon":                          nil,
+			"splash":                        nil,
+			"discovery_splash":              nil,
+			"owner_id":                      owner["id"],
+			"afk_channel_id":                nil,
+			"afk_timeout":                   300,
+			"widget_enabled":                false,
+			"widget_channel_id":             nil,
+			"verification_level":            0,
+			"default_message_notifications": 0,
+			"explicit_content_filter":       0,
+			"features":                      []any{},
+			"mfa_level":                     0,
+			"system_channel_id":             nil,
+			"system_channel_flags":          0,
+			"rules_channel_id":              nil,
+			"description":                   nil,
+			"banner":                        nil,
+			"premium_tier":                  0,
+			"preferred_locale":              "en-US",
+			"public_updates_channel_id":     nil,
+			"nsfw_level":                    0,
+			"safety_alerts_channel_id":      nil,
+			"premium_progress_bar_enabled":  false,
+		},
+		roles:    map[string]object{},
+		members:  map[string]object{},
+		bans:     map[string]object{},
+		emojis:   map[string]object{},
+		stickers: map[string]object{},
+		events:   map[string]object{},
+		automod:  map[string]object{},
+
+		templates:    map[string]object{},
+		sounds:       map[string]object{},
+		welcome:      object{"description": nil, "welcome_channels": []any{}},
+		onboarding:   object{"guild_id": id, "prompts": []any{}, "default_channel_ids": []any{}, "enabled": false, "mode": 0},
+		verification: object{"version": nil, "form_fields": []any{}, "description": nil},
+		commandPerms: map[string]object{},
+	}
+	s.guilds[id] = g
+
+	g.roles[id] = newRole(id, "@everyone", 0, defaultEveryonePermissions)
+	botRole := newRole(s.ids.next(), str(s.botUser["username"]), 1, "8")
+	botRole["managed"] = true
+	botRole["tags"] = object{"bot_id": s.botUser["id"]}
+	g.roles[str(botRole["id"])] = botRole
+
+	g.members[str(owner["id"])] = newMember(str(owner["id"]))
+	bot := newMember(str(s.botUser["id"]))
+	bot["roles"] = []any{botRole["id"]}
+	g.members[str(s.botUser["id"])] = bot
+
+	general := s.newChannel(id, object{"name": "general", "type": 0})
+	g.obj["system_channel_id"] = general["id"]
+	return id
+}
+
+// AddMember adds a new human user to a guild and returns the user ID.
+func (s *Server) AddMember(guildID, username string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	g, ok := s.guilds[guildID]
+	if !ok {
+		panic("fakediscord: unknown guild " + guildID)
+	}
+	u := s.newUser(username, false)
+	g.members[str(u["id"])] = newMember(str(u["id"]))
+	return str(u["id"])
+}
+
+// AddUser creates a user that is not a member of any guild and returns its ID.
+func (s *Server) AddUser(username string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return str(s.newUser(username, false)["id"])
+}
+
+func newRole(id, name string, position int, permissions string) object {
+	return object{
+		"id":            id,
+		"name":          name,
+		"color":         0,
+		"hoist":         false,
+		"icon":          nil,
+		"unicode_emoji": nil,
+		"position":      position,
+		"permissions":   permissions,
+		"managed":       false,
+		"mentionable":   false,
+		"flags":         0,
+	}
+}
+
+func newMember(userID string) object {
+	return object{
+		"user_id":                      userID,
+		"nick":                         nil,
+		"avatar":                       nil,
+		"roles":                        []any{},
+		"joined_at":                    now(),
+		"premium_since":                nil,
+		"deaf":                         false,
+		"mute":                         false,
+		"flags":                        0,
+		"pending":                      false,
+		"communication_disabled_until": nil,
+	}
+}
+
+func (s *Server) guildRoutes() {
+	s.handle("GET /guilds/{guild}", s.withGuild(s.getGuild))
+	s.handle("PATCH /guilds/{guild}", s.withGuild(s.modifyGuild))
+
+	s.handle("GET /guilds/{guild}/roles", s.withGuild(s.listRoles))
+	s.handle("POST /guilds/{guild}/roles", s.withGuild(s.createRole))
+	s.handle("PATCH /guilds/{guild}/roles", s.withGuild(s.modifyRolePositions))
+	s.handle("GET /guilds/{guild}/roles/{role}", s.withGuild(s.getRole))
+	s.handle("PATCH /guilds/{guild}/roles/{role}", s.withGuild(s.modifyRole))
+	s.handle("DELETE /guilds/{guild}/roles/{role}", s.withGuild(s.deleteRole))
+
+	s.handle("GET /guilds/{guild}/members/{user}", s.withGuild(s.getMember))
+	s.handle("PATCH /guilds/{guild}/members/{user}", s.withGuild(s.modifyMember))
+	s.handle("PUT /guilds/{guild}/members/{user}/roles/{role}", s.withGuild(s.addMemberRole))
+	s.handle("DELETE /guilds/{guild}/members/{user}/roles/{role}", s.withGuild(s.removeMemberRole))
+
+	s.handle("GET /guilds/{guild}/bans/{user}", s.withGuild(s.getBan))
+	s.handle("PUT /guilds/{guild}/bans/{user}", s.withGuild(s.createBan))
+	s.handle("DELETE /guilds/{guild}/bans/{user}", s.withGuild(s.removeBan))
+}
+
+type guildHandler func(w http.ResponseWriter, r *http.Request, g *guild)
+
+// withGuild resolves the {guild} path value or answers 10004 Unknown Guild.
+func (s *Server) withGuild(h guildHandler) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		g, ok := s.guilds[r.PathValue("guild")]
+		if !ok {
+			writeNotFound(w, 10004, "Guild")
+			return
+		}
+		h(w, r, g)
+	}
+}
+
+func (s *Server) guildJSON(g *guild) object {
+	out := cloneObject(g.obj)
+	out["roles"] = g.sortedRoles()
+	out["emojis"] = valuesByID(g.emojis)
+	out["stickers"] = valuesByID(g.stickers)
+	return out
+}
+
+func (s *Server) getGuild(w http.ResponseWriter, r *http.Request, g *guild) {
+	writeJSON(w, http.StatusOK, s.guildJSON(g))
+}
+
+func (s *Server) modifyGuild(w http.ResponseWriter, r *http.Request, g *guild) {
+	in, ok := decodeBody(w, r)
+	if !ok {
+		return
+	}
+
+	var errs formErrors
+	errs.length(in, "name", 2, 100)
+	errs.oneOf(in, "verification_level", 0, 1, 2, 3, 4)
+	errs.oneOf(in, "default_message_notifications", 0, 1)
+	errs.oneOf(in, "explicit_content_filter", 0, 1, 2)
+	errs.oneOf(in, "afk_timeout", 60, 300, 900, 1800, 3600)
+	errs.integer(in, "system_channel_flags", 0, 1<<8-1)
+	errs.length(in, "description", 0, 120)
+	s.checkGuildChannel(&errs, in, "afk_channel_id", g, 2)
+	for _, f := range []string{"system_channel_id", "rules_channel_id", "public_updates_channel_id", "safety_alerts_channel_id"} {
+		s.checkGuildChannel(&errs, in, f, g, 0)
+	}
+	for _, f := range []string{"icon", "splash", "discovery_splash", "banner"} {
+		checkImage(&errs, in, f)
+	}
+	if errs.write(w) {
+		return
+	}
+	if v, ok := in["owner_id"]; ok && str(v) != str(g.obj["owner_id"]) {
+		writeError(w, http.StatusForbidden, 50013, "Missing Permissions")
+		return
+	}
+
+	merge(g.obj, in,
+		"name", "verification_level", "default_message_notifications", "explicit_content_filter",
+		"afk_channel_id", "afk_timeout", "system_channel_id", "system_channel_flags", "rules_channel_id",
+		"public_updates_channel_id", "safety_alerts_channel_id", "preferred_locale", "features",
+		"description", "premium_progress_bar_enabled",
+	)
+	for _, f := range []string{"icon", "splash", "discovery_splash", "banner"} {
+		if v, ok := in[f]; ok {
+			g.obj[f] = imageHash(v)
+		}
+	}
+	writeJSON(w, http.StatusOK, s.guildJSON(g))
+}
+
+// checkGuildChannel validates that an optional channel ID field names a channel of
+// the given type in g. Null clears the field and always passes.
+func (s *Server) checkGuildChannel(errs *formErrors, in object, field string, g *guild, typ int) {
+	v, ok := in[field]
+	if !ok || v == nil {
+		return
+	}
+	ch, found := s.channels[str(v)]
+	if !found || str(ch["guild_id"]) != str(g.obj["id"]) || num(ch["type"]) != typ {
+		errs.add(field, "GUILD_CHANNEL_INVALID", "Invalid channel")
+	}
+}
+
+// checkImage validates an optional data URI image field.
+func checkImage(errs *formErrors, in object, field string) {
+	v, ok := in[field]
+	if !ok || v == nil {
+		return
+	}
+	if !strings.HasPrefix(str(v), "data:image/") || !strings.Contains(str(v), ";base64,") {
+		errs.add(field, "IMAGE_INVALID", "Invalid image data")
+	}
+}
+
+// imageHash stands in for the CDN hash Discord returns for an uploaded image.
+func imageHash(v any) any {
+	if v == nil {
+		return nil
+	}
+	sum := sha1.Sum([]byte(str(v)))
+	return hex.EncodeToString(sum[:16])
+}
+
+// sortedRoles returns the guild's roles ordered by position, then ID.
+func (g *guild) sortedRoles() []any {
+	roles := make([]object, 0, len(g.roles))
+	for _, r := range g.roles {
+		roles = append(roles, r)
+	}
+	sort.Slice(roles, func(i, j int) bool {
+		pi, pj := num(roles[i]["position"]), num(roles[j]["position"])
+		if pi != pj {
+			return pi < pj
+		}
+		return str(roles[i]["id"]) < str(roles[j]["id"])
+	})
+	out := make([]any, len(roles))
+	for i, r := range roles {
+		out[i] = cloneObject(r)
+	}
+	return out
+}
+
+// renumberRoles makes role positions contiguous, keeping their relative order.
+func (g *guild) renumberRoles() {
+	for i, r := range g.sortedRoles() {
+		g.roles[str(r.(object)["id"])]["position"] = i
+	}
+}
+
+func (s *Server) listRoles(w http.ResponseWriter, r *http.Request, g *guild) {
+	writeJSON(w, http.StatusOK, g.sortedRoles())
+}
+
+func validateRole(in object) formErrors {
+	var errs formErrors
+	errs.length(in, "name", 0, 100)
+	errs.permissions(in, "permissions")
+	errs.integer(in, "color", 0, 0xFFFFFF)
+	errs.boolean(in, "hoist")
+	errs.boolean(in, "mentionable")
+	checkImage(&errs, in, "icon")
+	return errs
+}
+
+func (s *Server) createRole(w http.ResponseWriter, r *http.Request, g *guild) {
+	in, ok := decodeBody(w, r)
+	if !ok {
+		return
+	}
+	if errs := validateRole(in); errs.write(w) {
+		return
+	}
+	if len(g.roles) >= 250 {
+		writeError(w, http.StatusBadRequest, 30005, "Maximum number of guild roles reached (250)")
+		return
+	}
+
+	// New roles go to the bottom of the list, just above @everyone.
+	for id, role := range g.roles {
+		if id != str(g.obj["id"]) {
+			role["position"] = num(role["position"]) + 1
+		}
+	}
+	role := newRole(s.ids.next(), "new role", 1, str(g.roles[str(g.obj["id"])]["permissions"]))
+	merge(role, in, "name", "permissions", "color", "hoist", "mentionable", "unicode_emoji")
+	if v, ok := in["icon"]; ok {
+		role["icon"] = imageHash(v)
+	}
+	g.roles[str(role["id"])] = role
+	writeJSON(w, http.StatusOK, role)
+}
+
+func (s *Server) getRole(w http.ResponseWriter, r *http.Request, g *guild) {
+	role, ok := g.roles[r.PathValue("role")]
+	if !ok {
+		writeNotFound(w, 10011, "Role")
+		return
+	}
+	writeJSON(w, http.StatusOK, role)
+}
+
+func (s *Server) modifyRole(w http.ResponseWriter, r *http.Request, g *guild) {
+	role, ok := g.roles[r.PathValue("role")]
+	if !ok {
+		writeNotFound(w, 10011, "Role")
+		return
+	}
+	in, ok := decodeBody(w, r)
+	if !ok {
+		return
+	}
+	if errs := validateRole(in); errs.write(w) {
+		return
+	}
+	if str(role["id"]) == str(g.obj["id"]) {
+		// @everyone keeps its name.
+		delete(in, "name")
+	}
+	merge(role, in, "name", "permissions", "color", "hoist", "mentionable", "unicode_emoji")
+	if v, ok := in["icon"]; ok {
+		role["icon"] = imageHash(v)
+	}
+	writeJSON(w, http.StatusOK, role)
+}
+
+func (s *Server) modifyRolePositions(w http.ResponseWriter, r *http.Request, g *guild) {
+	var in []object
+	if !decodeArray(w, r, &in) {
+		return
+	}
+	var errs formErrors
+	for i, p := range in {
+		id := str(p["id"])
+		if _, ok := g.roles[id]; !ok {
+			errs.add(fmtInt(i)+".id", "ROLE_INVALID", "Unknown role "+id)
+			continue
+		}
+		if id == str(g.obj["id"]) {
+			errs.add(fmtInt(i)+".id", "ROLE_INVALID", "The @everyone role cannot be moved")
+		}
+		errs.integer(p, "position", 1, len(g.roles)-1)
+	}
+	if errs.write(w) {
+		return
+	}
+	for _, p := range in {
+		if v, ok := p["position"]; ok && v != nil {
+			g.roles[str(p["id"])]["position"] = num(v)
+		}
+	}
+	g.renumberRoles()
+	writeJSON(w, http.StatusOK, g.sortedRoles())
+}
+
+func (s *Server) deleteRole(w http.ResponseWriter, r *http.Request, g *guild) {
+	id := r.PathValue("role")
+	role, ok := g.roles[id]
+	if !ok {
+		writeNotFound(w, 10011, "Role")
+		return
+	}
+	if id == str(g.obj["id"]) || role["managed"] == true {
+		writeError(w, http.StatusBadRequest, 50028, "Invalid Role")
+		return
+	}
+	delete(g.roles, id)
+	for _, m := range g.members {
+		m["roles"] = without(m["roles"], id)
+	}
+	g.renumberRoles()
+	writeNoContent(w)
+}
+
+func (s *Server) memberJSON(m object) object {
+	out := cloneObject(m)
+	delete(out, "user_id")
+	out["user"] = cloneObject(s.users[str(m["user_id"])])
+	return out
+}
+
+func (s *Server) getMember(w http.ResponseWriter, r *http.Request, g *guild) {
+	m, ok := g.members[r.PathValue("user")]
+	if !ok {
+		writeNotFound(w, 10007, "Member")
+		return
+	}
+	writeJSON(w, http.StatusOK, s.memberJSON(m))
+}
+
+func (s *Server) modifyMember(w http.ResponseWriter, r *http.Request, g *guild) {
+	userID := r.PathValue("user")
+	m, ok := g.members[userID]
+	if !ok {
+		writeNotFound(w, 10007, "Member")
+		return
+	}
+	in, ok := decodeBody(w, r)
+	if !ok {
+		return
+	}
+
+	var errs formErrors
+	errs.length(in, "nick", 1, 32)
+	errs.boolean(in, "mute")
+	errs.boolean(in, "deaf")
+	if until, ok := errs.timestamp(in, "communication_disabled_until"); ok {
+		if until.After(time.Now().Add(28 * 24 * time.Hour)) {
+			errs.add("communication_disabled_until", "BASE_TYPE_BAD_TIME", "Timeout can be at most 28 days.")
+		}
+	}
+	if v, ok := in["roles"]; ok {
+		roles, isList := v.([]any)
+		if !isList {
+			errs.add("roles", "BASE_TYPE_ARRAY", "Must be an array.")
+		}
+		for i, id := range roles {
+			role, found := g.roles[str(id)]
+			if !found || str(id) == str(g.obj["id"]) {
+				errs.add("roles."+fmtInt(i), "ROLE_INVALID", "Unknown role "+str(id))
+			} else if role["managed"] == true && !contains(m["roles"], str(id)) {
+				errs.add("roles."+fmtInt(i), "ROLE_INVALID", "Managed roles cannot be assigned")
+			}
+		}
+	}
+	if errs.write(w) {
+		return
+	}
+	if _, ok := in["communication_disabled_until"]; ok && userID == str(g.obj["owner_id"]) {
+		writeError(w, http.StatusForbidden, 50013, "Missing Permissions")
+		return
+	}
+
+	merge(m, in, "nick", "roles", "mute", "deaf", "communication_disabled_until", "flags")
+	m["communication_disabled_until"] = normalizeTimestamp(m["communication_disabled_until"])
+	writeJSON(w, http.StatusOK, s.memberJSON(m))
+}
+
+func (s *Server) addMemberRole(w http.ResponseWriter, r *http.Request, g *guild) {
+	m, ok := g.members[r.PathValue("user")]
+	if !ok {
+		writeNotFound(w, 10007, "Member")
+		return
+	}
+	roleID := r.PathValue("role")
+	if _, ok := g.roles[roleID]; !ok || roleID == str(g.obj["id"]) {
+		writeNotFound(w, 10011, "Role")
+		return
+	}
+	if !contains(m["roles"], roleID) {
+		m["roles"] = append(m["roles"].([]any), roleID)
+	}
+	writeNoContent(w)
+}
+
+func (s *Server) removeMemberRole(w http.ResponseWriter, r *http.Request, g *guild) {
+	m, ok := g.members[r.PathValue("user")]
+	if !ok {
+		writeNotFound(w, 10007, "Member")
+		return
+	}
+	roleID := r.PathValue("role")
+	if _, ok := g.roles[roleID]; !ok {
+		writeNotFound(w, 10011, "Role")
+		return
+	}
+	m["roles"] = without(m["roles"], roleID)
+	writeNoContent(w)
+}
+
+func (s *Server) getBan(w http.ResponseWriter, r *http.Request, g *guild) {
+	b, ok := g.bans[r.PathValue("user")]
+	if !ok {
+		writeNotFound(w, 10026, "Ban")
+		return
+	}
+	writeJSON(w, http.StatusOK, b)
+}
+
+func (s *Server) createBan(w http.ResponseWriter, r *http.Request, g *guild) {
+	userID := r.PathValue("user")
+	u, ok := s.users[userID]
+	if !ok {
+		writeNotFound(w, 10013, "User")
+		return
+	}
+	in, ok := decodeBody(w, r)
+	if !ok {
+		return
+	}
+	var errs formErrors
+	errs.integer(in, "delete_message_seconds", 0, 604800)
+	if errs.write(w) {
+		return
+	}
+	if userID == str(g.obj["owner_id"]) || userID == str(s.botUser["id"]) {
+		writeError(w, http.StatusForbidden, 50013, "Missing Permissions")
+		return
+	}
+
+	// Discord records the audit log reason as the ban reason.
+	var reason any
+	if v, _ := url.QueryUnescape(r.Header.Get("X-Audit-Log-Reason")); v != "" {
+		reason = v
+	}
+	g.bans[userID] = object{"reason": reason, "user": cloneObject(u)}
+	delete(g.members, userID)
+	writeNoContent(w)
+}
+
+func (s *Server) removeBan(w http.ResponseWriter, r *http.Request, g *guild) {
+	userID := r.PathValue("user")
+	if _, ok := g.bans[userID]; !ok {
+		writeNotFound(w, 10026, "Ban")
+		return
+	}
+	delete(g.bans, userID)
+	writeNoContent(w)
+}
+
+// valuesByID returns the objects of m ordered by ID, which is creation order.
+func valuesByID(m map[string]object) []any {
+	ids := make([]string, 0, len(m))
+	for id := range m {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		if len(ids[i]) != len(ids[j]) {
+			return len(ids[i]) < len(ids[j])
+		}
+		return ids[i] < ids[j]
+	})
+	out := make([]any, len(ids))
+	for i, id := range ids {
+		out[i] = cloneObject(m[id])
+	}
+	return out
+}
+
+func contains(list any, s string) bool {
+	items, _ := list.([]any)
+	for _, v := range items {
+		if str(v) == s {
+			return true
+		}
+	}
+	return false
+}
+
+func without(list any, s string) []any {
+	items, _ := list.([]any)
+	out := []any{}
+	for _, v := range items {
+		if str(v) != s {
+			out = append(out, v)
+		}
+	}
+	return out
+}
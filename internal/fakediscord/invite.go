@@ -0,0 +1,233 @@
+package fakediscord
+
+import (
+	"crypto/rand"
+	"net/http"
+	"time"
+)
+
+func (s *Server) inviteRoutes() {
+	s.handle("GET /channels/{channel}/invites", s.withChannel(s.listChannelInvites))
+	s.handle("POST /channels/{channel}/invites", s.withChannel(s.createInvite))
+
+	s.handle("GET /invites/{code}", s.withInvite(s.getInvite))
+	s.handle("DELETE /invites/{code}", s.withInvite(s.deleteInvite))
+
+	s.handle("GET /guilds/{guild}/templates", s.withGuild(s.listTemplates))
+	s.handle("POST /guilds/{guild}/templates", s.withGuild(s.createTemplate))
+	s.handle("PUT /guilds/{guild}/templates/{code}", s.withGuild(s.syncTemplate))
+	s.handle("PATCH /guilds/{guild}/templates/{code}", s.withGuild(s.modifyTemplate))
+	s.handle("DELETE /guilds/{guild}/templates/{code}", s.withGuild(s.deleteTemplate))
+}
+
+type inviteHandler func(w http.ResponseWriter, r *http.Request, inv object)
+
+// withInvite resolves the {code} path value or answers 10006 Unknown Invite.
+// Expired invites are gone, as they are on Discord.
+func (s *Server) withInvite(h inviteHandler) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		inv, ok := s.invites[r.PathValue("code")]
+		if ok && inviteExpired(inv) {
+			delete(s.invites, r.PathValue("code"))
+			ok = false
+		}
+		if !ok {
+			writeNotFound(w, 10006, "Invite")
+			return
+		}
+		h(w, r, inv)
+	}
+}
+
+func inviteExpired(inv object) bool {
+	exp, ok := inv["expires_at"].(string)
+	if !ok {
+		return false
+	}
+	t, err := time.Parse(time.RFC3339, exp)
+	return err == nil && time.Now().After(t)
+}
+
+func (s *Server) listChannelInvites(w http.ResponseWriter, r *http.Request, ch object) {
+	out := []any{}
+	for _, inv := range valuesByID(s.invites) {
+		if str(inv.(object)["channel_id"]) == str(ch["id"]) {
+			out = append(out, s.inviteJSON(inv.(object)))
+		}
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+func (s *Server) createInvite(w http.ResponseWriter, r *http.Request, ch object) {
+	if num(ch["type"]) == typeCategory || isThread(ch) {
+		writeError(w, http.StatusBadRequest, 50024, "Cannot execute action on this channel type")
+		return
+	}
+	in, ok := decodeBody(w, r)
+	if !ok {
+		return
+	}
+	var errs formErrors
+	errs.integer(in, "max_age", 0, 604800)
+	errs.integer(in, "max_uses", 0, 100)
+	errs.boolean(in, "temporary")
+	errs.boolean(in, "unique")
+	if errs.write(w) {
+		return
+	}
+
+	maxAge := 86400
+	if v, ok := in["max_age"]; ok && v != nil {
+		maxAge = num(v)
+	}
+	created := time.Now().UTC()
+	var expires any
+	if maxAge > 0 {
+		expires = created.Add(time.Duration(maxAge) * time.Second).Format(time.RFC3339Nano)
+	}
+	inv := object{
+		"code":       randomCode(),
+		"type":       0,
+		"channel_id": ch["id"],
+		"guild_id":   ch["guild_id"],
+		"inviter":    cloneObject(s.botUser),
+		"uses":       0,
+		"max_uses":   num(in["max_uses"]),
+		"max_age":    maxAge,
+		"temporary":  in["temporary"] == true,
+		"created_at": created.Format(time.RFC3339Nano),
+		"expires_at": expires,
+	}
+	s.invites[str(inv["code"])] = inv
+	writeJSON(w, http.StatusOK, s.inviteJSON(inv))
+}
+
+// inviteJSON expands the stored invite with the partial guild and channel
+// objects Discord embeds.
+func (s *Server) inviteJSON(inv object) object {
+	out := cloneObject(inv)
+	delete(out, "guild_id")
+	delete(out, "channel_id")
+	if ch, ok := s.channels[str(inv["channel_id"])]; ok {
+		out["channel"] = object{"id": ch["id"], "name": ch["name"], "type": ch["type"]}
+	}
+	if g, ok := s.guilds[str(inv["guild_id"])]; ok {
+		out["guild"] = object{"id": g.obj["id"], "name": g.obj["name"], "icon": g.obj["icon"], "features": g.obj["features"]}
+	}
+	return out
+}
+
+func (s *Server) getInvite(w http.ResponseWriter, r *http.Request, inv object) {
+	writeJSON(w, http.StatusOK, s.inviteJSON(inv))
+}
+
+func (s *Server) deleteInvite(w http.ResponseWriter, r *http.Request, inv object) {
+	delete(s.invites, str(inv["code"]))
+	writeJSON(w, http.StatusOK, s.inviteJSON(inv))
+}
+
+// randomCode returns an invite or template code: eight characters from the
+// alphabet Discord uses for vanity-less codes.
+func randomCode() string {
+	const alphabet = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789"
+	b := make([]byte, 8)
+	_, _ = rand.Read(b)
+	for i := range b {
+		b[i] = alphabet[int(b[i])%len(alphabet)]
+	}
+	return string(b)
+}
+
+func (s *Server) listTemplates(w http.ResponseWriter, r *http.Request, g *guild) {
+	out := []any{}
+	for _, t := range g.templates {
+		out = append(out, t)
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+func validateTemplate(in object, create bool) formErrors {
+	var errs formErrors
+	if create {
+		errs.requiredLength(in, "name", 1, 100)
+	} else {
+		errs.length(in, "name", 1, 100)
+	}
+	errs.length(in, "description", 0, 120)
+	return errs
+}
+
+func (s *Server) createTemplate(w http.ResponseWriter, r *http.Request, g *guild) {
+	in, ok := decodeBody(w, r)
+	if !ok {
+		return
+	}
+	if errs := validateTemplate(in, true); errs.write(w) {
+		return
+	}
+	// A guild has at most one template.
+	if len(g.templates) > 0 {
+		writeError(w, http.StatusBadRequest, 30031, "Guild already has a template")
+		return
+	}
+
+	ts := now()
+	t := object{
+		"code":                    randomCode(),
+		"name":                    in["name"],
+		"description":             in["description"],
+		"usage_count":             0,
+		"creator_id":              s.botUser["id"],
+		"creator":                 cloneObject(s.botUser),
+		"created_at":              ts,
+		"updated_at":              ts,
+		"source_guild_id":         g.obj["id"],
+		"serialized_source_guild": object{"name": g.obj["name"]},
+		"is_dirty":                nil,
+	}
+	g.templates[str(t["code"])] = t
+	writeJSON(w, http.StatusOK, t)
+}
+
+// template resolves the {code} path value or answers 10057 Unknown Guild Template.
+func (s *Server) template(w http.ResponseWriter, r *http.Request, g *guild) (object, bool) {
+	t, ok := g.templates[r.PathValue("code")]
+	if !ok {
+		writeNotFound(w, 10057, "Guild Template")
+	}
+	return t, ok
+}
+
+func (s *Server) syncTemplate(w http.ResponseWriter, r *http.Request, g *guild) {
+	t, ok := s.template(w, r, g)
+	if !ok {
+		return
+	}
+	t["updated_at"] = now()
+	t["is_dirty"] = nil
+	t["serialized_source_guild"] = object{"name": g.obj["name"]}
+	writeJSON(w, http.StatusOK, t)
+}
+
+func (s *Server) modifyTemplate(w http.ResponseWriter, r *http.Request, g *guild) {
+	t, ok := s.template(w, r, g)
+	if !ok {
+		return
+	}
+	in, ok := decodeBody(w, r)
+	if !ok {
+		return
+	}
+	if errs := validateTemplate(in, false); errs.write(w) {
+		return
+	}
+	merge(t, in, "name", "description")
+	writeJSON(w, http.StatusOK, t)
+}
+
+func (s *Server) deleteTemplate(w http.ResponseWriter, r *http.Request, g *guild) {
+	if t, ok := s.template(w, r, g); ok {
+		delete(g.templates, str(t["code"]))
+		writeJSON(w, http.StatusOK, t)
+	}
+}
@@ -0,0 +1,272 @@
+package fakediscord
+
+import (
+	"net/http"
+	"time"
+	"unicode/utf8"
+)
+
+// maxPins is the number of pinned messages Discord allows per channel.
+const maxPins = 50
+
+func (s *Server) messageRoutes() {
+	s.handle("GET /channels/{channel}/messages", s.withChannel(s.listMessages))
+	s.handle("POST /channels/{channel}/messages", s.withChannel(s.createMessage))
+	s.handle("GET /channels/{channel}/messages/{message}", s.withMessage(s.getMessage))
+	s.handle("PATCH /channels/{channel}/messages/{message}", s.withMessage(s.editMessage))
+	s.handle("DELETE /channels/{channel}/messages/{message}", s.withMessage(s.deleteMessage))
+
+	s.handle("PUT /channels/{channel}/pins/{message}", s.withMessage(s.pinMessage))
+	s.handle("DELETE /channels/{channel}/pins/{message}", s.withMessage(s.unpinMessage))
+}
+
+type messageHandler func(w http.ResponseWriter, r *http.Request, ch, msg object)
+
+// withMessage resolves {channel} and {message}, answering 10003 or 10008.
+func (s *Server) withMessage(h messageHandler) func(w http.ResponseWriter, r *http.Request) {
+	return s.withChannel(func(w http.ResponseWriter, r *http.Request, ch object) {
+		msg, ok := s.messages[r.PathValue("message")]
+		if !ok || str(msg["channel_id"]) != str(ch["id"]) {
+			writeNotFound(w, 10008, "Message")
+			return
+		}
+		h(w, r, ch, msg)
+	})
+}
+
+// listMessages serves the channel's messages newest first. Paging is not modeled;
+// Discord's default page of 50 is returned.
+func (s *Server) listMessages(w http.ResponseWriter, r *http.Request, ch object) {
+	all := valuesByID(s.messages)
+	out := []any{}
+	for i := len(all) - 1; i >= 0 && len(out) < 50; i-- {
+		if msg := all[i].(object); str(msg["channel_id"]) == str(ch["id"]) {
+			out = append(out, msg)
+		}
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+// canHoldMessages reports whether messages can be posted directly in the channel.
+func canHoldMessages(ch object) bool {
+	switch num(ch["type"]) {
+	case typeCategory, typeForum, typeMedia:
+		return false
+	}
+	return true
+}
+
+// validateMessage checks content and embeds of a create or edit body.
+func validateMessage(in object) formErrors {
+	var errs formErrors
+	errs.length(in, "content", 0, 2000)
+	if v, ok := in["embeds"]; ok && v != nil {
+		embeds, isList := v.([]any)
+		if !isList || len(embeds) > 10 {
+			errs.add("embeds", "BASE_TYPE_MAX_LENGTH", "Must be 10 or fewer in length.")
+		}
+		for i, e := range embeds {
+			validateEmbed(&errs, "embeds."+fmtInt(i), e)
+		}
+	}
+	return errs
+}
+
+func validateEmbed(errs *formErrors, prefix string, v any) {
+	e, ok := v.(object)
+	if !ok {
+		errs.add(prefix, "MODEL_TYPE_CONVERT", "Only dictionaries may be used in a ModelType")
+		return
+	}
+	sub := errs.at(prefix)
+	sub.length(e, "title", 0, 256)
+	sub.length(e, "description", 0, 4096)
+	sub.integer(e, "color", 0, 0xFFFFFF)
+	sub.timestamp(e, "timestamp")
+	if f, ok := e["footer"].(object); ok {
+		sub.at("footer").length(f, "text", 0, 2048)
+	}
+	if a, ok := e["author"].(object); ok {
+		sub.at("author").length(a, "name", 0, 256)
+	}
+	if fields, ok := e["fields"].([]any); ok {
+		if len(fields) > 25 {
+			sub.add("fields", "BASE_TYPE_MAX_LENGTH", "Must be 25 or fewer in length.")
+		}
+		for i, raw := range fields {
+			f, _ := raw.(object)
+			fe := sub.at("fields." + fmtInt(i))
+			fe.requiredLength(f, "name", 1, 256)
+			fe.requiredLength(f, "value", 1, 1024)
+		}
+	}
+	if embedLength(e) > 6000 {
+		errs.add(prefix, "BASE_TYPE_MAX_LENGTH", "Embed size exceeds maximum size of 6000")
+	}
+}
+
+// embedLength sums the text fields Discord counts against the 6000 limit.
+func embedLength(e object) int {
+	n := utf8.RuneCountInString(str(e["title"])) + utf8.RuneCountInString(str(e["description"]))
+	if f, ok := e["footer"].(object); ok {
+		n += utf8.RuneCountInString(str(f["text"]))
+	}
+	if a, ok := e["author"].(object); ok {
+		n += utf8.RuneCountInString(str(a["name"]))
+	}
+	if fields, ok := e["fields"].([]any); ok {
+		for _, raw := range fields {
+			f, _ := raw.(object)
+			n += utf8.RuneCountInString(str(f["name"])) + utf8.RuneCountInString(str(f["value"]))
+		}
+	}
+	return n
+}
+
+// normalizeEmbeds returns embeds as Discord echoes them: typed as rich, with
+// timestamps in UTC and proxy URLs for media.
+func normalizeEmbeds(v any) []any {
+	embeds, _ := v.([]any)
+	out := make([]any, 0, len(embeds))
+	for _, raw := range embeds {
+		e := cloneObject(raw.(object))
+		e["type"] = "rich"
+		if ts, ok := e["timestamp"]; ok && ts != nil {
+			if t, err := time.Parse(time.RFC3339, str(ts)); err == nil {
+				e["timestamp"] = t.UTC().Format("2006-01-02T15:04:05.000000+00:00")
+			}
+		}
+		for _, media := range []string{"image", "thumbnail"} {
+			if m, ok := e[media].(object); ok && str(m["url"]) != "" {
+				m["proxy_url"] = "https://media.discordapp.net/external/fake/" + str(m["url"])
+			}
+		}
+		out = append(out, e)
+	}
+	return out
+}
+
+func isEmptyMessage(in object) bool {
+	embeds, _ := in["embeds"].([]any)
+	return str(in["content"]) == "" && len(embeds) == 0 && in["sticker_ids"] == nil
+}
+
+func (s *Server) newMessage(ch object, author object, in object) object {
+	msg := object{
+		"id":               s.ids.next(),
+		"channel_id":       ch["id"],
+		"guild_id":         ch["guild_id"],
+		"author":           cloneObject(author),
+		"content":          "",
+		"timestamp":        now(),
+		"edited_timestamp": nil,
+		"tts":              false,
+		"mention_everyone": false,
+		"mentions":         []any{},
+		"mention_roles":    []any{},
+		"attachments":      []any{},
+		"embeds":           []any{},
+		"pinned":           false,
+		"type":             0,
+		"flags":            0,
+	}
+	merge(msg, in, "content", "tts", "nonce")
+	if v, ok := in["embeds"]; ok && v != nil {
+		msg["embeds"] = normalizeEmbeds(v)
+	}
+	s.messages[str(msg["id"])] = msg
+	ch["last_message_id"] = msg["id"]
+	return msg
+}
+
+func (s *Server) createMessage(w http.ResponseWriter, r *http.Request, ch object) {
+	if !canHoldMessages(ch) {
+		writeError(w, http.StatusBadRequest, 50008, "Cannot send messages in a non-text channel")
+		return
+	}
+	in, ok := decodeBody(w, r)
+	if !ok {
+		return
+	}
+	if errs := validateMessage(in); errs.write(w) {
+		return
+	}
+	if isEmptyMessage(in) {
+		writeError(w, http.StatusBadRequest, 50006, "Cannot send an empty message")
+		return
+	}
+	if meta, ok := ch["thread_metadata"].(object); ok && meta["archived"] == true {
+		writeError(w, http.StatusBadRequest, 50083, "Thread is archived")
+		return
+	}
+
+	// With enforce_nonce, a retried create returns the message it already made.
+	if nonce := str(in["nonce"]); nonce != "" && in["enforce_nonce"] == true {
+		for _, m := range s.messages {
+			if str(m["channel_id"]) == str(ch["id"]) && str(m["nonce"]) == nonce {
+				writeJSON(w, http.StatusOK, m)
+				return
+			}
+		}
+	}
+
+	writeJSON(w, http.StatusOK, s.newMessage(ch, s.botUser, in))
+}
+
+func (s *Server) getMessage(w http.ResponseWriter, r *http.Request, ch, msg object) {
+	writeJSON(w, http.StatusOK, msg)
+}
+
+func (s *Server) editMessage(w http.ResponseWriter, r *http.Request, ch, msg object) {
+	if str(msg["author"].(object)["id"]) != str(s.botUser["id"]) {
+		writeError(w, http.StatusForbidden, 50005, "Cannot edit a message authored by another user")
+		return
+	}
+	in, ok := decodeBody(w, r)
+	if !ok {
+		return
+	}
+	if errs := validateMessage(in); errs.write(w) {
+		return
+	}
+
+	edited := cloneObject(msg)
+	merge(edited, in, "content", "flags")
+	if v, ok := in["embeds"]; ok {
+		edited["embeds"] = normalizeEmbeds(v)
+	}
+	if isEmptyMessage(edited) {
+		writeError(w, http.StatusBadRequest, 50006, "Cannot send an empty message")
+		return
+	}
+	merge(msg, edited, "content", "flags", "embeds")
+	msg["edited_timestamp"] = now()
+	writeJSON(w, http.StatusOK, msg)
+}
+
+func (s *Server) deleteMessage(w http.ResponseWriter, r *http.Request, ch, msg object) {
+	delete(s.messages, str(msg["id"]))
+	writeNoContent(w)
+}
+
+func (s *Server) pinMessage(w http.ResponseWriter, r *http.Request, ch, msg object) {
+	if msg["pinned"] != true {
+		pins := 0
+		for _, m := range s.messages {
+			if str(m["channel_id"]) == str(ch["id"]) && m["pinned"] == true {
+				pins++
+			}
+		}
+		if pins >= maxPins {
+			writeError(w, http.StatusBadRequest, 30003, "Maximum number of pins reached (50)")
+			return
+		}
+	}
+	msg["pinned"] = true
+	writeNoContent(w)
+}
+
+func (s *Server) unpinMessage(w http.ResponseWriter, r *http.Request, ch, msg object) {
+	msg["pinned"] = false
+	writeNoContent(w)
+}
@@ -0,0 +1,173 @@
+package fakediscord
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+)
+
+// ClientID and ClientSecret are the application credentials the fake's token
+// endpoint accepts for the client credentials grant.
+const (
+	ClientID     = "fake-client-id"
+	ClientSecret = "fake-client-secret"
+)
+
+// DefaultTokenLifetime is how long access tokens issued by the fake stay valid.
+const DefaultTokenLifetime = 7 * 24 * time.Hour
+
+// CommandPermissionsScope is the scope a user's token needs to edit application
+// command permissions.
+const CommandPermissionsScope = "applications.commands.permissions.update"
+
+func (s *Server) oauth2Routes() {
+	s.handle("POST /oauth2/token", s.issueToken)
+
+	s.handle("GET /applications/{app}/guilds/{guild}/commands/{command}/permissions", s.withGuild(s.getCommandPermissions))
+	s.handle("PUT /applications/{app}/guilds/{guild}/commands/{command}/permissions", s.withGuild(s.editCommandPermissions))
+}
+
+// RevokeTokens invalidates every access token issued so far, as Discord does
+// when the application's secret is reset.
+func (s *Server) RevokeTokens() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	clear(s.bearers)
+	clear(s.userScopes)
+}
+
+// IssueUserToken returns an access token of a user who authorized the
+// application with scopes, as the authorization code grant would. The token
+// endpoint only serves the client credentials grant, which acts for the
+// application and not for a user.
+func (s *Server) IssueUserToken(scopes ...string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	tok := s.newAccessToken()
+	s.userScopes[tok] = scopes
+	return tok
+}
+
+// newAccessToken records and returns a new access token. s.mu must be held.
+func (s *Server) newAccessToken() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	tok := hex.EncodeToString(b)
+	s.bearers[tok] = time.Now().Add(s.TokenLifetime)
+	return tok
+}
+
+// authorized reports whether an Authorization header carries the bot token or a
+// valid access token.
+func (s *Server) authorized(header string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if tok, ok := strings.CutPrefix(header, "Bearer "); ok {
+		expires, issued := s.bearers[tok]
+		return issued && time.Now().Before(expires)
+	}
+	return header == "Bot "+s.token
+}
+
+// issueToken answers POST /oauth2/token for grant_type=client_credentials. Like
+// Discord it takes the client credentials from Basic auth or from the form.
+func (s *Server) issueToken(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		writeJSON(w, http.StatusBadRequest, object{"error": "invalid_request"})
+		return
+	}
+	id, secret, ok := r.BasicAuth()
+	if !ok {
+		id, secret = r.PostForm.Get("client_id"), r.PostForm.Get("client_secret")
+	}
+	if id != ClientID || secret != ClientSecret {
+		writeJSON(w, http.StatusUnauthorized, object{"error": "invalid_client"})
+		return
+	}
+	if g := r.PostForm.Get("grant_type"); g != "client_credentials" {
+		writeJSON(w, http.StatusBadRequest, object{"error": "unsupported_grant_type", "error_description": "Unsupported grant type " + g})
+		return
+	}
+	scope := r.PostForm.Get("scope")
+	if scope == "" {
+		writeJSON(w, http.StatusBadRequest, object{"error": "invalid_scope", "error_description": "No scopes requested"})
+		return
+	}
+
+	writeJSON(w, http.StatusOK, object{
+		"access_token": s.newAccessToken(),
+		"token_type":   "Bearer",
+		"expires_in":   int(s.TokenLifetime.Seconds()),
+		"scope":        scope,
+	})
+}
+
+// withCommand checks that {app} is the bot's application; commands themselves
+// are not modelled, so any {command} ID exists.
+func (s *Server) withCommand(w http.ResponseWriter, r *http.Request) bool {
+	if r.PathValue("app") != str(s.botUser["id"]) {
+		writeNotFound(w, 10002, "Application")
+		return false
+	}
+	return true
+}
+
+func (s *Server) getCommandPermissions(w http.ResponseWriter, r *http.Request, g *guild) {
+	if !s.withCommand(w, r) {
+		return
+	}
+	p, ok := g.commandPerms[r.PathValue("command")]
+	if !ok {
+		writeNotFound(w, 10066, "application command permissions")
+		return
+	}
+	writeJSON(w, http.StatusOK, p)
+}
+
+func (s *Server) editCommandPermissions(w http.ResponseWriter, r *http.Request, g *guild) {
+	tok, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok {
+		writeError(w, http.StatusForbidden, 20001, "Bots cannot use this endpoint")
+		return
+	}
+	// Client credentials tokens act for the application, not for a user who
+	// can manage the guild.
+	if !slices.Contains(s.userScopes[tok], CommandPermissionsScope) {
+		writeError(w, http.StatusForbidden, 50001, "Missing Access")
+		return
+	}
+	if !s.withCommand(w, r) {
+		return
+	}
+	in, ok := decodeBody(w, r)
+	if !ok {
+		return
+	}
+	perms, _ := in["permissions"].([]any)
+	var errs formErrors
+	for i, v := range perms {
+		p, _ := v.(map[string]any)
+		sub := errs.at("permissions." + fmtInt(i))
+		if str(p["id"]) == "" {
+			sub.add("id", "BASE_TYPE_REQUIRED", "This field is required")
+		}
+		sub.integer(p, "type", 1, 3)
+	}
+	if errs.write(w) {
+		return
+	}
+	if perms == nil {
+		perms = []any{}
+	}
+	out := object{
+		"id":             r.PathValue("command"),
+		"application_id": r.PathValue("app"),
+		"guild_id":       g.obj["id"],
+		"permissions":    perms,
+	}
+	g.commandPerms[r.PathValue("command")] = out
+	writeJSON(w, http.StatusOK, out)
+}
@@ -0,0 +1,99 @@
+package fakediscord
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// bucket is one per-route rate limit window.
+type bucket struct {
+	hash      string
+	remaining int
+	resetAt   time.Time
+}
+
+// rateLimited applies injected rules and the per-route bucket for r. It writes the
+// 429 response and returns true when the request must be rejected; otherwise it
+// sets the X-RateLimit-* headers for the response. The caller holds s.mu.
+func (s *Server) rateLimited(w http.ResponseWriter, r *http.Request) bool {
+	pattern := r.Pattern
+
+	for i, rule := range s.rules {
+		if rule.Method != "" && rule.Method != r.Method {
+			continue
+		}
+		if !strings.HasPrefix(r.URL.Path, rule.Path) {
+			continue
+		}
+		rule.Count--
+		if rule.Count <= 0 {
+			s.rules = append(s.rules[:i], s.rules[i+1:]...)
+		}
+		retry := rule.RetryAfter
+		if retry <= 0 {
+			retry = 50 * time.Millisecond
+		}
+		if rule.Global {
+			w.Header().Set("X-RateLimit-Global", "true")
+			w.Header().Set("X-RateLimit-Scope", "global")
+		} else {
+			w.Header().Set("X-RateLimit-Scope", "user")
+			w.Header().Set("X-RateLimit-Bucket", routeHash(pattern))
+		}
+		writeRateLimited(w, retry, rule.Global)
+		return true
+	}
+
+	key := bucketKey(pattern, r)
+	b, ok := s.buckets[key]
+	now := time.Now()
+	if !ok || now.After(b.resetAt) {
+		b = &bucket{hash: routeHash(pattern), remaining: s.BucketLimit, resetAt: now.Add(s.BucketWindow)}
+		s.buckets[key] = b
+	}
+	resetAfter := b.resetAt.Sub(now)
+	h := w.Header()
+	h.Set("X-RateLimit-Bucket", b.hash)
+	h.Set("X-RateLimit-Limit", strconv.Itoa(s.BucketLimit))
+	h.Set("X-RateLimit-Reset-After", strconv.FormatFloat(resetAfter.Seconds(), 'f', 3, 64))
+	h.Set("X-RateLimit-Reset", strconv.FormatFloat(float64(b.resetAt.UnixMilli())/1000, 'f', 3, 64))
+	if b.remaining <= 0 {
+		h.Set("X-RateLimit-Remaining", "0")
+		h.Set("X-RateLimit-Scope", "user")
+		writeRateLimited(w, resetAfter, false)
+		return true
+	}
+	b.remaining--
+	h.Set("X-RateLimit-Remaining", strconv.Itoa(b.remaining))
+	return false
+}
+
+func writeRateLimited(w http.ResponseWriter, retry time.Duration, global bool) {
+	secs := retry.Seconds()
+	w.Header().Set("Retry-After", strconv.FormatFloat(secs, 'f', 3, 64))
+	writeJSON(w, http.StatusTooManyRequests, object{
+		"message":     "You are being rate limited.",
+		"retry_after": secs,
+		"global":      global,
+	})
+}
+
+// bucketKey scopes a route's bucket by its major parameter (guild, channel or
+// webhook ID), like Discord does.
+func bucketKey(pattern string, r *http.Request) string {
+	for _, p := range []string{"guild", "channel", "webhook"} {
+		if v := r.PathValue(p); v != "" {
+			return pattern + "|" + v
+		}
+	}
+	return pattern
+}
+
+func routeHash(pattern string) string {
+	sum := sha1.Sum([]byte(pattern))
+	return hex.EncodeToString(sum[:8])
+}
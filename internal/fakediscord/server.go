@@ -0,0 +1,338 @@
+// Package fakediscord is an in-memory, stateful stand-in for the Discord REST API.
+//
+// It models the objects the provider manages (guilds, channels, roles, members,
+// permission overwrites, messages, threads, webhooks, emojis, stickers, scheduled
+// events, auto moderation rules, invites, templates, stage instances, soundboard
+// sounds, application command permissions and the widget, welcome screen,
+// onboarding and membership screening settings) closely enough to run
+// resource.Test steps offline: IDs are real snowflakes, invalid bodies get
+// Discord's 50035 field errors, unknown objects get the matching 404 JSON codes,
+// and per-route buckets send X-RateLimit-* headers and 429s. POST /oauth2/token issues Bearer tokens
+// for ClientID and ClientSecret, which are accepted alongside the bot token, and
+// IssueUserToken stands in for a user's token.
+//
+// Point the provider at it with base_url:
+//
+//	srv := fakediscord.New()
+//	defer srv.Close()
+//	guildID := srv.AddGuild("test")
+//	// provider "discord" { token = "fake" base_url = srv.URL }
+package fakediscord
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Token is the bot token the fake accepts when no other token is configured.
+const Token = "ZmFrZS1ib3Q.ZmFrZQ.fake-bot-token"
+
+// Request is one request the fake served, for assertions in tests.
+type Request struct {
+	Method string
+	// Path is the request path without the /api/v{n} prefix, e.g. /channels/123.
+	Path string
+	// Reason is the decoded X-Audit-Log-Reason header.
+	Reason string
+	Body   []byte
+}
+
+// RateLimitRule makes the next Count requests that match Method and Path fail with
+// 429. Path is matched as a prefix of the path without the /api/v{n} prefix, and
+// an empty Method matches any method.
+type RateLimitRule struct {
+	Method     string
+	Path       string
+	Count      int
+	Global     bool
+	RetryAfter time.Duration
+}
+
+// Server is a running fake Discord API. All methods are safe for concurrent use.
+type Server struct {
+	// URL is the value for the provider's base_url, e.g. http://127.0.0.1:1234/api.
+	URL string
+
+	// TokenLifetime is the expires_in of access tokens from POST /oauth2/token.
+	TokenLifetime time.Duration
+
+	// BucketLimit and BucketWindow size the per-route buckets: each route accepts
+	// BucketLimit requests per BucketWindow before answering 429.
+	BucketLimit  int
+	BucketWindow time.Duration
+
+	srv *httptest.Server
+	mux *http.ServeMux
+
+	mu         sync.Mutex
+	token      string
+	bearers    map[string]time.Time // access token to expiry
+	userScopes map[string][]string  // user access token to its scopes
+	ids        *snowflakes
+	botUser    object
+	users      map[string]object
+	guilds     map[string]*guild
+	channels   map[string]object
+	messages   map[string]object
+	webhooks   map[string]object
+	threadMems map[string]map[string]object
+	invites    map[string]object
+	stages     map[string]object
+	buckets    map[string]*bucket
+	rules      []*RateLimitRule
+	requests   []Request
+}
+
+// object is a Discord JSON object. Objects are stored and served as-is, so PATCH
+// semantics (absent keys unchanged, null clears) fall out of a key-wise merge.
+type object = map[string]any
+
+// New starts a fake Discord API with a bot user and no guilds.
+func New() *Server {
+	s := &Server{
+		TokenLifetime: DefaultTokenLifetime,
+		BucketLimit:   50,
+		BucketWindow:  time.Second,
+
+		token:      Token,
+		bearers:    map[string]time.Time{},
+		userScopes: map[string][]string{},
+		ids:        newSnowflakes(time.Now()),
+		users:      map[string]object{},
+		guilds:     map[string]*guild{},
+		channels:   map[string]object{},
+		messages:   map[string]object{},
+		webhooks:   map[string]object{},
+		threadMems: map[string]map[string]object{},
+		invites:    map[string]object{},
+		stages:     map[string]object{},
+		buckets:    map[string]*bucket{},
+	}
+	s.botUser = s.newUser("terraform-bot", true)
+
+	s.mux = http.NewServeMux()
+	s.routes()
+	s.srv = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	s.URL = s.srv.URL + "/api"
+	return s
+}
+
+// Close shuts the server down.
+func (s *Server) Close() {
+	s.srv.Close()
+}
+
+// SetToken changes the bot token the fake accepts.
+func (s *Server) SetToken(token string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.token = token
+}
+
+// BotUserID returns the ID of the bot user that owns the token.
+func (s *Server) BotUserID() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.botUser["id"].(string)
+}
+
+// RateLimit adds a rule that answers matching requests with 429.
+func (s *Server) RateLimit(rule RateLimitRule) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	r := rule
+	s.rules = append(s.rules, &r)
+}
+
+// Requests returns the requests served so far, oldest first.
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Request(nil), s.requests...)
+}
+
+var versionPrefix = regexp.MustCompile(`^/api/v[0-9]+/`)
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	loc := versionPrefix.FindString(r.URL.Path)
+	if loc == "" {
+		writeError(w, http.StatusNotFound, 0, "404: Not Found")
+		return
+	}
+	r.URL.Path = "/" + strings.TrimPrefix(r.URL.Path, loc)
+	r.URL.RawPath = ""
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, 50109, "The request body contains invalid JSON.")
+		return
+	}
+	r.Body = io.NopCloser(strings.NewReader(string(body)))
+	reason, _ := url.QueryUnescape(r.Header.Get("X-Audit-Log-Reason"))
+
+	s.mu.Lock()
+	s.requests = append(s.requests, Request{Method: r.Method, Path: r.URL.Path, Reason: reason, Body: body})
+	s.mu.Unlock()
+
+	// The token endpoint authenticates the application, not a bot or user.
+	if !s.authorized(r.Header.Get("Authorization")) && r.URL.Path != "/oauth2/token" {
+		writeError(w, http.StatusUnauthorized, 0, "401: Unauthorized")
+		return
+	}
+
+	_, pattern := s.mux.Handler(r)
+	if pattern == "" {
+		writeError(w, http.StatusNotFound, 0, "404: Not Found")
+		return
+	}
+	s.mux.ServeHTTP(w, r)
+}
+
+// handle registers a handler that runs with the state lock held, after the
+// request passed the route's rate limit.
+func (s *Server) handle(pattern string, h func(w http.ResponseWriter, r *http.Request)) {
+	s.mux.HandleFunc(pattern, func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if s.rateLimited(w, r) {
+			return
+		}
+		h(w, r)
+	})
+}
+
+func (s *Server) routes() {
+	s.handle("GET /users/@me", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, s.botUser)
+	})
+	s.handle("GET /users/{user}", s.getUser)
+
+	s.guildRoutes()
+	s.channelRoutes()
+	s.messageRoutes()
+	s.threadRoutes()
+	s.webhookRoutes()
+	s.assetRoutes()
+	s.eventRoutes()
+	s.inviteRoutes()
+	s.stageRoutes()
+	s.settingsRoutes()
+	s.oauth2Routes()
+}
+
+func (s *Server) getUser(w http.ResponseWriter, r *http.Request) {
+	u, ok := s.users[r.PathValue("user")]
+	if !ok {
+		writeError(w, http.StatusNotFound, 10013, "Unknown User")
+		return
+	}
+	writeJSON(w, http.StatusOK, u)
+}
+
+func (s *Server) newUser(name string, bot bool) object {
+	u := object{
+		"id":            s.ids.next(),
+		"username":      name,
+		"discriminator": "0",
+		"global_name":   nil,
+		"avatar":        nil,
+		"bot":           bot,
+	}
+	s.users[u["id"].(string)] = u
+	return u
+}
+
+// decodeBody decodes a JSON request body into an object. It writes the error
+// response and returns false when the body is not a JSON object.
+func decodeBody(w http.ResponseWriter, r *http.Request) (object, bool) {
+	var in object
+	b, _ := io.ReadAll(r.Body)
+	if len(strings.TrimSpace(string(b))) == 0 {
+		return object{}, true
+	}
+	if err := json.Unmarshal(b, &in); err != nil || in == nil {
+		writeError(w, http.StatusBadRequest, 50109, "The request body contains invalid JSON.")
+		return nil, false
+	}
+	return in, true
+}
+
+// decodeArray decodes a JSON array request body into out.
+func decodeArray(w http.ResponseWriter, r *http.Request, out any) bool {
+	if err := json.NewDecoder(r.Body).Decode(out); err != nil {
+		writeError(w, http.StatusBadRequest, 50109, "The request body contains invalid JSON.")
+		return false
+	}
+	return true
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeNoContent(w http.ResponseWriter) {
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func writeError(w http.ResponseWriter, status, code int, message string) {
+	writeJSON(w, status, object{"code": code, "message": message})
+}
+
+func writeNotFound(w http.ResponseWriter, code int, what string) {
+	writeError(w, http.StatusNotFound, code, "Unknown "+what)
+}
+
+// now returns the current time as Discord formats timestamps.
+func now() string {
+	return time.Now().UTC().Format("2006-01-02T15:04:05.000000+00:00")
+}
+
+func cloneObject(o object) object {
+	b, _ := json.Marshal(o)
+	var out object
+	_ = json.Unmarshal(b, &out)
+	return out
+}
+
+// merge applies a PATCH body to o for the allowed keys.
+func merge(o, patch object, allowed ...string) {
+	for _, k := range allowed {
+		if v, ok := patch[k]; ok {
+			o[k] = v
+		}
+	}
+}
+
+func str(v any) string {
+	s, _ := v.(string)
+	return s
+}
+
+func num(v any) int {
+	n, _ := intValue(v)
+	return n
+}
+
+func intValue(v any) (int, bool) {
+	switch n := v.(type) {
+	case float64:
+		return int(n), n == float64(int(n))
+	case int:
+		return n, true
+	}
+	return 0, false
+}
+
+func fmtInt(n int) string {
+	return fmt.Sprintf("%d", n)
+}
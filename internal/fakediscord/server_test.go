@@ -0,0 +1,555 @@
+package fakediscord
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/45ck/terraform-provider-discord/discord"
+)
+
+// newTestClient starts a fake with one guild and returns a client for it.
+func newTestClient(t *testing.T) (*Server, *discord.RestClient, string) {
+	t.Helper()
+	s := New()
+	t.Cleanup(s.Close)
+	guildID := s.AddGuild("Test")
+
+	c := discord.NewRestClient(Token, nil)
+	c.BaseURL = discord.APIBaseURL(s.URL, 0)
+	c.MaxRetries = 0
+	return s, c, guildID
+}
+
+func wantDiscordError(t *testing.T, err error, status, code int) *discord.DiscordHTTPError {
+	t.Helper()
+	e, ok := discord.AsDiscordHTTPError(err)
+	if !ok {
+		t.Fatalf("error = %v, want Discord error %d", err, code)
+	}
+	if e.StatusCode != status || e.Code != code {
+		t.Fatalf("error = %d/%d (%s), want %d/%d", e.StatusCode, e.Code, e.Message, status, code)
+	}
+	return e
+}
+
+func TestServer_Unauthorized(t *testing.T) {
+	_, c, guildID := newTestClient(t)
+	c.Token = "wrong"
+
+	_, err := c.GetGuild(context.Background(), guildID)
+	wantDiscordError(t, err, http.StatusUnauthorized, 0)
+}
+
+func TestServer_UnknownRoute(t *testing.T) {
+	_, c, _ := newTestClient(t)
+
+	err := c.DoJSON(context.Background(), "GET", "/nope", nil, nil, nil)
+	wantDiscordError(t, err, http.StatusNotFound, 0)
+}
+
+func TestServer_NewGuild(t *testing.T) {
+	s, c, guildID := newTestClient(t)
+	ctx := context.Background()
+
+	g, err := c.GetGuild(ctx, guildID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if g.Name != "Test" || g.SystemChannelID == "" || g.OwnerID == s.BotUserID() {
+		t.Fatalf("guild = %+v", g)
+	}
+
+	roles, err := c.GetGuildRoles(ctx, guildID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(roles) != 2 || roles[0].ID != guildID || roles[0].Name != "@everyone" || !roles[1].Managed {
+		t.Fatalf("roles = %+v", roles)
+	}
+
+	ch, err := c.GetChannel(ctx, g.SystemChannelID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ch.Name != "general" || ch.GuildID != guildID {
+		t.Fatalf("system channel = %+v", ch)
+	}
+}
+
+func TestServer_RoleLifecycle(t *testing.T) {
+	s, c, guildID := newTestClient(t)
+	ctx := context.Background()
+
+	role, err := c.CreateRole(ctx, guildID, &discord.RoleParams{
+		Name:  discord.Ptr("mods"),
+		Color: discord.Ptr(0xff0000),
+		Hoist: discord.Ptr(true),
+	}, "set up mods")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if role.Position != 1 || role.Color != 0xff0000 || !role.Hoist || role.Permissions != defaultEveryonePermissions {
+		t.Fatalf("created role = %+v", role)
+	}
+	if got := s.Requests(); got[len(got)-1].Reason != "set up mods" {
+		t.Fatalf("reason = %q", got[len(got)-1].Reason)
+	}
+
+	role, err = c.ModifyRole(ctx, guildID, role.ID, &discord.RoleParams{Hoist: discord.Ptr(false)}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if role.Hoist || role.Name != "mods" {
+		t.Fatalf("modified role = %+v", role)
+	}
+
+	if err := c.DeleteRole(ctx, guildID, role.ID, ""); err != nil {
+		t.Fatal(err)
+	}
+	err = c.DeleteRole(ctx, guildID, role.ID, "")
+	wantDiscordError(t, err, http.StatusNotFound, 10011)
+
+	err = c.DeleteRole(ctx, guildID, guildID, "")
+	wantDiscordError(t, err, http.StatusBadRequest, 50028)
+}
+
+func TestServer_FormErrors(t *testing.T) {
+	_, c, guildID := newTestClient(t)
+
+	_, err := c.CreateChannel(context.Background(), guildID, &discord.CreateChannelParams{
+		Name:  strings.Repeat("x", 101),
+		Type:  2,
+		Topic: discord.Ptr("voice channels have no topic limit here"),
+	}, "")
+	e := wantDiscordError(t, err, http.StatusBadRequest, 50035)
+	if len(e.Errors) != 1 || e.Errors[0].Field != "name" || e.Errors[0].Code != "BASE_TYPE_BAD_LENGTH" {
+		t.Fatalf("field errors = %+v", e.Errors)
+	}
+}
+
+func TestServer_ChannelNamesAndDelete(t *testing.T) {
+	_, c, guildID := newTestClient(t)
+	ctx := context.Background()
+
+	cat, err := c.CreateChannel(ctx, guildID, &discord.CreateChannelParams{Name: "Stuff", Type: 4}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ch, err := c.CreateChannel(ctx, guildID, &discord.CreateChannelParams{Name: "Hello World", ParentID: discord.Ptr(cat.ID)}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ch.Name != "hello-world" || ch.ParentID != cat.ID || ch.Position != 0 {
+		t.Fatalf("channel = %+v", ch)
+	}
+
+	if err := c.DeleteChannel(ctx, cat.ID, ""); err != nil {
+		t.Fatal(err)
+	}
+	ch, err = c.GetChannel(ctx, ch.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ch.ParentID != "" {
+		t.Fatalf("parent_id = %q after deleting the category", ch.ParentID)
+	}
+	_, err = c.GetChannel(ctx, cat.ID)
+	wantDiscordError(t, err, http.StatusNotFound, 10003)
+}
+
+func TestServer_Overwrites(t *testing.T) {
+	s, c, guildID := newTestClient(t)
+	ctx := context.Background()
+	g, _ := c.GetGuild(ctx, guildID)
+	userID := s.AddMember(guildID, "alice")
+
+	err := c.EditChannelPermissions(ctx, g.SystemChannelID, userID, &discord.EditChannelPermissionsParams{
+		Type: 1, Allow: "1024", Deny: "0",
+	}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ch, _ := c.GetChannel(ctx, g.SystemChannelID)
+	if len(ch.PermissionOverwrites) != 1 || ch.PermissionOverwrites[0].Allow != "1024" || ch.PermissionOverwrites[0].Deny != "0" {
+		t.Fatalf("overwrites = %+v", ch.PermissionOverwrites)
+	}
+
+	err = c.EditChannelPermissions(ctx, g.SystemChannelID, s.AddUser("stranger"), &discord.EditChannelPermissionsParams{Type: 1, Allow: "0", Deny: "0"}, "")
+	wantDiscordError(t, err, http.StatusNotFound, 10007)
+
+	if err := c.DeleteChannelPermission(ctx, g.SystemChannelID, userID, ""); err != nil {
+		t.Fatal(err)
+	}
+	err = c.DeleteChannelPermission(ctx, g.SystemChannelID, userID, "")
+	wantDiscordError(t, err, http.StatusNotFound, 10009)
+}
+
+func TestServer_Messages(t *testing.T) {
+	_, c, guildID := newTestClient(t)
+	ctx := context.Background()
+	g, _ := c.GetGuild(ctx, guildID)
+
+	_, err := c.CreateMessage(ctx, g.SystemChannelID, &discord.CreateMessageParams{})
+	wantDiscordError(t, err, http.StatusBadRequest, 50006)
+
+	msg, err := c.CreateMessage(ctx, g.SystemChannelID, &discord.CreateMessageParams{
+		Embeds: []discord.Embed{{Title: "hi", Timestamp: "2030-01-02T03:04:05+01:00"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(msg.Embeds) != 1 || msg.Embeds[0].Timestamp != "2030-01-02T02:04:05.000000+00:00" {
+		t.Fatalf("embeds = %+v", msg.Embeds)
+	}
+
+	msg, err = c.EditMessage(ctx, g.SystemChannelID, msg.ID, &discord.EditMessageParams{Content: discord.Ptr("edited")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if msg.Content != "edited" || msg.EditedTimestamp == "" {
+		t.Fatalf("edited message = %+v", msg)
+	}
+
+	if err := c.DeleteMessage(ctx, g.SystemChannelID, msg.ID, ""); err != nil {
+		t.Fatal(err)
+	}
+	_, err = c.GetMessage(ctx, g.SystemChannelID, msg.ID)
+	wantDiscordError(t, err, http.StatusNotFound, 10008)
+}
+
+func TestServer_Threads(t *testing.T) {
+	s, c, guildID := newTestClient(t)
+	ctx := context.Background()
+	g, _ := c.GetGuild(ctx, guildID)
+	userID := s.AddMember(guildID, "alice")
+
+	th, err := c.StartThread(ctx, g.SystemChannelID, &discord.StartThreadParams{Name: "talk", Type: discord.Ptr(uint(11))}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if th.ParentID != g.SystemChannelID || th.ThreadMetadata == nil || th.ThreadMetadata.AutoArchiveDuration != 4320 {
+		t.Fatalf("thread = %+v", th)
+	}
+
+	if err := c.AddThreadMember(ctx, th.ID, userID, ""); err != nil {
+		t.Fatal(err)
+	}
+	members, err := c.ListThreadMembers(ctx, th.ID, &discord.ListThreadMembersParams{WithMember: discord.Ptr(true)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(members) != 2 || members[1].UserID != userID || members[1].Member == nil {
+		t.Fatalf("members = %+v", members)
+	}
+	members, err = c.ListThreadMembers(ctx, th.ID, &discord.ListThreadMembersParams{After: members[0].UserID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(members) != 1 || members[0].UserID != userID {
+		t.Fatalf("members after = %+v", members)
+	}
+
+	archived := true
+	if _, err := c.ModifyChannel(ctx, th.ID, &discord.ModifyChannelParams{Archived: &archived}, ""); err != nil {
+		t.Fatal(err)
+	}
+	err = c.RemoveThreadMember(ctx, th.ID, userID, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = c.AddThreadMember(ctx, th.ID, userID, "")
+	wantDiscordError(t, err, http.StatusBadRequest, 50083)
+}
+
+func TestServer_ForumPost(t *testing.T) {
+	_, c, guildID := newTestClient(t)
+	ctx := context.Background()
+
+	forum, err := c.CreateChannel(ctx, guildID, &discord.CreateChannelParams{
+		Name:          "help",
+		Type:          15,
+		AvailableTags: []discord.ForumTag{{Name: "bug"}},
+	}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(forum.AvailableTags) != 1 || forum.AvailableTags[0].ID == "" {
+		t.Fatalf("tags = %+v", forum.AvailableTags)
+	}
+
+	_, err = c.StartThread(ctx, forum.ID, &discord.StartThreadParams{Name: "post"}, "")
+	e := wantDiscordError(t, err, http.StatusBadRequest, 50035)
+	if e.Errors[0].Field != "message" {
+		t.Fatalf("field errors = %+v", e.Errors)
+	}
+
+	post, err := c.StartThread(ctx, forum.ID, &discord.StartThreadParams{
+		Name:        "post",
+		Message:     &discord.ForumThreadMessageParams{Content: "help me"},
+		AppliedTags: []string{forum.AvailableTags[0].ID},
+	}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	starter, err := c.GetMessage(ctx, post.ID, post.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if starter.Content != "help me" || len(post.AppliedTags) != 1 {
+		t.Fatalf("post = %+v, starter = %+v", post, starter)
+	}
+}
+
+func TestServer_Webhooks(t *testing.T) {
+	_, c, guildID := newTestClient(t)
+	ctx := context.Background()
+	g, _ := c.GetGuild(ctx, guildID)
+
+	_, err := c.CreateWebhook(ctx, g.SystemChannelID, &discord.CreateWebhookParams{Name: "Clyde"}, "")
+	wantDiscordError(t, err, http.StatusBadRequest, 50035)
+
+	wh, err := c.CreateWebhook(ctx, g.SystemChannelID, &discord.CreateWebhookParams{Name: "alerts"}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if wh.Token == "" || !strings.HasSuffix(wh.URL, "/"+wh.ID+"/"+wh.Token) {
+		t.Fatalf("webhook = %+v", wh)
+	}
+
+	if err := c.DeleteChannel(ctx, g.SystemChannelID, ""); err != nil {
+		t.Fatal(err)
+	}
+	_, err = c.GetWebhook(ctx, wh.ID)
+	wantDiscordError(t, err, http.StatusNotFound, 10015)
+}
+
+func TestServer_Sticker(t *testing.T) {
+	_, c, guildID := newTestClient(t)
+	ctx := context.Background()
+
+	st, err := c.CreateGuildSticker(ctx, guildID, &discord.CreateStickerParams{
+		Name: "wave", Tags: "wave", FileName: "wave.png", File: []byte("\x89PNG"),
+	}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if st.FormatType != 1 || st.Description != "" || st.GuildID != guildID {
+		t.Fatalf("sticker = %+v", st)
+	}
+
+	_, err = c.CreateGuildSticker(ctx, guildID, &discord.CreateStickerParams{
+		Name: "wave", Tags: "wave", FileName: "wave.bmp", File: []byte("BM"),
+	}, "")
+	e := wantDiscordError(t, err, http.StatusBadRequest, 50035)
+	if e.Errors[0].Field != "file" {
+		t.Fatalf("field errors = %+v", e.Errors)
+	}
+}
+
+func TestServer_ScheduledEvent(t *testing.T) {
+	_, c, guildID := newTestClient(t)
+	ctx := context.Background()
+	start := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+
+	_, err := c.CreateScheduledEvent(ctx, guildID, &discord.ScheduledEventParams{
+		Name:               discord.Ptr("meetup"),
+		PrivacyLevel:       discord.Ptr(2),
+		EntityType:         discord.Ptr(3),
+		ScheduledStartTime: discord.Ptr(start.Format(time.RFC3339)),
+	}, "")
+	e := wantDiscordError(t, err, http.StatusBadRequest, 50035)
+	if len(e.Errors) != 2 {
+		t.Fatalf("field errors = %+v", e.Errors)
+	}
+
+	ev, err := c.CreateScheduledEvent(ctx, guildID, &discord.ScheduledEventParams{
+		Name:               discord.Ptr("meetup"),
+		PrivacyLevel:       discord.Ptr(2),
+		EntityType:         discord.Ptr(3),
+		EntityMetadata:     &discord.ScheduledEventEntityMetadata{Location: "park"},
+		ScheduledStartTime: discord.Ptr(start.Format(time.RFC3339)),
+		ScheduledEndTime:   discord.Ptr(start.Add(time.Hour).Format(time.RFC3339)),
+	}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ev.Status != 1 || ev.ScheduledStartTime != start.Format("2006-01-02T15:04:05.000000+00:00") {
+		t.Fatalf("event = %+v", ev)
+	}
+
+	_, err = c.ModifyScheduledEvent(ctx, guildID, ev.ID, &discord.ScheduledEventParams{Status: discord.Ptr(3)}, "")
+	wantDiscordError(t, err, http.StatusBadRequest, 180000)
+}
+
+func TestServer_AutoModRule(t *testing.T) {
+	_, c, guildID := newTestClient(t)
+	ctx := context.Background()
+
+	rule, err := c.CreateAutoModRule(ctx, guildID, &discord.AutoModRuleParams{
+		Name:            discord.Ptr("no spam"),
+		EventType:       discord.Ptr(1),
+		TriggerType:     discord.Ptr(1),
+		TriggerMetadata: &discord.AutoModTriggerMetadata{KeywordFilter: []string{"spam"}},
+		Actions:         &[]discord.AutoModAction{{Type: 1}},
+	}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rule.Enabled || len(rule.TriggerMetadata.KeywordFilter) != 1 || rule.ExemptRoles == nil {
+		t.Fatalf("rule = %+v", rule)
+	}
+
+	_, err = c.ModifyAutoModRule(ctx, guildID, rule.ID, &discord.AutoModRuleParams{TriggerType: discord.Ptr(3)}, "")
+	wantDiscordError(t, err, http.StatusBadRequest, 50035)
+}
+
+func TestServer_Invites(t *testing.T) {
+	_, c, guildID := newTestClient(t)
+	ctx := context.Background()
+	g, _ := c.GetGuild(ctx, guildID)
+
+	var inv struct {
+		Code    string `json:"code"`
+		MaxAge  int    `json:"max_age"`
+		Channel struct {
+			ID string `json:"id"`
+		} `json:"channel"`
+	}
+	if err := c.DoJSON(ctx, "POST", "/channels/"+g.SystemChannelID+"/invites", nil, map[string]any{"max_uses": 5}, &inv); err != nil {
+		t.Fatal(err)
+	}
+	if len(inv.Code) != 8 || inv.MaxAge != 86400 || inv.Channel.ID != g.SystemChannelID {
+		t.Fatalf("invite = %+v", inv)
+	}
+
+	err := c.DoJSON(ctx, "POST", "/channels/"+g.SystemChannelID+"/invites", nil, map[string]any{"max_age": 604801}, nil)
+	wantDiscordError(t, err, http.StatusBadRequest, 50035)
+
+	if err := c.DeleteChannel(ctx, g.SystemChannelID, ""); err != nil {
+		t.Fatal(err)
+	}
+	err = c.DoJSON(ctx, "GET", "/invites/"+inv.Code, nil, nil, nil)
+	wantDiscordError(t, err, http.StatusNotFound, 10006)
+}
+
+func TestServer_StageInstance(t *testing.T) {
+	_, c, guildID := newTestClient(t)
+	ctx := context.Background()
+	g, _ := c.GetGuild(ctx, guildID)
+
+	body := map[string]any{"channel_id": g.SystemChannelID, "topic": "town hall"}
+	err := c.DoJSON(ctx, "POST", "/stage-instances", nil, body, nil)
+	wantDiscordError(t, err, http.StatusBadRequest, 50024)
+
+	stage, err := c.CreateChannel(ctx, guildID, &discord.CreateChannelParams{Name: "Stage", Type: 13}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	body["channel_id"] = stage.ID
+	if err := c.DoJSON(ctx, "POST", "/stage-instances", nil, body, nil); err != nil {
+		t.Fatal(err)
+	}
+	err = c.DoJSON(ctx, "POST", "/stage-instances", nil, body, nil)
+	wantDiscordError(t, err, http.StatusBadRequest, 150006)
+
+	if err := c.DoJSON(ctx, "DELETE", "/stage-instances/"+stage.ID, nil, nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	err = c.DoJSON(ctx, "GET", "/stage-instances/"+stage.ID, nil, nil, nil)
+	wantDiscordError(t, err, http.StatusNotFound, 10067)
+}
+
+func TestServer_RateLimit(t *testing.T) {
+	s, c, guildID := newTestClient(t)
+	s.RateLimit(RateLimitRule{Method: "GET", Path: "/guilds/", Count: 2, RetryAfter: 10 * time.Millisecond})
+
+	if _, err := c.GetGuild(context.Background(), guildID); err != nil {
+		t.Fatal(err)
+	}
+	var gets int
+	for _, r := range s.Requests() {
+		if r.Method == "GET" {
+			gets++
+		}
+	}
+	if gets != 3 {
+		t.Fatalf("GET requests = %d, want 3 (two 429s, one success)", gets)
+	}
+}
+
+func TestServer_Buckets(t *testing.T) {
+	s, c, guildID := newTestClient(t)
+	s.BucketLimit = 2
+	s.BucketWindow = 50 * time.Millisecond
+	ctx := context.Background()
+
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		if _, err := c.GetGuild(ctx, guildID); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if time.Since(start) < 2*s.BucketWindow {
+		t.Fatalf("5 requests with a limit of 2 per %v took %v", s.BucketWindow, time.Since(start))
+	}
+}
+
+func TestServer_ClientCredentials(t *testing.T) {
+	s, c, guildID := newTestClient(t)
+	ctx := context.Background()
+	perms := []discord.ApplicationCommandPermission{{ID: guildID, Type: discord.CommandPermissionRole, Permission: false}}
+	app := s.BotUserID()
+
+	_, err := c.EditApplicationCommandPermissions(ctx, app, guildID, "42", perms)
+	if err == nil || !strings.Contains(err.Error(), "client_id and secret") {
+		t.Fatalf("expected a missing credentials error, got %v", err)
+	}
+
+	// A client credentials token acts for the application, which can't edit
+	// command permissions; that takes a user's token.
+	c.Bearer = discord.NewClientCredentials(ClientID, ClientSecret, nil, c.BaseURL+"/oauth2/token", nil)
+	_, err = c.EditApplicationCommandPermissions(ctx, app, guildID, "42", perms)
+	wantDiscordError(t, err, http.StatusForbidden, 50001)
+	c.BearerToken = s.IssueUserToken("identify")
+	_, err = c.EditApplicationCommandPermissions(ctx, app, guildID, "42", perms)
+	wantDiscordError(t, err, http.StatusForbidden, 50001)
+
+	c.BearerToken = s.IssueUserToken(CommandPermissionsScope)
+	got, err := c.EditApplicationCommandPermissions(ctx, app, guildID, "42", perms)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got.Permissions) != 1 || got.GuildID != guildID {
+		t.Fatalf("unexpected permissions %#v", got)
+	}
+	read, err := c.GetApplicationCommandPermissions(ctx, app, guildID, "42")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if read.Permissions[0].ID != guildID {
+		t.Fatalf("unexpected permissions %#v", read)
+	}
+
+	// The bot token is refused here.
+	err = c.DoJSON(ctx, "PUT", "/applications/"+app+"/guilds/"+guildID+"/commands/42/permissions", nil, map[string]any{"permissions": []any{}}, nil)
+	wantDiscordError(t, err, http.StatusForbidden, 20001)
+
+	// A revoked client credentials token is replaced.
+	c.BearerToken = ""
+	bearer := discord.WithAuth(ctx, discord.AuthBearer)
+	if err := c.DoJSON(bearer, "GET", "/users/@me", nil, nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	s.RevokeTokens()
+	if err := c.DoJSON(bearer, "GET", "/users/@me", nil, nil, nil); err != nil {
+		t.Fatalf("expected a new token after revocation: %v", err)
+	}
+
+	c.Bearer = discord.NewClientCredentials(ClientID, "wrong", nil, c.BaseURL+"/oauth2/token", nil)
+	if err := c.DoJSON(bearer, "GET", "/users/@me", nil, nil, nil); err == nil || !strings.Contains(err.Error(), "invalid_client") {
+		t.Fatalf("expected invalid_client, got %v", err)
+	}
+}
@@ -0,0 +1,194 @@
+package fakediscord
+
+import (
+	"net/http"
+)
+
+// settingsRoutes serves the guild settings that live outside the guild object:
+// widget, welcome screen, onboarding and membership screening.
+func (s *Server) settingsRoutes() {
+	s.handle("GET /guilds/{guild}/widget", s.withGuild(s.getWidget))
+	s.handle("PATCH /guilds/{guild}/widget", s.withGuild(s.modifyWidget))
+
+	s.handle("GET /guilds/{guild}/welcome-screen", s.withGuild(s.getWelcomeScreen))
+	s.handle("PATCH /guilds/{guild}/welcome-screen", s.withGuild(s.modifyWelcomeScreen))
+
+	s.handle("GET /guilds/{guild}/onboarding", s.withGuild(s.getOnboarding))
+	s.handle("PUT /guilds/{guild}/onboarding", s.withGuild(s.modifyOnboarding))
+
+	s.handle("GET /guilds/{guild}/member-verification", s.withGuild(s.getMemberVerification))
+	s.handle("PUT /guilds/{guild}/member-verification", s.withGuild(s.modifyMemberVerification))
+}
+
+// setFeature adds or removes a guild feature flag.
+func (g *guild) setFeature(feature string, on bool) {
+	features := without(g.obj["features"], feature)
+	if on {
+		features = append(features, feature)
+	}
+	g.obj["features"] = features
+}
+
+func widgetJSON(g *guild) object {
+	return object{"enabled": g.obj["widget_enabled"], "channel_id": g.obj["widget_channel_id"]}
+}
+
+func (s *Server) getWidget(w http.ResponseWriter, r *http.Request, g *guild) {
+	writeJSON(w, http.StatusOK, widgetJSON(g))
+}
+
+func (s *Server) modifyWidget(w http.ResponseWriter, r *http.Request, g *guild) {
+	in, ok := decodeBody(w, r)
+	if !ok {
+		return
+	}
+	var errs formErrors
+	errs.boolean(in, "enabled")
+	if v, ok := in["channel_id"]; ok && v != nil {
+		ch, found := s.channels[str(v)]
+		if !found || str(ch["guild_id"]) != str(g.obj["id"]) || isThread(ch) || num(ch["type"]) == typeCategory {
+			errs.add("channel_id", "GUILD_CHANNEL_INVALID", "Invalid channel")
+		}
+	}
+	if errs.write(w) {
+		return
+	}
+	if v, ok := in["enabled"]; ok {
+		g.obj["widget_enabled"] = v == true
+	}
+	if v, ok := in["channel_id"]; ok {
+		g.obj["widget_channel_id"] = v
+	}
+	writeJSON(w, http.StatusOK, widgetJSON(g))
+}
+
+// getWelcomeScreen serves the welcome screen. Whether it is shown is not part of
+// the object: Discord reports it as the WELCOME_SCREEN_ENABLED guild feature.
+func (s *Server) getWelcomeScreen(w http.ResponseWriter, r *http.Request, g *guild) {
+	writeJSON(w, http.StatusOK, g.welcome)
+}
+
+func (s *Server) modifyWelcomeScreen(w http.ResponseWriter, r *http.Request, g *guild) {
+	in, ok := decodeBody(w, r)
+	if !ok {
+		return
+	}
+	var errs formErrors
+	errs.boolean(in, "enabled")
+	errs.length(in, "description", 0, 140)
+	channels, _ := in["welcome_channels"].([]any)
+	if len(channels) > 5 {
+		errs.add("welcome_channels", "BASE_TYPE_MAX_LENGTH", "Must be 5 or fewer in length.")
+	}
+	for i, v := range channels {
+		c, _ := v.(object)
+		sub := errs.at("welcome_channels." + fmtInt(i))
+		s.checkGuildChannel(sub, c, "channel_id", g, typeText)
+		sub.requiredLength(c, "description", 1, 42)
+		sub.snowflake(c, "emoji_id")
+	}
+	if errs.write(w) {
+		return
+	}
+
+	if v, ok := in["enabled"]; ok {
+		g.setFeature("WELCOME_SCREEN_ENABLED", v == true)
+	}
+	merge(g.welcome, in, "description")
+	if _, ok := in["welcome_channels"]; ok {
+		out := []any{}
+		for _, v := range channels {
+			c := v.(object)
+			out = append(out, object{
+				"channel_id":  c["channel_id"],
+				"description": c["description"],
+				"emoji_id":    c["emoji_id"],
+				"emoji_name":  c["emoji_name"],
+			})
+		}
+		g.welcome["welcome_channels"] = out
+	}
+	writeJSON(w, http.StatusOK, g.welcome)
+}
+
+func (s *Server) getOnboarding(w http.ResponseWriter, r *http.Request, g *guild) {
+	writeJSON(w, http.StatusOK, g.onboarding)
+}
+
+// modifyOnboarding replaces the onboarding flow. Prompts and options sent
+// without an ID are new and get one.
+func (s *Server) modifyOnboarding(w http.ResponseWriter, r *http.Request, g *guild) {
+	in, ok := decodeBody(w, r)
+	if !ok {
+		return
+	}
+	var errs formErrors
+	errs.boolean(in, "enabled")
+	errs.oneOf(in, "mode", 0, 1)
+	defaults, _ := in["default_channel_ids"].([]any)
+	for i, id := range defaults {
+		s.checkGuildChannel(errs.at("default_channel_ids"), object{fmtInt(i): id}, fmtInt(i), g, typeText)
+	}
+	prompts, _ := in["prompts"].([]any)
+	for i, v := range prompts {
+		p, _ := v.(object)
+		sub := errs.at("prompts." + fmtInt(i))
+		sub.requiredLength(p, "title", 1, 100)
+		if opts, _ := p["options"].([]any); len(opts) == 0 {
+			sub.required("options")
+		}
+	}
+	if errs.write(w) {
+		return
+	}
+
+	for _, v := range prompts {
+		p := v.(object)
+		if !isSnowflake(str(p["id"])) {
+			p["id"] = s.ids.next()
+		}
+		opts, _ := p["options"].([]any)
+		for _, o := range opts {
+			if opt, ok := o.(object); ok && !isSnowflake(str(opt["id"])) {
+				opt["id"] = s.ids.next()
+			}
+		}
+	}
+	merge(g.onboarding, in, "prompts", "default_channel_ids", "enabled", "mode")
+	writeJSON(w, http.StatusOK, g.onboarding)
+}
+
+// getMemberVerification serves the membership screening form. Like the welcome
+// screen, whether it is enabled is the MEMBER_VERIFICATION_GATE_ENABLED feature.
+func (s *Server) getMemberVerification(w http.ResponseWriter, r *http.Request, g *guild) {
+	writeJSON(w, http.StatusOK, g.verification)
+}
+
+func (s *Server) modifyMemberVerification(w http.ResponseWriter, r *http.Request, g *guild) {
+	in, ok := decodeBody(w, r)
+	if !ok {
+		return
+	}
+	var errs formErrors
+	errs.boolean(in, "enabled")
+	errs.length(in, "description", 0, 300)
+	fields, _ := in["form_fields"].([]any)
+	for i, v := range fields {
+		f, _ := v.(object)
+		sub := errs.at("form_fields." + fmtInt(i))
+		sub.requiredLength(f, "label", 1, 300)
+		if str(f["field_type"]) != "TERMS" {
+			sub.add("field_type", "BASE_TYPE_CHOICES", "Value must be one of ('TERMS',).")
+		}
+	}
+	if errs.write(w) {
+		return
+	}
+
+	if v, ok := in["enabled"]; ok {
+		g.setFeature("MEMBER_VERIFICATION_GATE_ENABLED", v == true)
+	}
+	merge(g.verification, in, "form_fields", "description")
+	g.verification["version"] = now()
+	writeJSON(w, http.StatusOK, g.verification)
+}
@@ -0,0 +1,34 @@
+package fakediscord
+
+import (
+	"strconv"
+	"time"
+)
+
+// discordEpoch is the first millisecond of 2015, the epoch of Discord snowflakes.
+const discordEpoch = 1420070400000
+
+// snowflakes hands out increasing snowflake IDs. The timestamp part starts at the
+// wall clock and never goes backwards, so IDs sort by creation like Discord's.
+type snowflakes struct {
+	ms  int64
+	seq int64
+}
+
+func newSnowflakes(start time.Time) *snowflakes {
+	return &snowflakes{ms: start.UnixMilli() - discordEpoch}
+}
+
+func (s *snowflakes) next() string {
+	if ms := time.Now().UnixMilli() - discordEpoch; ms > s.ms {
+		s.ms = ms
+		s.seq = 0
+	}
+	if s.seq == 4096 {
+		s.ms++
+		s.seq = 0
+	}
+	id := s.ms<<22 | 1<<17 | 1<<12 | s.seq
+	s.seq++
+	return strconv.FormatInt(id, 10)
+}
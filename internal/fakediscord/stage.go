@@ -0,0 +1,209 @@
+package fakediscord
+
+import (
+	"net/http"
+	"strings"
+)
+
+func (s *Server) stageRoutes() {
+	s.handle("POST /stage-instances", s.createStageInstance)
+	s.handle("GET /stage-instances/{channel}", s.withStageInstance(s.getStageInstance))
+	s.handle("PATCH /stage-instances/{channel}", s.withStageInstance(s.modifyStageInstance))
+	s.handle("DELETE /stage-instances/{channel}", s.withStageInstance(s.deleteStageInstance))
+
+	s.handle("GET /guilds/{guild}/soundboard-sounds", s.withGuild(s.listSounds))
+	s.handle("POST /guilds/{guild}/soundboard-sounds", s.withGuild(s.createSound))
+	s.handle("GET /guilds/{guild}/soundboard-sounds/{sound}", s.withGuild(s.getSound))
+	s.handle("PATCH /guilds/{guild}/soundboard-sounds/{sound}", s.withGuild(s.modifySound))
+	s.handle("DELETE /guilds/{guild}/soundboard-sounds/{sound}", s.withGuild(s.deleteSound))
+}
+
+type stageHandler func(w http.ResponseWriter, r *http.Request, si object)
+
+// withStageInstance resolves the {channel} path value to the stage live in that
+// channel or answers 10067 Unknown Stage Instance.
+func (s *Server) withStageInstance(h stageHandler) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		si, ok := s.stages[r.PathValue("channel")]
+		if !ok {
+			writeNotFound(w, 10067, "Stage Instance")
+			return
+		}
+		h(w, r, si)
+	}
+}
+
+// validateStageInstance checks a create or modify body. Public stages (privacy
+// level 1) are deprecated and rejected.
+func validateStageInstance(in object, create bool) formErrors {
+	var errs formErrors
+	if create {
+		errs.requiredLength(in, "topic", 1, 120)
+	} else {
+		errs.length(in, "topic", 1, 120)
+	}
+	errs.oneOf(in, "privacy_level", 2)
+	errs.boolean(in, "send_start_notification")
+	errs.snowflake(in, "guild_scheduled_event_id")
+	return errs
+}
+
+func (s *Server) createStageInstance(w http.ResponseWriter, r *http.Request) {
+	in, ok := decodeBody(w, r)
+	if !ok {
+		return
+	}
+	errs := validateStageInstance(in, true)
+	if _, ok := in["channel_id"]; !ok {
+		errs.required("channel_id")
+	}
+	if errs.write(w) {
+		return
+	}
+	ch, ok := s.channels[str(in["channel_id"])]
+	if !ok {
+		writeNotFound(w, 10003, "Channel")
+		return
+	}
+	if num(ch["type"]) != typeStage {
+		writeError(w, http.StatusBadRequest, 50024, "Cannot execute action on this channel type")
+		return
+	}
+	if _, live := s.stages[str(ch["id"])]; live {
+		writeError(w, http.StatusBadRequest, 150006, "Stage already open")
+		return
+	}
+	if v := str(in["guild_scheduled_event_id"]); v != "" {
+		if _, found := s.guilds[str(ch["guild_id"])].events[v]; !found {
+			writeNotFound(w, 10070, "Guild Scheduled Event")
+			return
+		}
+	}
+
+	si := object{
+		"id":                       s.ids.next(),
+		"guild_id":                 ch["guild_id"],
+		"channel_id":               ch["id"],
+		"topic":                    in["topic"],
+		"privacy_level":            2,
+		"discoverable_disabled":    true,
+		"guild_scheduled_event_id": nil,
+	}
+	merge(si, in, "guild_scheduled_event_id")
+	s.stages[str(ch["id"])] = si
+	writeJSON(w, http.StatusOK, si)
+}
+
+func (s *Server) getStageInstance(w http.ResponseWriter, r *http.Request, si object) {
+	writeJSON(w, http.StatusOK, si)
+}
+
+func (s *Server) modifyStageInstance(w http.ResponseWriter, r *http.Request, si object) {
+	in, ok := decodeBody(w, r)
+	if !ok {
+		return
+	}
+	if errs := validateStageInstance(in, false); errs.write(w) {
+		return
+	}
+	merge(si, in, "topic", "privacy_level")
+	writeJSON(w, http.StatusOK, si)
+}
+
+func (s *Server) deleteStageInstance(w http.ResponseWriter, r *http.Request, si object) {
+	delete(s.stages, str(si["channel_id"]))
+	writeNoContent(w)
+}
+
+// validateSound checks a soundboard sound create or modify body. Sounds are sent
+// as data URIs, like images.
+func validateSound(in object, create bool) formErrors {
+	var errs formErrors
+	if create {
+		errs.requiredLength(in, "name", 2, 32)
+		if v, ok := in["sound"]; !ok || v == nil {
+			errs.required("sound")
+		} else if !strings.HasPrefix(str(v), "data:audio/") || !strings.Contains(str(v), ";base64,") {
+			errs.add("sound", "SOUND_INVALID", "Invalid sound data")
+		}
+	} else {
+		errs.length(in, "name", 2, 32)
+	}
+	if v, ok := in["volume"]; ok && v != nil {
+		if f, isNum := v.(float64); !isNum || f < 0 || f > 1 {
+			errs.add("volume", "NUMBER_TYPE_MAX", "Value should be between 0 and 1.")
+		}
+	}
+	errs.snowflake(in, "emoji_id")
+	return errs
+}
+
+func (s *Server) listSounds(w http.ResponseWriter, r *http.Request, g *guild) {
+	writeJSON(w, http.StatusOK, object{"items": valuesByID(g.sounds)})
+}
+
+func (s *Server) createSound(w http.ResponseWriter, r *http.Request, g *guild) {
+	in, ok := decodeBody(w, r)
+	if !ok {
+		return
+	}
+	if errs := validateSound(in, true); errs.write(w) {
+		return
+	}
+	if len(g.sounds) >= 8 {
+		writeError(w, http.StatusBadRequest, 30045, "Maximum number of soundboard sounds reached (8)")
+		return
+	}
+
+	snd := object{
+		"sound_id":   s.ids.next(),
+		"guild_id":   g.obj["id"],
+		"name":       in["name"],
+		"volume":     1.0,
+		"emoji_id":   nil,
+		"emoji_name": nil,
+		"available":  true,
+		"user":       cloneObject(s.botUser),
+	}
+	merge(snd, in, "volume", "emoji_id", "emoji_name")
+	g.sounds[str(snd["sound_id"])] = snd
+	writeJSON(w, http.StatusOK, snd)
+}
+
+// sound resolves the {sound} path value or answers 10097 Unknown Sound.
+func (s *Server) sound(w http.ResponseWriter, r *http.Request, g *guild) (object, bool) {
+	snd, ok := g.sounds[r.PathValue("sound")]
+	if !ok {
+		writeNotFound(w, 10097, "Sound")
+	}
+	return snd, ok
+}
+
+func (s *Server) getSound(w http.ResponseWriter, r *http.Request, g *guild) {
+	if snd, ok := s.sound(w, r, g); ok {
+		writeJSON(w, http.StatusOK, snd)
+	}
+}
+
+func (s *Server) modifySound(w http.ResponseWriter, r *http.Request, g *guild) {
+	snd, ok := s.sound(w, r, g)
+	if !ok {
+		return
+	}
+	in, ok := decodeBody(w, r)
+	if !ok {
+		return
+	}
+	if errs := validateSound(in, false); errs.write(w) {
+		return
+	}
+	merge(snd, in, "name", "volume", "emoji_id", "emoji_name")
+	writeJSON(w, http.StatusOK, snd)
+}
+
+func (s *Server) deleteSound(w http.ResponseWriter, r *http.Request, g *guild) {
+	if snd, ok := s.sound(w, r, g); ok {
+		delete(g.sounds, str(snd["sound_id"]))
+		writeNoContent(w)
+	}
+}
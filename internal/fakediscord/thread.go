@@ -0,0 +1,339 @@
+package fakediscord
+
+import (
+	"net/http"
+	"strconv"
+)
+
+func (s *Server) threadRoutes() {
+	s.handle("POST /channels/{channel}/threads", s.withChannel(s.startThread))
+	s.handle("POST /channels/{channel}/messages/{message}/threads", s.withMessage(s.startThreadFromMessage))
+
+	s.handle("GET /channels/{channel}/thread-members", s.withThread(s.listThreadMembers))
+	s.handle("PUT /channels/{channel}/thread-members/{user}", s.withThread(s.addThreadMember))
+	s.handle("GET /channels/{channel}/thread-members/{user}", s.withThread(s.getThreadMember))
+	s.handle("DELETE /channels/{channel}/thread-members/{user}", s.withThread(s.removeThreadMember))
+}
+
+// withThread is withChannel for routes that only exist on threads.
+func (s *Server) withThread(h channelHandler) func(w http.ResponseWriter, r *http.Request) {
+	return s.withChannel(func(w http.ResponseWriter, r *http.Request, ch object) {
+		if !isThread(ch) {
+			writeError(w, http.StatusBadRequest, 50024, "Cannot execute action on this channel type")
+			return
+		}
+		h(w, r, ch)
+	})
+}
+
+func validateThread(in object, create bool) formErrors {
+	var errs formErrors
+	if create {
+		errs.requiredLength(in, "name", 1, 100)
+	} else {
+		errs.length(in, "name", 1, 100)
+	}
+	errs.oneOf(in, "auto_archive_duration", 60, 1440, 4320, 10080)
+	errs.integer(in, "rate_limit_per_user", 0, 21600)
+	errs.boolean(in, "archived")
+	errs.boolean(in, "locked")
+	errs.boolean(in, "invitable")
+	if v, ok := in["applied_tags"]; ok && v != nil {
+		if tags, isList := v.([]any); !isList || len(tags) > 5 {
+			errs.add("applied_tags", "BASE_TYPE_MAX_LENGTH", "Must be 5 or fewer in length.")
+		}
+	}
+	return errs
+}
+
+// newThread stores a thread under parent. id is the thread ID, which is the
+// starter message's ID for threads started from a message.
+func (s *Server) newThread(id string, parent object, typ int, in object) object {
+	autoArchive := num(parent["default_auto_archive_duration"])
+	if autoArchive == 0 {
+		autoArchive = 4320
+	}
+	meta := object{
+		"archived":              false,
+		"auto_archive_duration": autoArchive,
+		"archive_timestamp":     now(),
+		"locked":                false,
+		"create_timestamp":      now(),
+	}
+	if typ == typePrivThread {
+		meta["invitable"] = true
+	}
+	merge(meta, in, "auto_archive_duration", "invitable")
+
+	th := object{
+		"id":                  id,
+		"type":                typ,
+		"guild_id":            parent["guild_id"],
+		"parent_id":           parent["id"],
+		"owner_id":            s.botUser["id"],
+		"name":                in["name"],
+		"last_message_id":     nil,
+		"rate_limit_per_user": 0,
+		"message_count":       0,
+		"member_count":        1,
+		"total_message_sent":  0,
+		"thread_metadata":     meta,
+		"flags":               0,
+	}
+	if isForum(num(parent["type"])) {
+		th["applied_tags"] = []any{}
+	}
+	merge(th, in, "rate_limit_per_user", "applied_tags")
+	s.channels[id] = th
+	s.threadMems[id] = map[string]object{
+		str(s.botUser["id"]): s.newThreadMember(id, str(s.botUser["id"])),
+	}
+	return th
+}
+
+func (s *Server) newThreadMember(threadID, userID string) object {
+	return object{"id": threadID, "user_id": userID, "join_timestamp": now(), "flags": 0}
+}
+
+func (s *Server) startThread(w http.ResponseWriter, r *http.Request, parent object) {
+	in, ok := decodeBody(w, r)
+	if !ok {
+		return
+	}
+	errs := validateThread(in, true)
+	parentType := num(parent["type"])
+
+	typ := typePrivThread
+	switch {
+	case isForum(parentType):
+		typ = typePublicThread
+		msg, _ := in["message"].(object)
+		if msg == nil {
+			errs.required("message")
+		} else if isEmptyMessage(msg) {
+			writeError(w, http.StatusBadRequest, 50006, "Cannot send an empty message")
+			return
+		} else {
+			errs.nest("message", validateMessage(msg))
+		}
+		if tags, ok := in["applied_tags"].([]any); ok {
+			for i, t := range tags {
+				if !hasTag(parent, str(t)) {
+					errs.add("applied_tags."+strconv.Itoa(i), "TAG_INVALID", "Unknown tag "+str(t))
+				}
+			}
+		}
+	case parentType == typeText || parentType == typeAnnouncement:
+		if v, ok := in["type"]; ok && v != nil {
+			typ = num(v)
+		}
+		allowed := []int{typePublicThread, typePrivThread}
+		if parentType == typeAnnouncement {
+			allowed = []int{typeAnnThread}
+			if _, ok := in["type"]; !ok {
+				typ = typeAnnThread
+			}
+		}
+		errs.oneOf(object{"type": typ}, "type", allowed...)
+	default:
+		writeError(w, http.StatusBadRequest, 50024, "Cannot execute action on this channel type")
+		return
+	}
+	if errs.write(w) {
+		return
+	}
+
+	th := s.newThread(s.ids.next(), parent, typ, in)
+	if msg, ok := in["message"].(object); ok && isForum(parentType) {
+		// A forum post's starter message has the thread's ID.
+		starter := s.newMessage(th, s.botUser, msg)
+		delete(s.messages, str(starter["id"]))
+		starter["id"] = th["id"]
+		s.messages[str(th["id"])] = starter
+		th["last_message_id"] = th["id"]
+		th["message_count"] = 1
+		th["total_message_sent"] = 1
+	}
+	writeJSON(w, http.StatusCreated, th)
+}
+
+func hasTag(forum object, id string) bool {
+	tags, _ := forum["available_tags"].([]any)
+	for _, t := range tags {
+		if str(t.(object)["id"]) == id {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Server) startThreadFromMessage(w http.ResponseWriter, r *http.Request, parent, msg object) {
+	in, ok := decodeBody(w, r)
+	if !ok {
+		return
+	}
+	parentType := num(parent["type"])
+	if parentType != typeText && parentType != typeAnnouncement {
+		writeError(w, http.StatusBadRequest, 50024, "Cannot execute action on this channel type")
+		return
+	}
+	if errs := validateThread(in, true); errs.write(w) {
+		return
+	}
+	if _, exists := s.channels[str(msg["id"])]; exists {
+		writeError(w, http.StatusBadRequest, 160004, "A thread has already been created for this message")
+		return
+	}
+
+	typ := typePublicThread
+	if parentType == typeAnnouncement {
+		typ = typeAnnThread
+	}
+	delete(in, "invitable")
+	th := s.newThread(str(msg["id"]), parent, typ, in)
+	msg["thread"] = cloneObject(th)
+	writeJSON(w, http.StatusCreated, th)
+}
+
+// modifyThread handles PATCH /channels/{id} for threads.
+func (s *Server) modifyThread(w http.ResponseWriter, th, in object) {
+	errs := validateThread(in, false)
+	if tags, ok := in["applied_tags"].([]any); ok {
+		parent := s.channels[str(th["parent_id"])]
+		for i, t := range tags {
+			if parent == nil || !hasTag(parent, str(t)) {
+				errs.add("applied_tags."+strconv.Itoa(i), "TAG_INVALID", "Unknown tag "+str(t))
+			}
+		}
+	}
+	if errs.write(w) {
+		return
+	}
+
+	meta := th["thread_metadata"].(object)
+	if meta["archived"] == true && in["archived"] != false {
+		// Only unarchiving is allowed on an archived thread.
+		for k := range in {
+			if k != "archived" && k != "locked" {
+				writeError(w, http.StatusBadRequest, 50083, "Thread is archived")
+				return
+			}
+		}
+	}
+	if _, ok := in["invitable"]; ok && num(th["type"]) != typePrivThread {
+		delete(in, "invitable")
+	}
+
+	merge(th, in, "name", "rate_limit_per_user", "flags", "applied_tags")
+	if v, ok := in["archived"]; ok && v != meta["archived"] {
+		meta["archive_timestamp"] = now()
+	}
+	merge(meta, in, "archived", "auto_archive_duration", "locked", "invitable")
+	writeJSON(w, http.StatusOK, th)
+}
+
+// threadUserID resolves the {user} path value, where @me is the bot.
+func (s *Server) threadUserID(r *http.Request) string {
+	if id := r.PathValue("user"); id != "@me" {
+		return id
+	}
+	return str(s.botUser["id"])
+}
+
+func (s *Server) addThreadMember(w http.ResponseWriter, r *http.Request, th object) {
+	userID := s.threadUserID(r)
+	if _, ok := s.guilds[str(th["guild_id"])].members[userID]; !ok {
+		writeNotFound(w, 10007, "Member")
+		return
+	}
+	if meta := th["thread_metadata"].(object); meta["archived"] == true {
+		writeError(w, http.StatusBadRequest, 50083, "Thread is archived")
+		return
+	}
+	members := s.threadMems[str(th["id"])]
+	if _, ok := members[userID]; !ok {
+		members[userID] = s.newThreadMember(str(th["id"]), userID)
+		th["member_count"] = len(members)
+	}
+	writeNoContent(w)
+}
+
+func (s *Server) getThreadMember(w http.ResponseWriter, r *http.Request, th object) {
+	m, ok := s.threadMems[str(th["id"])][s.threadUserID(r)]
+	if !ok {
+		writeNotFound(w, 10007, "Member")
+		return
+	}
+	s.writeThreadMember(w, th, m, r.URL.Query().Get("with_member") == "true")
+}
+
+func (s *Server) writeThreadMember(w http.ResponseWriter, th, m object, withMember bool) {
+	writeJSON(w, http.StatusOK, s.threadMemberJSON(th, m, withMember))
+}
+
+func (s *Server) threadMemberJSON(th, m object, withMember bool) object {
+	out := cloneObject(m)
+	if withMember {
+		if gm, ok := s.guilds[str(th["guild_id"])].members[str(m["user_id"])]; ok {
+			out["member"] = s.memberJSON(gm)
+		}
+	}
+	return out
+}
+
+func (s *Server) removeThreadMember(w http.ResponseWriter, r *http.Request, th object) {
+	members := s.threadMems[str(th["id"])]
+	userID := s.threadUserID(r)
+	if _, ok := members[userID]; !ok {
+		writeNotFound(w, 10007, "Member")
+		return
+	}
+	delete(members, userID)
+	th["member_count"] = len(members)
+	writeNoContent(w)
+}
+
+func (s *Server) listThreadMembers(w http.ResponseWriter, r *http.Request, th object) {
+	q := r.URL.Query()
+	limit := 100
+	if v := q.Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > 100 {
+			var errs formErrors
+			errs.add("limit", "NUMBER_TYPE_MAX", "Int value should be between 1 and 100.")
+			errs.write(w)
+			return
+		}
+		limit = n
+	}
+	after := q.Get("after")
+	withMember := q.Get("with_member") == "true"
+
+	out := []any{}
+	for _, m := range valuesByID(indexBy(s.threadMems[str(th["id"])], "user_id")) {
+		if len(out) == limit {
+			break
+		}
+		if after != "" && !snowflakeLess(after, str(m.(object)["user_id"])) {
+			continue
+		}
+		out = append(out, s.threadMemberJSON(th, m.(object), withMember))
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+// indexBy re-keys objects by one of their fields so valuesByID sorts by it.
+func indexBy(m map[string]object, field string) map[string]object {
+	out := make(map[string]object, len(m))
+	for _, o := range m {
+		out[str(o[field])] = o
+	}
+	return out
+}
+
+func snowflakeLess(a, b string) bool {
+	if len(a) != len(b) {
+		return len(a) < len(b)
+	}
+	return a < b
+}
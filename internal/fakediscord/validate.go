@@ -0,0 +1,194 @@
+package fakediscord
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// formErrors collects field errors for a 50035 Invalid Form Body response.
+type formErrors struct {
+	tree   object
+	prefix string
+}
+
+// at returns a view that records errors below a nested field, e.g. "embeds.0".
+func (e *formErrors) at(field string) *formErrors {
+	if e.tree == nil {
+		e.tree = object{}
+	}
+	return &formErrors{tree: e.tree, prefix: e.prefix + field + "."}
+}
+
+// add records an error for a dotted field path such as "embeds.0.title".
+func (e *formErrors) add(field, code, message string) {
+	if e.tree == nil {
+		e.tree = object{}
+	}
+	node := e.tree
+	for _, part := range strings.Split(e.prefix+field, ".") {
+		next, ok := node[part].(object)
+		if !ok {
+			next = object{}
+			node[part] = next
+		}
+		node = next
+	}
+	leaves, _ := node["_errors"].([]any)
+	node["_errors"] = append(leaves, object{"code": code, "message": message})
+}
+
+// nest records the errors of a nested object's own validation below field.
+func (e *formErrors) nest(field string, sub formErrors) {
+	if len(sub.tree) == 0 {
+		return
+	}
+	if e.tree == nil {
+		e.tree = object{}
+	}
+	node := e.tree
+	parts := strings.Split(e.prefix+field, ".")
+	for _, part := range parts[:len(parts)-1] {
+		next, ok := node[part].(object)
+		if !ok {
+			next = object{}
+			node[part] = next
+		}
+		node = next
+	}
+	node[parts[len(parts)-1]] = sub.tree
+}
+
+func (e *formErrors) required(field string) {
+	e.add(field, "BASE_TYPE_REQUIRED", "This field is required")
+}
+
+// length checks the length of an optional string field. Absent and null values pass.
+func (e *formErrors) length(in object, field string, min, max int) {
+	v, ok := in[field]
+	if !ok || v == nil {
+		return
+	}
+	s, isString := v.(string)
+	if !isString {
+		e.add(field, "BASE_TYPE_STRING", "Must be a string.")
+		return
+	}
+	if n := utf8.RuneCountInString(s); n < min || n > max {
+		e.add(field, "BASE_TYPE_BAD_LENGTH", fmt.Sprintf("Must be between %d and %d in length.", min, max))
+	}
+}
+
+// requiredLength is length for a field that must be present.
+func (e *formErrors) requiredLength(in object, field string, min, max int) {
+	if v, ok := in[field]; !ok || v == nil {
+		e.required(field)
+		return
+	}
+	e.length(in, field, min, max)
+}
+
+// integer checks an optional integer field against a range.
+func (e *formErrors) integer(in object, field string, min, max int) {
+	v, ok := in[field]
+	if !ok || v == nil {
+		return
+	}
+	n, isInt := intValue(v)
+	if !isInt {
+		e.add(field, "NUMBER_TYPE_COERCE", fmt.Sprintf("Value %q is not int.", fmt.Sprint(v)))
+		return
+	}
+	if n < min {
+		e.add(field, "NUMBER_TYPE_MIN", fmt.Sprintf("Int value should be greater than or equal to %d.", min))
+	}
+	if n > max {
+		e.add(field, "NUMBER_TYPE_MAX", fmt.Sprintf("Int value should be less than or equal to %d.", max))
+	}
+}
+
+// oneOf checks an optional integer field against a set of allowed values.
+func (e *formErrors) oneOf(in object, field string, allowed ...int) {
+	v, ok := in[field]
+	if !ok || v == nil {
+		return
+	}
+	n, _ := intValue(v)
+	for _, a := range allowed {
+		if n == a {
+			return
+		}
+	}
+	e.add(field, "BASE_TYPE_CHOICES", fmt.Sprintf("Value must be one of %v.", allowed))
+}
+
+// boolean checks an optional boolean field.
+func (e *formErrors) boolean(in object, field string) {
+	v, ok := in[field]
+	if !ok || v == nil {
+		return
+	}
+	if _, isBool := v.(bool); !isBool {
+		e.add(field, "BASE_TYPE_BOOLEAN", "Must be either true or false.")
+	}
+}
+
+// snowflake checks an optional snowflake field.
+func (e *formErrors) snowflake(in object, field string) {
+	v, ok := in[field]
+	if !ok || v == nil {
+		return
+	}
+	if !isSnowflake(str(v)) {
+		e.add(field, "NUMBER_TYPE_COERCE", fmt.Sprintf("Value %q is not snowflake.", fmt.Sprint(v)))
+	}
+}
+
+// timestamp checks an optional ISO8601 timestamp field and returns the parsed value.
+func (e *formErrors) timestamp(in object, field string) (time.Time, bool) {
+	v, ok := in[field]
+	if !ok || v == nil {
+		return time.Time{}, false
+	}
+	t, err := time.Parse(time.RFC3339, str(v))
+	if err != nil {
+		e.add(field, "DATE_TIME_TYPE_PARSE", fmt.Sprintf("Could not parse %s. Should be ISO8601.", fmt.Sprint(v)))
+		return time.Time{}, false
+	}
+	return t, true
+}
+
+// permissions checks an optional permission bit set sent as a decimal string.
+func (e *formErrors) permissions(in object, field string) {
+	v, ok := in[field]
+	if !ok || v == nil {
+		return
+	}
+	if _, err := strconv.ParseUint(str(v), 10, 64); err != nil {
+		e.add(field, "NUMBER_TYPE_COERCE", fmt.Sprintf("Value %q is not int.", fmt.Sprint(v)))
+	}
+}
+
+// write sends the 50035 response and returns true if any error was recorded.
+func (e *formErrors) write(w http.ResponseWriter) bool {
+	if len(e.tree) == 0 {
+		return false
+	}
+	writeJSON(w, http.StatusBadRequest, object{
+		"code":    50035,
+		"message": "Invalid Form Body",
+		"errors":  e.tree,
+	})
+	return true
+}
+
+func isSnowflake(s string) bool {
+	if len(s) < 17 || len(s) > 20 {
+		return false
+	}
+	_, err := strconv.ParseUint(s, 10, 64)
+	return err == nil
+}
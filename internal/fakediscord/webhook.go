@@ -0,0 +1,135 @@
+package fakediscord
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"strings"
+)
+
+func (s *Server) webhookRoutes() {
+	s.handle("GET /channels/{channel}/webhooks", s.withChannel(s.listChannelWebhooks))
+	s.handle("POST /channels/{channel}/webhooks", s.withChannel(s.createWebhook))
+
+	s.handle("GET /webhooks/{webhook}", s.withWebhook(s.getWebhook))
+	s.handle("PATCH /webhooks/{webhook}", s.withWebhook(s.modifyWebhook))
+	s.handle("DELETE /webhooks/{webhook}", s.withWebhook(s.deleteWebhook))
+}
+
+type webhookHandler func(w http.ResponseWriter, r *http.Request, wh object)
+
+// withWebhook resolves the {webhook} path value or answers 10015 Unknown Webhook.
+func (s *Server) withWebhook(h webhookHandler) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		wh, ok := s.webhooks[r.PathValue("webhook")]
+		if !ok {
+			writeNotFound(w, 10015, "Webhook")
+			return
+		}
+		h(w, r, wh)
+	}
+}
+
+// validateWebhook checks a webhook create or modify body. Discord rejects names
+// that contain "discord" or "clyde".
+func (s *Server) validateWebhook(in object, create bool) formErrors {
+	var errs formErrors
+	if create {
+		errs.requiredLength(in, "name", 1, 80)
+	} else {
+		errs.length(in, "name", 1, 80)
+	}
+	if name := strings.ToLower(str(in["name"])); strings.Contains(name, "discord") || strings.Contains(name, "clyde") {
+		errs.add("name", "WEBHOOK_INVALID_USERNAME", "Username cannot contain \"discord\" or \"clyde\"")
+	}
+	checkImage(&errs, in, "avatar")
+	return errs
+}
+
+func (s *Server) createWebhook(w http.ResponseWriter, r *http.Request, ch object) {
+	if isThread(ch) || num(ch["type"]) == typeCategory {
+		writeError(w, http.StatusBadRequest, 50024, "Cannot execute action on this channel type")
+		return
+	}
+	in, ok := decodeBody(w, r)
+	if !ok {
+		return
+	}
+	if errs := s.validateWebhook(in, true); errs.write(w) {
+		return
+	}
+	count := 0
+	for _, wh := range s.webhooks {
+		if str(wh["channel_id"]) == str(ch["id"]) {
+			count++
+		}
+	}
+	if count >= 15 {
+		writeError(w, http.StatusBadRequest, 30007, "Maximum number of webhooks reached (15)")
+		return
+	}
+
+	id := s.ids.next()
+	token := randomToken()
+	wh := object{
+		"id":             id,
+		"type":           1,
+		"guild_id":       ch["guild_id"],
+		"channel_id":     ch["id"],
+		"user":           cloneObject(s.botUser),
+		"name":           in["name"],
+		"avatar":         imageHash(in["avatar"]),
+		"token":          token,
+		"application_id": nil,
+		"url":            "https://discord.com/api/webhooks/" + id + "/" + token,
+	}
+	s.webhooks[id] = wh
+	writeJSON(w, http.StatusOK, wh)
+}
+
+func (s *Server) listChannelWebhooks(w http.ResponseWriter, r *http.Request, ch object) {
+	var out []object
+	for _, wh := range s.webhooks {
+		if str(wh["channel_id"]) == str(ch["id"]) {
+			out = append(out, wh)
+		}
+	}
+	writeJSON(w, http.StatusOK, valuesByID(indexByID(out)))
+}
+
+func (s *Server) getWebhook(w http.ResponseWriter, r *http.Request, wh object) {
+	writeJSON(w, http.StatusOK, wh)
+}
+
+func (s *Server) modifyWebhook(w http.ResponseWriter, r *http.Request, wh object) {
+	in, ok := decodeBody(w, r)
+	if !ok {
+		return
+	}
+	errs := s.validateWebhook(in, false)
+	if v, ok := in["channel_id"]; ok && v != nil {
+		ch, found := s.channels[str(v)]
+		if !found || str(ch["guild_id"]) != str(wh["guild_id"]) || isThread(ch) || num(ch["type"]) == typeCategory {
+			errs.add("channel_id", "CHANNEL_INVALID", "Unknown channel "+str(v))
+		}
+	}
+	if errs.write(w) {
+		return
+	}
+	merge(wh, in, "name", "channel_id")
+	if v, ok := in["avatar"]; ok {
+		wh["avatar"] = imageHash(v)
+	}
+	writeJSON(w, http.StatusOK, wh)
+}
+
+func (s *Server) deleteWebhook(w http.ResponseWriter, r *http.Request, wh object) {
+	delete(s.webhooks, str(wh["id"]))
+	writeNoContent(w)
+}
+
+func randomToken() string {
+	b := make([]byte, 34)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
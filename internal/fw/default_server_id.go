@@ -0,0 +1,57 @@
+package fw
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// serverIDDescription documents the server_id of guild-scoped resources and
+// data sources, which falls back to the provider's default_server_id.
+const serverIDDescription = "ID of the server (guild). Defaults to the provider's `default_server_id`."
+
+// planServerID fills in a server_id left unset in the configuration with the
+// provider's default_server_id, so that the plan shows the server it applies to.
+// server_id keeps its prior state through UseStateForUnknown; when replace is
+// set, a change of the default then replaces the resource like an edited
+// server_id does.
+func planServerID(ctx context.Context, defaultServerID string, replace bool, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		// Destroy plan.
+		return
+	}
+	var configured types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("server_id"), &configured)...)
+	if resp.Diagnostics.HasError() || !configured.IsNull() {
+		return
+	}
+	if defaultServerID == "" {
+		resp.Diagnostics.AddAttributeError(path.Root("server_id"), "Missing server_id", "Set server_id, or default_server_id in the provider configuration.")
+		return
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("server_id"), defaultServerID)...)
+
+	if !replace || req.State.Raw.IsNull() {
+		return
+	}
+	var prior types.String
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("server_id"), &prior)...)
+	if !prior.IsNull() && prior.ValueString() != defaultServerID {
+		resp.RequiresReplace.Append(path.Root("server_id"))
+	}
+}
+
+// serverIDOrDefault returns the server_id a data source reads from: the
+// configured one, or the provider's default_server_id.
+func serverIDOrDefault(configured types.String, defaultServerID string, diags *diag.Diagnostics) string {
+	if !configured.IsNull() && configured.ValueString() != "" {
+		return configured.ValueString()
+	}
+	if defaultServerID == "" {
+		diags.AddAttributeError(path.Root("server_id"), "Missing server_id", "Set server_id, or default_server_id in the provider configuration.")
+	}
+	return defaultServerID
+}
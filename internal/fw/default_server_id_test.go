@@ -0,0 +1,107 @@
+package fw
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestDefaultServerID_Fake(t *testing.T) {
+	e := newFakeEnv(t)
+	otherID := e.srv.AddGuild("tf-test-other")
+	const body = `
+resource "discord_role" "mod" {
+  name = "moderator"
+}
+
+data "discord_role" "mod" {
+  role_id = discord_role.mod.id
+}
+`
+	// roleIn fails unless the moderator role lives in guildID and only there.
+	roleIn := func(guildID, otherID string) resource.TestCheckFunc {
+		return func(s *terraform.State) error {
+			for _, g := range []string{guildID, otherID} {
+				roles, err := e.client().GetGuildRoles(context.Background(), g)
+				if err != nil {
+					return err
+				}
+				found := false
+				for _, r := range roles {
+					found = found || r.Name == "moderator"
+				}
+				if found != (g == guildID) {
+					return fmt.Errorf("moderator role in %s: %v", g, found)
+				}
+			}
+			return nil
+		}
+	}
+
+	e.defaultServerID = e.guildID
+	first := e.config(body)
+	// Changing the default moves the role, as editing server_id would.
+	e.defaultServerID = otherID
+	second := e.config(body)
+
+	e.test(t, resource.TestCase{
+		Steps: []resource.TestStep{
+			{
+				Config: first,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("discord_role.mod", "server_id", e.guildID),
+					resource.TestCheckResourceAttr("data.discord_role.mod", "server_id", e.guildID),
+					roleIn(e.guildID, otherID),
+				),
+			},
+			{
+				Config: second,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("discord_role.mod", "server_id", otherID),
+					resource.TestCheckResourceAttr("data.discord_role.mod", "server_id", otherID),
+					roleIn(otherID, e.guildID),
+				),
+			},
+			{
+				// An explicit server_id wins over the default.
+				Config: e.config(`
+resource "discord_role" "mod" {
+  server_id = %q
+  name      = "moderator"
+}
+`, e.guildID),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("discord_role.mod", "server_id", e.guildID),
+					roleIn(e.guildID, otherID),
+				),
+			},
+		},
+	})
+}
+
+func TestDefaultServerID_FakeMissing(t *testing.T) {
+	e := newFakeEnv(t)
+
+	e.test(t, resource.TestCase{
+		Steps: []resource.TestStep{
+			{
+				Config: e.config(`
+resource "discord_role" "mod" {
+  name = "moderator"
+}
+`),
+				ExpectError: regexp.MustCompile(`Missing server_id`),
+			},
+			{
+				Config: e.config(`
+data "discord_system_channel" "s" {}
+`),
+				ExpectError: regexp.MustCompile(`Missing server_id`),
+			},
+		},
+	})
+}
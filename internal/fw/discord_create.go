@@ -0,0 +1,127 @@
+package fw
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/45ck/terraform-provider-discord/discord"
+)
+
+// createClockSkew is how far Discord's clock may be behind ours when matching a
+// snowflake's creation time against the start of a create.
+const createClockSkew = 5 * time.Second
+
+// readAfterCreateBackoff are the waits between reads of an object that is not
+// visible yet. Discord is eventually consistent and can answer 404 right after a
+// create; taking that as "gone" would put a null ID in state and orphan the object.
+var readAfterCreateBackoff = []time.Duration{250 * time.Millisecond, 500 * time.Millisecond, time.Second, 2 * time.Second}
+
+// readAfterCreate calls read, which reports whether the object was found, until
+// it is found or the backoff runs out. what names the object in the error. read
+// gets a context that bypasses the read cache.
+func readAfterCreate(ctx context.Context, diags discordFrameworkDiagnostics, what, id string, read func(ctx context.Context) bool) {
+	fresh := discord.WithFreshReads(ctx)
+	for attempt := 0; ; attempt++ {
+		if read(fresh) || diags.HasError() {
+			return
+		}
+		if attempt == len(readAfterCreateBackoff) {
+			diags.AddError("Discord API error", fmt.Sprintf("%s %s was created but Discord keeps reporting it as not found. It may need to be imported.", what, id))
+			return
+		}
+		t := time.NewTimer(readAfterCreateBackoff[attempt])
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			diags.AddError("Discord API error", ctx.Err().Error())
+			return
+		case <-t.C:
+		}
+	}
+}
+
+// createTracker records the creates this provider process has under way and
+// the IDs its creates ended up with, so that recovering one create never adopts
+// an object that belongs to another resource of the same apply.
+type createTracker struct {
+	mu sync.Mutex
+	// pending counts unfinished creates per key.
+	pending map[string]int
+	// owned are the IDs creates have returned or recovered.
+	owned map[string]bool
+}
+
+var creates = &createTracker{pending: map[string]int{}, owned: map[string]bool{}}
+
+// pendingCreate is one create in progress.
+type pendingCreate struct {
+	t       *createTracker
+	key     string
+	started time.Time
+}
+
+// beginCreate registers a create. key describes what the create asks for (the
+// kind of object, where and the planned fields recovery matches on), so that
+// creates with equal plans can tell each other apart. Call end when done.
+func beginCreate(key ...any) *pendingCreate {
+	p := &pendingCreate{t: creates, key: fmt.Sprintf("%q", key), started: time.Now()}
+	p.t.mu.Lock()
+	p.t.pending[p.key]++
+	p.t.mu.Unlock()
+	return p
+}
+
+// created records id as owned by this create.
+func (p *pendingCreate) created(id string) {
+	p.t.mu.Lock()
+	p.t.owned[id] = true
+	p.t.mu.Unlock()
+}
+
+func (p *pendingCreate) end() {
+	p.t.mu.Lock()
+	if p.t.pending[p.key]--; p.t.pending[p.key] <= 0 {
+		delete(p.t.pending, p.key)
+	}
+	p.t.mu.Unlock()
+}
+
+// recover looks for the object a failed create may have made anyway. A 5xx or a
+// connection dropped after sending doesn't say whether Discord applied the
+// create, and creates are not retried because that could duplicate the object.
+// find lists the IDs of objects matching the plan; the single one created since
+// the create started that no other create owns is adopted. When another object
+// could be it, or another create with the same plan is under way, nothing is
+// adopted and the error says so. Errors that are not ambiguous are returned as
+// they are.
+func (p *pendingCreate) recover(ctx context.Context, err error, find func(context.Context) ([]string, error)) (string, error) {
+	if !discord.IsAmbiguousWriteError(err) {
+		return "", err
+	}
+	ids, ferr := find(ctx)
+	if ferr != nil {
+		return "", err
+	}
+
+	p.t.mu.Lock()
+	defer p.t.mu.Unlock()
+	var found []string
+	for _, id := range ids {
+		created, ok := discord.SnowflakeTime(id)
+		if !ok || created.Before(p.started.Add(-createClockSkew)) || p.t.owned[id] {
+			continue
+		}
+		found = append(found, id)
+	}
+	switch {
+	case len(found) == 0:
+		return "", err
+	case len(found) > 1 || p.t.pending[p.key] > 1:
+		return "", fmt.Errorf("%w\n\nDiscord may have applied the create anyway, but the matching new object (%s) could also belong to another resource with the same settings in this apply, so it was not adopted. Check for a duplicate and import it if it belongs to this resource", err, strings.Join(found, ", "))
+	}
+	p.t.owned[found[0]] = true
+	return found[0], nil
+}
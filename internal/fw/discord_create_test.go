@@ -0,0 +1,93 @@
+package fw
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/45ck/terraform-provider-discord/discord"
+)
+
+// newSnowflake returns a snowflake created at t; n tells apart IDs of the
+// same millisecond.
+func newSnowflake(t time.Time, n uint64) string {
+	return strconv.FormatUint(uint64(t.UnixMilli()-discord.DiscordEpoch)<<22|n, 10)
+}
+
+func TestPendingCreate_Recover(t *testing.T) {
+	ctx := context.Background()
+	bad := &discord.DiscordHTTPError{Method: "POST", Path: "/guilds/1/roles", StatusCode: 502, Message: "Bad Gateway"}
+	list := func(ids ...string) func(context.Context) ([]string, error) {
+		return func(context.Context) ([]string, error) { return ids, nil }
+	}
+	now := time.Now()
+	old := newSnowflake(now.Add(-time.Hour), 1)
+
+	t.Run("adopts the one new match", func(t *testing.T) {
+		c := beginCreate(t.Name())
+		defer c.end()
+		fresh := newSnowflake(now, 2)
+		id, err := c.recover(ctx, bad, list(old, fresh))
+		if err != nil || id != fresh {
+			t.Fatalf("recover = %q, %v; want %s", id, err, fresh)
+		}
+	})
+
+	t.Run("keeps errors Discord did not apply", func(t *testing.T) {
+		c := beginCreate(t.Name())
+		defer c.end()
+		denied := &discord.DiscordHTTPError{Method: "POST", Path: "/guilds/1/roles", StatusCode: 400, Message: "Invalid Form Body"}
+		if _, err := c.recover(ctx, denied, list(newSnowflake(now, 3))); !errors.Is(err, denied) {
+			t.Fatalf("recover = %v, want the original error", err)
+		}
+	})
+
+	t.Run("skips objects other creates own", func(t *testing.T) {
+		sibling := beginCreate(t.Name())
+		theirs := newSnowflake(now, 4)
+		sibling.created(theirs)
+		sibling.end()
+
+		c := beginCreate(t.Name())
+		defer c.end()
+		if id, err := c.recover(ctx, bad, list(theirs)); err == nil || id != "" {
+			t.Fatalf("recover = %q, %v; want the original error", id, err)
+		}
+	})
+
+	t.Run("refuses when a sibling with the same plan is under way", func(t *testing.T) {
+		sibling := beginCreate(t.Name())
+		defer sibling.end()
+
+		c := beginCreate(t.Name())
+		defer c.end()
+		fresh := newSnowflake(now, 5)
+		_, err := c.recover(ctx, bad, list(fresh))
+		if err == nil || !errors.Is(err, bad) || !strings.Contains(err.Error(), fresh) {
+			t.Fatalf("recover = %v, want an error naming %s", err, fresh)
+		}
+	})
+
+	t.Run("refuses more than one match", func(t *testing.T) {
+		c := beginCreate(t.Name())
+		defer c.end()
+		a, b := newSnowflake(now, 6), newSnowflake(now, 7)
+		if id, err := c.recover(ctx, bad, list(a, b)); err == nil || id != "" {
+			t.Fatalf("recover = %q, %v; want an error", id, err)
+		}
+	})
+
+	t.Run("a finished sibling does not block", func(t *testing.T) {
+		beginCreate(t.Name()).end()
+
+		c := beginCreate(t.Name())
+		defer c.end()
+		fresh := newSnowflake(now, 8)
+		if id, err := c.recover(ctx, bad, list(fresh)); err != nil || id != fresh {
+			t.Fatalf("recover = %q, %v; want %s", id, err, fresh)
+		}
+	})
+}
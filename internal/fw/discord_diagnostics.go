@@ -0,0 +1,66 @@
+package fw
+
+import (
+	"strings"
+
+	"github.com/45ck/terraform-provider-discord/discord"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+// discordFieldPaths maps Discord request field names to schema paths for
+// addDiscordAPIError. Keys are dotted JSON paths ("name", "embeds.0.title").
+type discordFieldPaths map[string]path.Path
+
+// sameNameFieldPaths maps each top-level JSON field to the root attribute of the same name.
+func sameNameFieldPaths(names ...string) discordFieldPaths {
+	out := make(discordFieldPaths, len(names))
+	for _, n := range names {
+		out[n] = path.Root(n)
+	}
+	return out
+}
+
+// lookup finds the schema path for a Discord field, falling back to its closest mapped
+// parent (e.g. "embeds.0.title" -> "embeds").
+func (m discordFieldPaths) lookup(field string) (path.Path, bool) {
+	for field != "" {
+		if p, ok := m[field]; ok {
+			return p, true
+		}
+		i := strings.LastIndexByte(field, '.')
+		if i < 0 {
+			break
+		}
+		field = field[:i]
+	}
+	return path.Empty(), false
+}
+
+// addDiscordAPIError reports a Discord API error. Field errors Discord returns for
+// mapped fields become attribute errors on the matching schema path, so Terraform
+// points at the offending line of configuration; everything else is reported as a
+// single "Discord API error" diagnostic including the hint for the error code.
+func addDiscordAPIError(diags *diag.Diagnostics, err error, fields discordFieldPaths) {
+	httpErr, ok := discord.AsDiscordHTTPError(err)
+	if !ok || len(httpErr.Errors) == 0 || len(fields) == 0 {
+		diags.AddError("Discord API error", err.Error())
+		return
+	}
+
+	var unmapped []discord.DiscordFieldError
+	for _, fe := range httpErr.Errors {
+		p, ok := fields.lookup(fe.Field)
+		if !ok {
+			unmapped = append(unmapped, fe)
+			continue
+		}
+		diags.AddAttributeError(p, "Discord API error", fe.String())
+	}
+
+	if len(unmapped) > 0 {
+		rest := *httpErr
+		rest.Errors = unmapped
+		diags.AddError("Discord API error", rest.Error())
+	}
+}
@@ -0,0 +1,67 @@
+package fw
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/45ck/terraform-provider-discord/discord"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+func TestAddDiscordAPIError_MapsFieldErrorsToAttributes(t *testing.T) {
+	err := &discord.DiscordHTTPError{
+		Method:     "POST",
+		Path:       "/guilds/1/channels",
+		StatusCode: 400,
+		Code:       50035,
+		Message:    "Invalid Form Body",
+		Errors: []discord.DiscordFieldError{
+			{Field: "name", Code: "BASE_TYPE_MAX_LENGTH", Message: "Must be 100 or fewer in length."},
+			{Field: "available_tags.0.name", Code: "BASE_TYPE_REQUIRED", Message: "This field is required"},
+			{Field: "unknown_field", Code: "X", Message: "Unmapped"},
+		},
+	}
+
+	var diags diag.Diagnostics
+	addDiscordAPIError(&diags, err, channelFieldPaths)
+
+	if diags.ErrorsCount() != 3 {
+		t.Fatalf("expected 3 diagnostics, got %d: %v", diags.ErrorsCount(), diags)
+	}
+
+	attrPaths := map[string]bool{}
+	var general []string
+	for _, d := range diags {
+		if wp, ok := d.(diag.DiagnosticWithPath); ok {
+			attrPaths[wp.Path().String()] = true
+			continue
+		}
+		general = append(general, d.Detail())
+	}
+	if !attrPaths[path.Root("name").String()] || !attrPaths[path.Root("available_tag").String()] {
+		t.Fatalf("expected attribute errors on name and available_tag, got %v", attrPaths)
+	}
+	if len(general) != 1 || !strings.Contains(general[0], "unknown_field: Unmapped") || strings.Contains(general[0], "name: Must be") {
+		t.Fatalf("expected only the unmapped field in the general error, got %q", general)
+	}
+}
+
+func TestAddDiscordAPIError_PlainErrors(t *testing.T) {
+	var diags diag.Diagnostics
+	addDiscordAPIError(&diags, errors.New("boom"), roleFieldPaths)
+	addDiscordAPIError(&diags, &discord.DiscordHTTPError{StatusCode: 403, Code: 50013, Message: "Missing Permissions"}, roleFieldPaths)
+
+	if diags.ErrorsCount() != 2 {
+		t.Fatalf("expected 2 diagnostics, got %d", diags.ErrorsCount())
+	}
+	for _, d := range diags {
+		if _, ok := d.(diag.DiagnosticWithPath); ok {
+			t.Fatalf("did not expect attribute diagnostics without field errors")
+		}
+	}
+	if !strings.Contains(diags[1].Detail(), "Hint:") {
+		t.Fatalf("expected hint in detail, got %q", diags[1].Detail())
+	}
+}
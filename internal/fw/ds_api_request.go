@@ -7,8 +7,10 @@ import (
 	"strconv"
 
 	"github.com/45ck/terraform-provider-discord/discord"
+	"github.com/45ck/terraform-provider-discord/internal/fw/validate"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -24,6 +26,7 @@ type apiRequestModel struct {
 	ID           types.String `tfsdk:"id"`
 	Path         types.String `tfsdk:"path"`
 	QueryJSON    types.String `tfsdk:"query_json"`
+	Auth         types.String `tfsdk:"auth"`
 	ResponseJSON types.String `tfsdk:"response_json"`
 }
 
@@ -45,6 +48,13 @@ func (d *apiRequestDataSource) Schema(_ context.Context, _ datasource.SchemaRequ
 				Optional:    true,
 				Description: "JSON object encoded query params. Example: jsonencode({ limit = 100 })",
 			},
+			"auth": schema.StringAttribute{
+				Optional:    true,
+				Description: "Authorization: \"bot\" (default) or \"bearer\", the provider's bearer_token or else an OAuth2 token from its client_id and secret.",
+				Validators: []validator.String{
+					validate.OneOf("BOT", "BEARER"),
+				},
+			},
 			"response_json": schema.StringAttribute{
 				Computed:    true,
 				Description: "Normalized JSON response body.",
@@ -103,7 +113,7 @@ func (d *apiRequestDataSource) Read(ctx context.Context, req datasource.ReadRequ
 	}
 
 	var out interface{}
-	if err := d.c.DoJSON(ctx, "GET", pathStr, query, nil, &out); err != nil {
+	if err := d.c.DoJSON(withAPIAuth(ctx, data.Auth), "GET", pathStr, query, nil, &out); err != nil {
 		resp.Diagnostics.AddError("Discord API error", err.Error())
 		return
 	}
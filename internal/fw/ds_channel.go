@@ -17,14 +17,8 @@ func NewChannelDataSource() datasource.DataSource {
 }
 
 type channelDataSource struct {
-	c *discord.RestClient
-}
-
-type restChannelLite struct {
-	ID       string `json:"id"`
-	Name     string `json:"name"`
-	Type     uint   `json:"type"`
-	ParentID string `json:"parent_id"`
+	c               *discord.RestClient
+	defaultServerID string
 }
 
 type channelModel struct {
@@ -44,7 +38,9 @@ func (d *channelDataSource) Schema(_ context.Context, _ datasource.SchemaRequest
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{Computed: true},
 			"server_id": schema.StringAttribute{
-				Required: true,
+				Optional:    true,
+				Computed:    true,
+				Description: serverIDDescription,
 				Validators: []validator.String{
 					validate.Snowflake(),
 				},
@@ -66,6 +62,7 @@ func (d *channelDataSource) Configure(ctx context.Context, req datasource.Config
 		return
 	}
 	d.c = c.Rest
+	d.defaultServerID = c.Config.DefaultServerID
 }
 
 func (d *channelDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -75,17 +72,21 @@ func (d *channelDataSource) Read(ctx context.Context, req datasource.ReadRequest
 		return
 	}
 
-	serverID := data.ServerID.ValueString()
+	serverID := serverIDOrDefault(data.ServerID, d.defaultServerID, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.ServerID = types.StringValue(serverID)
 	name := data.Name.ValueString()
 	wantType := data.Type.ValueString()
 
-	var channels []restChannelLite
-	if err := d.c.DoJSON(ctx, "GET", fmt.Sprintf("/guilds/%s/channels", serverID), nil, nil, &channels); err != nil {
+	channels, err := d.c.ListGuildChannels(ctx, serverID)
+	if err != nil {
 		resp.Diagnostics.AddError("Discord API error", err.Error())
 		return
 	}
 
-	var matches []restChannelLite
+	var matches []discord.Channel
 	for _, ch := range channels {
 		if ch.Name != name {
 			continue
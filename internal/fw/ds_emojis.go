@@ -2,7 +2,6 @@ package fw
 
 import (
 	"context"
-	"fmt"
 
 	"github.com/45ck/terraform-provider-discord/discord"
 	"github.com/45ck/terraform-provider-discord/internal/fw/validate"
@@ -17,14 +16,8 @@ func NewEmojisDataSource() datasource.DataSource {
 }
 
 type emojisDataSource struct {
-	c *discord.RestClient
-}
-
-type restEmojiLite struct {
-	ID       string `json:"id"`
-	Name     string `json:"name"`
-	Managed  bool   `json:"managed"`
-	Animated bool   `json:"animated"`
+	c               *discord.RestClient
+	defaultServerID string
 }
 
 type emojiModel struct {
@@ -49,7 +42,9 @@ func (d *emojisDataSource) Schema(_ context.Context, _ datasource.SchemaRequest,
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{Computed: true},
 			"server_id": schema.StringAttribute{
-				Required: true,
+				Optional:    true,
+				Computed:    true,
+				Description: serverIDDescription,
 				Validators: []validator.String{
 					validate.Snowflake(),
 				},
@@ -76,6 +71,7 @@ func (d *emojisDataSource) Configure(ctx context.Context, req datasource.Configu
 		return
 	}
 	d.c = c.Rest
+	d.defaultServerID = c.Config.DefaultServerID
 }
 
 func (d *emojisDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -85,9 +81,13 @@ func (d *emojisDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 		return
 	}
 
-	serverID := data.ServerID.ValueString()
-	var out []restEmojiLite
-	if err := d.c.DoJSON(ctx, "GET", fmt.Sprintf("/guilds/%s/emojis", serverID), nil, nil, &out); err != nil {
+	serverID := serverIDOrDefault(data.ServerID, d.defaultServerID, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.ServerID = types.StringValue(serverID)
+	out, err := d.c.ListGuildEmojis(ctx, serverID)
+	if err != nil {
 		resp.Diagnostics.AddError("Discord API error", err.Error())
 		return
 	}
@@ -16,22 +16,8 @@ func NewMemberDataSource() datasource.DataSource {
 }
 
 type memberDataSource struct {
-	c *discord.RestClient
-}
-
-type restUserDS struct {
-	ID            string `json:"id"`
-	Username      string `json:"username"`
-	Discriminator string `json:"discriminator"`
-	Avatar        string `json:"avatar"`
-}
-
-type restMemberDS struct {
-	User         restUserDS `json:"user"`
-	Nick         string     `json:"nick"`
-	Roles        []string   `json:"roles"`
-	JoinedAt     string     `json:"joined_at"`
-	PremiumSince string     `json:"premium_since"`
+	c               *discord.RestClient
+	defaultServerID string
 }
 
 type memberModel struct {
@@ -57,7 +43,9 @@ func (d *memberDataSource) Schema(_ context.Context, _ datasource.SchemaRequest,
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{Computed: true},
 			"server_id": schema.StringAttribute{
-				Required: true,
+				Optional:    true,
+				Computed:    true,
+				Description: serverIDDescription,
 				Validators: []validator.String{
 					validate.Snowflake(),
 				},
@@ -91,6 +79,7 @@ func (d *memberDataSource) Configure(ctx context.Context, req datasource.Configu
 		return
 	}
 	d.c = c.Rest
+	d.defaultServerID = c.Config.DefaultServerID
 }
 
 func (d *memberDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -106,15 +95,18 @@ func (d *memberDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 		return
 	}
 
-	serverID := data.ServerID.ValueString()
+	serverID := serverIDOrDefault(data.ServerID, d.defaultServerID, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.ServerID = types.StringValue(serverID)
 	userID := data.UserID.ValueString()
 	if userID == "" {
 		resp.Diagnostics.AddError("Missing user_id", "either user_id or username must be set (user_id required for bot tokens)")
 		return
 	}
 
-	var member restMemberDS
-	err := d.c.DoJSON(ctx, "GET", "/guilds/"+serverID+"/members/"+userID, nil, nil, &member)
+	member, err := d.c.GetMember(ctx, serverID, userID)
 	if err != nil {
 		if discord.IsDiscordHTTPStatus(err, 404) {
 			data.ID = types.StringValue(userID)
@@ -18,18 +18,8 @@ func NewRoleDataSource() datasource.DataSource {
 }
 
 type roleDataSource struct {
-	c *discord.RestClient
-}
-
-type restRoleDS struct {
-	ID          string `json:"id"`
-	Name        string `json:"name"`
-	Color       int    `json:"color"`
-	Hoist       bool   `json:"hoist"`
-	Mentionable bool   `json:"mentionable"`
-	Managed     bool   `json:"managed"`
-	Position    int    `json:"position"`
-	Permissions string `json:"permissions"`
+	c               *discord.RestClient
+	defaultServerID string
 }
 
 type roleModel struct {
@@ -55,7 +45,9 @@ func (d *roleDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, r
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{Computed: true},
 			"server_id": schema.StringAttribute{
-				Required: true,
+				Optional:    true,
+				Computed:    true,
+				Description: serverIDDescription,
 				Validators: []validator.String{
 					validate.Snowflake(),
 				},
@@ -92,6 +84,7 @@ func (d *roleDataSource) Configure(ctx context.Context, req datasource.Configure
 		return
 	}
 	d.c = c.Rest
+	d.defaultServerID = c.Config.DefaultServerID
 }
 
 func (d *roleDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -101,14 +94,18 @@ func (d *roleDataSource) Read(ctx context.Context, req datasource.ReadRequest, r
 		return
 	}
 
-	serverID := data.ServerID.ValueString()
-	var roles []restRoleDS
-	if err := d.c.DoJSON(ctx, "GET", "/guilds/"+serverID+"/roles", nil, nil, &roles); err != nil {
+	serverID := serverIDOrDefault(data.ServerID, d.defaultServerID, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.ServerID = types.StringValue(serverID)
+	roles, err := d.c.GetGuildRoles(ctx, serverID)
+	if err != nil {
 		resp.Diagnostics.AddError("Discord API error", err.Error())
 		return
 	}
 
-	var role *restRoleDS
+	var role *discord.Role
 	if !data.RoleID.IsNull() && data.RoleID.ValueString() != "" {
 		id := data.RoleID.ValueString()
 		for i := range roles {
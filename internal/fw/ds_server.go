@@ -2,7 +2,6 @@ package fw
 
 import (
 	"context"
-	"fmt"
 
 	"github.com/45ck/terraform-provider-discord/discord"
 	"github.com/45ck/terraform-provider-discord/internal/fw/validate"
@@ -17,7 +16,8 @@ func NewServerDataSource() datasource.DataSource {
 }
 
 type serverDataSource struct {
-	c *discord.RestClient
+	c               *discord.RestClient
+	defaultServerID string
 }
 
 type serverModel struct {
@@ -44,7 +44,9 @@ func (d *serverDataSource) Schema(_ context.Context, _ datasource.SchemaRequest,
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{Computed: true},
 			"server_id": schema.StringAttribute{
-				Optional: true,
+				Optional:    true,
+				Computed:    true,
+				Description: serverIDDescription,
 				Validators: []validator.String{
 					validate.Snowflake(),
 				},
@@ -70,6 +72,7 @@ func (d *serverDataSource) Configure(ctx context.Context, req datasource.Configu
 		return
 	}
 	d.c = c.Rest
+	d.defaultServerID = c.Config.DefaultServerID
 }
 
 func (d *serverDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -84,14 +87,14 @@ func (d *serverDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 		return
 	}
 
-	serverID := data.ServerID.ValueString()
-	if serverID == "" {
-		resp.Diagnostics.AddError("Missing server_id", "either server_id or name must be set")
+	serverID := serverIDOrDefault(data.ServerID, d.defaultServerID, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
 		return
 	}
+	data.ServerID = types.StringValue(serverID)
 
-	var guild discordRestGuild
-	if err := d.c.DoJSON(ctx, "GET", "/guilds/"+serverID, nil, nil, &guild); err != nil {
+	guild, err := d.c.GetGuild(ctx, serverID)
+	if err != nil {
 		resp.Diagnostics.AddError("Discord API error", err.Error())
 		return
 	}
@@ -119,23 +122,3 @@ func (d *serverDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
-
-// discordRestGuild matches fields we need from GET /guilds/{id}.
-type discordRestGuild struct {
-	ID                          string `json:"id"`
-	Name                        string `json:"name"`
-	Region                      string `json:"region"`
-	DefaultMessageNotifications int    `json:"default_message_notifications"`
-	VerificationLevel           int    `json:"verification_level"`
-	ExplicitContentFilter       int    `json:"explicit_content_filter"`
-	AfkTimeout                  int    `json:"afk_timeout"`
-	AfkChannelID                string `json:"afk_channel_id"`
-	OwnerID                     string `json:"owner_id"`
-	Icon                        string `json:"icon"`
-	Splash                      string `json:"splash"`
-	SystemChannelID             string `json:"system_channel_id"`
-}
-
-func (g discordRestGuild) String() string {
-	return fmt.Sprintf("guild(%s)", g.ID)
-}
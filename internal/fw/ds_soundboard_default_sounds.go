@@ -64,8 +64,8 @@ func (d *soundboardDefaultSoundsDataSource) Read(ctx context.Context, req dataso
 		return
 	}
 
-	var out []restSoundboardSound
-	if err := d.c.DoJSON(ctx, "GET", "/soundboard-default-sounds", nil, nil, &out); err != nil {
+	out, err := d.c.ListDefaultSoundboardSounds(ctx)
+	if err != nil {
 		resp.Diagnostics.AddError("Discord API error", err.Error())
 		return
 	}
@@ -2,7 +2,6 @@ package fw
 
 import (
 	"context"
-	"fmt"
 
 	"github.com/45ck/terraform-provider-discord/discord"
 	"github.com/45ck/terraform-provider-discord/internal/fw/validate"
@@ -17,16 +16,8 @@ func NewSoundboardSoundsDataSource() datasource.DataSource {
 }
 
 type soundboardSoundsDataSource struct {
-	c *discord.RestClient
-}
-
-type restSoundboardSound struct {
-	SoundID   string  `json:"sound_id"`
-	Name      string  `json:"name"`
-	Volume    float64 `json:"volume"`
-	EmojiID   string  `json:"emoji_id"`
-	EmojiName string  `json:"emoji_name"`
-	Available bool    `json:"available"`
+	c               *discord.RestClient
+	defaultServerID string
 }
 
 type soundboardSoundModel struct {
@@ -53,7 +44,9 @@ func (d *soundboardSoundsDataSource) Schema(_ context.Context, _ datasource.Sche
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{Computed: true},
 			"server_id": schema.StringAttribute{
-				Required: true,
+				Optional:    true,
+				Computed:    true,
+				Description: serverIDDescription,
 				Validators: []validator.String{
 					validate.Snowflake(),
 				},
@@ -82,6 +75,7 @@ func (d *soundboardSoundsDataSource) Configure(ctx context.Context, req datasour
 		return
 	}
 	d.c = c.Rest
+	d.defaultServerID = c.Config.DefaultServerID
 }
 
 func (d *soundboardSoundsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -91,9 +85,13 @@ func (d *soundboardSoundsDataSource) Read(ctx context.Context, req datasource.Re
 		return
 	}
 
-	serverID := data.ServerID.ValueString()
-	var out []restSoundboardSound
-	if err := d.c.DoJSON(ctx, "GET", fmt.Sprintf("/guilds/%s/soundboard-sounds", serverID), nil, nil, &out); err != nil {
+	serverID := serverIDOrDefault(data.ServerID, d.defaultServerID, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.ServerID = types.StringValue(serverID)
+	out, err := d.c.ListGuildSoundboardSounds(ctx, serverID)
+	if err != nil {
 		resp.Diagnostics.AddError("Discord API error", err.Error())
 		return
 	}
@@ -2,7 +2,6 @@ package fw
 
 import (
 	"context"
-	"fmt"
 
 	"github.com/45ck/terraform-provider-discord/discord"
 	"github.com/45ck/terraform-provider-discord/internal/fw/validate"
@@ -17,15 +16,8 @@ func NewStickersDataSource() datasource.DataSource {
 }
 
 type stickersDataSource struct {
-	c *discord.RestClient
-}
-
-type restStickerLite struct {
-	ID          string `json:"id"`
-	Name        string `json:"name"`
-	Description string `json:"description"`
-	Tags        string `json:"tags"`
-	FormatType  int    `json:"format_type"`
+	c               *discord.RestClient
+	defaultServerID string
 }
 
 type stickerModel struct {
@@ -51,7 +43,9 @@ func (d *stickersDataSource) Schema(_ context.Context, _ datasource.SchemaReques
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{Computed: true},
 			"server_id": schema.StringAttribute{
-				Required: true,
+				Optional:    true,
+				Computed:    true,
+				Description: serverIDDescription,
 				Validators: []validator.String{
 					validate.Snowflake(),
 				},
@@ -79,6 +73,7 @@ func (d *stickersDataSource) Configure(ctx context.Context, req datasource.Confi
 		return
 	}
 	d.c = c.Rest
+	d.defaultServerID = c.Config.DefaultServerID
 }
 
 func (d *stickersDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -88,9 +83,13 @@ func (d *stickersDataSource) Read(ctx context.Context, req datasource.ReadReques
 		return
 	}
 
-	serverID := data.ServerID.ValueString()
-	var out []restStickerLite
-	if err := d.c.DoJSON(ctx, "GET", fmt.Sprintf("/guilds/%s/stickers", serverID), nil, nil, &out); err != nil {
+	serverID := serverIDOrDefault(data.ServerID, d.defaultServerID, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.ServerID = types.StringValue(serverID)
+	out, err := d.c.ListGuildStickers(ctx, serverID)
+	if err != nil {
 		resp.Diagnostics.AddError("Discord API error", err.Error())
 		return
 	}
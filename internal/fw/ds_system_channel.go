@@ -16,7 +16,8 @@ func NewSystemChannelDataSource() datasource.DataSource {
 }
 
 type systemChannelDataSource struct {
-	c *discord.RestClient
+	c               *discord.RestClient
+	defaultServerID string
 }
 
 type systemChannelModel struct {
@@ -34,7 +35,9 @@ func (d *systemChannelDataSource) Schema(_ context.Context, _ datasource.SchemaR
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{Computed: true},
 			"server_id": schema.StringAttribute{
-				Required: true,
+				Optional:    true,
+				Computed:    true,
+				Description: serverIDDescription,
 				Validators: []validator.String{
 					validate.Snowflake(),
 				},
@@ -51,6 +54,7 @@ func (d *systemChannelDataSource) Configure(ctx context.Context, req datasource.
 		return
 	}
 	d.c = c.Rest
+	d.defaultServerID = c.Config.DefaultServerID
 }
 
 func (d *systemChannelDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -60,9 +64,13 @@ func (d *systemChannelDataSource) Read(ctx context.Context, req datasource.ReadR
 		return
 	}
 
-	serverID := data.ServerID.ValueString()
-	var guild discordRestGuild
-	if err := d.c.DoJSON(ctx, "GET", "/guilds/"+serverID, nil, nil, &guild); err != nil {
+	serverID := serverIDOrDefault(data.ServerID, d.defaultServerID, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.ServerID = types.StringValue(serverID)
+	guild, err := d.c.GetGuild(ctx, serverID)
+	if err != nil {
 		resp.Diagnostics.AddError("Discord API error", err.Error())
 		return
 	}
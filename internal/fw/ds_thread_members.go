@@ -2,11 +2,9 @@ package fw
 
 import (
 	"context"
-	"fmt"
-	"net/url"
-	"strconv"
 
 	"github.com/45ck/terraform-provider-discord/discord"
+	"github.com/45ck/terraform-provider-discord/internal/fw/fwutil"
 	"github.com/45ck/terraform-provider-discord/internal/fw/validate"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
@@ -22,13 +20,6 @@ type threadMembersDataSource struct {
 	c *discord.RestClient
 }
 
-type restThreadMember struct {
-	ID            string `json:"id"`
-	UserID        string `json:"user_id"`
-	JoinTimestamp string `json:"join_timestamp"`
-	Flags         int    `json:"flags"`
-}
-
 type threadMemberModel struct {
 	UserID        types.String `tfsdk:"user_id"`
 	JoinTimestamp types.String `tfsdk:"join_timestamp"`
@@ -101,23 +92,16 @@ func (d *threadMembersDataSource) Read(ctx context.Context, req datasource.ReadR
 	}
 
 	threadID := data.ThreadID.ValueString()
-	q := url.Values{}
-	if !data.Limit.IsNull() && !data.Limit.IsUnknown() && data.Limit.ValueInt64() > 0 {
-		q.Set("limit", strconv.FormatInt(data.Limit.ValueInt64(), 10))
+	params := &discord.ListThreadMembersParams{
+		After:      data.After.ValueString(),
+		WithMember: fwutil.OptionalBool(data.WithMember),
 	}
-	if !data.After.IsNull() && data.After.ValueString() != "" {
-		q.Set("after", data.After.ValueString())
-	}
-	if !data.WithMember.IsNull() && !data.WithMember.IsUnknown() {
-		if data.WithMember.ValueBool() {
-			q.Set("with_member", "true")
-		} else {
-			q.Set("with_member", "false")
-		}
+	if !data.Limit.IsNull() && !data.Limit.IsUnknown() {
+		params.Limit = int(data.Limit.ValueInt64())
 	}
 
-	var out []restThreadMember
-	if err := d.c.DoJSON(ctx, "GET", fmt.Sprintf("/channels/%s/thread-members", threadID), q, nil, &out); err != nil {
+	out, err := d.c.ListThreadMembers(ctx, threadID, params)
+	if err != nil {
 		resp.Diagnostics.AddError("Discord API error", err.Error())
 		return
 	}
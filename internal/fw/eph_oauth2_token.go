@@ -0,0 +1,100 @@
+package fw
+
+import (
+	"context"
+	"time"
+
+	"github.com/45ck/terraform-provider-discord/discord"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func NewOAuth2TokenEphemeralResource() ephemeral.EphemeralResource {
+	return &oauth2TokenEphemeralResource{}
+}
+
+// oauth2TokenEphemeralResource hands out an access token from the provider's
+// client credentials without putting it in state or plan.
+type oauth2TokenEphemeralResource struct {
+	bearer *discord.ClientCredentials
+}
+
+type oauth2TokenModel struct {
+	Scopes      types.List   `tfsdk:"scopes"`
+	AccessToken types.String `tfsdk:"access_token"`
+	Scope       types.String `tfsdk:"scope"`
+	ExpiresAt   types.String `tfsdk:"expires_at"`
+}
+
+func (e *oauth2TokenEphemeralResource) Metadata(_ context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_oauth2_token"
+}
+
+func (e *oauth2TokenEphemeralResource) Schema(_ context.Context, _ ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "An OAuth2 Bearer access token for the application, from the client credentials grant with the provider's client_id and secret.",
+		Attributes: map[string]schema.Attribute{
+			"scopes": schema.ListAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "Scopes to request. Defaults to the provider's oauth2_scopes.",
+			},
+			"access_token": schema.StringAttribute{
+				Computed:  true,
+				Sensitive: true,
+			},
+			"scope": schema.StringAttribute{
+				Computed:    true,
+				Description: "Space-separated scopes Discord granted.",
+			},
+			"expires_at": schema.StringAttribute{
+				Computed:    true,
+				Description: "RFC 3339 time the token expires.",
+			},
+		},
+	}
+}
+
+func (e *oauth2TokenEphemeralResource) Configure(ctx context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	c, diags := getContextFromProviderData(req.ProviderData)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	e.bearer = c.Rest.Bearer
+}
+
+func (e *oauth2TokenEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var data oauth2TokenModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if e.bearer == nil {
+		resp.Diagnostics.AddError("Missing OAuth2 client credentials", "discord_oauth2_token needs client_id and secret in the provider configuration.")
+		return
+	}
+
+	cc := e.bearer
+	if !data.Scopes.IsNull() {
+		var scopes []string
+		resp.Diagnostics.Append(data.Scopes.ElementsAs(ctx, &scopes, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		cc = discord.NewClientCredentials(cc.ClientID, cc.Secret, scopes, cc.TokenURL, cc.HTTP)
+	}
+	// A token of its own: the provider's cached one is renewed and invalidated
+	// independently of wherever this one ends up.
+	tok, err := cc.Exchange(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Discord API error", err.Error())
+		return
+	}
+
+	data.AccessToken = types.StringValue(tok.AccessToken)
+	data.Scope = types.StringValue(tok.Scope)
+	data.ExpiresAt = types.StringValue(tok.Expires.UTC().Format(time.RFC3339))
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
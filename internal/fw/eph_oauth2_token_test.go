@@ -0,0 +1,66 @@
+package fw
+
+import (
+	"net/http"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/45ck/terraform-provider-discord/internal/fakediscord"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestOAuth2TokenEphemeralResource_Fake(t *testing.T) {
+	e := newFakeEnv(t)
+
+	p, schemas := newProtocolServer(t, map[string]any{"base_url": e.srv.URL})
+	_, diags := openEphemeral(t, p, schemas, "discord_oauth2_token", nil)
+	if len(diags) == 0 || !regexp.MustCompile(`Missing OAuth2 client credentials`).MatchString(diags[0].Summary) {
+		t.Fatalf("expected an error without client credentials, got %v", diags)
+	}
+
+	p, schemas = newProtocolServer(t, map[string]any{
+		"base_url":  e.srv.URL,
+		"client_id": fakediscord.ClientID,
+		"secret":    fakediscord.ClientSecret,
+	})
+	for _, tc := range []struct {
+		scopes tftypes.Value
+		want   string
+	}{
+		{tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, nil), "applications.commands.update"},
+		{tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, []tftypes.Value{
+			tftypes.NewValue(tftypes.String, "identify"),
+			tftypes.NewValue(tftypes.String, "guilds"),
+		}), "identify guilds"},
+	} {
+		got, diags := openEphemeral(t, p, schemas, "discord_oauth2_token", map[string]tftypes.Value{"scopes": tc.scopes})
+		if len(diags) > 0 {
+			t.Fatalf("Open: %s: %s", diags[0].Summary, diags[0].Detail)
+		}
+		if !got["scope"].Equal(tftypes.NewValue(tftypes.String, tc.want)) {
+			t.Errorf("scope = %s, want %q", got["scope"], tc.want)
+		}
+		var token, expiresAt string
+		_ = got["access_token"].As(&token)
+		_ = got["expires_at"].As(&expiresAt)
+		if token == "" {
+			t.Error("no access_token")
+		}
+		// The API accepts the token.
+		req, _ := http.NewRequest("GET", e.srv.URL+"/v10/users/@me", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		res, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		res.Body.Close()
+		if res.StatusCode != http.StatusOK {
+			t.Errorf("GET /users/@me with access_token: HTTP %d", res.StatusCode)
+		}
+		expires, err := time.Parse(time.RFC3339, expiresAt)
+		if err != nil || time.Until(expires) < fakediscord.DefaultTokenLifetime-time.Minute {
+			t.Errorf("expires_at = %q, want about %s from now", expiresAt, fakediscord.DefaultTokenLifetime)
+		}
+	}
+}
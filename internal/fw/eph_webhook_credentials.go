@@ -0,0 +1,105 @@
+package fw
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/45ck/terraform-provider-discord/discord"
+	"github.com/45ck/terraform-provider-discord/internal/fw/validate"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func NewWebhookCredentialsEphemeralResource() ephemeral.EphemeralResource {
+	return &webhookCredentialsEphemeralResource{}
+}
+
+// webhookCredentialsEphemeralResource reads a webhook's token without putting
+// it in state or plan, for write-only arguments of other resources.
+type webhookCredentialsEphemeralResource struct {
+	c *discord.RestClient
+}
+
+type webhookCredentialsModel struct {
+	WebhookID types.String `tfsdk:"webhook_id"`
+	ChannelID types.String `tfsdk:"channel_id"`
+	GuildID   types.String `tfsdk:"guild_id"`
+	Name      types.String `tfsdk:"name"`
+	Token     types.String `tfsdk:"token"`
+	URL       types.String `tfsdk:"url"`
+}
+
+func (e *webhookCredentialsEphemeralResource) Metadata(_ context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_webhook_credentials"
+}
+
+func (e *webhookCredentialsEphemeralResource) Schema(_ context.Context, _ ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "The token and execute URL of an incoming webhook, fetched when Terraform needs them and never stored.",
+		Attributes: map[string]schema.Attribute{
+			"webhook_id": schema.StringAttribute{
+				Required: true,
+				Validators: []validator.String{
+					validate.Snowflake(),
+				},
+			},
+			"channel_id": schema.StringAttribute{Computed: true},
+			"guild_id":   schema.StringAttribute{Computed: true},
+			"name":       schema.StringAttribute{Computed: true},
+			"token": schema.StringAttribute{
+				Computed:  true,
+				Sensitive: true,
+			},
+			"url": schema.StringAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				Description: "Execute URL, as Discord returns it or else built from the provider's base_url and api_version: {base_url}/v{api_version}/webhooks/{id}/{token}. Anyone with it can post as the webhook.",
+			},
+		},
+	}
+}
+
+func (e *webhookCredentialsEphemeralResource) Configure(ctx context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	c, diags := getContextFromProviderData(req.ProviderData)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	e.c = c.Rest
+}
+
+func (e *webhookCredentialsEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var data webhookCredentialsModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	hook, err := e.c.GetWebhook(discord.WithFreshReads(ctx), data.WebhookID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Discord API error", err.Error())
+		return
+	}
+	// Discord leaves the token out for channel follower and application
+	// webhooks, and for bots without Manage Webhooks.
+	if strings.TrimSpace(hook.Token) == "" {
+		resp.Diagnostics.AddAttributeError(path.Root("webhook_id"), "Webhook has no token",
+			fmt.Sprintf("Discord returned webhook %s without a token. Only incoming webhooks have one, and the bot needs the Manage Webhooks permission to read it.", hook.ID))
+		return
+	}
+
+	data.ChannelID = types.StringValue(hook.ChannelID)
+	data.GuildID = types.StringValue(hook.GuildID)
+	data.Name = types.StringValue(hook.Name)
+	data.Token = types.StringValue(hook.Token)
+	url := hook.URL
+	if url == "" {
+		url = e.c.WebhookExecuteURL(hook.ID, hook.Token)
+	}
+	data.URL = types.StringValue(url)
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
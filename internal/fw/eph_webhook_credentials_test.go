@@ -0,0 +1,85 @@
+package fw
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/45ck/terraform-provider-discord/discord"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestWebhookCredentialsEphemeralResource_Fake(t *testing.T) {
+	e := newFakeEnv(t)
+	ctx := context.Background()
+	ch, err := e.client().CreateChannel(ctx, e.guildID, &discord.CreateChannelParams{Name: "alerts"}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	hook, err := e.client().CreateWebhook(ctx, ch.ID, &discord.CreateWebhookParams{Name: "alerts"}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	p, schemas := newProtocolServer(t, map[string]any{"base_url": e.srv.URL})
+
+	got, diags := openEphemeral(t, p, schemas, "discord_webhook_credentials", map[string]tftypes.Value{
+		"webhook_id": tftypes.NewValue(tftypes.String, hook.ID),
+	})
+	if len(diags) > 0 {
+		t.Fatalf("Open: %s: %s", diags[0].Summary, diags[0].Detail)
+	}
+	want := map[string]string{
+		"channel_id": ch.ID,
+		"guild_id":   e.guildID,
+		"name":       "alerts",
+		"token":      hook.Token,
+		"url":        "https://discord.com/api/webhooks/" + hook.ID + "/" + hook.Token,
+	}
+	for k, v := range want {
+		if !got[k].Equal(tftypes.NewValue(tftypes.String, v)) {
+			t.Errorf("%s = %s, want %q", k, got[k], v)
+		}
+	}
+
+	_, diags = openEphemeral(t, p, schemas, "discord_webhook_credentials", map[string]tftypes.Value{
+		"webhook_id": tftypes.NewValue(tftypes.String, "1"),
+	})
+	if len(diags) == 0 || !regexp.MustCompile(`Unknown Webhook`).MatchString(diags[0].Detail) {
+		t.Fatalf("expected Unknown Webhook for a missing webhook, got %v", diags)
+	}
+}
+
+// openEphemeral opens an ephemeral resource with config, leaving the
+// attributes it does not set null, and returns the result's attributes.
+func openEphemeral(t *testing.T, p tfprotov6.ProviderServer, schemas *tfprotov6.GetProviderSchemaResponse, typ string, config map[string]tftypes.Value) (map[string]tftypes.Value, []*tfprotov6.Diagnostic) {
+	t.Helper()
+	objType := schemas.EphemeralResourceSchemas[typ].ValueType().(tftypes.Object)
+	vals := map[string]tftypes.Value{}
+	for name, at := range objType.AttributeTypes {
+		vals[name] = tftypes.NewValue(at, nil)
+	}
+	for name, v := range config {
+		vals[name] = v
+	}
+	dv, err := tfprotov6.NewDynamicValue(objType, tftypes.NewValue(objType, vals))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := p.OpenEphemeralResource(context.Background(), &tfprotov6.OpenEphemeralResourceRequest{TypeName: typ, Config: &dv})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Diagnostics) > 0 {
+		return nil, resp.Diagnostics
+	}
+	v, err := resp.Result.Unmarshal(objType)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out map[string]tftypes.Value
+	if err := v.As(&out); err != nil {
+		t.Fatal(err)
+	}
+	return out, nil
+}
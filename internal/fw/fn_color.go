@@ -0,0 +1,60 @@
+package fw
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"gopkg.in/go-playground/colors.v1"
+)
+
+func NewColorFunction() function.Function {
+	return &colorFunction{}
+}
+
+// colorFunction converts a color to Discord's integer form, as discord_color
+// does without a data source read.
+type colorFunction struct{}
+
+func (f *colorFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "color"
+}
+
+func (f *colorFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Integer color from hex or rgb",
+		Description: "Returns a color given as \"#rrggbb\", \"#rgb\" or \"rgb(r, g, b)\" as the integer Discord uses for role and embed colors.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "color",
+				Description: "Color as \"#rrggbb\", \"#rgb\" or \"rgb(r, g, b)\".",
+			},
+		},
+		Return: function.Int64Return{},
+	}
+}
+
+func (f *colorFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var s string
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &s))
+	if resp.Error != nil {
+		return
+	}
+
+	s = strings.TrimSpace(s)
+	var clr colors.Color
+	var err error
+	if strings.HasPrefix(strings.ToLower(s), "rgb(") {
+		clr, err = colors.ParseRGB(strings.ToLower(s))
+	} else {
+		clr, err = colors.ParseHEX(s)
+	}
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("invalid color %q: expected \"#rrggbb\", \"#rgb\" or \"rgb(r, g, b)\"", s))
+		return
+	}
+	rgb := clr.ToRGB()
+	v := int64(rgb.R)<<16 | int64(rgb.G)<<8 | int64(rgb.B)
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, v))
+}
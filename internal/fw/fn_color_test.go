@@ -0,0 +1,33 @@
+package fw
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestColorFunction(t *testing.T) {
+	p, schemas := newProtocolServer(t, nil)
+
+	for in, want := range map[string]int64{
+		"#5865F2":           0x5865f2,
+		" #fff ":            0xffffff,
+		"rgb(88, 101, 242)": 0x5865f2,
+		"RGB(0,0,0)":        0,
+	} {
+		got, ferr := callFunction(t, p, schemas, "color", tftypes.NewValue(tftypes.String, in))
+		if ferr != nil {
+			t.Fatalf("color(%q): %s", in, ferr.Text)
+		}
+		if !got.Equal(tftypes.NewValue(tftypes.Number, big.NewFloat(float64(want)))) {
+			t.Errorf("color(%q) = %s, want %d", in, got, want)
+		}
+	}
+
+	for _, in := range []string{"5865F2", "#5865F", "rgb(300, 0, 0)", "blurple"} {
+		if _, ferr := callFunction(t, p, schemas, "color", tftypes.NewValue(tftypes.String, in)); ferr == nil {
+			t.Errorf("color(%q): expected an error", in)
+		}
+	}
+}
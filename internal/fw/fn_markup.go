@@ -0,0 +1,298 @@
+package fw
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/45ck/terraform-provider-discord/discord"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func NewMentionUserFunction() function.Function {
+	return &mentionFunction{name: "mention_user", what: "user", format: discord.MentionUser}
+}
+
+func NewMentionRoleFunction() function.Function {
+	return &mentionFunction{name: "mention_role", what: "role", format: discord.MentionRole}
+}
+
+func NewMentionChannelFunction() function.Function {
+	return &mentionFunction{name: "mention_channel", what: "channel", format: discord.MentionChannel}
+}
+
+// mentionFunction formats the mention of a user, role or channel ID.
+type mentionFunction struct {
+	name   string
+	what   string
+	format func(id string) (string, error)
+}
+
+func (f *mentionFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = f.name
+}
+
+func (f *mentionFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	example, _ := f.format("80351110224678912")
+	resp.Definition = function.Definition{
+		Summary:     "Mention of a " + f.what,
+		Description: fmt.Sprintf("Returns the message markup that mentions a %s, e.g. %s.", f.what, example),
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "id",
+				Description: "Snowflake ID of the " + f.what + ".",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *mentionFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var id string
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &id))
+	if resp.Error != nil {
+		return
+	}
+	s, err := f.format(id)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, s))
+}
+
+func NewTimestampTagFunction() function.Function {
+	return &timestampTagFunction{}
+}
+
+// timestampTagFunction formats a time that Discord shows in each reader's
+// own time zone and locale.
+type timestampTagFunction struct{}
+
+func (f *timestampTagFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "timestamp_tag"
+}
+
+func (f *timestampTagFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Timestamp markup",
+		Description: "Returns the message markup <t:unix:style> for a time, which Discord shows in each reader's time zone.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "timestamp",
+				Description: "RFC 3339 time, e.g. from timestamp() or snowflake_time().",
+			},
+			function.StringParameter{
+				Name:        "style",
+				Description: "One of " + strings.Join(discord.TimestampStyles, ", ") + " (R is relative, e.g. \"in 2 hours\"), or \"\" for Discord's default.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *timestampTagFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var ts, style string
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &ts, &style))
+	if resp.Error != nil {
+		return
+	}
+	t, err := time.Parse(time.RFC3339, ts)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("invalid timestamp %q: expected RFC 3339, e.g. 2024-01-02T15:04:05Z", ts))
+		return
+	}
+	s, err := discord.TimestampTag(t, style)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(1, err.Error())
+		return
+	}
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, s))
+}
+
+func NewCustomEmojiFunction() function.Function {
+	return &customEmojiFunction{}
+}
+
+// customEmojiFunction formats a server emoji for use in message content.
+type customEmojiFunction struct{}
+
+func (f *customEmojiFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "custom_emoji"
+}
+
+func (f *customEmojiFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Custom emoji markup",
+		Description: "Returns the message markup of a custom emoji, <:name:id>, or <a:name:id> for an animated one.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "name",
+				Description: "Emoji name, 2-32 letters, digits or underscores.",
+			},
+			function.StringParameter{
+				Name:        "id",
+				Description: "Snowflake ID of the emoji, e.g. a discord_emoji's id.",
+			},
+			function.BoolParameter{
+				Name:        "animated",
+				Description: "Whether the emoji is animated.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *customEmojiFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var name, id string
+	var animated bool
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &name, &id, &animated))
+	if resp.Error != nil {
+		return
+	}
+	s, err := discord.CustomEmoji(name, id, animated)
+	if err != nil {
+		resp.Error = function.NewFuncError(err.Error())
+		return
+	}
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, s))
+}
+
+func NewMessageLinkFunction() function.Function {
+	return &messageLinkFunction{}
+}
+
+// messageLinkFunction builds the jump link to a message.
+type messageLinkFunction struct{}
+
+func (f *messageLinkFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "message_link"
+}
+
+func (f *messageLinkFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Link to a message",
+		Description: "Returns the link that jumps to a message, https://discord.com/channels/{server}/{channel}/{message}.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "server_id",
+				Description: "Snowflake ID of the server, or \"@me\" for a direct message.",
+			},
+			function.StringParameter{
+				Name:        "channel_id",
+				Description: "Snowflake ID of the channel or thread.",
+			},
+			function.StringParameter{
+				Name:        "message_id",
+				Description: "Snowflake ID of the message.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *messageLinkFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var serverID, channelID, messageID string
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &serverID, &channelID, &messageID))
+	if resp.Error != nil {
+		return
+	}
+	s, err := discord.MessageLink(serverID, channelID, messageID)
+	if err != nil {
+		resp.Error = function.NewFuncError(err.Error())
+		return
+	}
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, s))
+}
+
+func NewEscapeMarkdownFunction() function.Function {
+	return &escapeMarkdownFunction{}
+}
+
+// escapeMarkdownFunction makes text show literally in message content.
+type escapeMarkdownFunction struct{}
+
+func (f *escapeMarkdownFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "escape_markdown"
+}
+
+func (f *escapeMarkdownFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Escape Discord Markdown",
+		Description: "Returns text with Discord Markdown escaped, so names and other outside text show as written. Mentions are not Markdown and are left as they are.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "text",
+				Description: "Text to escape.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *escapeMarkdownFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var text string
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &text))
+	if resp.Error != nil {
+		return
+	}
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, discord.EscapeMarkdown(text)))
+}
+
+func NewOAuth2InviteURLFunction() function.Function {
+	return &oauth2InviteURLFunction{}
+}
+
+// oauth2InviteURLFunction builds the link that adds a bot to a server.
+type oauth2InviteURLFunction struct{}
+
+func (f *oauth2InviteURLFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "oauth2_invite_url"
+}
+
+func (f *oauth2InviteURLFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Bot invite link",
+		Description: "Returns the " + discord.OAuth2AuthorizeURL + " link that adds an application to a server with the given scopes and bot permissions.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "client_id",
+				Description: "Application (client) ID.",
+			},
+			function.StringParameter{
+				Name:           "permissions",
+				AllowNullValue: true,
+				Description:    "Permission bits for the bot's role as a 64-bit integer string, e.g. from permissions(). null or \"\" for none.",
+			},
+			function.ListParameter{
+				Name:        "scopes",
+				ElementType: types.StringType,
+				Description: "OAuth2 scopes, e.g. [\"bot\", \"applications.commands\"].",
+			},
+			function.StringParameter{
+				Name:           "guild_id",
+				AllowNullValue: true,
+				Description:    "Server to preselect. null or \"\" to let the user pick.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *oauth2InviteURLFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var clientID string
+	var permissions, guildID types.String
+	var scopes []string
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &clientID, &permissions, &scopes, &guildID))
+	if resp.Error != nil {
+		return
+	}
+	s, err := discord.OAuth2InviteURL(clientID, permissions.ValueString(), scopes, guildID.ValueString())
+	if err != nil {
+		resp.Error = function.NewFuncError(err.Error())
+		return
+	}
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, s))
+}
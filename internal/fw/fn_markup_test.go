@@ -0,0 +1,66 @@
+package fw
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// TestMarkupFunctions checks the wiring of the markup functions; the formats
+// themselves are tested in the discord package.
+func TestMarkupFunctions(t *testing.T) {
+	p, schemas := newProtocolServer(t, nil)
+
+	str := func(s string) tftypes.Value { return tftypes.NewValue(tftypes.String, s) }
+	null := tftypes.NewValue(tftypes.String, nil)
+	scopes := tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, []tftypes.Value{str("bot"), str("applications.commands")})
+
+	tests := []struct {
+		name string
+		args []tftypes.Value
+		want string
+	}{
+		{"mention_user", []tftypes.Value{str("80351110224678912")}, "<@80351110224678912>"},
+		{"mention_role", []tftypes.Value{str("80351110224678912")}, "<@&80351110224678912>"},
+		{"mention_channel", []tftypes.Value{str("80351110224678912")}, "<#80351110224678912>"},
+		{"timestamp_tag", []tftypes.Value{str("2024-01-02T03:04:05+02:00"), str("R")}, "<t:1704157445:R>"},
+		{"timestamp_tag", []tftypes.Value{str("2024-01-02T01:04:05Z"), str("")}, "<t:1704157445>"},
+		{"custom_emoji", []tftypes.Value{str("blob"), str("80351110224678912"), tftypes.NewValue(tftypes.Bool, true)}, "<a:blob:80351110224678912>"},
+		{"message_link", []tftypes.Value{str("@me"), str("80351110224678912"), str("175928847299117063")}, "https://discord.com/channels/@me/80351110224678912/175928847299117063"},
+		{"escape_markdown", []tftypes.Value{str("**not bold**")}, `\*\*not bold\*\*`},
+		{"oauth2_invite_url", []tftypes.Value{str("80351110224678912"), str("8"), scopes, null}, "https://discord.com/oauth2/authorize?client_id=80351110224678912&permissions=8&scope=bot+applications.commands"},
+	}
+	for _, tt := range tests {
+		got, ferr := callFunction(t, p, schemas, tt.name, tt.args...)
+		if ferr != nil {
+			t.Errorf("%s: %s", tt.name, ferr.Text)
+			continue
+		}
+		if !got.Equal(str(tt.want)) {
+			t.Errorf("%s = %s, want %q", tt.name, got, tt.want)
+		}
+	}
+
+	errs := []struct {
+		name string
+		args []tftypes.Value
+		arg  int64
+	}{
+		{"mention_role", []tftypes.Value{str("mod")}, 0},
+		{"timestamp_tag", []tftypes.Value{str("yesterday"), str("R")}, 0},
+		{"timestamp_tag", []tftypes.Value{str("2024-01-02T01:04:05Z"), str("x")}, 1},
+	}
+	for _, tt := range errs {
+		_, ferr := callFunction(t, p, schemas, tt.name, tt.args...)
+		if ferr == nil {
+			t.Errorf("%s(%v): expected an error", tt.name, tt.args)
+			continue
+		}
+		if ferr.FunctionArgument == nil || *ferr.FunctionArgument != tt.arg {
+			t.Errorf("%s(%v): error not on argument %d: %s", tt.name, tt.args, tt.arg, ferr.Text)
+		}
+	}
+	if _, ferr := callFunction(t, p, schemas, "oauth2_invite_url", str("80351110224678912"), null, tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, []tftypes.Value{}), null); ferr == nil {
+		t.Error("oauth2_invite_url without scopes: expected an error")
+	}
+}
@@ -0,0 +1,114 @@
+package fw
+
+import (
+	"context"
+	"fmt"
+	"math/bits"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// permissionAliases are older names in permissionBits for a bit that has a
+// newer one. permission_names leaves them out so each bit decodes to one name.
+var permissionAliases = map[string]bool{
+	"manage_emojis":             true,
+	"manage_expressions":        true,
+	"start_embedded_activities": true,
+}
+
+func NewPermissionsFunction() function.Function {
+	return &permissionsFunction{}
+}
+
+// permissionsFunction encodes permission names, as discord_permission does
+// for allow_bits64 without a data source read.
+type permissionsFunction struct{}
+
+func (f *permissionsFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "permissions"
+}
+
+func (f *permissionsFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Permission bits from permission names",
+		Description: "Returns the permission bits of names such as \"send_messages\" as a decimal 64-bit integer string, for role and overwrite permissions.",
+		Parameters: []function.Parameter{
+			function.ListParameter{
+				Name:        "names",
+				ElementType: types.StringType,
+				Description: "Permission names, as the arguments of the discord_permission data source.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *permissionsFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var names []string
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &names))
+	if resp.Error != nil {
+		return
+	}
+
+	var v uint64
+	for _, name := range names {
+		bit, ok := permissionBits[strings.ToLower(strings.TrimSpace(name))]
+		if !ok {
+			resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("unknown permission %q", name))
+			return
+		}
+		v |= bit
+	}
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, strconv.FormatUint(v, 10)))
+}
+
+func NewPermissionNamesFunction() function.Function {
+	return &permissionNamesFunction{}
+}
+
+// permissionNamesFunction decodes permission bits back into names.
+type permissionNamesFunction struct{}
+
+func (f *permissionNamesFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "permission_names"
+}
+
+func (f *permissionNamesFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Permission names from permission bits",
+		Description: "Returns the sorted names of the permissions set in a 64-bit integer string (decimal or 0x...). Bits the provider has no name for are left out.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "bits",
+				Description: "Permission bits, e.g. a role's permissions_bits64.",
+			},
+		},
+		Return: function.ListReturn{ElementType: types.StringType},
+	}
+}
+
+func (f *permissionNamesFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var s string
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &s))
+	if resp.Error != nil {
+		return
+	}
+	v, err := strconv.ParseUint(strings.TrimSpace(s), 0, 64)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("invalid permission bits %q: expected a 64-bit integer string", s))
+		return
+	}
+
+	names := make([]string, 0, bits.OnesCount64(v))
+	for name, bit := range permissionBits {
+		if v&bit != 0 && !permissionAliases[name] {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, names))
+}
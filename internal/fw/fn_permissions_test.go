@@ -0,0 +1,81 @@
+package fw
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestPermissionsFunctions(t *testing.T) {
+	p, schemas := newProtocolServer(t, nil)
+	names := func(ns ...string) tftypes.Value {
+		vals := make([]tftypes.Value, len(ns))
+		for i, n := range ns {
+			vals[i] = tftypes.NewValue(tftypes.String, n)
+		}
+		return tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, vals)
+	}
+
+	got, ferr := callFunction(t, p, schemas, "permissions", names("send_messages", "view_channel", "Send_Messages"))
+	if ferr != nil {
+		t.Fatal(ferr.Text)
+	}
+	want := strconv.FormatUint(permissionBits["send_messages"]|permissionBits["view_channel"], 10)
+	if !got.Equal(tftypes.NewValue(tftypes.String, want)) {
+		t.Fatalf("permissions = %s, want %q", got, want)
+	}
+
+	// Aliased bits decode to their current name, and unknown bits are dropped.
+	bits := permissionBits["view_channel"] | permissionBits["send_messages"] | permissionBits["use_embedded_activities"] | 1<<63
+	for _, in := range []string{strconv.FormatUint(bits, 10), "0x" + strconv.FormatUint(bits, 16)} {
+		got, ferr = callFunction(t, p, schemas, "permission_names", tftypes.NewValue(tftypes.String, in))
+		if ferr != nil {
+			t.Fatal(ferr.Text)
+		}
+		if want := names("send_messages", "use_embedded_activities", "view_channel"); !got.Equal(want) {
+			t.Fatalf("permission_names(%s) = %s, want %s", in, got, want)
+		}
+	}
+
+	_, ferr = callFunction(t, p, schemas, "permissions", names("send_messages", "sned_messages"))
+	if ferr == nil || !strings.Contains(ferr.Text, `unknown permission "sned_messages"`) {
+		t.Fatalf("expected an unknown permission error, got %v", ferr)
+	}
+	_, ferr = callFunction(t, p, schemas, "permission_names", tftypes.NewValue(tftypes.String, "-1"))
+	if ferr == nil || ferr.FunctionArgument == nil || *ferr.FunctionArgument != 0 {
+		t.Fatalf("expected an argument error, got %v", ferr)
+	}
+}
+
+// callFunction calls a provider function the way Terraform does.
+func callFunction(t *testing.T, p tfprotov6.ProviderServer, schemas *tfprotov6.GetProviderSchemaResponse, name string, args ...tftypes.Value) (tftypes.Value, *tfprotov6.FunctionError) {
+	t.Helper()
+	fn, ok := schemas.Functions[name]
+	if !ok {
+		t.Fatalf("no function %q", name)
+	}
+	req := &tfprotov6.CallFunctionRequest{Name: name}
+	for i, a := range args {
+		dv, err := tfprotov6.NewDynamicValue(fn.Parameters[i].Type, a)
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Arguments = append(req.Arguments, &dv)
+	}
+	resp, err := p.CallFunction(context.Background(), req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Error != nil {
+		return tftypes.Value{}, resp.Error
+	}
+	v, err := resp.Result.Unmarshal(fn.Return.Type)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return v, nil
+}
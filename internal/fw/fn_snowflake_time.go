@@ -0,0 +1,49 @@
+package fw
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/45ck/terraform-provider-discord/discord"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+func NewSnowflakeTimeFunction() function.Function {
+	return &snowflakeTimeFunction{}
+}
+
+// snowflakeTimeFunction returns the creation time encoded in a Discord ID.
+type snowflakeTimeFunction struct{}
+
+func (f *snowflakeTimeFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "snowflake_time"
+}
+
+func (f *snowflakeTimeFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Creation time of a Discord ID",
+		Description: "Returns the time a Discord snowflake ID was created, as an RFC 3339 UTC timestamp.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "id",
+				Description: "Snowflake ID of a server, channel, user, message or other Discord object.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *snowflakeTimeFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var id string
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &id))
+	if resp.Error != nil {
+		return
+	}
+	t, ok := discord.SnowflakeTime(id)
+	if !ok {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("invalid snowflake %q: expected a decimal Discord ID", id))
+		return
+	}
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, t.Format(time.RFC3339)))
+}
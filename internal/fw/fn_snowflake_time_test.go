@@ -0,0 +1,24 @@
+package fw
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestSnowflakeTimeFunction(t *testing.T) {
+	p, schemas := newProtocolServer(t, nil)
+
+	// The example from Discord's API reference.
+	got, ferr := callFunction(t, p, schemas, "snowflake_time", tftypes.NewValue(tftypes.String, "175928847299117063"))
+	if ferr != nil {
+		t.Fatal(ferr.Text)
+	}
+	if want := "2016-04-30T11:18:25Z"; !got.Equal(tftypes.NewValue(tftypes.String, want)) {
+		t.Fatalf("snowflake_time = %s, want %q", got, want)
+	}
+
+	if _, ferr := callFunction(t, p, schemas, "snowflake_time", tftypes.NewValue(tftypes.String, "not-an-id")); ferr == nil {
+		t.Fatal("expected an error for an invalid ID")
+	}
+}
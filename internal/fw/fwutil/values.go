@@ -0,0 +1,74 @@
+package fwutil
+
+import "github.com/hashicorp/terraform-plugin-framework/types"
+
+// OptionalString returns nil for a null or unknown value, so the field is left out of
+// a request body, and a pointer to the value otherwise.
+func OptionalString(v types.String) *string {
+	if v.IsNull() || v.IsUnknown() {
+		return nil
+	}
+	s := v.ValueString()
+	return &s
+}
+
+// OptionalInt is OptionalString for Int64 attributes sent as JSON integers.
+func OptionalInt(v types.Int64) *int {
+	if v.IsNull() || v.IsUnknown() {
+		return nil
+	}
+	i := int(v.ValueInt64())
+	return &i
+}
+
+// OptionalBool is OptionalString for Bool attributes.
+func OptionalBool(v types.Bool) *bool {
+	if v.IsNull() || v.IsUnknown() {
+		return nil
+	}
+	b := v.ValueBool()
+	return &b
+}
+
+// NonEmptyString is OptionalString that also leaves out empty strings.
+func NonEmptyString(v types.String) *string {
+	if v.IsNull() || v.IsUnknown() || v.ValueString() == "" {
+		return nil
+	}
+	s := v.ValueString()
+	return &s
+}
+
+// StringOrNull maps the empty string Discord uses for "not set" to a null value.
+func StringOrNull(s string) types.String {
+	if s == "" {
+		return types.StringNull()
+	}
+	return types.StringValue(s)
+}
+
+// KeepNullString returns remote as a String, except that an empty remote value
+// stays null while prior is null. Discord echoes unset optional fields as zero
+// values, which would otherwise show up as a diff against an unset attribute.
+func KeepNullString(prior types.String, remote string) types.String {
+	if remote == "" && prior.IsNull() {
+		return types.StringNull()
+	}
+	return types.StringValue(remote)
+}
+
+// KeepNullInt64 is KeepNullString for Int64 attributes.
+func KeepNullInt64(prior types.Int64, remote int64) types.Int64 {
+	if remote == 0 && prior.IsNull() {
+		return types.Int64Null()
+	}
+	return types.Int64Value(remote)
+}
+
+// KeepNullBool is KeepNullString for Bool attributes.
+func KeepNullBool(prior types.Bool, remote bool) types.Bool {
+	if !remote && prior.IsNull() {
+		return types.BoolNull()
+	}
+	return types.BoolValue(remote)
+}
@@ -0,0 +1,61 @@
+package fw
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// resourceIdentity is a resource's identity: its attributes in the order of
+// the string import ID they replace (server_id:role_id is server_id, role_id),
+// each with the state attribute it is copied from.
+type resourceIdentity []identityAttribute
+
+type identityAttribute struct {
+	name  string
+	state path.Path
+}
+
+// schema returns the identity schema. Every attribute is a string that import
+// blocks must set.
+func (ri resourceIdentity) schema() identityschema.Schema {
+	attrs := make(map[string]identityschema.Attribute, len(ri))
+	for _, a := range ri {
+		attrs[a.name] = identityschema.StringAttribute{RequiredForImport: true}
+	}
+	return identityschema.Schema{Attributes: attrs}
+}
+
+// set copies the identity from state at the end of a create, read or update.
+// It does nothing when the operation failed or removed the resource.
+func (ri resourceIdentity) set(ctx context.Context, state *tfsdk.State, identity *tfsdk.ResourceIdentity, diags *diag.Diagnostics) {
+	if identity == nil || diags.HasError() || state.Raw.IsNull() {
+		return
+	}
+	for _, a := range ri {
+		var v types.String
+		diags.Append(state.GetAttribute(ctx, a.state, &v)...)
+		diags.Append(identity.SetAttribute(ctx, path.Root(a.name), v)...)
+	}
+}
+
+// importID returns the string import ID: the one given to terraform import, or
+// for an import block with identity, the identity attributes joined with ":".
+func (ri resourceIdentity) importID(ctx context.Context, req resource.ImportStateRequest, diags *diag.Diagnostics) string {
+	if req.ID != "" || req.Identity == nil {
+		return req.ID
+	}
+	parts := make([]string, len(ri))
+	for i, a := range ri {
+		var v types.String
+		diags.Append(req.Identity.GetAttribute(ctx, path.Root(a.name), &v)...)
+		parts[i] = v.ValueString()
+	}
+	return strings.Join(parts, ":")
+}
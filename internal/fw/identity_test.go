@@ -0,0 +1,143 @@
+package fw
+
+import (
+	"context"
+	"encoding/json"
+	"maps"
+	"testing"
+
+	"github.com/45ck/terraform-provider-discord/internal/fakediscord"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// importIdentities has, for every resource, an identity and the string import
+// ID it stands for.
+var importIdentities = map[string]struct {
+	identity map[string]string
+	id       string
+}{
+	"discord_api_resource":        {map[string]string{"id": "111"}, "111"},
+	"discord_automod_rule":        {map[string]string{"server_id": "111", "rule_id": "222"}, "111:222"},
+	"discord_ban":                 {map[string]string{"server_id": "111", "user_id": "222"}, "111:222"},
+	"discord_channel":             {map[string]string{"channel_id": "111"}, "111"},
+	"discord_channel_order":       {map[string]string{"server_id": "111"}, "111"},
+	"discord_channel_permission":  {map[string]string{"channel_id": "111", "overwrite_id": "222", "type": "role"}, "111:222:role"},
+	"discord_channel_permissions": {map[string]string{"channel_id": "111"}, "111"},
+	"discord_emoji":               {map[string]string{"server_id": "111", "emoji_id": "222"}, "111:222"},
+	"discord_guild_settings":      {map[string]string{"server_id": "111"}, "111"},
+	"discord_guild_template":      {map[string]string{"server_id": "111", "template_code": "abc"}, "111:abc"},
+	"discord_guild_template_sync": {map[string]string{"server_id": "111", "template_code": "abc"}, "111:abc"},
+	"discord_invite":              {map[string]string{"code": "abc"}, "abc"},
+	"discord_member_nickname":     {map[string]string{"server_id": "111", "user_id": "222"}, "111:222"},
+	"discord_member_roles":        {map[string]string{"server_id": "111", "user_id": "222"}, "111:222"},
+	"discord_member_timeout":      {map[string]string{"server_id": "111", "user_id": "222"}, "111:222"},
+	"discord_member_verification": {map[string]string{"server_id": "111"}, "111"},
+	"discord_message":             {map[string]string{"channel_id": "111", "message_id": "222"}, "111:222"},
+	"discord_onboarding":          {map[string]string{"server_id": "111"}, "111"},
+	"discord_role":                {map[string]string{"server_id": "111", "role_id": "222"}, "111:222"},
+	"discord_role_everyone":       {map[string]string{"server_id": "111"}, "111"},
+	"discord_role_order":          {map[string]string{"server_id": "111"}, "111"},
+	"discord_scheduled_event":     {map[string]string{"server_id": "111", "event_id": "222"}, "111:222"},
+	"discord_server":              {map[string]string{"server_id": "111"}, "111"},
+	"discord_soundboard_sound":    {map[string]string{"server_id": "111", "sound_id": "222"}, "111:222"},
+	"discord_stage_instance":      {map[string]string{"channel_id": "111"}, "111"},
+	"discord_sticker":             {map[string]string{"server_id": "111", "sticker_id": "222"}, "111:222"},
+	"discord_system_channel":      {map[string]string{"server_id": "111"}, "111"},
+	"discord_thread":              {map[string]string{"thread_id": "111"}, "111"},
+	"discord_thread_member":       {map[string]string{"thread_id": "111", "user_id": "222"}, "111:222"},
+	"discord_webhook":             {map[string]string{"webhook_id": "111"}, "111"},
+	"discord_welcome_screen":      {map[string]string{"server_id": "111"}, "111"},
+	"discord_widget_settings":     {map[string]string{"server_id": "111"}, "111"},
+}
+
+// TestResourceIdentity_Import checks that every resource has an identity and
+// that importing by identity gives the same state as importing by string ID.
+func TestResourceIdentity_Import(t *testing.T) {
+	ctx := context.Background()
+	p, schemas := newProtocolServer(t, nil)
+	identities, err := p.GetResourceIdentitySchemas(ctx, &tfprotov6.GetResourceIdentitySchemasRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for typ, res := range schemas.ResourceSchemas {
+		t.Run(typ, func(t *testing.T) {
+			is, ok := identities.IdentitySchemas[typ]
+			if !ok {
+				t.Fatal("no identity schema")
+			}
+			tc, ok := importIdentities[typ]
+			if !ok {
+				t.Fatal("no test case")
+			}
+			if len(is.IdentityAttributes) != len(tc.identity) {
+				t.Fatalf("identity has %d attributes, test case %d", len(is.IdentityAttributes), len(tc.identity))
+			}
+
+			vals := map[string]tftypes.Value{}
+			for k, v := range tc.identity {
+				vals[k] = tftypes.NewValue(tftypes.String, v)
+			}
+			identity, err := tfprotov6.NewDynamicValue(is.ValueType(), tftypes.NewValue(is.ValueType(), vals))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			byIdentity := importState(t, p, &tfprotov6.ImportResourceStateRequest{TypeName: typ, Identity: &tfprotov6.ResourceIdentityData{IdentityData: &identity}}, res.ValueType())
+			byID := importState(t, p, &tfprotov6.ImportResourceStateRequest{TypeName: typ, ID: tc.id}, res.ValueType())
+			if !byIdentity.Equal(byID) {
+				t.Fatalf("import by identity:\n%s\nimport by ID %q:\n%s", byIdentity, tc.id, byID)
+			}
+		})
+	}
+}
+
+// newProtocolServer returns the provider as Terraform talks to it and its
+// schemas. It is configured with the fake's bot token, settings and a base_url
+// nothing listens on unless settings has another.
+func newProtocolServer(t *testing.T, settings map[string]any) (tfprotov6.ProviderServer, *tfprotov6.GetProviderSchemaResponse) {
+	t.Helper()
+	ctx := context.Background()
+	p, err := providerserver.NewProtocol6WithError(New("test")())()
+	if err != nil {
+		t.Fatal(err)
+	}
+	schemas, err := p.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg := map[string]any{"token": fakediscord.Token, "base_url": "http://127.0.0.1:1/api"}
+	maps.Copy(cfg, settings)
+	raw, err := json.Marshal(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	configured, err := p.ConfigureProvider(ctx, &tfprotov6.ConfigureProviderRequest{Config: &tfprotov6.DynamicValue{JSON: raw}})
+	if err != nil || len(configured.Diagnostics) > 0 {
+		t.Fatalf("ConfigureProvider: %v %v", err, configured.Diagnostics)
+	}
+	return p, schemas
+}
+
+func importState(t *testing.T, p tfprotov6.ProviderServer, req *tfprotov6.ImportResourceStateRequest, typ tftypes.Type) tftypes.Value {
+	t.Helper()
+	resp, err := p.ImportResourceState(context.Background(), req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, d := range resp.Diagnostics {
+		if d.Severity == tfprotov6.DiagnosticSeverityError {
+			t.Fatalf("ImportResourceState: %s: %s", d.Summary, d.Detail)
+		}
+	}
+	if len(resp.ImportedResources) != 1 {
+		t.Fatalf("imported %d resources", len(resp.ImportedResources))
+	}
+	v, err := resp.ImportedResources[0].State.Unmarshal(typ)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return v
+}
@@ -2,31 +2,73 @@ package fw
 
 import (
 	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
 
 	"github.com/45ck/terraform-provider-discord/discord"
+	"github.com/45ck/terraform-provider-discord/internal/fw/validate"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 // New returns a constructor for the Plugin Framework provider implementation.
 func New(version string) func() provider.Provider {
+	return NewWithTransport(version, nil)
+}
+
+// NewWithTransport is New with a hook that wraps the provider's HTTP transport,
+// used by tests to record and replay Discord traffic.
+func NewWithTransport(version string, wrap func(http.RoundTripper) http.RoundTripper) func() provider.Provider {
 	return func() provider.Provider {
-		return &discordProvider{version: version}
+		return &discordProvider{version: version, wrapTransport: wrap}
 	}
 }
 
 type discordProvider struct {
-	version string
+	version       string
+	wrapTransport func(http.RoundTripper) http.RoundTripper
 }
 
 type providerModel struct {
-	Token    types.String `tfsdk:"token"`
+	Token        types.String `tfsdk:"token"`
+	TokenFile    types.String `tfsdk:"token_file"`
+	TokenCommand types.List   `tfsdk:"token_command"`
+	VerifyToken  types.Bool   `tfsdk:"verify_token"`
+
 	ClientID types.String `tfsdk:"client_id"`
 	Secret   types.String `tfsdk:"secret"`
+
+	OAuth2Scopes types.List   `tfsdk:"oauth2_scopes"`
+	BearerToken  types.String `tfsdk:"bearer_token"`
+
+	DefaultServerID       types.String `tfsdk:"default_server_id"`
+	DefaultAuditLogReason types.String `tfsdk:"default_audit_log_reason"`
+	ReadOnly              types.Bool   `tfsdk:"read_only"`
+
+	MaxRetries      types.Int64  `tfsdk:"max_retries"`
+	RetryMaxBackoff types.String `tfsdk:"retry_max_backoff"`
+
+	BaseURL        types.String `tfsdk:"base_url"`
+	APIVersion     types.Int64  `tfsdk:"api_version"`
+	ProxyURL       types.String `tfsdk:"proxy_url"`
+	RequestTimeout types.String `tfsdk:"request_timeout"`
+	CACertFile     types.String `tfsdk:"ca_cert_file"`
+
+	RateLimitStateDir       types.String `tfsdk:"rate_limit_state_dir"`
+	MaxConcurrentRequests   types.Int64  `tfsdk:"max_concurrent_requests"`
+	SerializeGuildMutations types.Bool   `tfsdk:"serialize_guild_mutations"`
 }
 
 func (p *discordProvider) Metadata(_ context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -39,15 +81,102 @@ func (p *discordProvider) Schema(_ context.Context, _ provider.SchemaRequest, re
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
 			"token": schema.StringAttribute{
-				Required:  true,
-				Sensitive: true,
+				Optional:    true,
+				Sensitive:   true,
+				Description: fmt.Sprintf("Bot token. When unset, it is read from the %s environment variable, then from token_file, then from token_command.", discord.TokenEnv),
+			},
+			"token_file": schema.StringAttribute{
+				Optional:    true,
+				Description: "Path of a file holding the bot token, e.g. a mounted secret.",
+			},
+			"token_command": schema.ListAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "Command and arguments, run without a shell, whose standard output is the bot token, e.g. [\"op\", \"read\", \"op://vault/discord/token\"].",
+			},
+			"verify_token": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Check the token with GET /users/@me when the provider is configured, so that a rejected token fails before any resource is touched. Defaults to false.",
 			},
 			"client_id": schema.StringAttribute{
-				Optional: true,
+				Optional:    true,
+				Description: "OAuth2 client ID of the application. With `secret` it is exchanged for a Bearer token (client credentials grant) for the endpoints that need one. That token acts for the application, so it cannot edit application command permissions; use `bearer_token` for those.",
 			},
 			"secret": schema.StringAttribute{
-				Optional:  true,
-				Sensitive: true,
+				Optional:    true,
+				Sensitive:   true,
+				Description: "OAuth2 client secret of the application. Required with `client_id`.",
+			},
+			"oauth2_scopes": schema.ListAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: fmt.Sprintf("Scopes requested with the client credentials. Defaults to %q.", strings.Join(discord.DefaultOAuth2Scopes, " ")),
+			},
+			"bearer_token": schema.StringAttribute{
+				Optional:    true,
+				Sensitive:   true,
+				Description: "OAuth2 access token of a user, without the `Bearer ` prefix, sent instead of the client credentials token on the endpoints that need a Bearer token. Editing application command permissions needs one with the `applications.commands.permissions.update` scope, from a user who can manage the server and its roles.",
+			},
+			"default_server_id": schema.StringAttribute{
+				Optional:    true,
+				Description: "Server (guild) ID used by guild-scoped resources and data sources that leave `server_id` unset.",
+				Validators: []validator.String{
+					validate.Snowflake(),
+				},
+			},
+			"default_audit_log_reason": schema.StringAttribute{
+				Optional:    true,
+				Description: fmt.Sprintf("Audit log reason for changes whose resource sets no `reason`, as a Go template. It can use {{.ResourceType}}, {{.Operation}} (create, update or delete), {{.Workspace}} ($%s, or \"default\") and {{.Ticket}} ($%s).", discord.WorkspaceEnv, discord.ChangeTicketEnv),
+			},
+			"read_only": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Refuse every Discord request but GET, so that refresh and plan cannot change anything, even through `discord_api_resource`. Applies that would change something fail. Defaults to false.",
+			},
+			"max_retries": schema.Int64Attribute{
+				Optional:    true,
+				Description: fmt.Sprintf("Retries for transient Discord failures (HTTP 500/502/503/504, connection resets). 0 disables them. Defaults to %d.", discord.DefaultMaxRetries),
+			},
+			"retry_max_backoff": schema.StringAttribute{
+				Optional:    true,
+				Description: fmt.Sprintf("Upper bound for the exponential backoff between transient retries, as a duration (e.g. \"10s\"). Defaults to %q.", discord.DefaultRetryMaxBackoff.String()),
+				Validators: []validator.String{
+					validate.Duration(),
+				},
+			},
+			"base_url": schema.StringAttribute{
+				Optional:    true,
+				Description: fmt.Sprintf("Discord REST API root without the version segment. Defaults to %q. Useful for local mocks and API gateways.", discord.DefaultBaseURL),
+			},
+			"api_version": schema.Int64Attribute{
+				Optional:    true,
+				Description: fmt.Sprintf("Discord REST API version, appended to base_url as /v{api_version}. Defaults to %d.", discord.DefaultAPIVersion),
+			},
+			"proxy_url": schema.StringAttribute{
+				Optional:    true,
+				Description: "HTTP(S) proxy for all Discord requests (e.g. http://proxy.internal:3128). Defaults to the HTTPS_PROXY/HTTP_PROXY environment variables.",
+			},
+			"request_timeout": schema.StringAttribute{
+				Optional:    true,
+				Description: "Timeout for a single HTTP attempt, as a duration (e.g. \"30s\"). Defaults to no timeout.",
+				Validators: []validator.String{
+					validate.Duration(),
+				},
+			},
+			"ca_cert_file": schema.StringAttribute{
+				Optional:    true,
+				Description: "Path to a PEM bundle of additional trusted CA certificates, e.g. for a TLS-intercepting egress proxy.",
+			},
+			"rate_limit_state_dir": schema.StringAttribute{
+				Optional:    true,
+				Description: "Directory for rate limit state shared by provider processes on this machine that use the same token, e.g. parallel CI workspaces. When set, global cooldowns and per-route buckets are coordinated through a locked file in this directory.",
+			},
+			"max_concurrent_requests": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Maximum number of Discord HTTP requests in flight at once, across all resources. Unset or 0 means unlimited.",
+			},
+			"serialize_guild_mutations": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Serialize per server the mutation endpoints that conflict when called concurrently, such as the bulk role and channel position updates (`PATCH /guilds/{id}/roles` and `PATCH /guilds/{id}/channels`). Defaults to false.",
 			},
 		},
 	}
@@ -61,21 +190,165 @@ func (p *discordProvider) Configure(ctx context.Context, req provider.ConfigureR
 		return
 	}
 
+	token, tokenSource := resolveToken(ctx, cfg, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if token == "" {
+		resp.Diagnostics.AddError("Missing Discord token", fmt.Sprintf("Set token, the %s environment variable, token_file or token_command.", discord.TokenEnv))
+		return
+	}
+	if err := discord.ValidateToken(token); err != nil {
+		resp.Diagnostics.AddAttributeError(tokenSource.path, "Invalid Discord token", fmt.Sprintf("The token from %s is invalid: %s.", tokenSource.name, err))
+		return
+	}
+
 	c := &discord.Config{
-		Token:    cfg.Token.ValueString(),
+		Token:    token,
 		ClientID: cfg.ClientID.ValueString(),
 		Secret:   cfg.Secret.ValueString(),
+
+		DefaultServerID: cfg.DefaultServerID.ValueString(),
+		ReadOnly:        cfg.ReadOnly.ValueBool(),
+
+		MaxRetries: discord.DefaultMaxRetries,
+	}
+
+	if (c.ClientID == "") != (c.Secret == "") {
+		resp.Diagnostics.AddAttributeError(path.Root("secret"), "Incomplete OAuth2 client credentials", "client_id and secret must be set together.")
+		return
+	}
+	c.BearerToken = strings.TrimSpace(cfg.BearerToken.ValueString())
+	if strings.HasPrefix(c.BearerToken, "Bearer ") {
+		resp.Diagnostics.AddAttributeError(path.Root("bearer_token"), "Invalid bearer_token", `bearer_token must not include the "Bearer " prefix; the provider adds it.`)
+		return
+	}
+	if !cfg.OAuth2Scopes.IsNull() && !cfg.OAuth2Scopes.IsUnknown() {
+		resp.Diagnostics.Append(cfg.OAuth2Scopes.ElementsAs(ctx, &c.OAuth2Scopes, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
 	}
 
+	if !cfg.MaxRetries.IsNull() && !cfg.MaxRetries.IsUnknown() {
+		if cfg.MaxRetries.ValueInt64() < 0 {
+			resp.Diagnostics.AddAttributeError(path.Root("max_retries"), "Invalid max_retries", "max_retries must be 0 or greater.")
+			return
+		}
+		c.MaxRetries = int(cfg.MaxRetries.ValueInt64())
+	}
+	if v := strings.TrimSpace(cfg.RetryMaxBackoff.ValueString()); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("retry_max_backoff"), "Invalid retry_max_backoff", err.Error())
+			return
+		}
+		c.RetryMaxBackoff = d
+	}
+
+	if v := strings.TrimSpace(cfg.BaseURL.ValueString()); v != "" {
+		u, err := url.Parse(v)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			resp.Diagnostics.AddAttributeError(path.Root("base_url"), "Invalid base_url", fmt.Sprintf("Expected an absolute http(s) URL such as %q, got %q.", discord.DefaultBaseURL, v))
+			return
+		}
+		c.BaseURL = v
+	}
+	if !cfg.APIVersion.IsNull() && !cfg.APIVersion.IsUnknown() {
+		if cfg.APIVersion.ValueInt64() < 1 {
+			resp.Diagnostics.AddAttributeError(path.Root("api_version"), "Invalid api_version", "api_version must be 1 or greater.")
+			return
+		}
+		c.APIVersion = int(cfg.APIVersion.ValueInt64())
+	}
+	c.ProxyURL = strings.TrimSpace(cfg.ProxyURL.ValueString())
+	if v := strings.TrimSpace(cfg.RequestTimeout.ValueString()); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("request_timeout"), "Invalid request_timeout", err.Error())
+			return
+		}
+		c.RequestTimeout = d
+	}
+	c.CACertFile = strings.TrimSpace(cfg.CACertFile.ValueString())
+	c.RateLimitStateDir = strings.TrimSpace(cfg.RateLimitStateDir.ValueString())
+	if !cfg.MaxConcurrentRequests.IsNull() && !cfg.MaxConcurrentRequests.IsUnknown() {
+		if cfg.MaxConcurrentRequests.ValueInt64() < 0 {
+			resp.Diagnostics.AddAttributeError(path.Root("max_concurrent_requests"), "Invalid max_concurrent_requests", "max_concurrent_requests must be 0 or greater.")
+			return
+		}
+		c.MaxConcurrentRequests = int(cfg.MaxConcurrentRequests.ValueInt64())
+	}
+	c.SerializeGuildMutations = cfg.SerializeGuildMutations.ValueBool()
+	if v := cfg.DefaultAuditLogReason.ValueString(); v != "" {
+		if _, err := discord.ParseReasonTemplate(v); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("default_audit_log_reason"), "Invalid default_audit_log_reason", err.Error())
+			return
+		}
+		c.DefaultAuditLogReason = v
+	}
+	c.WrapTransport = p.wrapTransport
+
 	client, err := c.Client()
 	if err != nil {
 		resp.Diagnostics.AddError("Provider configuration error", err.Error())
 		return
 	}
+	if cfg.VerifyToken.ValueBool() {
+		if _, err := client.Rest.GetCurrentUser(ctx); err != nil {
+			if discord.IsDiscordHTTPStatus(err, 401) {
+				resp.Diagnostics.AddAttributeError(tokenSource.path, "Discord rejected the token", fmt.Sprintf("GET /users/@me with the token from %s returned 401 Unauthorized. Check that it is the bot's current token; resetting it in the developer portal invalidates the old one.", tokenSource.name))
+				return
+			}
+			resp.Diagnostics.AddError("Could not verify the Discord token", err.Error())
+			return
+		}
+	}
 
 	// ProviderData is passed into DataSource/Resource Configure.
 	resp.DataSourceData = client
 	resp.ResourceData = client
+	resp.EphemeralResourceData = client
+}
+
+// tokenSource is where the bot token came from, for diagnostics.
+type tokenSource struct {
+	name string
+	path path.Path
+}
+
+// resolveToken returns the bot token from, in order, token, the DISCORD_TOKEN
+// environment variable, token_file and token_command. It returns "" when none
+// is set and reports sources that fail.
+func resolveToken(ctx context.Context, cfg providerModel, diags *diag.Diagnostics) (string, tokenSource) {
+	if v := strings.TrimSpace(cfg.Token.ValueString()); v != "" {
+		return v, tokenSource{"token", path.Root("token")}
+	}
+	if v := strings.TrimSpace(os.Getenv(discord.TokenEnv)); v != "" {
+		return v, tokenSource{"the " + discord.TokenEnv + " environment variable", path.Root("token")}
+	}
+	if f := strings.TrimSpace(cfg.TokenFile.ValueString()); f != "" {
+		src := tokenSource{"token_file", path.Root("token_file")}
+		v, err := discord.ReadTokenFile(f)
+		if err != nil {
+			diags.AddAttributeError(src.path, "Invalid token_file", err.Error())
+		}
+		return v, src
+	}
+	if !cfg.TokenCommand.IsNull() && !cfg.TokenCommand.IsUnknown() {
+		src := tokenSource{"token_command", path.Root("token_command")}
+		var argv []string
+		diags.Append(cfg.TokenCommand.ElementsAs(ctx, &argv, false)...)
+		if diags.HasError() {
+			return "", src
+		}
+		v, err := discord.RunTokenCommand(ctx, argv)
+		if err != nil {
+			diags.AddAttributeError(src.path, "token_command failed", err.Error())
+		}
+		return v, src
+	}
+	return "", tokenSource{"token", path.Root("token")}
 }
 
 func (p *discordProvider) Resources(_ context.Context) []func() resource.Resource {
@@ -134,6 +407,30 @@ func (p *discordProvider) DataSources(_ context.Context) []func() datasource.Dat
 	}
 }
 
+func (p *discordProvider) EphemeralResources(_ context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		NewWebhookCredentialsEphemeralResource,
+		NewOAuth2TokenEphemeralResource,
+	}
+}
+
+func (p *discordProvider) Functions(_ context.Context) []func() function.Function {
+	return []func() function.Function{
+		NewPermissionsFunction,
+		NewPermissionNamesFunction,
+		NewColorFunction,
+		NewSnowflakeTimeFunction,
+		NewMentionUserFunction,
+		NewMentionRoleFunction,
+		NewMentionChannelFunction,
+		NewTimestampTagFunction,
+		NewCustomEmojiFunction,
+		NewMessageLinkFunction,
+		NewEscapeMarkdownFunction,
+		NewOAuth2InviteURLFunction,
+	}
+}
+
 func getContextFromProviderData(d any) (*discord.Context, diag.Diagnostics) {
 	if d == nil {
 		return nil, diag.Diagnostics{diag.NewErrorDiagnostic("Provider not configured", "provider data was nil")}
@@ -0,0 +1,471 @@
+package fw
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/45ck/terraform-provider-discord/discord"
+	"github.com/45ck/terraform-provider-discord/internal/fakediscord"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+// fakeEnv is a fakediscord server with one guild, for resource tests that run
+// without TF_ACC or real Discord credentials.
+type fakeEnv struct {
+	srv     *fakediscord.Server
+	guildID string
+	// maxRetries is the provider's max_retries. Zero keeps failures fast.
+	maxRetries int
+	// bearerToken is the provider's bearer_token, for Bearer endpoints.
+	bearerToken string
+	// defaultServerID is the provider's default_server_id, if set.
+	defaultServerID string
+	// auditLogReason is the provider's default_audit_log_reason, if set.
+	auditLogReason string
+	// readOnly sets the provider's read_only.
+	readOnly bool
+}
+
+func newFakeEnv(t *testing.T) *fakeEnv {
+	t.Helper()
+	srv := fakediscord.New()
+	t.Cleanup(srv.Close)
+	return &fakeEnv{srv: srv, guildID: srv.AddGuild("tf-test")}
+}
+
+// config prefixes body with a provider block pointed at the fake.
+func (e *fakeEnv) config(body string, args ...any) string {
+	var extra string
+	if e.bearerToken != "" {
+		extra += fmt.Sprintf("  bearer_token = %q\n", e.bearerToken)
+	}
+	if e.defaultServerID != "" {
+		extra += fmt.Sprintf("  default_server_id = %q\n", e.defaultServerID)
+	}
+	if e.auditLogReason != "" {
+		extra += fmt.Sprintf("  default_audit_log_reason = %q\n", e.auditLogReason)
+	}
+	if e.readOnly {
+		extra += "  read_only   = true\n"
+	}
+	return fmt.Sprintf(`
+provider "discord" {
+  token       = %q
+  base_url    = %q
+  max_retries = %d
+%s}
+`, fakediscord.Token, e.srv.URL, e.maxRetries, extra) + fmt.Sprintf(body, args...)
+}
+
+func (e *fakeEnv) providerFactories() map[string]func() (tfprotov6.ProviderServer, error) {
+	return map[string]func() (tfprotov6.ProviderServer, error){
+		"discord": providerserver.NewProtocol6WithError(New("test")()),
+	}
+}
+
+// test runs tc against the fake. It needs a terraform CLI, found the same way
+// terraform-plugin-testing finds it, and skips when there is none rather than
+// downloading one.
+func (e *fakeEnv) test(t *testing.T, tc resource.TestCase) {
+	t.Helper()
+	if os.Getenv("TF_ACC_TERRAFORM_PATH") == "" {
+		if _, err := exec.LookPath("terraform"); err != nil {
+			t.Skip("terraform CLI not found; install it or set TF_ACC_TERRAFORM_PATH")
+		}
+	}
+	tc.ProtoV6ProviderFactories = e.providerFactories()
+	resource.UnitTest(t, tc)
+}
+
+// faults makes the provider inject rules into its requests, through the file
+// named by discord.FaultRulesEnv. The test must not run in parallel.
+func (e *fakeEnv) faults(t *testing.T, rules ...discord.FaultRule) {
+	t.Helper()
+	raw, err := json.Marshal(discord.FaultRules{Rules: rules})
+	if err != nil {
+		t.Fatal(err)
+	}
+	file := filepath.Join(t.TempDir(), "faults.json")
+	if err := os.WriteFile(file, raw, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv(discord.FaultRulesEnv, file)
+}
+
+// mishaps are the faults every create should survive: a global rate limit, a
+// 502 for a create Discord did apply, and a 404 when reading the new object.
+func mishaps(createPath, getPath string) []discord.FaultRule {
+	return []discord.FaultRule{
+		{Method: "POST", Path: createPath, Status: 429, Global: true, RetryAfter: 0.05},
+		{Method: "POST", Path: createPath, Status: 502, AfterSend: true},
+		{Method: "GET", Path: getPath, Status: 404, Created: true},
+	}
+}
+
+// checkSingle fails unless list returns exactly one ID and it is the one in state.
+func (e *fakeEnv) checkSingle(name string, list func(ctx context.Context, c *discord.RestClient) ([]string, error)) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("%s not found in state", name)
+		}
+		ids, err := list(context.Background(), e.client())
+		if err != nil {
+			return err
+		}
+		if len(ids) != 1 || ids[0] != rs.Primary.ID {
+			return fmt.Errorf("%s: expected exactly %s on Discord, found %v", name, rs.Primary.ID, ids)
+		}
+		return nil
+	}
+}
+
+// client returns a RestClient for the fake, for checks that look past state.
+func (e *fakeEnv) client() *discord.RestClient {
+	c := discord.NewRestClient(fakediscord.Token, nil)
+	c.BaseURL = discord.APIBaseURL(e.srv.URL, 0)
+	c.MaxRetries = 0
+	return c
+}
+
+// checkDestroyed returns a CheckDestroy that fails unless get answers 404 for
+// every resource of type typ in the final state.
+func (e *fakeEnv) checkDestroyed(typ string, get func(ctx context.Context, c *discord.RestClient, rs *terraform.ResourceState) error) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		c := e.client()
+		for name, rs := range s.RootModule().Resources {
+			if rs.Type != typ {
+				continue
+			}
+			err := get(context.Background(), c, rs)
+			if err == nil {
+				return fmt.Errorf("%s still exists", name)
+			}
+			if !discord.IsDiscordHTTPStatus(err, 404) {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// serverScopedImportID builds the server_id:id import ID used by resources that
+// live inside a guild.
+func serverScopedImportID(name string) resource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return "", fmt.Errorf("%s not found in state", name)
+		}
+		return rs.Primary.Attributes["server_id"] + ":" + rs.Primary.ID, nil
+	}
+}
+
+// Terraform refuses every resource of a provider whose schema is invalid, for
+// example one with a default on an attribute that is not computed.
+func TestProvider_SchemaIsValid(t *testing.T) {
+	p, err := providerserver.NewProtocol6WithError(New("test")())()
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := p.GetProviderSchema(context.Background(), &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, d := range resp.Diagnostics {
+		t.Errorf("%s: %s", d.Summary, d.Detail)
+	}
+}
+
+// Resource IDs keep their prior state during updates. Without this every update
+// shows the ID as "known after apply", and Update sees an unknown, empty ID.
+func TestProvider_ResourceIDsKeepPriorState(t *testing.T) {
+	ctx := context.Background()
+	useState := stringplanmodifier.UseStateForUnknown().Description(ctx)
+	for _, f := range New("test")().Resources(ctx) {
+		r := f()
+		var md fwresource.MetadataResponse
+		r.Metadata(ctx, fwresource.MetadataRequest{ProviderTypeName: "discord"}, &md)
+		var sr fwresource.SchemaResponse
+		r.Schema(ctx, fwresource.SchemaRequest{}, &sr)
+
+		id, ok := sr.Schema.Attributes["id"].(schema.StringAttribute)
+		if !ok {
+			t.Errorf("%s: no string id attribute", md.TypeName)
+			continue
+		}
+		if !slices.ContainsFunc(id.PlanModifiers, func(m planmodifier.String) bool { return m.Description(ctx) == useState }) {
+			t.Errorf("%s: id does not use the prior state when unknown", md.TypeName)
+		}
+	}
+}
+
+func TestProvider_FakeTokenSources(t *testing.T) {
+	e := newFakeEnv(t)
+	file := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(file, []byte(fakediscord.Token+"\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	server := fmt.Sprintf(`
+data "discord_server" "s" {
+  server_id = %q
+}
+`, e.guildID)
+
+	t.Setenv(discord.TokenEnv, fakediscord.Token)
+	e.test(t, resource.TestCase{
+		Steps: []resource.TestStep{
+			{
+				// The environment variable replaces token.
+				Config: fmt.Sprintf(`
+provider "discord" {
+  base_url     = %q
+  max_retries  = 0
+  verify_token = true
+}
+`, e.srv.URL) + server,
+				Check: resource.TestCheckResourceAttr("data.discord_server.s", "name", "tf-test"),
+			},
+		},
+	})
+
+	t.Setenv(discord.TokenEnv, "")
+	e.test(t, resource.TestCase{
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+provider "discord" {
+  token_file   = %q
+  base_url     = %q
+  max_retries  = 0
+  verify_token = true
+}
+`, file, e.srv.URL) + server,
+				Check: resource.TestCheckResourceAttr("data.discord_server.s", "name", "tf-test"),
+			},
+		},
+	})
+}
+
+func TestProvider_FakeBadToken(t *testing.T) {
+	e := newFakeEnv(t)
+	t.Setenv(discord.TokenEnv, "")
+	server := fmt.Sprintf(`
+data "discord_server" "s" {
+  server_id = %q
+}
+`, e.guildID)
+
+	e.test(t, resource.TestCase{
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+provider "discord" {
+  token    = "Bot %s"
+  base_url = %q
+}
+`, fakediscord.Token, e.srv.URL) + server,
+				ExpectError: regexp.MustCompile(`Invalid Discord token`),
+			},
+			{
+				// Well-formed but not the bot's token: verify_token catches it in
+				// Configure instead of on the first read.
+				Config: fmt.Sprintf(`
+provider "discord" {
+  token        = "d3Jvbmc.d3Jvbmc.wrong-token"
+  base_url     = %q
+  max_retries  = 0
+  verify_token = true
+}
+`, e.srv.URL) + server,
+				ExpectError: regexp.MustCompile(`Discord rejected the token`),
+			},
+			{
+				Config: fmt.Sprintf(`
+provider "discord" {
+  base_url = %q
+}
+`, e.srv.URL) + server,
+				ExpectError: regexp.MustCompile(`Missing Discord token`),
+			},
+		},
+	})
+}
+
+func TestProvider_FakeDefaultAuditLogReason(t *testing.T) {
+	e := newFakeEnv(t)
+	t.Setenv(discord.ChangeTicketEnv, "CHG-7")
+	// reasons returns the audit log reasons of the fake's requests with method.
+	reasons := func(method string) []string {
+		var out []string
+		for _, r := range e.srv.Requests() {
+			if r.Method == method && strings.HasPrefix(r.Path, "/guilds/") {
+				out = append(out, r.Reason)
+			}
+		}
+		return out
+	}
+	checkReasons := func(method string, want ...string) resource.TestCheckFunc {
+		return func(*terraform.State) error {
+			if got := reasons(method); !slices.Equal(got, want) {
+				return fmt.Errorf("%s reasons = %q, want %q", method, got, want)
+			}
+			return nil
+		}
+	}
+
+	e.auditLogReason = "terraform {{.Operation}} {{.ResourceType}} ({{.Ticket}})"
+	e.test(t, resource.TestCase{
+		Steps: []resource.TestStep{
+			{
+				Config: e.config(`
+resource "discord_role" "mod" {
+  server_id = %q
+  name      = "moderator"
+}
+`, e.guildID),
+				Check: checkReasons("POST", "terraform create discord_role (CHG-7)"),
+			},
+			{
+				// A resource's own reason wins.
+				Config: e.config(`
+resource "discord_role" "mod" {
+  server_id = %q
+  name      = "moderators"
+  reason    = "renamed"
+}
+`, e.guildID),
+				Check: checkReasons("PATCH", "renamed"),
+			},
+		},
+	})
+
+	e.auditLogReason = "{{.Ticket"
+	e.test(t, resource.TestCase{
+		Steps: []resource.TestStep{
+			{
+				Config: e.config(`
+data "discord_server" "s" {
+  server_id = %q
+}
+`, e.guildID),
+				ExpectError: regexp.MustCompile(`Invalid default_audit_log_reason`),
+			},
+		},
+	})
+}
+
+// writes counts the requests the fake served that were not GETs.
+func (e *fakeEnv) writes() int {
+	n := 0
+	for _, r := range e.srv.Requests() {
+		if r.Method != "GET" {
+			n++
+		}
+	}
+	return n
+}
+
+func TestProvider_FakeReadOnly(t *testing.T) {
+	e := newFakeEnv(t)
+	const role = `
+resource "discord_role" "mod" {
+  server_id = %q
+  name      = %q
+}
+`
+	created := e.config(role, e.guildID, "moderator")
+	e.readOnly = true
+	renamed := e.config(role, e.guildID, "moderators")
+	apiResource := e.config(`
+resource "discord_api_resource" "role" {
+  create_path      = "/guilds/%[1]s/roles"
+  create_body_json = jsonencode({ name = "raw" })
+  read_path        = "/guilds/%[1]s/roles"
+}
+
+data "discord_api_request" "roles" {
+  path = "/guilds/%[1]s/roles"
+}
+`, e.guildID)
+	e.readOnly = false
+
+	var before int
+	e.test(t, resource.TestCase{
+		Steps: []resource.TestStep{
+			{
+				Config: created,
+				Check: func(*terraform.State) error {
+					before = e.writes()
+					return nil
+				},
+			},
+			{
+				// Refresh and plan work and show the change.
+				Config:             renamed,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				// Applying it does not.
+				Config:      renamed,
+				ExpectError: regexp.MustCompile(`read-only[\s\S]*refusing PATCH`),
+			},
+			{
+				Config:      apiResource,
+				ExpectError: regexp.MustCompile(`read-only[\s\S]*refusing POST`),
+			},
+			{
+				Config: created,
+				Check: func(*terraform.State) error {
+					if n := e.writes(); n != before {
+						return fmt.Errorf("%d writes were sent in read-only mode", n-before)
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
+
+func TestProvider_FakeBearerToken(t *testing.T) {
+	e := newFakeEnv(t)
+	const me = `
+data "discord_api_request" "me" {
+  path = "/users/@me"
+  auth = "bearer"
+}
+`
+	e.bearerToken = e.srv.IssueUserToken(fakediscord.CommandPermissionsScope)
+	valid := e.config(me)
+	e.bearerToken = "Bearer " + e.bearerToken
+	prefixed := e.config(me)
+
+	e.test(t, resource.TestCase{
+		Steps: []resource.TestStep{
+			{
+				Config: valid,
+				Check:  resource.TestCheckResourceAttrSet("data.discord_api_request.me", "response_json"),
+			},
+			{
+				Config:      prefixed,
+				ExpectError: regexp.MustCompile(`Invalid bearer_token`),
+			},
+		},
+	})
+}
@@ -42,12 +42,14 @@ type apiResourceModel struct {
 	UpdateMethod   types.String `tfsdk:"update_method"`
 	UpdatePath     types.String `tfsdk:"update_path"`
 	UpdateBodyJSON types.String `tfsdk:"update_body_json"`
+	BodyVersion    types.Int64  `tfsdk:"body_version"`
 
 	DeleteMethod   types.String `tfsdk:"delete_method"`
 	DeletePath     types.String `tfsdk:"delete_path"`
 	DeleteBodyJSON types.String `tfsdk:"delete_body_json"`
 
 	Reason types.String `tfsdk:"reason"`
+	Auth   types.String `tfsdk:"auth"`
 
 	ResponseJSON types.String `tfsdk:"response_json"`
 }
@@ -62,9 +64,11 @@ func (r *apiResourceResource) Schema(_ context.Context, _ resource.SchemaRequest
 	}
 
 	resp.Schema = schema.Schema{
+		Version: 1,
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
-				Computed: true,
+				Computed:      true,
+				PlanModifiers: stringUseState,
 			},
 
 			"id_field": schema.StringAttribute{
@@ -87,12 +91,11 @@ func (r *apiResourceResource) Schema(_ context.Context, _ resource.SchemaRequest
 			"create_body_json": schema.StringAttribute{
 				Optional:  true,
 				Sensitive: true,
+				WriteOnly: true,
 				Validators: []validator.String{
 					validate.JSONString(),
 				},
-				PlanModifiers: []planmodifier.String{
-					planmod.NormalizeJSONString(),
-				},
+				Description: "JSON body of the create call. Write-only: it is not stored in state.",
 			},
 
 			"read_path": schema.StringAttribute{
@@ -121,12 +124,15 @@ func (r *apiResourceResource) Schema(_ context.Context, _ resource.SchemaRequest
 			"update_body_json": schema.StringAttribute{
 				Optional:  true,
 				Sensitive: true,
+				WriteOnly: true,
 				Validators: []validator.String{
 					validate.JSONString(),
 				},
-				PlanModifiers: []planmodifier.String{
-					planmod.NormalizeJSONString(),
-				},
+				Description: "JSON body of the update call. Write-only: it is not stored in state, so changing it alone plans no update; change body_version to send it again.",
+			},
+			"body_version": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Any change to this value plans an update, which sends update_body_json.",
 			},
 
 			"delete_method": schema.StringAttribute{
@@ -148,13 +154,14 @@ func (r *apiResourceResource) Schema(_ context.Context, _ resource.SchemaRequest
 				},
 			},
 
-			"reason": schema.StringAttribute{
-				Optional:  true,
-				Sensitive: true,
-				PlanModifiers: []planmodifier.String{
-					planmod.IgnoreChangesString(),
+			"reason": reasonAttribute(),
+
+			"auth": schema.StringAttribute{
+				Optional:    true,
+				Description: "Authorization for all calls: \"bot\" (default) or \"bearer\", the provider's bearer_token or else an OAuth2 token from its client_id and secret.",
+				Validators: []validator.String{
+					validate.OneOf("BOT", "BEARER"),
 				},
-				Description: "Optional audit log reason (X-Audit-Log-Reason). This value is not readable.",
 			},
 
 			"response_json": schema.StringAttribute{
@@ -165,6 +172,18 @@ func (r *apiResourceResource) Schema(_ context.Context, _ resource.SchemaRequest
 	}
 }
 
+var apiResourceIdentity = resourceIdentity{
+	{"id", path.Root("id")},
+}
+
+func (r *apiResourceResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = apiResourceIdentity.schema()
+}
+
+func (r *apiResourceResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return writeOnlyStateUpgraders("create_body_json", "update_body_json", "reason")
+}
+
 func (r *apiResourceResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	c, diags := getContextFromProviderData(req.ProviderData)
 	resp.Diagnostics.Append(diags...)
@@ -207,8 +226,14 @@ func (r *apiResourceResource) ModifyPlan(ctx context.Context, req resource.Modif
 }
 
 func (r *apiResourceResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := startResourceSpan(ctx, "discord_api_resource", "create")
+	defer endResourceSpan(span, &resp.Diagnostics)
+	defer apiResourceIdentity.set(ctx, &resp.State, resp.Identity, &resp.Diagnostics)
+
 	var plan apiResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("reason"), &plan.Reason)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("create_body_json"), &plan.CreateBodyJSON)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -233,7 +258,7 @@ func (r *apiResourceResource) Create(ctx context.Context, req resource.CreateReq
 	}
 
 	var out any
-	if err := r.c.DoJSONWithReason(ctx, method, path, nil, body, &out, plan.Reason.ValueString()); err != nil {
+	if err := r.c.DoJSONWithReason(withAPIAuth(ctx, plan.Auth), method, path, nil, body, &out, plan.Reason.ValueString()); err != nil {
 		resp.Diagnostics.AddError("Discord API error", err.Error())
 		return
 	}
@@ -270,6 +295,10 @@ func (r *apiResourceResource) Create(ctx context.Context, req resource.CreateReq
 }
 
 func (r *apiResourceResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := startResourceSpan(ctx, "discord_api_resource", "read")
+	defer endResourceSpan(span, &resp.Diagnostics)
+	defer apiResourceIdentity.set(ctx, &resp.State, resp.Identity, &resp.Diagnostics)
+
 	var state apiResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
@@ -288,9 +317,15 @@ func (r *apiResourceResource) Read(ctx context.Context, req resource.ReadRequest
 }
 
 func (r *apiResourceResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := startResourceSpan(ctx, "discord_api_resource", "update")
+	defer endResourceSpan(span, &resp.Diagnostics)
+	defer apiResourceIdentity.set(ctx, &resp.State, resp.Identity, &resp.Diagnostics)
+
 	var plan apiResourceModel
 	var state apiResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("reason"), &plan.Reason)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("update_body_json"), &plan.UpdateBodyJSON)...)
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -319,7 +354,7 @@ func (r *apiResourceResource) Update(ctx context.Context, req resource.UpdateReq
 	}
 
 	var out any
-	if err := r.c.DoJSONWithReason(ctx, method, path, nil, body, &out, plan.Reason.ValueString()); err != nil {
+	if err := r.c.DoJSONWithReason(withAPIAuth(ctx, plan.Auth), method, path, nil, body, &out, plan.Reason.ValueString()); err != nil {
 		resp.Diagnostics.AddError("Discord API error", err.Error())
 		return
 	}
@@ -330,6 +365,9 @@ func (r *apiResourceResource) Update(ctx context.Context, req resource.UpdateReq
 }
 
 func (r *apiResourceResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := startResourceSpan(ctx, "discord_api_resource", "delete")
+	defer endResourceSpan(span, &resp.Diagnostics)
+
 	var state apiResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
@@ -356,7 +394,7 @@ func (r *apiResourceResource) Delete(ctx context.Context, req resource.DeleteReq
 		return
 	}
 
-	if err := r.c.DoJSONWithReason(ctx, method, path, nil, body, nil, state.Reason.ValueString()); err != nil {
+	if err := r.c.DoJSONWithReason(withAPIAuth(ctx, state.Auth), method, path, nil, body, nil, ""); err != nil {
 		if discord.IsDiscordHTTPStatus(err, 404) {
 			resp.State.RemoveResource(ctx)
 			return
@@ -368,7 +406,11 @@ func (r *apiResourceResource) Delete(ctx context.Context, req resource.DeleteReq
 }
 
 func (r *apiResourceResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	id := apiResourceIdentity.importID(ctx, req, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
 }
 
 func applyDefaults(m *apiResourceModel) {
@@ -386,6 +428,12 @@ func applyDefaults(m *apiResourceModel) {
 	}
 }
 
+// withAPIAuth applies the auth attribute of the raw API resource and data source.
+func withAPIAuth(ctx context.Context, auth types.String) context.Context {
+	scheme, _ := discord.ParseAuthScheme(auth.ValueString())
+	return discord.WithAuth(ctx, scheme)
+}
+
 func substituteID(p, id string) string {
 	return strings.ReplaceAll(p, "{id}", id)
 }
@@ -437,7 +485,7 @@ func (r *apiResourceResource) readIntoState(ctx context.Context, state *apiResou
 	}
 
 	var out any
-	if err := r.c.DoJSON(ctx, "GET", path, q, nil, &out); err != nil {
+	if err := r.c.DoJSON(withAPIAuth(ctx, state.Auth), "GET", path, q, nil, &out); err != nil {
 		if discord.IsDiscordHTTPStatus(err, 404) {
 			state.ID = types.StringNull()
 			return
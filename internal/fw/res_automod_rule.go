@@ -3,7 +3,6 @@ package fw
 import (
 	"context"
 	"encoding/json"
-	"fmt"
 
 	"github.com/45ck/terraform-provider-discord/discord"
 	"github.com/45ck/terraform-provider-discord/internal/fw/fwutil"
@@ -25,7 +24,8 @@ func NewAutoModRuleResource() resource.Resource {
 }
 
 type autoModRuleResource struct {
-	c *discord.RestClient
+	c               *discord.RestClient
+	defaultServerID string
 }
 
 type autoModRuleModel struct {
@@ -39,21 +39,21 @@ type autoModRuleModel struct {
 	EffectiveGID types.String `tfsdk:"effective_server_id"`
 }
 
-type restAutoModRuleLite struct {
-	ID string `json:"id"`
-}
-
 func (r *autoModRuleResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_automod_rule"
 }
 
 func (r *autoModRuleResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Version: 1,
 		Attributes: map[string]schema.Attribute{
-			"id": schema.StringAttribute{Computed: true},
+			"id": schema.StringAttribute{Computed: true, PlanModifiers: stringUseState},
 			"server_id": schema.StringAttribute{
-				Required: true,
+				Optional:    true,
+				Computed:    true,
+				Description: serverIDDescription,
 				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
 					stringplanmodifier.RequiresReplace(),
 				},
 				Validators: []validator.String{
@@ -72,14 +72,7 @@ func (r *autoModRuleResource) Schema(_ context.Context, _ resource.SchemaRequest
 				Computed:    true,
 				Description: "Normalized JSON returned from the Discord API for this rule.",
 			},
-			"reason": schema.StringAttribute{
-				Optional:  true,
-				Sensitive: true,
-				PlanModifiers: []planmodifier.String{
-					planmod.IgnoreChangesString(),
-				},
-				Description: "Optional audit log reason (X-Audit-Log-Reason). This value is not readable.",
-			},
+			"reason": reasonAttribute(),
 			// Convenience computed fields for debugging and composition.
 			"effective_id": schema.StringAttribute{Computed: true},
 			"effective_server_id": schema.StringAttribute{
@@ -89,6 +82,19 @@ func (r *autoModRuleResource) Schema(_ context.Context, _ resource.SchemaRequest
 	}
 }
 
+var autoModRuleIdentity = resourceIdentity{
+	{"server_id", path.Root("server_id")},
+	{"rule_id", path.Root("id")},
+}
+
+func (r *autoModRuleResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = autoModRuleIdentity.schema()
+}
+
+func (r *autoModRuleResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return writeOnlyStateUpgraders("reason")
+}
+
 func (r *autoModRuleResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	c, diags := getContextFromProviderData(req.ProviderData)
 	resp.Diagnostics.Append(diags...)
@@ -96,37 +102,58 @@ func (r *autoModRuleResource) Configure(ctx context.Context, req resource.Config
 		return
 	}
 	r.c = c.Rest
+	r.defaultServerID = c.Config.DefaultServerID
+}
+
+func (r *autoModRuleResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	planServerID(ctx, r.defaultServerID, true, req, resp)
 }
 
 func (r *autoModRuleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := startResourceSpan(ctx, "discord_automod_rule", "create")
+	defer endResourceSpan(span, &resp.Diagnostics)
+	defer autoModRuleIdentity.set(ctx, &resp.State, resp.Identity, &resp.Diagnostics)
+
 	var plan autoModRuleModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("reason"), &plan.Reason)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	var payload any
-	if err := json.Unmarshal([]byte(plan.PayloadJSON.ValueString()), &payload); err != nil {
-		resp.Diagnostics.AddError("Invalid JSON", err.Error())
+	payload := json.RawMessage(plan.PayloadJSON.ValueString())
+	if !json.Valid(payload) {
+		resp.Diagnostics.AddError("Invalid JSON", "payload_json is not valid JSON")
 		return
 	}
 
-	var out restAutoModRuleLite
-	if err := r.c.DoJSONWithReason(ctx, "POST", fmt.Sprintf("/guilds/%s/auto-moderation/rules", plan.ServerID.ValueString()), nil, payload, &out, plan.Reason.ValueString()); err != nil {
+	out, err := r.c.CreateAutoModRuleJSON(ctx, plan.ServerID.ValueString(), payload, plan.Reason.ValueString())
+	if err != nil {
 		resp.Diagnostics.AddError("Discord API error", err.Error())
 		return
 	}
-	if out.ID == "" {
+	var rule struct {
+		ID string `json:"id"`
+	}
+	if err := json.Unmarshal(out, &rule); err != nil {
+		resp.Diagnostics.AddError("JSON error", err.Error())
+		return
+	}
+	if rule.ID == "" {
 		resp.Diagnostics.AddError("Discord API error", "discord api did not return rule id")
 		return
 	}
 
-	plan.ID = types.StringValue(out.ID)
+	plan.ID = types.StringValue(rule.ID)
 	r.readIntoState(ctx, &plan, &resp.Diagnostics)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 }
 
 func (r *autoModRuleResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := startResourceSpan(ctx, "discord_automod_rule", "read")
+	defer endResourceSpan(span, &resp.Diagnostics)
+	defer autoModRuleIdentity.set(ctx, &resp.State, resp.Identity, &resp.Diagnostics)
+
 	var state autoModRuleModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
@@ -152,8 +179,8 @@ func (r *autoModRuleResource) readIntoState(ctx context.Context, state *autoModR
 		return
 	}
 
-	var out any
-	if err := r.c.DoJSON(ctx, "GET", fmt.Sprintf("/guilds/%s/auto-moderation/rules/%s", serverID, ruleID), nil, nil, &out); err != nil {
+	out, err := r.c.GetAutoModRuleJSON(ctx, serverID, ruleID)
+	if err != nil {
 		if discord.IsDiscordHTTPStatus(err, 404) {
 			state.ID = types.StringNull()
 			return
@@ -162,12 +189,7 @@ func (r *autoModRuleResource) readIntoState(ctx context.Context, state *autoModR
 		return
 	}
 
-	b, err := json.Marshal(out)
-	if err != nil {
-		diags.AddError("JSON error", err.Error())
-		return
-	}
-	norm, err := discord.NormalizeJSON(string(b))
+	norm, err := discord.NormalizeJSON(string(out))
 	if err != nil {
 		diags.AddError("JSON error", err.Error())
 		return
@@ -179,19 +201,24 @@ func (r *autoModRuleResource) readIntoState(ctx context.Context, state *autoModR
 }
 
 func (r *autoModRuleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := startResourceSpan(ctx, "discord_automod_rule", "update")
+	defer endResourceSpan(span, &resp.Diagnostics)
+	defer autoModRuleIdentity.set(ctx, &resp.State, resp.Identity, &resp.Diagnostics)
+
 	var plan autoModRuleModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("reason"), &plan.Reason)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	var payload any
-	if err := json.Unmarshal([]byte(plan.PayloadJSON.ValueString()), &payload); err != nil {
-		resp.Diagnostics.AddError("Invalid JSON", err.Error())
+	payload := json.RawMessage(plan.PayloadJSON.ValueString())
+	if !json.Valid(payload) {
+		resp.Diagnostics.AddError("Invalid JSON", "payload_json is not valid JSON")
 		return
 	}
 
-	if err := r.c.DoJSONWithReason(ctx, "PATCH", fmt.Sprintf("/guilds/%s/auto-moderation/rules/%s", plan.ServerID.ValueString(), plan.ID.ValueString()), nil, payload, nil, plan.Reason.ValueString()); err != nil {
+	if _, err := r.c.ModifyAutoModRuleJSON(ctx, plan.ServerID.ValueString(), plan.ID.ValueString(), payload, plan.Reason.ValueString()); err != nil {
 		resp.Diagnostics.AddError("Discord API error", err.Error())
 		return
 	}
@@ -201,13 +228,16 @@ func (r *autoModRuleResource) Update(ctx context.Context, req resource.UpdateReq
 }
 
 func (r *autoModRuleResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := startResourceSpan(ctx, "discord_automod_rule", "delete")
+	defer endResourceSpan(span, &resp.Diagnostics)
+
 	var state autoModRuleModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	if err := r.c.DoJSONWithReason(ctx, "DELETE", fmt.Sprintf("/guilds/%s/auto-moderation/rules/%s", state.ServerID.ValueString(), state.ID.ValueString()), nil, nil, nil, state.Reason.ValueString()); err != nil {
+	if err := r.c.DeleteAutoModRule(ctx, state.ServerID.ValueString(), state.ID.ValueString(), ""); err != nil {
 		if discord.IsDiscordHTTPStatus(err, 404) {
 			resp.State.RemoveResource(ctx)
 			return
@@ -219,8 +249,12 @@ func (r *autoModRuleResource) Delete(ctx context.Context, req resource.DeleteReq
 }
 
 func (r *autoModRuleResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	id := autoModRuleIdentity.importID(ctx, req, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	// Import format: server_id:rule_id
-	serverID, ruleID, err := fwutil.ParseTwoIDs(req.ID)
+	serverID, ruleID, err := fwutil.ParseTwoIDs(id)
 	if err != nil {
 		resp.Diagnostics.AddError("Invalid import ID", "Expected server_id:rule_id")
 		return
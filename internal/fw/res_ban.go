@@ -7,7 +7,6 @@ import (
 
 	"github.com/45ck/terraform-provider-discord/discord"
 	"github.com/45ck/terraform-provider-discord/internal/fw/fwutil"
-	"github.com/45ck/terraform-provider-discord/internal/fw/planmod"
 	"github.com/45ck/terraform-provider-discord/internal/fw/validate"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -24,7 +23,8 @@ func NewBanResource() resource.Resource {
 }
 
 type banResource struct {
-	c *discord.RestClient
+	c               *discord.RestClient
+	defaultServerID string
 }
 
 type banModel struct {
@@ -43,12 +43,16 @@ func (r *banResource) Metadata(_ context.Context, req resource.MetadataRequest,
 
 func (r *banResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Version: 1,
 		Attributes: map[string]schema.Attribute{
-			"id": schema.StringAttribute{Computed: true},
+			"id": schema.StringAttribute{Computed: true, PlanModifiers: stringUseState},
 
 			"server_id": schema.StringAttribute{
-				Required: true,
+				Optional:    true,
+				Computed:    true,
+				Description: serverIDDescription,
 				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
 					stringplanmodifier.RequiresReplace(),
 				},
 				Validators: []validator.String{
@@ -72,18 +76,24 @@ func (r *banResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *
 					int64planmodifier.RequiresReplace(),
 				},
 			},
-			"reason": schema.StringAttribute{
-				Optional:  true,
-				Sensitive: true,
-				PlanModifiers: []planmodifier.String{
-					planmod.IgnoreChangesString(),
-				},
-				Description: "Optional audit log reason (X-Audit-Log-Reason). This value is not readable.",
-			},
+			"reason": reasonAttribute(),
 		},
 	}
 }
 
+var banIdentity = resourceIdentity{
+	{"server_id", path.Root("server_id")},
+	{"user_id", path.Root("user_id")},
+}
+
+func (r *banResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = banIdentity.schema()
+}
+
+func (r *banResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return writeOnlyStateUpgraders("reason")
+}
+
 func (r *banResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	c, diags := getContextFromProviderData(req.ProviderData)
 	resp.Diagnostics.Append(diags...)
@@ -91,6 +101,11 @@ func (r *banResource) Configure(ctx context.Context, req resource.ConfigureReque
 		return
 	}
 	r.c = c.Rest
+	r.defaultServerID = c.Config.DefaultServerID
+}
+
+func (r *banResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	planServerID(ctx, r.defaultServerID, true, req, resp)
 }
 
 func banID(serverID, userID string) string {
@@ -98,8 +113,13 @@ func banID(serverID, userID string) string {
 }
 
 func (r *banResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := startResourceSpan(ctx, "discord_ban", "create")
+	defer endResourceSpan(span, &resp.Diagnostics)
+	defer banIdentity.set(ctx, &resp.State, resp.Identity, &resp.Diagnostics)
+
 	var plan banModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("reason"), &plan.Reason)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -112,7 +132,7 @@ func (r *banResource) Create(ctx context.Context, req resource.CreateRequest, re
 		q.Set("delete_message_seconds", fmt.Sprintf("%d", plan.DeleteMessageSeconds.ValueInt64()))
 	}
 
-	if err := r.c.DoJSONWithReason(ctx, "PUT", fmt.Sprintf("/guilds/%s/bans/%s", serverID, userID), q, nil, nil, plan.Reason.ValueString()); err != nil {
+	if err := r.c.DoJSONWithReason(ctx, "PUT", "/guilds/"+serverID+"/bans/"+userID, q, nil, nil, plan.Reason.ValueString()); err != nil {
 		resp.Diagnostics.AddError("Discord API error", err.Error())
 		return
 	}
@@ -122,6 +142,10 @@ func (r *banResource) Create(ctx context.Context, req resource.CreateRequest, re
 }
 
 func (r *banResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := startResourceSpan(ctx, "discord_ban", "read")
+	defer endResourceSpan(span, &resp.Diagnostics)
+	defer banIdentity.set(ctx, &resp.State, resp.Identity, &resp.Diagnostics)
+
 	var state banModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
@@ -134,8 +158,7 @@ func (r *banResource) Read(ctx context.Context, req resource.ReadRequest, resp *
 		return
 	}
 
-	var out any
-	if err := r.c.DoJSON(ctx, "GET", fmt.Sprintf("/guilds/%s/bans/%s", serverID, userID), nil, nil, &out); err != nil {
+	if _, err := r.c.GetGuildBan(ctx, serverID, userID); err != nil {
 		if discord.IsDiscordHTTPStatus(err, 404) {
 			resp.State.RemoveResource(ctx)
 			return
@@ -150,11 +173,27 @@ func (r *banResource) Read(ctx context.Context, req resource.ReadRequest, resp *
 }
 
 func (r *banResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	// No Update: create-only; replace on change.
-	resp.Diagnostics.AddError("Unsupported operation", "discord_ban does not support in-place updates")
+	ctx, span := startResourceSpan(ctx, "discord_ban", "update")
+	defer endResourceSpan(span, &resp.Diagnostics)
+	defer banIdentity.set(ctx, &resp.State, resp.Identity, &resp.Diagnostics)
+
+	// Everything but the write-only reason forces replacement, so there is
+	// nothing to send.
+	var plan, state banModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("reason"), &plan.Reason)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	plan.ID = state.ID
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 }
 
 func (r *banResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := startResourceSpan(ctx, "discord_ban", "delete")
+	defer endResourceSpan(span, &resp.Diagnostics)
+
 	var state banModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
@@ -172,7 +211,7 @@ func (r *banResource) Delete(ctx context.Context, req resource.DeleteRequest, re
 		}
 	}
 
-	if err := r.c.DoJSONWithReason(ctx, "DELETE", fmt.Sprintf("/guilds/%s/bans/%s", serverID, userID), nil, nil, nil, state.Reason.ValueString()); err != nil {
+	if err := r.c.RemoveGuildBan(ctx, serverID, userID, ""); err != nil {
 		if discord.IsDiscordHTTPStatus(err, 404) {
 			resp.State.RemoveResource(ctx)
 			return
@@ -185,6 +224,10 @@ func (r *banResource) Delete(ctx context.Context, req resource.DeleteRequest, re
 }
 
 func (r *banResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	id := banIdentity.importID(ctx, req, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	// ID is server_id:user_id
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
 }
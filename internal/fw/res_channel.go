@@ -3,10 +3,10 @@ package fw
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/45ck/terraform-provider-discord/discord"
 	"github.com/45ck/terraform-provider-discord/internal/fw/fwutil"
-	"github.com/45ck/terraform-provider-discord/internal/fw/planmod"
 	"github.com/45ck/terraform-provider-discord/internal/fw/validate"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -21,8 +21,20 @@ func NewChannelResource() resource.Resource {
 	return &channelResource{}
 }
 
+// channelFieldPaths maps Discord request fields to schema attributes for per-attribute errors.
+var channelFieldPaths = func() discordFieldPaths {
+	m := sameNameFieldPaths(
+		"name", "position", "parent_id", "topic", "nsfw", "rate_limit_per_user", "bitrate", "user_limit",
+		"rtc_region", "video_quality_mode", "default_auto_archive_duration", "default_thread_rate_limit_per_user",
+		"default_reaction_emoji", "default_sort_order", "default_forum_layout",
+	)
+	m["available_tags"] = path.Root("available_tag")
+	return m
+}()
+
 type channelResource struct {
-	c *discord.RestClient
+	c               *discord.RestClient
+	defaultServerID string
 }
 
 type channelForumTagModel struct {
@@ -65,53 +77,22 @@ type channelResourceModel struct {
 	DefaultForumLayout   types.Int64                  `tfsdk:"default_forum_layout"`
 }
 
-type restChannel struct {
-	ID                     string               `json:"id"`
-	GuildID                string               `json:"guild_id"`
-	Name                   string               `json:"name"`
-	Type                   uint                 `json:"type"`
-	Position               int                  `json:"position"`
-	ParentID               string               `json:"parent_id"`
-	Topic                  string               `json:"topic"`
-	NSFW                   bool                 `json:"nsfw"`
-	RateLimitPerUser       int                  `json:"rate_limit_per_user"`
-	Bitrate                int                  `json:"bitrate"`
-	UserLimit              int                  `json:"user_limit"`
-	RTCRegion              string               `json:"rtc_region"`
-	VideoQualityMode       int                  `json:"video_quality_mode"`
-	DefaultAutoArchiveDur  int                  `json:"default_auto_archive_duration"`
-	DefaultThreadRateLimit int                  `json:"default_thread_rate_limit_per_user"`
-	AvailableTags          []restForumTag       `json:"available_tags"`
-	DefaultReactionEmoji   *restDefaultReaction `json:"default_reaction_emoji"`
-	DefaultSortOrder       int                  `json:"default_sort_order"`
-	DefaultForumLayout     int                  `json:"default_forum_layout"`
-}
-
-type restForumTag struct {
-	ID        string `json:"id"`
-	Name      string `json:"name"`
-	Moderated bool   `json:"moderated"`
-	EmojiID   string `json:"emoji_id"`
-	EmojiName string `json:"emoji_name"`
-}
-
-type restDefaultReaction struct {
-	EmojiID   string `json:"emoji_id"`
-	EmojiName string `json:"emoji_name"`
-}
-
 func (r *channelResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_channel"
 }
 
 func (r *channelResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Version: 1,
 		Attributes: map[string]schema.Attribute{
-			"id": schema.StringAttribute{Computed: true},
+			"id": schema.StringAttribute{Computed: true, PlanModifiers: stringUseState},
 
 			"server_id": schema.StringAttribute{
-				Required: true,
+				Optional:    true,
+				Computed:    true,
+				Description: serverIDDescription,
 				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
 					stringplanmodifier.RequiresReplace(),
 				},
 				Validators: []validator.String{
@@ -124,45 +105,44 @@ func (r *channelResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
-			"name": schema.StringAttribute{Required: true},
-			"reason": schema.StringAttribute{
-				Optional:  true,
-				Sensitive: true,
-				PlanModifiers: []planmodifier.String{
-					planmod.IgnoreChangesString(),
-				},
-				Description: "Optional audit log reason (X-Audit-Log-Reason). This value is not readable.",
-			},
+			"name":   schema.StringAttribute{Required: true},
+			"reason": reasonAttribute(),
 
-			"position": schema.Int64Attribute{Optional: true},
+			// Discord assigns position and fills in a default for every setting that is
+			// left out, so these are computed when unset.
+			"position": schema.Int64Attribute{Optional: true, Computed: true},
 			"parent_id": schema.StringAttribute{
 				Optional: true,
 				Validators: []validator.String{
 					validate.Snowflake(),
 				},
 			},
-			"topic": schema.StringAttribute{Optional: true},
-			"nsfw":  schema.BoolAttribute{Optional: true},
-
-			"rate_limit_per_user": schema.Int64Attribute{Optional: true},
-			"bitrate":             schema.Int64Attribute{Optional: true},
-			"user_limit":          schema.Int64Attribute{Optional: true},
-			"rtc_region":          schema.StringAttribute{Optional: true},
-			"video_quality_mode":  schema.Int64Attribute{Optional: true},
+			"topic": schema.StringAttribute{Optional: true, Computed: true, PlanModifiers: stringUseState},
+			"nsfw":  schema.BoolAttribute{Optional: true, Computed: true, PlanModifiers: boolUseState},
+
+			"rate_limit_per_user": schema.Int64Attribute{Optional: true, Computed: true, PlanModifiers: int64UseState},
+			"bitrate":             schema.Int64Attribute{Optional: true, Computed: true, PlanModifiers: int64UseState},
+			"user_limit":          schema.Int64Attribute{Optional: true, Computed: true, PlanModifiers: int64UseState},
+			"rtc_region":          schema.StringAttribute{Optional: true, Computed: true, PlanModifiers: stringUseState},
+			"video_quality_mode":  schema.Int64Attribute{Optional: true, Computed: true, PlanModifiers: int64UseState},
 			"default_auto_archive_duration": schema.Int64Attribute{
-				Optional: true,
+				Optional:      true,
+				Computed:      true,
+				PlanModifiers: int64UseState,
 			},
 			"default_thread_rate_limit_per_user": schema.Int64Attribute{
-				Optional: true,
+				Optional:      true,
+				Computed:      true,
+				PlanModifiers: int64UseState,
 			},
 
 			"available_tag": schema.ListNestedAttribute{
 				Optional: true,
 				NestedObject: schema.NestedAttributeObject{
 					Attributes: map[string]schema.Attribute{
-						"id":         schema.StringAttribute{Optional: true},
+						"id":         schema.StringAttribute{Optional: true, Computed: true},
 						"name":       schema.StringAttribute{Required: true},
-						"moderated":  schema.BoolAttribute{Optional: true},
+						"moderated":  schema.BoolAttribute{Optional: true, Computed: true},
 						"emoji_id":   schema.StringAttribute{Optional: true},
 						"emoji_name": schema.StringAttribute{Optional: true},
 					},
@@ -178,17 +158,33 @@ func (r *channelResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 				Description: "Forum default reaction emoji.",
 			},
 			"default_sort_order": schema.Int64Attribute{
-				Optional:    true,
-				Description: "Forum default sort order.",
+				Optional:      true,
+				Computed:      true,
+				PlanModifiers: int64UseState,
+				Description:   "Forum default sort order.",
 			},
 			"default_forum_layout": schema.Int64Attribute{
-				Optional:    true,
-				Description: "Forum default layout.",
+				Optional:      true,
+				Computed:      true,
+				PlanModifiers: int64UseState,
+				Description:   "Forum default layout.",
 			},
 		},
 	}
 }
 
+var channelIdentity = resourceIdentity{
+	{"channel_id", path.Root("id")},
+}
+
+func (r *channelResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = channelIdentity.schema()
+}
+
+func (r *channelResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return writeOnlyStateUpgraders("reason")
+}
+
 func (r *channelResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	c, diags := getContextFromProviderData(req.ProviderData)
 	resp.Diagnostics.Append(diags...)
@@ -196,6 +192,11 @@ func (r *channelResource) Configure(ctx context.Context, req resource.ConfigureR
 		return
 	}
 	r.c = c.Rest
+	r.defaultServerID = c.Config.DefaultServerID
+}
+
+func (r *channelResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	planServerID(ctx, r.defaultServerID, true, req, resp)
 }
 
 func (r *channelResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
@@ -242,10 +243,10 @@ func validateChannelType(t string) (uint, error) {
 	return v, nil
 }
 
-func expandForumTags(v []channelForumTagModel) []restForumTag {
-	out := make([]restForumTag, 0, len(v))
+func expandForumTags(v []channelForumTagModel) []discord.ForumTag {
+	out := make([]discord.ForumTag, 0, len(v))
 	for _, raw := range v {
-		tag := restForumTag{
+		tag := discord.ForumTag{
 			ID:        raw.ID.ValueString(),
 			Name:      raw.Name.ValueString(),
 			Moderated: !raw.Moderated.IsNull() && raw.Moderated.ValueBool(),
@@ -261,20 +262,27 @@ func expandForumTags(v []channelForumTagModel) []restForumTag {
 	return out
 }
 
-func flattenForumTags(v []restForumTag) []channelForumTagModel {
+func flattenForumTags(v []discord.ForumTag) []channelForumTagModel {
 	out := make([]channelForumTagModel, 0, len(v))
 	for _, tag := range v {
 		out = append(out, channelForumTagModel{
 			ID:        types.StringValue(tag.ID),
 			Name:      types.StringValue(tag.Name),
 			Moderated: types.BoolValue(tag.Moderated),
-			EmojiID:   types.StringValue(tag.EmojiID),
-			EmojiName: types.StringValue(tag.EmojiName),
+			EmojiID:   fwutil.StringOrNull(tag.EmojiID),
+			EmojiName: fwutil.StringOrNull(tag.EmojiName),
 		})
 	}
 	return out
 }
 
+func expandDefaultReaction(v *channelDefaultReactionModel) *discord.DefaultReaction {
+	return &discord.DefaultReaction{
+		EmojiID:   v.EmojiID.ValueString(),
+		EmojiName: v.EmojiName.ValueString(),
+	}
+}
+
 func equalForumTags(a, b []channelForumTagModel) bool {
 	if len(a) != len(b) {
 		return false
@@ -302,8 +310,13 @@ func equalDefaultReaction(a, b *channelDefaultReactionModel) bool {
 }
 
 func (r *channelResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := startResourceSpan(ctx, "discord_channel", "create")
+	defer endResourceSpan(span, &resp.Diagnostics)
+	defer channelIdentity.set(ctx, &resp.State, resp.Identity, &resp.Diagnostics)
+
 	var plan channelResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("reason"), &plan.Reason)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -314,72 +327,72 @@ func (r *channelResource) Create(ctx context.Context, req resource.CreateRequest
 		return
 	}
 
-	body := map[string]any{
-		"type": int(typ),
-		"name": plan.Name.ValueString(),
-	}
-
-	if !plan.Position.IsNull() {
-		body["position"] = int(plan.Position.ValueInt64())
-	}
-	if !plan.Topic.IsNull() && plan.Topic.ValueString() != "" {
-		body["topic"] = plan.Topic.ValueString()
-	}
-	if !plan.NSFW.IsNull() {
-		body["nsfw"] = plan.NSFW.ValueBool()
-	}
-	if !plan.RateLimitPerUser.IsNull() {
-		body["rate_limit_per_user"] = int(plan.RateLimitPerUser.ValueInt64())
-	}
-	if !plan.Bitrate.IsNull() {
-		body["bitrate"] = int(plan.Bitrate.ValueInt64())
-	}
-	if !plan.UserLimit.IsNull() {
-		body["user_limit"] = int(plan.UserLimit.ValueInt64())
-	}
-	if !plan.RTCRegion.IsNull() && plan.RTCRegion.ValueString() != "" {
-		body["rtc_region"] = plan.RTCRegion.ValueString()
-	}
-	if !plan.VideoQualityMode.IsNull() {
-		body["video_quality_mode"] = int(plan.VideoQualityMode.ValueInt64())
-	}
-	if !plan.DefaultAutoArchiveDuration.IsNull() {
-		body["default_auto_archive_duration"] = int(plan.DefaultAutoArchiveDuration.ValueInt64())
-	}
-	if !plan.DefaultThreadRateLimitPerUser.IsNull() {
-		body["default_thread_rate_limit_per_user"] = int(plan.DefaultThreadRateLimitPerUser.ValueInt64())
+	params := &discord.CreateChannelParams{
+		Type:                          typ,
+		Name:                          plan.Name.ValueString(),
+		Position:                      fwutil.OptionalInt(plan.Position),
+		Topic:                         fwutil.NonEmptyString(plan.Topic),
+		NSFW:                          fwutil.OptionalBool(plan.NSFW),
+		RateLimitPerUser:              fwutil.OptionalInt(plan.RateLimitPerUser),
+		Bitrate:                       fwutil.OptionalInt(plan.Bitrate),
+		UserLimit:                     fwutil.OptionalInt(plan.UserLimit),
+		RTCRegion:                     fwutil.NonEmptyString(plan.RTCRegion),
+		VideoQualityMode:              fwutil.OptionalInt(plan.VideoQualityMode),
+		DefaultAutoArchiveDuration:    fwutil.OptionalInt(plan.DefaultAutoArchiveDuration),
+		DefaultThreadRateLimitPerUser: fwutil.OptionalInt(plan.DefaultThreadRateLimitPerUser),
+		DefaultSortOrder:              fwutil.OptionalInt(plan.DefaultSortOrder),
+		DefaultForumLayout:            fwutil.OptionalInt(plan.DefaultForumLayout),
+		ParentID:                      fwutil.NonEmptyString(plan.ParentID),
 	}
 	if plan.AvailableTag != nil {
-		body["available_tags"] = expandForumTags(plan.AvailableTag)
+		params.AvailableTags = expandForumTags(plan.AvailableTag)
 	}
 	if plan.DefaultReactionEmoji != nil {
-		body["default_reaction_emoji"] = map[string]any{
-			"emoji_id":   plan.DefaultReactionEmoji.EmojiID.ValueString(),
-			"emoji_name": plan.DefaultReactionEmoji.EmojiName.ValueString(),
-		}
-	}
-	if !plan.DefaultSortOrder.IsNull() {
-		body["default_sort_order"] = int(plan.DefaultSortOrder.ValueInt64())
-	}
-	if !plan.DefaultForumLayout.IsNull() {
-		body["default_forum_layout"] = int(plan.DefaultForumLayout.ValueInt64())
-	}
-	if !plan.ParentID.IsNull() && plan.ParentID.ValueString() != "" {
-		body["parent_id"] = plan.ParentID.ValueString()
+		params.DefaultReactionEmoji = expandDefaultReaction(plan.DefaultReactionEmoji)
 	}
 
-	var out restChannel
-	if err := r.c.DoJSONWithReason(ctx, "POST", fmt.Sprintf("/guilds/%s/channels", plan.ServerID.ValueString()), nil, body, &out, plan.Reason.ValueString()); err != nil {
-		resp.Diagnostics.AddError("Discord API error", err.Error())
-		return
+	topic, parentID := plan.Topic.ValueString(), plan.ParentID.ValueString()
+	nsfw := params.NSFW != nil && *params.NSFW
+	create := beginCreate("channel", plan.ServerID.ValueString(), typ, strings.ToLower(params.Name), parentID, topic, nsfw)
+	defer create.end()
+	out, err := r.c.CreateChannel(ctx, plan.ServerID.ValueString(), params, plan.Reason.ValueString())
+	if err != nil {
+		id, err := create.recover(ctx, err, func(ctx context.Context) ([]string, error) {
+			channels, err := r.c.ListGuildChannels(ctx, plan.ServerID.ValueString())
+			if err != nil {
+				return nil, err
+			}
+			var ids []string
+			for _, ch := range channels {
+				if ch.Type == typ && strings.EqualFold(ch.Name, params.Name) && ch.ParentID == parentID &&
+					ch.Topic == topic && ch.NSFW == nsfw {
+					ids = append(ids, ch.ID)
+				}
+			}
+			return ids, nil
+		})
+		if err != nil {
+			addDiscordAPIError(&resp.Diagnostics, err, channelFieldPaths)
+			return
+		}
+		out = &discord.Channel{ID: id}
 	}
+	create.created(out.ID)
 
+	readAfterCreate(ctx, &resp.Diagnostics, "Channel", out.ID, func(ctx context.Context) bool {
+		plan.ID = types.StringValue(out.ID)
+		r.readIntoState(ctx, &plan, &resp.Diagnostics)
+		return !plan.ID.IsNull()
+	})
 	plan.ID = types.StringValue(out.ID)
-	r.readIntoState(ctx, &plan, &resp.Diagnostics)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 }
 
 func (r *channelResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := startResourceSpan(ctx, "discord_channel", "read")
+	defer endResourceSpan(span, &resp.Diagnostics)
+	defer channelIdentity.set(ctx, &resp.State, resp.Identity, &resp.Diagnostics)
+
 	var state channelResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
@@ -398,97 +411,100 @@ func (r *channelResource) Read(ctx context.Context, req resource.ReadRequest, re
 }
 
 func (r *channelResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := startResourceSpan(ctx, "discord_channel", "update")
+	defer endResourceSpan(span, &resp.Diagnostics)
+	defer channelIdentity.set(ctx, &resp.State, resp.Identity, &resp.Diagnostics)
+
 	var plan channelResourceModel
 	var state channelResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("reason"), &plan.Reason)...)
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	body := map[string]any{}
+	params := &discord.ModifyChannelParams{}
+	changed := false
 
 	if fwutil.ChangedString(plan.Name, state.Name) {
-		body["name"] = plan.Name.ValueString()
+		params.Name = discord.Ptr(plan.Name.ValueString())
+		changed = true
 	}
 	if fwutil.ChangedInt64(plan.Position, state.Position) {
-		if plan.Position.IsNull() {
-			body["position"] = 0
-		} else {
-			body["position"] = int(plan.Position.ValueInt64())
-		}
+		params.Position = discord.Ptr(int(plan.Position.ValueInt64()))
+		changed = true
 	}
 	if fwutil.ChangedString(plan.ParentID, state.ParentID) {
-		if plan.ParentID.IsNull() || plan.ParentID.ValueString() == "" {
-			body["parent_id"] = nil
-		} else {
-			body["parent_id"] = plan.ParentID.ValueString()
-		}
+		params.ParentID = discord.NullIfEmpty(plan.ParentID.ValueString())
+		changed = true
 	}
 	if fwutil.ChangedString(plan.Topic, state.Topic) {
-		if plan.Topic.IsNull() {
-			body["topic"] = ""
-		} else {
-			body["topic"] = plan.Topic.ValueString()
-		}
+		params.Topic = discord.Ptr(plan.Topic.ValueString())
+		changed = true
 	}
 	if fwutil.ChangedBool(plan.NSFW, state.NSFW) {
-		body["nsfw"] = !plan.NSFW.IsNull() && plan.NSFW.ValueBool()
+		params.NSFW = discord.Ptr(plan.NSFW.ValueBool())
+		changed = true
 	}
 	if fwutil.ChangedInt64(plan.RateLimitPerUser, state.RateLimitPerUser) {
-		body["rate_limit_per_user"] = int(plan.RateLimitPerUser.ValueInt64())
+		params.RateLimitPerUser = discord.Ptr(int(plan.RateLimitPerUser.ValueInt64()))
+		changed = true
 	}
 	if fwutil.ChangedInt64(plan.Bitrate, state.Bitrate) {
-		body["bitrate"] = int(plan.Bitrate.ValueInt64())
+		params.Bitrate = discord.Ptr(int(plan.Bitrate.ValueInt64()))
+		changed = true
 	}
 	if fwutil.ChangedInt64(plan.UserLimit, state.UserLimit) {
-		body["user_limit"] = int(plan.UserLimit.ValueInt64())
+		params.UserLimit = discord.Ptr(int(plan.UserLimit.ValueInt64()))
+		changed = true
 	}
 	if fwutil.ChangedString(plan.RTCRegion, state.RTCRegion) {
-		if plan.RTCRegion.IsNull() || plan.RTCRegion.ValueString() == "" {
-			body["rtc_region"] = nil
-		} else {
-			body["rtc_region"] = plan.RTCRegion.ValueString()
-		}
+		params.RTCRegion = discord.NullIfEmpty(plan.RTCRegion.ValueString())
+		changed = true
 	}
 	if fwutil.ChangedInt64(plan.VideoQualityMode, state.VideoQualityMode) {
-		body["video_quality_mode"] = int(plan.VideoQualityMode.ValueInt64())
+		params.VideoQualityMode = discord.Ptr(int(plan.VideoQualityMode.ValueInt64()))
+		changed = true
 	}
 	if fwutil.ChangedInt64(plan.DefaultAutoArchiveDuration, state.DefaultAutoArchiveDuration) {
-		body["default_auto_archive_duration"] = int(plan.DefaultAutoArchiveDuration.ValueInt64())
+		params.DefaultAutoArchiveDuration = discord.Ptr(int(plan.DefaultAutoArchiveDuration.ValueInt64()))
+		changed = true
 	}
 	if fwutil.ChangedInt64(plan.DefaultThreadRateLimitPerUser, state.DefaultThreadRateLimitPerUser) {
-		body["default_thread_rate_limit_per_user"] = int(plan.DefaultThreadRateLimitPerUser.ValueInt64())
+		params.DefaultThreadRateLimitPerUser = discord.Ptr(int(plan.DefaultThreadRateLimitPerUser.ValueInt64()))
+		changed = true
 	}
 
 	if !equalForumTags(plan.AvailableTag, state.AvailableTag) {
-		body["available_tags"] = expandForumTags(plan.AvailableTag)
+		tags := expandForumTags(plan.AvailableTag)
+		params.AvailableTags = &tags
+		changed = true
 	}
 	if !equalDefaultReaction(plan.DefaultReactionEmoji, state.DefaultReactionEmoji) {
 		if plan.DefaultReactionEmoji == nil {
-			body["default_reaction_emoji"] = nil
+			params.DefaultReactionEmoji = discord.Null[discord.DefaultReaction]()
 		} else {
-			body["default_reaction_emoji"] = map[string]any{
-				"emoji_id":   plan.DefaultReactionEmoji.EmojiID.ValueString(),
-				"emoji_name": plan.DefaultReactionEmoji.EmojiName.ValueString(),
-			}
+			params.DefaultReactionEmoji = discord.Value(*expandDefaultReaction(plan.DefaultReactionEmoji))
 		}
+		changed = true
 	}
 	if fwutil.ChangedInt64(plan.DefaultSortOrder, state.DefaultSortOrder) {
-		body["default_sort_order"] = int(plan.DefaultSortOrder.ValueInt64())
+		params.DefaultSortOrder = discord.Ptr(int(plan.DefaultSortOrder.ValueInt64()))
+		changed = true
 	}
 	if fwutil.ChangedInt64(plan.DefaultForumLayout, state.DefaultForumLayout) {
-		body["default_forum_layout"] = int(plan.DefaultForumLayout.ValueInt64())
+		params.DefaultForumLayout = discord.Ptr(int(plan.DefaultForumLayout.ValueInt64()))
+		changed = true
 	}
 
-	if len(body) == 0 {
+	if !changed {
 		resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 		return
 	}
 
-	var out restChannel
-	if err := r.c.DoJSONWithReason(ctx, "PATCH", fmt.Sprintf("/channels/%s", state.ID.ValueString()), nil, body, &out, plan.Reason.ValueString()); err != nil {
-		resp.Diagnostics.AddError("Discord API error", err.Error())
+	if _, err := r.c.ModifyChannel(ctx, state.ID.ValueString(), params, plan.Reason.ValueString()); err != nil {
+		addDiscordAPIError(&resp.Diagnostics, err, channelFieldPaths)
 		return
 	}
 
@@ -498,13 +514,16 @@ func (r *channelResource) Update(ctx context.Context, req resource.UpdateRequest
 }
 
 func (r *channelResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := startResourceSpan(ctx, "discord_channel", "delete")
+	defer endResourceSpan(span, &resp.Diagnostics)
+
 	var state channelResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	if err := r.c.DoJSONWithReason(ctx, "DELETE", fmt.Sprintf("/channels/%s", state.ID.ValueString()), nil, nil, nil, state.Reason.ValueString()); err != nil {
+	if err := r.c.DeleteChannel(ctx, state.ID.ValueString(), ""); err != nil {
 		if discord.IsDiscordHTTPStatus(err, 404) {
 			resp.State.RemoveResource(ctx)
 			return
@@ -516,12 +535,34 @@ func (r *channelResource) Delete(ctx context.Context, req resource.DeleteRequest
 }
 
 func (r *channelResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	id := channelIdentity.importID(ctx, req, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+}
+
+// fetchGuildChannel finds a channel in its guild's channel list, which the
+// provider's read cache shares between all channels of the guild. Threads,
+// channels of an unknown guild and fresh reads after a write are read on their own.
+func fetchGuildChannel(ctx context.Context, c *discord.RestClient, guildID, channelID string) (*discord.Channel, error) {
+	if guildID != "" && !discord.FreshReads(ctx) {
+		channels, err := c.ListGuildChannels(ctx, guildID)
+		if err != nil && !discord.IsDiscordHTTPStatus(err, 404) {
+			return nil, err
+		}
+		for i := range channels {
+			if channels[i].ID == channelID {
+				return &channels[i], nil
+			}
+		}
+	}
+	return c.GetChannel(ctx, channelID)
 }
 
 func (r *channelResource) readIntoState(ctx context.Context, state *channelResourceModel, diags discordFrameworkDiagnostics) {
-	var out restChannel
-	if err := r.c.DoJSON(ctx, "GET", fmt.Sprintf("/channels/%s", state.ID.ValueString()), nil, nil, &out); err != nil {
+	out, err := fetchGuildChannel(ctx, r.c, state.ServerID.ValueString(), state.ID.ValueString())
+	if err != nil {
 		if discord.IsDiscordHTTPStatus(err, 404) {
 			state.ID = types.StringNull()
 			return
@@ -552,8 +593,8 @@ func (r *channelResource) readIntoState(ctx context.Context, state *channelResou
 	state.UserLimit = types.Int64Value(int64(out.UserLimit))
 	state.RTCRegion = types.StringValue(out.RTCRegion)
 	state.VideoQualityMode = types.Int64Value(int64(out.VideoQualityMode))
-	state.DefaultAutoArchiveDuration = types.Int64Value(int64(out.DefaultAutoArchiveDur))
-	state.DefaultThreadRateLimitPerUser = types.Int64Value(int64(out.DefaultThreadRateLimit))
+	state.DefaultAutoArchiveDuration = types.Int64Value(int64(out.DefaultAutoArchiveDuration))
+	state.DefaultThreadRateLimitPerUser = types.Int64Value(int64(out.DefaultThreadRateLimitPerUser))
 
 	if out.AvailableTags != nil {
 		state.AvailableTag = flattenForumTags(out.AvailableTags)
@@ -563,8 +604,8 @@ func (r *channelResource) readIntoState(ctx context.Context, state *channelResou
 
 	if out.DefaultReactionEmoji != nil {
 		state.DefaultReactionEmoji = &channelDefaultReactionModel{
-			EmojiID:   types.StringValue(out.DefaultReactionEmoji.EmojiID),
-			EmojiName: types.StringValue(out.DefaultReactionEmoji.EmojiName),
+			EmojiID:   fwutil.StringOrNull(out.DefaultReactionEmoji.EmojiID),
+			EmojiName: fwutil.StringOrNull(out.DefaultReactionEmoji.EmojiName),
 		}
 	} else {
 		state.DefaultReactionEmoji = nil
@@ -5,7 +5,7 @@ import (
 	"fmt"
 
 	"github.com/45ck/terraform-provider-discord/discord"
-	"github.com/45ck/terraform-provider-discord/internal/fw/planmod"
+	"github.com/45ck/terraform-provider-discord/internal/fw/fwutil"
 	"github.com/45ck/terraform-provider-discord/internal/fw/validate"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -21,7 +21,9 @@ func NewChannelOrderResource() resource.Resource {
 }
 
 type channelOrderResource struct {
-	c *discord.RestClient
+	c               *discord.RestClient
+	defaultServerID string
+	positions       *discord.PositionBatcher
 }
 
 type channelOrderItemModel struct {
@@ -39,30 +41,21 @@ type channelOrderModel struct {
 	Reason   types.String            `tfsdk:"reason"`
 }
 
-type restChannelPosition struct {
-	ID              string `json:"id"`
-	Position        int    `json:"position,omitempty"`
-	ParentID        string `json:"parent_id,omitempty"`
-	LockPermissions *bool  `json:"lock_permissions,omitempty"`
-}
-
-type restGuildChannel struct {
-	ID       string `json:"id"`
-	Position int    `json:"position"`
-	ParentID string `json:"parent_id"`
-}
-
 func (r *channelOrderResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_channel_order"
 }
 
 func (r *channelOrderResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Version: 1,
 		Attributes: map[string]schema.Attribute{
-			"id": schema.StringAttribute{Computed: true},
+			"id": schema.StringAttribute{Computed: true, PlanModifiers: stringUseState},
 			"server_id": schema.StringAttribute{
-				Required: true,
+				Optional:    true,
+				Computed:    true,
+				Description: serverIDDescription,
 				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
 					stringplanmodifier.RequiresReplace(),
 				},
 				Validators: []validator.String{
@@ -81,7 +74,9 @@ func (r *channelOrderResource) Schema(_ context.Context, _ resource.SchemaReques
 						},
 						"position": schema.Int64Attribute{Required: true},
 						"parent_id": schema.StringAttribute{
+							// Left unchanged when unset, so the current parent is read back.
 							Optional: true,
+							Computed: true,
 							Validators: []validator.String{
 								validate.Snowflake(),
 							},
@@ -92,18 +87,23 @@ func (r *channelOrderResource) Schema(_ context.Context, _ resource.SchemaReques
 					},
 				},
 			},
-			"reason": schema.StringAttribute{
-				Optional:  true,
-				Sensitive: true,
-				PlanModifiers: []planmodifier.String{
-					planmod.IgnoreChangesString(),
-				},
-				Description: "Optional audit log reason (X-Audit-Log-Reason). This value is not readable.",
-			},
+			"reason": reasonAttribute(),
 		},
 	}
 }
 
+var channelOrderIdentity = resourceIdentity{
+	{"server_id", path.Root("server_id")},
+}
+
+func (r *channelOrderResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = channelOrderIdentity.schema()
+}
+
+func (r *channelOrderResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return writeOnlyStateUpgraders("reason")
+}
+
 func (r *channelOrderResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	c, diags := getContextFromProviderData(req.ProviderData)
 	resp.Diagnostics.Append(diags...)
@@ -111,21 +111,26 @@ func (r *channelOrderResource) Configure(ctx context.Context, req resource.Confi
 		return
 	}
 	r.c = c.Rest
+	r.positions = c.Positions
+	r.defaultServerID = c.Config.DefaultServerID
+}
+
+func (r *channelOrderResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	planServerID(ctx, r.defaultServerID, true, req, resp)
 }
 
-func expandChannelPositions(items []channelOrderItemModel) []restChannelPosition {
-	out := make([]restChannelPosition, 0, len(items))
+func expandChannelPositions(items []channelOrderItemModel) []discord.ChannelPosition {
+	out := make([]discord.ChannelPosition, 0, len(items))
 	for _, it := range items {
-		p := restChannelPosition{
-			ID:       it.ChannelID.ValueString(),
-			Position: int(it.Position.ValueInt64()),
+		p := discord.ChannelPosition{
+			ID:              it.ChannelID.ValueString(),
+			LockPermissions: fwutil.OptionalBool(it.LockPermissions),
 		}
-		if !it.ParentID.IsNull() && it.ParentID.ValueString() != "" {
-			p.ParentID = it.ParentID.ValueString()
+		if pos := int(it.Position.ValueInt64()); pos != 0 {
+			p.Position = &pos
 		}
-		if !it.LockPermissions.IsNull() {
-			b := it.LockPermissions.ValueBool()
-			p.LockPermissions = &b
+		if !it.ParentID.IsNull() && it.ParentID.ValueString() != "" {
+			p.ParentID = discord.Value(it.ParentID.ValueString())
 		}
 		out = append(out, p)
 	}
@@ -133,14 +138,18 @@ func expandChannelPositions(items []channelOrderItemModel) []restChannelPosition
 }
 
 func (r *channelOrderResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := startResourceSpan(ctx, "discord_channel_order", "create")
+	defer endResourceSpan(span, &resp.Diagnostics)
+	defer channelOrderIdentity.set(ctx, &resp.State, resp.Identity, &resp.Diagnostics)
+
 	var plan channelOrderModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("reason"), &plan.Reason)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	body := expandChannelPositions(plan.Channel)
-	if err := r.c.DoJSONWithReason(ctx, "PATCH", fmt.Sprintf("/guilds/%s/channels", plan.ServerID.ValueString()), nil, body, nil, plan.Reason.ValueString()); err != nil {
+	if err := r.positions.ModifyGuildChannelPositions(ctx, r.c, plan.ServerID.ValueString(), expandChannelPositions(plan.Channel), plan.Reason.ValueString()); err != nil {
 		resp.Diagnostics.AddError("Discord API error", err.Error())
 		return
 	}
@@ -151,14 +160,18 @@ func (r *channelOrderResource) Create(ctx context.Context, req resource.CreateRe
 }
 
 func (r *channelOrderResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := startResourceSpan(ctx, "discord_channel_order", "update")
+	defer endResourceSpan(span, &resp.Diagnostics)
+	defer channelOrderIdentity.set(ctx, &resp.State, resp.Identity, &resp.Diagnostics)
+
 	var plan channelOrderModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("reason"), &plan.Reason)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	body := expandChannelPositions(plan.Channel)
-	if err := r.c.DoJSONWithReason(ctx, "PATCH", fmt.Sprintf("/guilds/%s/channels", plan.ServerID.ValueString()), nil, body, nil, plan.Reason.ValueString()); err != nil {
+	if err := r.positions.ModifyGuildChannelPositions(ctx, r.c, plan.ServerID.ValueString(), expandChannelPositions(plan.Channel), plan.Reason.ValueString()); err != nil {
 		resp.Diagnostics.AddError("Discord API error", err.Error())
 		return
 	}
@@ -169,6 +182,10 @@ func (r *channelOrderResource) Update(ctx context.Context, req resource.UpdateRe
 }
 
 func (r *channelOrderResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := startResourceSpan(ctx, "discord_channel_order", "read")
+	defer endResourceSpan(span, &resp.Diagnostics)
+	defer channelOrderIdentity.set(ctx, &resp.State, resp.Identity, &resp.Diagnostics)
+
 	var state channelOrderModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
@@ -192,8 +209,8 @@ func (r *channelOrderResource) readIntoState(ctx context.Context, state *channel
 		serverID = state.ServerID.ValueString()
 	}
 
-	var channels []restGuildChannel
-	if err := r.c.DoJSON(ctx, "GET", fmt.Sprintf("/guilds/%s/channels", serverID), nil, nil, &channels); err != nil {
+	channels, err := r.c.ListGuildChannels(ctx, serverID)
+	if err != nil {
 		if discord.IsDiscordHTTPStatus(err, 404) {
 			state.ID = types.StringNull()
 			return
@@ -202,7 +219,7 @@ func (r *channelOrderResource) readIntoState(ctx context.Context, state *channel
 		return
 	}
 
-	index := map[string]restGuildChannel{}
+	index := map[string]discord.Channel{}
 	for _, ch := range channels {
 		index[ch.ID] = ch
 	}
@@ -219,7 +236,7 @@ func (r *channelOrderResource) readIntoState(ctx context.Context, state *channel
 		out = append(out, channelOrderItemModel{
 			ChannelID:       types.StringValue(id),
 			Position:        types.Int64Value(int64(ch.Position)),
-			ParentID:        types.StringValue(ch.ParentID),
+			ParentID:        fwutil.StringOrNull(ch.ParentID),
 			LockPermissions: it.LockPermissions,
 		})
 	}
@@ -230,12 +247,19 @@ func (r *channelOrderResource) readIntoState(ctx context.Context, state *channel
 }
 
 func (r *channelOrderResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := startResourceSpan(ctx, "discord_channel_order", "delete")
+	defer endResourceSpan(span, &resp.Diagnostics)
+
 	// No-op. Ordering is not meaningfully "deletable".
 	resp.Diagnostics.AddWarning("discord_channel_order does not revert ordering on destroy", "Destroying this resource removes it from state only.")
 	resp.State.RemoveResource(ctx)
 }
 
 func (r *channelOrderResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("server_id"), req, resp)
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	id := channelOrderIdentity.importID(ctx, req, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("server_id"), id)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
 }
@@ -38,24 +38,6 @@ type channelPermissionModel struct {
 	DenyBits64  types.String `tfsdk:"deny_bits64"`
 }
 
-type restPermOverwriteRead struct {
-	ID    string `json:"id"`
-	Type  int    `json:"type"` // 0=role, 1=member
-	Allow string `json:"allow"`
-	Deny  string `json:"deny"`
-}
-
-type restChannelPermsRead struct {
-	ID                   string                  `json:"id"`
-	PermissionOverwrites []restPermOverwriteRead `json:"permission_overwrites"`
-}
-
-type restPermOverwriteUpsert struct {
-	Allow string `json:"allow"`
-	Deny  string `json:"deny"`
-	Type  int    `json:"type"`
-}
-
 func (r *channelPermissionResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_channel_permission"
 }
@@ -63,7 +45,7 @@ func (r *channelPermissionResource) Metadata(_ context.Context, req resource.Met
 func (r *channelPermissionResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
-			"id": schema.StringAttribute{Computed: true},
+			"id": schema.StringAttribute{Computed: true, PlanModifiers: stringUseState},
 			"channel_id": schema.StringAttribute{
 				Required: true,
 				PlanModifiers: []planmodifier.String{
@@ -112,6 +94,16 @@ func (r *channelPermissionResource) Schema(_ context.Context, _ resource.SchemaR
 	}
 }
 
+var channelPermissionIdentity = resourceIdentity{
+	{"channel_id", path.Root("channel_id")},
+	{"overwrite_id", path.Root("overwrite_id")},
+	{"type", path.Root("type")},
+}
+
+func (r *channelPermissionResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = channelPermissionIdentity.schema()
+}
+
 func (r *channelPermissionResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	c, diags := getContextFromProviderData(req.ProviderData)
 	resp.Diagnostics.Append(diags...)
@@ -133,6 +125,10 @@ func owTypeToIntLegacy(t string) (int, error) {
 }
 
 func (r *channelPermissionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := startResourceSpan(ctx, "discord_channel_permission", "create")
+	defer endResourceSpan(span, &resp.Diagnostics)
+	defer channelPermissionIdentity.set(ctx, &resp.State, resp.Identity, &resp.Diagnostics)
+
 	var plan channelPermissionModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
@@ -146,6 +142,10 @@ func (r *channelPermissionResource) Create(ctx context.Context, req resource.Cre
 }
 
 func (r *channelPermissionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := startResourceSpan(ctx, "discord_channel_permission", "update")
+	defer endResourceSpan(span, &resp.Diagnostics)
+	defer channelPermissionIdentity.set(ctx, &resp.State, resp.Identity, &resp.Diagnostics)
+
 	var plan channelPermissionModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
@@ -203,13 +203,13 @@ func (r *channelPermissionResource) upsert(ctx context.Context, plan *channelPer
 		deny = v
 	}
 
-	body := restPermOverwriteUpsert{
+	params := &discord.EditChannelPermissionsParams{
 		Allow: strconv.FormatUint(allow, 10),
 		Deny:  strconv.FormatUint(deny, 10),
 		Type:  typ,
 	}
 
-	if err := r.c.DoJSON(ctx, "PUT", fmt.Sprintf("/channels/%s/permissions/%s", channelID, overwriteID), nil, body, nil); err != nil {
+	if err := r.c.EditChannelPermissions(ctx, channelID, overwriteID, params, ""); err != nil {
 		diags.AddError("Discord API error", err.Error())
 		return
 	}
@@ -219,6 +219,10 @@ func (r *channelPermissionResource) upsert(ctx context.Context, plan *channelPer
 }
 
 func (r *channelPermissionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := startResourceSpan(ctx, "discord_channel_permission", "read")
+	defer endResourceSpan(span, &resp.Diagnostics)
+	defer channelPermissionIdentity.set(ctx, &resp.State, resp.Identity, &resp.Diagnostics)
+
 	var state channelPermissionModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
@@ -245,8 +249,8 @@ func (r *channelPermissionResource) readIntoState(ctx context.Context, state *ch
 		return
 	}
 
-	var ch restChannelPermsRead
-	if err := r.c.DoJSON(ctx, "GET", fmt.Sprintf("/channels/%s", channelID), nil, nil, &ch); err != nil {
+	ch, err := r.c.GetChannel(ctx, channelID)
+	if err != nil {
 		if discord.IsDiscordHTTPStatus(err, 404) {
 			state.ID = types.StringNull()
 			return
@@ -291,6 +295,9 @@ func (r *channelPermissionResource) readIntoState(ctx context.Context, state *ch
 }
 
 func (r *channelPermissionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := startResourceSpan(ctx, "discord_channel_permission", "delete")
+	defer endResourceSpan(span, &resp.Diagnostics)
+
 	var state channelPermissionModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
@@ -300,7 +307,7 @@ func (r *channelPermissionResource) Delete(ctx context.Context, req resource.Del
 	channelID := state.ChannelID.ValueString()
 	overwriteID := state.OverwriteID.ValueString()
 
-	if err := r.c.DoJSON(ctx, "DELETE", fmt.Sprintf("/channels/%s/permissions/%s", channelID, overwriteID), nil, nil, nil); err != nil {
+	if err := r.c.DeleteChannelPermission(ctx, channelID, overwriteID, ""); err != nil {
 		if discord.IsDiscordHTTPStatus(err, 404) {
 			resp.State.RemoveResource(ctx)
 			return
@@ -313,8 +320,12 @@ func (r *channelPermissionResource) Delete(ctx context.Context, req resource.Del
 }
 
 func (r *channelPermissionResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	id := channelPermissionIdentity.importID(ctx, req, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	// Import format: channel_id:overwrite_id:type
-	parts := strings.SplitN(req.ID, ":", 3)
+	parts := strings.SplitN(id, ":", 3)
 	if len(parts) != 3 {
 		resp.Diagnostics.AddError("Invalid import ID", "Expected channel_id:overwrite_id:type")
 		return
@@ -7,7 +7,6 @@ import (
 	"strings"
 
 	"github.com/45ck/terraform-provider-discord/discord"
-	"github.com/45ck/terraform-provider-discord/internal/fw/planmod"
 	"github.com/45ck/terraform-provider-discord/internal/fw/validate"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -43,18 +42,6 @@ type channelPermissionsModel struct {
 	Reason    types.String                       `tfsdk:"reason"`
 }
 
-type restPermOverwrite struct {
-	ID    string `json:"id"`
-	Type  int    `json:"type"` // 0=role, 1=member
-	Allow string `json:"allow"`
-	Deny  string `json:"deny"`
-}
-
-type restChannelOverwrites struct {
-	ID                   string              `json:"id"`
-	PermissionOverwrites []restPermOverwrite `json:"permission_overwrites"`
-}
-
 type owKey struct {
 	Type string
 	ID   string
@@ -66,8 +53,9 @@ func (r *channelPermissionsResource) Metadata(_ context.Context, req resource.Me
 
 func (r *channelPermissionsResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Version: 1,
 		Attributes: map[string]schema.Attribute{
-			"id": schema.StringAttribute{Computed: true},
+			"id": schema.StringAttribute{Computed: true, PlanModifiers: stringUseState},
 			"channel_id": schema.StringAttribute{
 				Required: true,
 				PlanModifiers: []planmodifier.String{
@@ -113,17 +101,23 @@ func (r *channelPermissionsResource) Schema(_ context.Context, _ resource.Schema
 					},
 				},
 			},
-			"reason": schema.StringAttribute{
-				Optional:  true,
-				Sensitive: true,
-				PlanModifiers: []planmodifier.String{
-					planmod.IgnoreChangesString(),
-				},
-			},
+			"reason": reasonAttribute(),
 		},
 	}
 }
 
+var channelPermissionsIdentity = resourceIdentity{
+	{"channel_id", path.Root("channel_id")},
+}
+
+func (r *channelPermissionsResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = channelPermissionsIdentity.schema()
+}
+
+func (r *channelPermissionsResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return writeOnlyStateUpgraders("reason")
+}
+
 func (r *channelPermissionsResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	c, diags := getContextFromProviderData(req.ProviderData)
 	resp.Diagnostics.Append(diags...)
@@ -174,8 +168,8 @@ func intToUint64String(v int64) string {
 	return strconv.FormatUint(uint64(v), 10)
 }
 
-func desiredOverwrites(d channelPermissionsModel) (map[owKey]map[string]any, error) {
-	out := map[owKey]map[string]any{}
+func desiredOverwrites(d channelPermissionsModel) (map[owKey]*discord.EditChannelPermissionsParams, error) {
+	out := map[owKey]*discord.EditChannelPermissionsParams{}
 	for _, m := range d.Overwrite {
 		typ := m.Type.ValueString()
 		oid := m.OverwriteID.ValueString()
@@ -210,26 +204,23 @@ func desiredOverwrites(d channelPermissionsModel) (map[owKey]map[string]any, err
 			}
 		}
 
-		out[owKey{Type: typ, ID: oid}] = map[string]any{
-			"type":  ti,
-			"allow": allowStr,
-			"deny":  denyStr,
+		out[owKey{Type: typ, ID: oid}] = &discord.EditChannelPermissionsParams{
+			Type:  ti,
+			Allow: allowStr,
+			Deny:  denyStr,
 		}
 	}
 	return out, nil
 }
 
-func readChannelOverwrites(ctx context.Context, c *discord.RestClient, channelID string) (*restChannelOverwrites, error) {
-	var out restChannelOverwrites
-	if err := c.DoJSON(ctx, "GET", fmt.Sprintf("/channels/%s", channelID), nil, nil, &out); err != nil {
-		return nil, err
-	}
-	return &out, nil
-}
-
 func (r *channelPermissionsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := startResourceSpan(ctx, "discord_channel_permissions", "create")
+	defer endResourceSpan(span, &resp.Diagnostics)
+	defer channelPermissionsIdentity.set(ctx, &resp.State, resp.Identity, &resp.Diagnostics)
+
 	var plan channelPermissionsModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("reason"), &plan.Reason)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -242,8 +233,13 @@ func (r *channelPermissionsResource) Create(ctx context.Context, req resource.Cr
 }
 
 func (r *channelPermissionsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := startResourceSpan(ctx, "discord_channel_permissions", "update")
+	defer endResourceSpan(span, &resp.Diagnostics)
+	defer channelPermissionsIdentity.set(ctx, &resp.State, resp.Identity, &resp.Diagnostics)
+
 	var plan channelPermissionsModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("reason"), &plan.Reason)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -259,7 +255,7 @@ func (r *channelPermissionsResource) upsert(ctx context.Context, plan channelPer
 	channelID := plan.ChannelID.ValueString()
 	reason := plan.Reason.ValueString()
 
-	ch, err := readChannelOverwrites(ctx, r.c, channelID)
+	ch, err := r.c.GetChannel(ctx, channelID)
 	if err != nil {
 		diags.AddError("Discord API error", err.Error())
 		return
@@ -275,7 +271,7 @@ func (r *channelPermissionsResource) upsert(ctx context.Context, plan channelPer
 	for _, ow := range ch.PermissionOverwrites {
 		k := owKey{Type: owTypeFromInt(ow.Type), ID: ow.ID}
 		if _, ok := want[k]; !ok {
-			if err := r.c.DoJSONWithReason(ctx, "DELETE", fmt.Sprintf("/channels/%s/permissions/%s", channelID, ow.ID), nil, nil, nil, reason); err != nil {
+			if err := r.c.DeleteChannelPermission(ctx, channelID, ow.ID, reason); err != nil {
 				diags.AddError("Discord API error", err.Error())
 				return
 			}
@@ -283,8 +279,8 @@ func (r *channelPermissionsResource) upsert(ctx context.Context, plan channelPer
 	}
 
 	// Upsert desired overwrites.
-	for k, body := range want {
-		if err := r.c.DoJSONWithReason(ctx, "PUT", fmt.Sprintf("/channels/%s/permissions/%s", channelID, k.ID), nil, body, nil, reason); err != nil {
+	for k, params := range want {
+		if err := r.c.EditChannelPermissions(ctx, channelID, k.ID, params, reason); err != nil {
 			diags.AddError("Discord API error", err.Error())
 			return
 		}
@@ -292,6 +288,10 @@ func (r *channelPermissionsResource) upsert(ctx context.Context, plan channelPer
 }
 
 func (r *channelPermissionsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := startResourceSpan(ctx, "discord_channel_permissions", "read")
+	defer endResourceSpan(span, &resp.Diagnostics)
+	defer channelPermissionsIdentity.set(ctx, &resp.State, resp.Identity, &resp.Diagnostics)
+
 	var state channelPermissionsModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
@@ -299,7 +299,7 @@ func (r *channelPermissionsResource) Read(ctx context.Context, req resource.Read
 	}
 
 	channelID := state.ChannelID.ValueString()
-	ch, err := readChannelOverwrites(ctx, r.c, channelID)
+	ch, err := r.c.GetChannel(ctx, channelID)
 	if err != nil {
 		if discord.IsDiscordHTTPStatus(err, 404) {
 			resp.State.RemoveResource(ctx)
@@ -353,12 +353,19 @@ func (r *channelPermissionsResource) Read(ctx context.Context, req resource.Read
 }
 
 func (r *channelPermissionsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := startResourceSpan(ctx, "discord_channel_permissions", "delete")
+	defer endResourceSpan(span, &resp.Diagnostics)
+
 	// Authoritative overwrites resource: on destroy, remove only from state.
 	resp.Diagnostics.AddWarning("discord_channel_permissions does not revert overwrites on destroy", "Destroying this resource removes it from state only.")
 	resp.State.RemoveResource(ctx)
 }
 
 func (r *channelPermissionsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("channel_id"), req, resp)
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	id := channelPermissionsIdentity.importID(ctx, req, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("channel_id"), id)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
 }
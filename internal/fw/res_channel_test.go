@@ -0,0 +1,44 @@
+package fw
+
+import (
+	"context"
+	"testing"
+
+	"github.com/45ck/terraform-provider-discord/discord"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestChannelResource_FakeFaults(t *testing.T) {
+	e := newFakeEnv(t)
+	e.maxRetries = 1
+	e.faults(t, mishaps("/guilds/*/channels", "/channels/*")...)
+	listChannels := func(ctx context.Context, c *discord.RestClient) ([]string, error) {
+		channels, err := c.ListGuildChannels(ctx, e.guildID)
+		var ids []string
+		for _, ch := range channels {
+			if ch.Name == "flaky" {
+				ids = append(ids, ch.ID)
+			}
+		}
+		return ids, err
+	}
+
+	e.test(t, resource.TestCase{
+		Steps: []resource.TestStep{
+			{
+				Config: e.config(`
+resource "discord_channel" "flaky" {
+  server_id = %q
+  type      = "text"
+  name      = "flaky"
+  topic     = "created through faults"
+}
+`, e.guildID),
+				Check: resource.ComposeTestCheckFunc(
+					e.checkSingle("discord_channel.flaky", listChannels),
+					resource.TestCheckResourceAttr("discord_channel.flaky", "topic", "created through faults"),
+				),
+			},
+		},
+	})
+}
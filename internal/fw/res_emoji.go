@@ -2,12 +2,10 @@ package fw
 
 import (
 	"context"
-	"fmt"
 	"strings"
 
 	"github.com/45ck/terraform-provider-discord/discord"
 	"github.com/45ck/terraform-provider-discord/internal/fw/fwutil"
-	"github.com/45ck/terraform-provider-discord/internal/fw/planmod"
 	"github.com/45ck/terraform-provider-discord/internal/fw/validate"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/path"
@@ -23,16 +21,16 @@ func NewEmojiResource() resource.Resource {
 	return &emojiResource{}
 }
 
-type emojiResource struct {
-	c *discord.RestClient
+// emojiFieldPaths maps Discord request fields to schema attributes for per-attribute errors.
+var emojiFieldPaths = discordFieldPaths{
+	"name":  path.Root("name"),
+	"image": path.Root("image_data_uri"),
+	"roles": path.Root("roles"),
 }
 
-type restEmoji struct {
-	ID       string   `json:"id"`
-	Name     string   `json:"name"`
-	Roles    []string `json:"roles"`
-	Managed  bool     `json:"managed"`
-	Animated bool     `json:"animated"`
+type emojiResource struct {
+	c               *discord.RestClient
+	defaultServerID string
 }
 
 type emojiResourceModel struct {
@@ -54,11 +52,15 @@ func (r *emojiResource) Metadata(_ context.Context, req resource.MetadataRequest
 
 func (r *emojiResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Version: 1,
 		Attributes: map[string]schema.Attribute{
-			"id": schema.StringAttribute{Computed: true},
+			"id": schema.StringAttribute{Computed: true, PlanModifiers: stringUseState},
 			"server_id": schema.StringAttribute{
-				Required: true,
+				Optional:    true,
+				Computed:    true,
+				Description: serverIDDescription,
 				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
 					stringplanmodifier.RequiresReplace(),
 				},
 				Validators: []validator.String{
@@ -89,14 +91,7 @@ func (r *emojiResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 			"animated": schema.BoolAttribute{
 				Computed: true,
 			},
-			"reason": schema.StringAttribute{
-				Optional:  true,
-				Sensitive: true,
-				PlanModifiers: []planmodifier.String{
-					planmod.IgnoreChangesString(),
-				},
-				Description: "Optional audit log reason (X-Audit-Log-Reason). This value is not readable.",
-			},
+			"reason": reasonAttribute(),
 			// Convenience: often used for naming references in other resources; mirrors `name`.
 			"effective_name": schema.StringAttribute{
 				Computed: true,
@@ -105,6 +100,19 @@ func (r *emojiResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 	}
 }
 
+var emojiIdentity = resourceIdentity{
+	{"server_id", path.Root("server_id")},
+	{"emoji_id", path.Root("id")},
+}
+
+func (r *emojiResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = emojiIdentity.schema()
+}
+
+func (r *emojiResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return writeOnlyStateUpgraders("reason")
+}
+
 func (r *emojiResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	c, diags := getContextFromProviderData(req.ProviderData)
 	resp.Diagnostics.Append(diags...)
@@ -112,11 +120,21 @@ func (r *emojiResource) Configure(ctx context.Context, req resource.ConfigureReq
 		return
 	}
 	r.c = c.Rest
+	r.defaultServerID = c.Config.DefaultServerID
+}
+
+func (r *emojiResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	planServerID(ctx, r.defaultServerID, true, req, resp)
 }
 
 func (r *emojiResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := startResourceSpan(ctx, "discord_emoji", "create")
+	defer endResourceSpan(span, &resp.Diagnostics)
+	defer emojiIdentity.set(ctx, &resp.State, resp.Identity, &resp.Diagnostics)
+
 	var plan emojiResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("reason"), &plan.Reason)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -134,15 +152,15 @@ func (r *emojiResource) Create(ctx context.Context, req resource.CreateRequest,
 		}
 	}
 
-	body := map[string]any{
-		"name":  plan.Name.ValueString(),
-		"image": plan.ImageDataURI.ValueString(),
-		"roles": roles,
+	params := &discord.CreateEmojiParams{
+		Name:  plan.Name.ValueString(),
+		Image: plan.ImageDataURI.ValueString(),
+		Roles: roles,
 	}
 
-	var out restEmoji
-	if err := r.c.DoJSONWithReason(ctx, "POST", fmt.Sprintf("/guilds/%s/emojis", plan.ServerID.ValueString()), nil, body, &out, plan.Reason.ValueString()); err != nil {
-		resp.Diagnostics.AddError("Discord API error", err.Error())
+	out, err := r.c.CreateGuildEmoji(ctx, plan.ServerID.ValueString(), params, plan.Reason.ValueString())
+	if err != nil {
+		addDiscordAPIError(&resp.Diagnostics, err, emojiFieldPaths)
 		return
 	}
 
@@ -152,6 +170,10 @@ func (r *emojiResource) Create(ctx context.Context, req resource.CreateRequest,
 }
 
 func (r *emojiResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := startResourceSpan(ctx, "discord_emoji", "read")
+	defer endResourceSpan(span, &resp.Diagnostics)
+	defer emojiIdentity.set(ctx, &resp.State, resp.Identity, &resp.Diagnostics)
+
 	var state emojiResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
@@ -177,8 +199,8 @@ func (r *emojiResource) readIntoState(ctx context.Context, state *emojiResourceM
 		return
 	}
 
-	var out restEmoji
-	if err := r.c.DoJSON(ctx, "GET", fmt.Sprintf("/guilds/%s/emojis/%s", serverID, emojiID), nil, nil, &out); err != nil {
+	out, err := r.c.GetGuildEmoji(ctx, serverID, emojiID)
+	if err != nil {
 		if discord.IsDiscordHTTPStatus(err, 404) {
 			state.ID = types.StringNull()
 			return
@@ -195,15 +217,22 @@ func (r *emojiResource) readIntoState(ctx context.Context, state *emojiResourceM
 	state.ID = types.StringValue(out.ID)
 	state.ServerID = types.StringValue(serverID)
 	state.Name = types.StringValue(out.Name)
-	state.Roles = types.SetValueMust(types.StringType, vals)
+	if len(vals) > 0 || !state.Roles.IsNull() {
+		state.Roles = types.SetValueMust(types.StringType, vals)
+	}
 	state.Managed = types.BoolValue(out.Managed)
 	state.Animated = types.BoolValue(out.Animated)
 	state.EffectiveName = types.StringValue(out.Name)
 }
 
 func (r *emojiResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := startResourceSpan(ctx, "discord_emoji", "update")
+	defer endResourceSpan(span, &resp.Diagnostics)
+	defer emojiIdentity.set(ctx, &resp.State, resp.Identity, &resp.Diagnostics)
+
 	var plan emojiResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("reason"), &plan.Reason)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -216,14 +245,14 @@ func (r *emojiResource) Update(ctx context.Context, req resource.UpdateRequest,
 		}
 	}
 
-	body := map[string]any{
-		"name":  plan.Name.ValueString(),
-		"roles": roles,
+	params := &discord.ModifyEmojiParams{
+		Name:  discord.Ptr(plan.Name.ValueString()),
+		Roles: &roles,
 	}
 
-	var out restEmoji
-	if err := r.c.DoJSONWithReason(ctx, "PATCH", fmt.Sprintf("/guilds/%s/emojis/%s", plan.ServerID.ValueString(), plan.ID.ValueString()), nil, body, &out, plan.Reason.ValueString()); err != nil {
-		resp.Diagnostics.AddError("Discord API error", err.Error())
+	out, err := r.c.ModifyGuildEmoji(ctx, plan.ServerID.ValueString(), plan.ID.ValueString(), params, plan.Reason.ValueString())
+	if err != nil {
+		addDiscordAPIError(&resp.Diagnostics, err, emojiFieldPaths)
 		return
 	}
 
@@ -233,13 +262,16 @@ func (r *emojiResource) Update(ctx context.Context, req resource.UpdateRequest,
 }
 
 func (r *emojiResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := startResourceSpan(ctx, "discord_emoji", "delete")
+	defer endResourceSpan(span, &resp.Diagnostics)
+
 	var state emojiResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	if err := r.c.DoJSONWithReason(ctx, "DELETE", fmt.Sprintf("/guilds/%s/emojis/%s", state.ServerID.ValueString(), state.ID.ValueString()), nil, nil, nil, state.Reason.ValueString()); err != nil {
+	if err := r.c.DeleteGuildEmoji(ctx, state.ServerID.ValueString(), state.ID.ValueString(), ""); err != nil {
 		if discord.IsDiscordHTTPStatus(err, 404) {
 			resp.State.RemoveResource(ctx)
 			return
@@ -251,8 +283,12 @@ func (r *emojiResource) Delete(ctx context.Context, req resource.DeleteRequest,
 }
 
 func (r *emojiResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	id := emojiIdentity.importID(ctx, req, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	// Import format: server_id:emoji_id
-	serverID, emojiID, err := fwutil.ParseTwoIDs(req.ID)
+	serverID, emojiID, err := fwutil.ParseTwoIDs(id)
 	if err != nil {
 		resp.Diagnostics.AddError("Invalid import ID", "Expected server_id:emoji_id")
 		return
@@ -3,7 +3,6 @@ package fw
 import (
 	"context"
 	"encoding/json"
-	"fmt"
 
 	"github.com/45ck/terraform-provider-discord/discord"
 	"github.com/45ck/terraform-provider-discord/internal/fw/planmod"
@@ -21,7 +20,8 @@ func NewGuildSettingsResource() resource.Resource {
 }
 
 type guildSettingsResource struct {
-	c *discord.RestClient
+	c               *discord.RestClient
+	defaultServerID string
 }
 
 type guildSettingsModel struct {
@@ -34,16 +34,24 @@ type guildSettingsModel struct {
 
 func (r *guildSettingsResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_guild_settings"
+	// server_id, the identity, can be changed in place: the settings are then
+	// applied to the other server.
+	resp.ResourceBehavior.MutableIdentity = true
 }
 
 func (r *guildSettingsResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Version: 1,
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
-				Computed: true,
+				Computed:      true,
+				PlanModifiers: stringUseState,
 			},
 			"server_id": schema.StringAttribute{
-				Required: true,
+				Optional:      true,
+				Computed:      true,
+				Description:   serverIDDescription,
+				PlanModifiers: stringUseState,
 				Validators: []validator.String{
 					validate.Snowflake(),
 				},
@@ -58,14 +66,7 @@ func (r *guildSettingsResource) Schema(_ context.Context, _ resource.SchemaReque
 				},
 				Description: "JSON payload to PATCH to /guilds/{guild.id}",
 			},
-			"reason": schema.StringAttribute{
-				Optional:  true,
-				Sensitive: true,
-				PlanModifiers: []planmodifier.String{
-					planmod.IgnoreChangesString(),
-				},
-				Description: "Optional audit log reason (X-Audit-Log-Reason). This value is not readable.",
-			},
+			"reason": reasonAttribute(),
 			"state_json": schema.StringAttribute{
 				Computed:    true,
 				Description: "Normalized JSON returned from GET /guilds/{guild.id}",
@@ -74,6 +75,18 @@ func (r *guildSettingsResource) Schema(_ context.Context, _ resource.SchemaReque
 	}
 }
 
+var guildSettingsIdentity = resourceIdentity{
+	{"server_id", path.Root("server_id")},
+}
+
+func (r *guildSettingsResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = guildSettingsIdentity.schema()
+}
+
+func (r *guildSettingsResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return writeOnlyStateUpgraders("reason")
+}
+
 func (r *guildSettingsResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	c, diags := getContextFromProviderData(req.ProviderData)
 	resp.Diagnostics.Append(diags...)
@@ -81,24 +94,33 @@ func (r *guildSettingsResource) Configure(ctx context.Context, req resource.Conf
 		return
 	}
 	r.c = c.Rest
+	r.defaultServerID = c.Config.DefaultServerID
+}
+
+func (r *guildSettingsResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	planServerID(ctx, r.defaultServerID, false, req, resp)
 }
 
 func (r *guildSettingsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := startResourceSpan(ctx, "discord_guild_settings", "create")
+	defer endResourceSpan(span, &resp.Diagnostics)
+	defer guildSettingsIdentity.set(ctx, &resp.State, resp.Identity, &resp.Diagnostics)
+
 	var plan guildSettingsModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("reason"), &plan.Reason)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
 	serverID := plan.ServerID.ValueString()
-	var payload any
-	if err := json.Unmarshal([]byte(plan.PayloadJSON.ValueString()), &payload); err != nil {
-		resp.Diagnostics.AddError("Invalid payload_json", err.Error())
+	payload := json.RawMessage(plan.PayloadJSON.ValueString())
+	if !json.Valid(payload) {
+		resp.Diagnostics.AddError("Invalid payload_json", "payload_json is not valid JSON")
 		return
 	}
 
-	var out any
-	if err := r.c.DoJSONWithReason(ctx, "PATCH", fmt.Sprintf("/guilds/%s", serverID), nil, payload, &out, plan.Reason.ValueString()); err != nil {
+	if _, err := r.c.ModifyGuildJSON(ctx, serverID, payload, plan.Reason.ValueString()); err != nil {
 		resp.Diagnostics.AddError("Discord API error", err.Error())
 		return
 	}
@@ -115,6 +137,10 @@ func (r *guildSettingsResource) Create(ctx context.Context, req resource.CreateR
 }
 
 func (r *guildSettingsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := startResourceSpan(ctx, "discord_guild_settings", "read")
+	defer endResourceSpan(span, &resp.Diagnostics)
+	defer guildSettingsIdentity.set(ctx, &resp.State, resp.Identity, &resp.Diagnostics)
+
 	var state guildSettingsModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
@@ -136,23 +162,27 @@ func (r *guildSettingsResource) Read(ctx context.Context, req resource.ReadReque
 }
 
 func (r *guildSettingsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := startResourceSpan(ctx, "discord_guild_settings", "update")
+	defer endResourceSpan(span, &resp.Diagnostics)
+	defer guildSettingsIdentity.set(ctx, &resp.State, resp.Identity, &resp.Diagnostics)
+
 	var plan guildSettingsModel
 	var state guildSettingsModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("reason"), &plan.Reason)...)
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
 	serverID := plan.ServerID.ValueString()
-	var payload any
-	if err := json.Unmarshal([]byte(plan.PayloadJSON.ValueString()), &payload); err != nil {
-		resp.Diagnostics.AddError("Invalid payload_json", err.Error())
+	payload := json.RawMessage(plan.PayloadJSON.ValueString())
+	if !json.Valid(payload) {
+		resp.Diagnostics.AddError("Invalid payload_json", "payload_json is not valid JSON")
 		return
 	}
 
-	var out any
-	if err := r.c.DoJSONWithReason(ctx, "PATCH", fmt.Sprintf("/guilds/%s", serverID), nil, payload, &out, plan.Reason.ValueString()); err != nil {
+	if _, err := r.c.ModifyGuildJSON(ctx, serverID, payload, plan.Reason.ValueString()); err != nil {
 		resp.Diagnostics.AddError("Discord API error", err.Error())
 		return
 	}
@@ -166,6 +196,9 @@ func (r *guildSettingsResource) Update(ctx context.Context, req resource.UpdateR
 }
 
 func (r *guildSettingsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := startResourceSpan(ctx, "discord_guild_settings", "delete")
+	defer endResourceSpan(span, &resp.Diagnostics)
+
 	// No-op; do not attempt to "revert" arbitrary guild settings.
 	resp.Diagnostics.AddWarning(
 		"discord_guild_settings does not revert guild settings on destroy",
@@ -175,9 +208,13 @@ func (r *guildSettingsResource) Delete(ctx context.Context, req resource.DeleteR
 }
 
 func (r *guildSettingsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	id := guildSettingsIdentity.importID(ctx, req, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	// ID is the server/guild ID.
-	resource.ImportStatePassthroughID(ctx, path.Root("server_id"), req, resp)
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("server_id"), id)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
 }
 
 func (r *guildSettingsResource) readIntoState(ctx context.Context, state *guildSettingsModel, diags discordFrameworkDiagnostics) (missing bool) {
@@ -186,8 +223,8 @@ func (r *guildSettingsResource) readIntoState(ctx context.Context, state *guildS
 		serverID = state.ServerID.ValueString()
 	}
 
-	var out any
-	if err := r.c.DoJSON(ctx, "GET", fmt.Sprintf("/guilds/%s", serverID), nil, nil, &out); err != nil {
+	out, err := r.c.GetGuildJSON(ctx, serverID)
+	if err != nil {
 		if discord.IsDiscordHTTPStatus(err, 404) {
 			state.ID = types.StringNull()
 			return true
@@ -196,12 +233,7 @@ func (r *guildSettingsResource) readIntoState(ctx context.Context, state *guildS
 		return false
 	}
 
-	b, err := json.Marshal(out)
-	if err != nil {
-		diags.AddError("JSON error", err.Error())
-		return false
-	}
-	norm, err := discord.NormalizeJSON(string(b))
+	norm, err := discord.NormalizeJSON(string(out))
 	if err != nil {
 		diags.AddError("JSON error", err.Error())
 		return false
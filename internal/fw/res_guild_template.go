@@ -2,11 +2,9 @@ package fw
 
 import (
 	"context"
-	"fmt"
 
 	"github.com/45ck/terraform-provider-discord/discord"
 	"github.com/45ck/terraform-provider-discord/internal/fw/fwutil"
-	"github.com/45ck/terraform-provider-discord/internal/fw/planmod"
 	"github.com/45ck/terraform-provider-discord/internal/fw/validate"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -22,7 +20,8 @@ func NewGuildTemplateResource() resource.Resource {
 }
 
 type guildTemplateResource struct {
-	c *discord.RestClient
+	c               *discord.RestClient
+	defaultServerID string
 }
 
 type guildTemplateModel struct {
@@ -40,30 +39,21 @@ type guildTemplateModel struct {
 	CreatorID  types.String `tfsdk:"creator_id"`
 }
 
-type restGuildTemplate struct {
-	Code        string `json:"code"`
-	Name        string `json:"name"`
-	Description string `json:"description"`
-
-	UsageCount int64  `json:"usage_count"`
-	IsDirty    bool   `json:"is_dirty"`
-	CreatedAt  string `json:"created_at"`
-	UpdatedAt  string `json:"updated_at"`
-
-	CreatorID string `json:"creator_id"`
-}
-
 func (r *guildTemplateResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_guild_template"
 }
 
 func (r *guildTemplateResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Version: 1,
 		Attributes: map[string]schema.Attribute{
-			"id": schema.StringAttribute{Computed: true, Description: "Template code."},
+			"id": schema.StringAttribute{Computed: true, PlanModifiers: stringUseState, Description: "Template code."},
 			"server_id": schema.StringAttribute{
-				Required: true,
+				Optional:    true,
+				Computed:    true,
+				Description: serverIDDescription,
 				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
 					stringplanmodifier.RequiresReplace(),
 				},
 				Validators: []validator.String{
@@ -76,14 +66,7 @@ func (r *guildTemplateResource) Schema(_ context.Context, _ resource.SchemaReque
 			"description": schema.StringAttribute{
 				Optional: true,
 			},
-			"reason": schema.StringAttribute{
-				Optional:  true,
-				Sensitive: true,
-				PlanModifiers: []planmodifier.String{
-					planmod.IgnoreChangesString(),
-				},
-				Description: "Optional audit log reason (X-Audit-Log-Reason). This value is not readable.",
-			},
+			"reason": reasonAttribute(),
 
 			"usage_count": schema.Int64Attribute{
 				Computed: true,
@@ -104,6 +87,19 @@ func (r *guildTemplateResource) Schema(_ context.Context, _ resource.SchemaReque
 	}
 }
 
+var guildTemplateIdentity = resourceIdentity{
+	{"server_id", path.Root("server_id")},
+	{"template_code", path.Root("id")},
+}
+
+func (r *guildTemplateResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = guildTemplateIdentity.schema()
+}
+
+func (r *guildTemplateResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return writeOnlyStateUpgraders("reason")
+}
+
 func (r *guildTemplateResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	c, diags := getContextFromProviderData(req.ProviderData)
 	resp.Diagnostics.Append(diags...)
@@ -111,24 +107,32 @@ func (r *guildTemplateResource) Configure(ctx context.Context, req resource.Conf
 		return
 	}
 	r.c = c.Rest
+	r.defaultServerID = c.Config.DefaultServerID
+}
+
+func (r *guildTemplateResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	planServerID(ctx, r.defaultServerID, true, req, resp)
 }
 
 func (r *guildTemplateResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := startResourceSpan(ctx, "discord_guild_template", "create")
+	defer endResourceSpan(span, &resp.Diagnostics)
+	defer guildTemplateIdentity.set(ctx, &resp.State, resp.Identity, &resp.Diagnostics)
+
 	var plan guildTemplateModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("reason"), &plan.Reason)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	body := map[string]any{
-		"name": plan.Name.ValueString(),
-	}
-	if !(plan.Description.IsNull() || plan.Description.IsUnknown()) {
-		body["description"] = plan.Description.ValueString()
+	params := &discord.GuildTemplateParams{
+		Name:        discord.Ptr(plan.Name.ValueString()),
+		Description: fwutil.OptionalString(plan.Description),
 	}
 
-	var out restGuildTemplate
-	if err := r.c.DoJSONWithReason(ctx, "POST", fmt.Sprintf("/guilds/%s/templates", plan.ServerID.ValueString()), nil, body, &out, plan.Reason.ValueString()); err != nil {
+	out, err := r.c.CreateGuildTemplate(ctx, plan.ServerID.ValueString(), params, plan.Reason.ValueString())
+	if err != nil {
 		resp.Diagnostics.AddError("Discord API error", err.Error())
 		return
 	}
@@ -142,6 +146,10 @@ func (r *guildTemplateResource) Create(ctx context.Context, req resource.CreateR
 }
 
 func (r *guildTemplateResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := startResourceSpan(ctx, "discord_guild_template", "read")
+	defer endResourceSpan(span, &resp.Diagnostics)
+	defer guildTemplateIdentity.set(ctx, &resp.State, resp.Identity, &resp.Diagnostics)
+
 	var state guildTemplateModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
@@ -167,8 +175,8 @@ func (r *guildTemplateResource) readIntoState(ctx context.Context, state *guildT
 		return
 	}
 
-	var out []restGuildTemplate
-	if err := r.c.DoJSON(ctx, "GET", fmt.Sprintf("/guilds/%s/templates", serverID), nil, nil, &out); err != nil {
+	out, err := r.c.GetGuildTemplates(ctx, serverID)
+	if err != nil {
 		if discord.IsDiscordHTTPStatus(err, 404) {
 			state.ID = types.StringNull()
 			return
@@ -181,7 +189,7 @@ func (r *guildTemplateResource) readIntoState(ctx context.Context, state *guildT
 		if t.Code == code {
 			state.ID = types.StringValue(t.Code)
 			state.Name = types.StringValue(t.Name)
-			state.Description = types.StringValue(t.Description)
+			state.Description = fwutil.KeepNullString(state.Description, t.Description)
 			state.UsageCount = types.Int64Value(t.UsageCount)
 			state.IsDirty = types.BoolValue(t.IsDirty)
 			state.CreatedAt = types.StringValue(t.CreatedAt)
@@ -196,24 +204,26 @@ func (r *guildTemplateResource) readIntoState(ctx context.Context, state *guildT
 }
 
 func (r *guildTemplateResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := startResourceSpan(ctx, "discord_guild_template", "update")
+	defer endResourceSpan(span, &resp.Diagnostics)
+	defer guildTemplateIdentity.set(ctx, &resp.State, resp.Identity, &resp.Diagnostics)
+
 	var plan guildTemplateModel
 	var prior guildTemplateModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("reason"), &plan.Reason)...)
 	resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
 	code := prior.ID.ValueString()
-	body := map[string]any{
-		"name": plan.Name.ValueString(),
-	}
-	if !(plan.Description.IsNull() || plan.Description.IsUnknown()) {
-		body["description"] = plan.Description.ValueString()
+	params := &discord.GuildTemplateParams{
+		Name:        discord.Ptr(plan.Name.ValueString()),
+		Description: fwutil.OptionalString(plan.Description),
 	}
 
-	var out restGuildTemplate
-	if err := r.c.DoJSONWithReason(ctx, "PATCH", fmt.Sprintf("/guilds/%s/templates/%s", plan.ServerID.ValueString(), code), nil, body, &out, plan.Reason.ValueString()); err != nil {
+	if _, err := r.c.ModifyGuildTemplate(ctx, plan.ServerID.ValueString(), code, params, plan.Reason.ValueString()); err != nil {
 		resp.Diagnostics.AddError("Discord API error", err.Error())
 		return
 	}
@@ -227,6 +237,9 @@ func (r *guildTemplateResource) Update(ctx context.Context, req resource.UpdateR
 }
 
 func (r *guildTemplateResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := startResourceSpan(ctx, "discord_guild_template", "delete")
+	defer endResourceSpan(span, &resp.Diagnostics)
+
 	var state guildTemplateModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
@@ -240,7 +253,7 @@ func (r *guildTemplateResource) Delete(ctx context.Context, req resource.DeleteR
 		return
 	}
 
-	if err := r.c.DoJSONWithReason(ctx, "DELETE", fmt.Sprintf("/guilds/%s/templates/%s", serverID, code), nil, nil, nil, state.Reason.ValueString()); err != nil {
+	if err := r.c.DeleteGuildTemplate(ctx, serverID, code, ""); err != nil {
 		if discord.IsDiscordHTTPStatus(err, 404) {
 			resp.State.RemoveResource(ctx)
 			return
@@ -253,8 +266,12 @@ func (r *guildTemplateResource) Delete(ctx context.Context, req resource.DeleteR
 }
 
 func (r *guildTemplateResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	id := guildTemplateIdentity.importID(ctx, req, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	// Import ID format: "{server_id}:{template_code}".
-	serverID, code, err := fwutil.ParseTwoIDs(req.ID)
+	serverID, code, err := fwutil.ParseTwoIDs(id)
 	if err != nil {
 		resp.Diagnostics.AddError("Invalid import ID", err.Error())
 		return
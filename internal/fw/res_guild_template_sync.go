@@ -6,7 +6,6 @@ import (
 
 	"github.com/45ck/terraform-provider-discord/discord"
 	"github.com/45ck/terraform-provider-discord/internal/fw/fwutil"
-	"github.com/45ck/terraform-provider-discord/internal/fw/planmod"
 	"github.com/45ck/terraform-provider-discord/internal/fw/validate"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -27,7 +26,8 @@ func NewGuildTemplateSyncResource() resource.Resource {
 }
 
 type guildTemplateSyncResource struct {
-	c *discord.RestClient
+	c               *discord.RestClient
+	defaultServerID string
 }
 
 type guildTemplateSyncModel struct {
@@ -47,11 +47,15 @@ func (r *guildTemplateSyncResource) Metadata(_ context.Context, req resource.Met
 
 func (r *guildTemplateSyncResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Version: 1,
 		Attributes: map[string]schema.Attribute{
-			"id": schema.StringAttribute{Computed: true},
+			"id": schema.StringAttribute{Computed: true, PlanModifiers: stringUseState},
 			"server_id": schema.StringAttribute{
-				Required: true,
+				Optional:    true,
+				Computed:    true,
+				Description: serverIDDescription,
 				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
 					stringplanmodifier.RequiresReplace(),
 				},
 				Validators: []validator.String{
@@ -68,14 +72,7 @@ func (r *guildTemplateSyncResource) Schema(_ context.Context, _ resource.SchemaR
 				Optional:    true,
 				Description: "Change this value to force a resync (Update) without replacing the resource.",
 			},
-			"reason": schema.StringAttribute{
-				Optional:  true,
-				Sensitive: true,
-				PlanModifiers: []planmodifier.String{
-					planmod.IgnoreChangesString(),
-				},
-				Description: "Optional audit log reason (X-Audit-Log-Reason). This value is not readable.",
-			},
+			"reason": reasonAttribute(),
 			"updated_at": schema.StringAttribute{
 				Computed: true,
 			},
@@ -86,6 +83,19 @@ func (r *guildTemplateSyncResource) Schema(_ context.Context, _ resource.SchemaR
 	}
 }
 
+var guildTemplateSyncIdentity = resourceIdentity{
+	{"server_id", path.Root("server_id")},
+	{"template_code", path.Root("template_code")},
+}
+
+func (r *guildTemplateSyncResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = guildTemplateSyncIdentity.schema()
+}
+
+func (r *guildTemplateSyncResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return writeOnlyStateUpgraders("reason")
+}
+
 func (r *guildTemplateSyncResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	c, diags := getContextFromProviderData(req.ProviderData)
 	resp.Diagnostics.Append(diags...)
@@ -93,11 +103,21 @@ func (r *guildTemplateSyncResource) Configure(ctx context.Context, req resource.
 		return
 	}
 	r.c = c.Rest
+	r.defaultServerID = c.Config.DefaultServerID
+}
+
+func (r *guildTemplateSyncResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	planServerID(ctx, r.defaultServerID, true, req, resp)
 }
 
 func (r *guildTemplateSyncResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := startResourceSpan(ctx, "discord_guild_template_sync", "create")
+	defer endResourceSpan(span, &resp.Diagnostics)
+	defer guildTemplateSyncIdentity.set(ctx, &resp.State, resp.Identity, &resp.Diagnostics)
+
 	var plan guildTemplateSyncModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("reason"), &plan.Reason)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -110,6 +130,10 @@ func (r *guildTemplateSyncResource) Create(ctx context.Context, req resource.Cre
 }
 
 func (r *guildTemplateSyncResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := startResourceSpan(ctx, "discord_guild_template_sync", "read")
+	defer endResourceSpan(span, &resp.Diagnostics)
+	defer guildTemplateSyncIdentity.set(ctx, &resp.State, resp.Identity, &resp.Diagnostics)
+
 	var state guildTemplateSyncModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
@@ -117,8 +141,8 @@ func (r *guildTemplateSyncResource) Read(ctx context.Context, req resource.ReadR
 	}
 
 	// Best-effort: confirm template still exists.
-	var out []restGuildTemplate
-	if err := r.c.DoJSON(ctx, "GET", fmt.Sprintf("/guilds/%s/templates", state.ServerID.ValueString()), nil, nil, &out); err != nil {
+	out, err := r.c.GetGuildTemplates(ctx, state.ServerID.ValueString())
+	if err != nil {
 		if discord.IsDiscordHTTPStatus(err, 404) {
 			resp.State.RemoveResource(ctx)
 			return
@@ -145,9 +169,14 @@ func (r *guildTemplateSyncResource) Read(ctx context.Context, req resource.ReadR
 }
 
 func (r *guildTemplateSyncResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := startResourceSpan(ctx, "discord_guild_template_sync", "update")
+	defer endResourceSpan(span, &resp.Diagnostics)
+	defer guildTemplateSyncIdentity.set(ctx, &resp.State, resp.Identity, &resp.Diagnostics)
+
 	var plan guildTemplateSyncModel
 	var prior guildTemplateSyncModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("reason"), &plan.Reason)...)
 	resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -165,8 +194,8 @@ func (r *guildTemplateSyncResource) sync(ctx context.Context, plan *guildTemplat
 	serverID := plan.ServerID.ValueString()
 	code := plan.TemplateCode.ValueString()
 
-	var out restGuildTemplate
-	if err := r.c.DoJSONWithReason(ctx, "PUT", fmt.Sprintf("/guilds/%s/templates/%s", serverID, code), nil, nil, &out, plan.Reason.ValueString()); err != nil {
+	out, err := r.c.SyncGuildTemplate(ctx, serverID, code, plan.Reason.ValueString())
+	if err != nil {
 		diags.AddError("Discord API error", err.Error())
 		return
 	}
@@ -182,8 +211,12 @@ func (r *guildTemplateSyncResource) Delete(ctx context.Context, _ resource.Delet
 }
 
 func (r *guildTemplateSyncResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	id := guildTemplateSyncIdentity.importID(ctx, req, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	// Import ID format: "{server_id}:{template_code}".
-	serverID, code, err := fwutil.ParseTwoIDs(req.ID)
+	serverID, code, err := fwutil.ParseTwoIDs(id)
 	if err != nil {
 		resp.Diagnostics.AddError("Invalid import ID", err.Error())
 		return
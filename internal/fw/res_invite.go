@@ -2,9 +2,9 @@ package fw
 
 import (
 	"context"
-	"fmt"
 
 	"github.com/45ck/terraform-provider-discord/discord"
+	"github.com/45ck/terraform-provider-discord/internal/fw/fwutil"
 	"github.com/45ck/terraform-provider-discord/internal/fw/validate"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -37,17 +37,6 @@ type inviteModel struct {
 	Code types.String `tfsdk:"code"`
 }
 
-type restInvite struct {
-	Code string `json:"code"`
-}
-
-type restInviteCreate struct {
-	MaxAge    int  `json:"max_age,omitempty"`
-	MaxUses   int  `json:"max_uses,omitempty"`
-	Temporary bool `json:"temporary,omitempty"`
-	Unique    bool `json:"unique,omitempty"`
-}
-
 func (r *inviteResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_invite"
 }
@@ -55,7 +44,7 @@ func (r *inviteResource) Metadata(_ context.Context, req resource.MetadataReques
 func (r *inviteResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
-			"id": schema.StringAttribute{Computed: true},
+			"id": schema.StringAttribute{Computed: true, PlanModifiers: stringUseState},
 			"channel_id": schema.StringAttribute{
 				Required: true,
 				PlanModifiers: []planmodifier.String{
@@ -97,6 +86,14 @@ func (r *inviteResource) Schema(_ context.Context, _ resource.SchemaRequest, res
 	}
 }
 
+var inviteIdentity = resourceIdentity{
+	{"code", path.Root("id")},
+}
+
+func (r *inviteResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = inviteIdentity.schema()
+}
+
 func (r *inviteResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	c, diags := getContextFromProviderData(req.ProviderData)
 	resp.Diagnostics.Append(diags...)
@@ -107,30 +104,27 @@ func (r *inviteResource) Configure(ctx context.Context, req resource.ConfigureRe
 }
 
 func (r *inviteResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := startResourceSpan(ctx, "discord_invite", "create")
+	defer endResourceSpan(span, &resp.Diagnostics)
+	defer inviteIdentity.set(ctx, &resp.State, resp.Identity, &resp.Diagnostics)
+
 	var plan inviteModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	maxAge := int64(86400)
-	if !plan.MaxAge.IsNull() {
-		maxAge = plan.MaxAge.ValueInt64()
+	params := &discord.CreateChannelInviteParams{
+		MaxUses:   fwutil.OptionalInt(plan.MaxUses),
+		Temporary: fwutil.OptionalBool(plan.Temporary),
+		Unique:    fwutil.OptionalBool(plan.Unique),
 	}
-	maxUses := int64(0)
-	if !plan.MaxUses.IsNull() {
-		maxUses = plan.MaxUses.ValueInt64()
+	if maxAge := int(plan.MaxAge.ValueInt64()); maxAge != 0 {
+		params.MaxAge = &maxAge
 	}
 
-	body := restInviteCreate{
-		MaxAge:    int(maxAge),
-		MaxUses:   int(maxUses),
-		Temporary: !plan.Temporary.IsNull() && plan.Temporary.ValueBool(),
-		Unique:    !plan.Unique.IsNull() && plan.Unique.ValueBool(),
-	}
-
-	var out restInvite
-	if err := r.c.DoJSON(ctx, "POST", fmt.Sprintf("/channels/%s/invites", plan.ChannelID.ValueString()), nil, body, &out); err != nil {
+	out, err := r.c.CreateChannelInvite(ctx, plan.ChannelID.ValueString(), params, "")
+	if err != nil {
 		resp.Diagnostics.AddError("Discord API error", err.Error())
 		return
 	}
@@ -141,14 +135,18 @@ func (r *inviteResource) Create(ctx context.Context, req resource.CreateRequest,
 }
 
 func (r *inviteResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := startResourceSpan(ctx, "discord_invite", "read")
+	defer endResourceSpan(span, &resp.Diagnostics)
+	defer inviteIdentity.set(ctx, &resp.State, resp.Identity, &resp.Diagnostics)
+
 	var state inviteModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	var out restInvite
-	if err := r.c.DoJSON(ctx, "GET", fmt.Sprintf("/invites/%s", state.ID.ValueString()), nil, nil, &out); err != nil {
+	out, err := r.c.GetInvite(ctx, state.ID.ValueString())
+	if err != nil {
 		if discord.IsDiscordHTTPStatus(err, 404) {
 			resp.State.RemoveResource(ctx)
 			return
@@ -161,17 +159,24 @@ func (r *inviteResource) Read(ctx context.Context, req resource.ReadRequest, res
 }
 
 func (r *inviteResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := startResourceSpan(ctx, "discord_invite", "update")
+	defer endResourceSpan(span, &resp.Diagnostics)
+	defer inviteIdentity.set(ctx, &resp.State, resp.Identity, &resp.Diagnostics)
+
 	resp.Diagnostics.AddError("Unsupported operation", "discord_invite does not support updates (replace on change)")
 }
 
 func (r *inviteResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := startResourceSpan(ctx, "discord_invite", "delete")
+	defer endResourceSpan(span, &resp.Diagnostics)
+
 	var state inviteModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	if err := r.c.DoJSON(ctx, "DELETE", fmt.Sprintf("/invites/%s", state.ID.ValueString()), nil, nil, nil); err != nil {
+	if err := r.c.DeleteInvite(ctx, state.ID.ValueString(), ""); err != nil {
 		if discord.IsDiscordHTTPStatus(err, 404) {
 			resp.State.RemoveResource(ctx)
 			return
@@ -183,6 +188,10 @@ func (r *inviteResource) Delete(ctx context.Context, req resource.DeleteRequest,
 }
 
 func (r *inviteResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
-	resource.ImportStatePassthroughID(ctx, path.Root("code"), req, resp)
+	id := inviteIdentity.importID(ctx, req, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("code"), id)...)
 }
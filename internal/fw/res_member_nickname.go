@@ -6,7 +6,6 @@ import (
 
 	"github.com/45ck/terraform-provider-discord/discord"
 	"github.com/45ck/terraform-provider-discord/internal/fw/fwutil"
-	"github.com/45ck/terraform-provider-discord/internal/fw/planmod"
 	"github.com/45ck/terraform-provider-discord/internal/fw/validate"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -22,15 +21,8 @@ func NewMemberNicknameResource() resource.Resource {
 }
 
 type memberNicknameResource struct {
-	c *discord.RestClient
-}
-
-type restGuildMember struct {
-	User struct {
-		ID string `json:"id"`
-	} `json:"user"`
-	CommunicationDisabledUntil string `json:"communication_disabled_until"`
-	Nick                       string `json:"nick"`
+	c               *discord.RestClient
+	defaultServerID string
 }
 
 type memberNicknameModel struct {
@@ -48,11 +40,15 @@ func (r *memberNicknameResource) Metadata(_ context.Context, req resource.Metada
 
 func (r *memberNicknameResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Version: 1,
 		Attributes: map[string]schema.Attribute{
-			"id": schema.StringAttribute{Computed: true},
+			"id": schema.StringAttribute{Computed: true, PlanModifiers: stringUseState},
 			"server_id": schema.StringAttribute{
-				Required: true,
+				Optional:    true,
+				Computed:    true,
+				Description: serverIDDescription,
 				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
 					stringplanmodifier.RequiresReplace(),
 				},
 				Validators: []validator.String{
@@ -72,17 +68,24 @@ func (r *memberNicknameResource) Schema(_ context.Context, _ resource.SchemaRequ
 				Required:    true,
 				Description: "Nickname for the member. Use an empty string to clear.",
 			},
-			"reason": schema.StringAttribute{
-				Optional:  true,
-				Sensitive: true,
-				PlanModifiers: []planmodifier.String{
-					planmod.IgnoreChangesString(),
-				},
-			},
+			"reason": reasonAttribute(),
 		},
 	}
 }
 
+var memberNicknameIdentity = resourceIdentity{
+	{"server_id", path.Root("server_id")},
+	{"user_id", path.Root("user_id")},
+}
+
+func (r *memberNicknameResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = memberNicknameIdentity.schema()
+}
+
+func (r *memberNicknameResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return writeOnlyStateUpgraders("reason")
+}
+
 func (r *memberNicknameResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	c, diags := getContextFromProviderData(req.ProviderData)
 	resp.Diagnostics.Append(diags...)
@@ -90,11 +93,21 @@ func (r *memberNicknameResource) Configure(ctx context.Context, req resource.Con
 		return
 	}
 	r.c = c.Rest
+	r.defaultServerID = c.Config.DefaultServerID
+}
+
+func (r *memberNicknameResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	planServerID(ctx, r.defaultServerID, true, req, resp)
 }
 
 func (r *memberNicknameResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := startResourceSpan(ctx, "discord_member_nickname", "create")
+	defer endResourceSpan(span, &resp.Diagnostics)
+	defer memberNicknameIdentity.set(ctx, &resp.State, resp.Identity, &resp.Diagnostics)
+
 	var plan memberNicknameModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("reason"), &plan.Reason)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -106,8 +119,13 @@ func (r *memberNicknameResource) Create(ctx context.Context, req resource.Create
 }
 
 func (r *memberNicknameResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := startResourceSpan(ctx, "discord_member_nickname", "update")
+	defer endResourceSpan(span, &resp.Diagnostics)
+	defer memberNicknameIdentity.set(ctx, &resp.State, resp.Identity, &resp.Diagnostics)
+
 	var plan memberNicknameModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("reason"), &plan.Reason)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -122,16 +140,11 @@ func (r *memberNicknameResource) upsert(ctx context.Context, plan *memberNicknam
 	serverID := plan.ServerID.ValueString()
 	userID := plan.UserID.ValueString()
 
-	nick := plan.Nick.ValueString()
-	var val any
-	if nick == "" {
-		val = nil
-	} else {
-		val = nick
+	params := &discord.ModifyMemberParams{
+		Nick: discord.NullIfEmpty(plan.Nick.ValueString()),
 	}
-	body := map[string]any{"nick": val}
 
-	if err := r.c.DoJSONWithReason(ctx, "PATCH", fmt.Sprintf("/guilds/%s/members/%s", serverID, userID), nil, body, nil, plan.Reason.ValueString()); err != nil {
+	if err := r.c.ModifyMember(ctx, serverID, userID, params, plan.Reason.ValueString()); err != nil {
 		diags.AddError("Discord API error", err.Error())
 		return
 	}
@@ -141,6 +154,10 @@ func (r *memberNicknameResource) upsert(ctx context.Context, plan *memberNicknam
 }
 
 func (r *memberNicknameResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := startResourceSpan(ctx, "discord_member_nickname", "read")
+	defer endResourceSpan(span, &resp.Diagnostics)
+	defer memberNicknameIdentity.set(ctx, &resp.State, resp.Identity, &resp.Diagnostics)
+
 	var state memberNicknameModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
@@ -170,8 +187,8 @@ func (r *memberNicknameResource) readIntoState(ctx context.Context, state *membe
 		serverID, userID = sid, uid
 	}
 
-	var out restGuildMember
-	if err := r.c.DoJSON(ctx, "GET", fmt.Sprintf("/guilds/%s/members/%s", serverID, userID), nil, nil, &out); err != nil {
+	out, err := r.c.GetMember(ctx, serverID, userID)
+	if err != nil {
 		if discord.IsDiscordHTTPStatus(err, 404) {
 			state.ID = types.StringNull()
 			return
@@ -187,6 +204,9 @@ func (r *memberNicknameResource) readIntoState(ctx context.Context, state *membe
 }
 
 func (r *memberNicknameResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := startResourceSpan(ctx, "discord_member_nickname", "delete")
+	defer endResourceSpan(span, &resp.Diagnostics)
+
 	var state memberNicknameModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
@@ -196,8 +216,8 @@ func (r *memberNicknameResource) Delete(ctx context.Context, req resource.Delete
 	serverID := state.ServerID.ValueString()
 	userID := state.UserID.ValueString()
 
-	body := map[string]any{"nick": nil}
-	if err := r.c.DoJSONWithReason(ctx, "PATCH", fmt.Sprintf("/guilds/%s/members/%s", serverID, userID), nil, body, nil, state.Reason.ValueString()); err != nil {
+	params := &discord.ModifyMemberParams{Nick: discord.Null[string]()}
+	if err := r.c.ModifyMember(ctx, serverID, userID, params, ""); err != nil {
 		if discord.IsDiscordHTTPStatus(err, 404) {
 			resp.State.RemoveResource(ctx)
 			return
@@ -209,7 +229,11 @@ func (r *memberNicknameResource) Delete(ctx context.Context, req resource.Delete
 }
 
 func (r *memberNicknameResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	serverID, userID, err := fwutil.ParseTwoIDs(req.ID)
+	id := memberNicknameIdentity.importID(ctx, req, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	serverID, userID, err := fwutil.ParseTwoIDs(id)
 	if err != nil {
 		resp.Diagnostics.AddError("Invalid import ID", err.Error())
 		return
@@ -11,6 +11,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
@@ -22,7 +23,8 @@ func NewMemberRolesResource() resource.Resource {
 }
 
 type memberRolesResource struct {
-	c *discord.RestClient
+	c               *discord.RestClient
+	defaultServerID string
 }
 
 type memberRoleItemModel struct {
@@ -38,14 +40,6 @@ type memberRolesModel struct {
 	Role     []memberRoleItemModel `tfsdk:"role"`
 }
 
-type restMemberRoles struct {
-	Roles []string `json:"roles"`
-}
-
-type restMemberForRoles struct {
-	Roles []string `json:"roles"`
-}
-
 func (r *memberRolesResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_member_roles"
 }
@@ -53,7 +47,7 @@ func (r *memberRolesResource) Metadata(_ context.Context, req resource.MetadataR
 func (r *memberRolesResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
-			"id": schema.StringAttribute{Computed: true},
+			"id": schema.StringAttribute{Computed: true, PlanModifiers: stringUseState},
 			"user_id": schema.StringAttribute{
 				Required: true,
 				PlanModifiers: []planmodifier.String{
@@ -64,8 +58,11 @@ func (r *memberRolesResource) Schema(_ context.Context, _ resource.SchemaRequest
 				},
 			},
 			"server_id": schema.StringAttribute{
-				Required: true,
+				Optional:    true,
+				Computed:    true,
+				Description: serverIDDescription,
 				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
 					stringplanmodifier.RequiresReplace(),
 				},
 				Validators: []validator.String{
@@ -84,6 +81,8 @@ func (r *memberRolesResource) Schema(_ context.Context, _ resource.SchemaRequest
 						},
 						"has_role": schema.BoolAttribute{
 							Optional:    true,
+							Computed:    true,
+							Default:     booldefault.StaticBool(true),
 							Description: "Whether the member should have this role. Defaults to true.",
 						},
 					},
@@ -93,6 +92,15 @@ func (r *memberRolesResource) Schema(_ context.Context, _ resource.SchemaRequest
 	}
 }
 
+var memberRolesIdentity = resourceIdentity{
+	{"server_id", path.Root("server_id")},
+	{"user_id", path.Root("user_id")},
+}
+
+func (r *memberRolesResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = memberRolesIdentity.schema()
+}
+
 func (r *memberRolesResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	c, diags := getContextFromProviderData(req.ProviderData)
 	resp.Diagnostics.Append(diags...)
@@ -100,6 +108,11 @@ func (r *memberRolesResource) Configure(ctx context.Context, req resource.Config
 		return
 	}
 	r.c = c.Rest
+	r.defaultServerID = c.Config.DefaultServerID
+}
+
+func (r *memberRolesResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	planServerID(ctx, r.defaultServerID, true, req, resp)
 }
 
 func memberHasRole(roles []string, roleID string) bool {
@@ -122,6 +135,10 @@ func removeRoleID(roles []string, roleID string) []string {
 }
 
 func (r *memberRolesResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := startResourceSpan(ctx, "discord_member_roles", "create")
+	defer endResourceSpan(span, &resp.Diagnostics)
+	defer memberRolesIdentity.set(ctx, &resp.State, resp.Identity, &resp.Diagnostics)
+
 	var plan memberRolesModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
@@ -132,8 +149,7 @@ func (r *memberRolesResource) Create(ctx context.Context, req resource.CreateReq
 	userID := plan.UserID.ValueString()
 
 	// Validate member exists.
-	var member restMemberForRoles
-	if err := r.c.DoJSON(ctx, "GET", "/guilds/"+serverID+"/members/"+userID, nil, nil, &member); err != nil {
+	if _, err := r.c.GetMember(ctx, serverID, userID); err != nil {
 		resp.Diagnostics.AddError("Discord API error", err.Error())
 		return
 	}
@@ -149,6 +165,10 @@ func (r *memberRolesResource) Create(ctx context.Context, req resource.CreateReq
 }
 
 func (r *memberRolesResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := startResourceSpan(ctx, "discord_member_roles", "read")
+	defer endResourceSpan(span, &resp.Diagnostics)
+	defer memberRolesIdentity.set(ctx, &resp.State, resp.Identity, &resp.Diagnostics)
+
 	var state memberRolesModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
@@ -170,8 +190,8 @@ func (r *memberRolesResource) readIntoState(ctx context.Context, state *memberRo
 	serverID := state.ServerID.ValueString()
 	userID := state.UserID.ValueString()
 
-	var member restMemberForRoles
-	if err := r.c.DoJSON(ctx, "GET", "/guilds/"+serverID+"/members/"+userID, nil, nil, &member); err != nil {
+	member, err := r.c.GetMember(ctx, serverID, userID)
+	if err != nil {
 		if discord.IsDiscordHTTPStatus(err, 404) {
 			state.ID = types.StringNull()
 			return
@@ -192,6 +212,10 @@ func (r *memberRolesResource) readIntoState(ctx context.Context, state *memberRo
 }
 
 func (r *memberRolesResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := startResourceSpan(ctx, "discord_member_roles", "update")
+	defer endResourceSpan(span, &resp.Diagnostics)
+	defer memberRolesIdentity.set(ctx, &resp.State, resp.Identity, &resp.Diagnostics)
+
 	var plan memberRolesModel
 	var state memberRolesModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
@@ -230,8 +254,8 @@ func (r *memberRolesResource) applyDesired(ctx context.Context, plan *memberRole
 	serverID := plan.ServerID.ValueString()
 	userID := plan.UserID.ValueString()
 
-	var member restMemberForRoles
-	if err := r.c.DoJSON(ctx, "GET", "/guilds/"+serverID+"/members/"+userID, nil, nil, &member); err != nil {
+	member, err := r.c.GetMember(ctx, serverID, userID)
+	if err != nil {
 		if discord.IsDiscordHTTPStatus(err, 404) {
 			diags.AddError("Member not found", fmt.Sprintf("member %s not found in server %s", userID, serverID))
 			return
@@ -269,13 +293,16 @@ func (r *memberRolesResource) applyDesired(ctx context.Context, plan *memberRole
 		}
 	}
 
-	if err := r.c.DoJSON(ctx, "PATCH", "/guilds/"+serverID+"/members/"+userID, nil, restMemberRoles{Roles: roles}, nil); err != nil {
+	if err := r.c.ModifyMember(ctx, serverID, userID, &discord.ModifyMemberParams{Roles: &roles}, ""); err != nil {
 		diags.AddError("Discord API error", err.Error())
 		return
 	}
 }
 
 func (r *memberRolesResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := startResourceSpan(ctx, "discord_member_roles", "delete")
+	defer endResourceSpan(span, &resp.Diagnostics)
+
 	var state memberRolesModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
@@ -285,8 +312,8 @@ func (r *memberRolesResource) Delete(ctx context.Context, req resource.DeleteReq
 	serverID := state.ServerID.ValueString()
 	userID := state.UserID.ValueString()
 
-	var member restMemberForRoles
-	if err := r.c.DoJSON(ctx, "GET", "/guilds/"+serverID+"/members/"+userID, nil, nil, &member); err != nil {
+	member, err := r.c.GetMember(ctx, serverID, userID)
+	if err != nil {
 		if discord.IsDiscordHTTPStatus(err, 404) {
 			resp.State.RemoveResource(ctx)
 			return
@@ -303,7 +330,7 @@ func (r *memberRolesResource) Delete(ctx context.Context, req resource.DeleteReq
 		}
 	}
 
-	if err := r.c.DoJSON(ctx, "PATCH", "/guilds/"+serverID+"/members/"+userID, nil, restMemberRoles{Roles: roles}, nil); err != nil {
+	if err := r.c.ModifyMember(ctx, serverID, userID, &discord.ModifyMemberParams{Roles: &roles}, ""); err != nil {
 		resp.Diagnostics.AddError("Discord API error", err.Error())
 		return
 	}
@@ -312,8 +339,12 @@ func (r *memberRolesResource) Delete(ctx context.Context, req resource.DeleteReq
 }
 
 func (r *memberRolesResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	id := memberRolesIdentity.importID(ctx, req, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	// Import format: server_id:user_id
-	serverID, userID, err := fwutil.ParseTwoIDs(req.ID)
+	serverID, userID, err := fwutil.ParseTwoIDs(id)
 	if err != nil {
 		resp.Diagnostics.AddError("Invalid import ID", "Expected server_id:user_id")
 		return
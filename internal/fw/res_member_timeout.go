@@ -6,7 +6,6 @@ import (
 
 	"github.com/45ck/terraform-provider-discord/discord"
 	"github.com/45ck/terraform-provider-discord/internal/fw/fwutil"
-	"github.com/45ck/terraform-provider-discord/internal/fw/planmod"
 	"github.com/45ck/terraform-provider-discord/internal/fw/validate"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -22,7 +21,8 @@ func NewMemberTimeoutResource() resource.Resource {
 }
 
 type memberTimeoutResource struct {
-	c *discord.RestClient
+	c               *discord.RestClient
+	defaultServerID string
 }
 
 type memberTimeoutModel struct {
@@ -40,11 +40,15 @@ func (r *memberTimeoutResource) Metadata(_ context.Context, req resource.Metadat
 
 func (r *memberTimeoutResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Version: 1,
 		Attributes: map[string]schema.Attribute{
-			"id": schema.StringAttribute{Computed: true},
+			"id": schema.StringAttribute{Computed: true, PlanModifiers: stringUseState},
 			"server_id": schema.StringAttribute{
-				Required: true,
+				Optional:    true,
+				Computed:    true,
+				Description: serverIDDescription,
 				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
 					stringplanmodifier.RequiresReplace(),
 				},
 				Validators: []validator.String{
@@ -67,17 +71,24 @@ func (r *memberTimeoutResource) Schema(_ context.Context, _ resource.SchemaReque
 					validate.RFC3339Timestamp(),
 				},
 			},
-			"reason": schema.StringAttribute{
-				Optional:  true,
-				Sensitive: true,
-				PlanModifiers: []planmodifier.String{
-					planmod.IgnoreChangesString(),
-				},
-			},
+			"reason": reasonAttribute(),
 		},
 	}
 }
 
+var memberTimeoutIdentity = resourceIdentity{
+	{"server_id", path.Root("server_id")},
+	{"user_id", path.Root("user_id")},
+}
+
+func (r *memberTimeoutResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = memberTimeoutIdentity.schema()
+}
+
+func (r *memberTimeoutResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return writeOnlyStateUpgraders("reason")
+}
+
 func (r *memberTimeoutResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	c, diags := getContextFromProviderData(req.ProviderData)
 	resp.Diagnostics.Append(diags...)
@@ -85,11 +96,21 @@ func (r *memberTimeoutResource) Configure(ctx context.Context, req resource.Conf
 		return
 	}
 	r.c = c.Rest
+	r.defaultServerID = c.Config.DefaultServerID
+}
+
+func (r *memberTimeoutResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	planServerID(ctx, r.defaultServerID, true, req, resp)
 }
 
 func (r *memberTimeoutResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := startResourceSpan(ctx, "discord_member_timeout", "create")
+	defer endResourceSpan(span, &resp.Diagnostics)
+	defer memberTimeoutIdentity.set(ctx, &resp.State, resp.Identity, &resp.Diagnostics)
+
 	var plan memberTimeoutModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("reason"), &plan.Reason)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -101,8 +122,13 @@ func (r *memberTimeoutResource) Create(ctx context.Context, req resource.CreateR
 }
 
 func (r *memberTimeoutResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := startResourceSpan(ctx, "discord_member_timeout", "update")
+	defer endResourceSpan(span, &resp.Diagnostics)
+	defer memberTimeoutIdentity.set(ctx, &resp.State, resp.Identity, &resp.Diagnostics)
+
 	var plan memberTimeoutModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("reason"), &plan.Reason)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -117,19 +143,11 @@ func (r *memberTimeoutResource) upsert(ctx context.Context, plan *memberTimeoutM
 	serverID := plan.ServerID.ValueString()
 	userID := plan.UserID.ValueString()
 
-	until := plan.Until.ValueString()
-	var val any
-	if until == "" {
-		val = nil
-	} else {
-		val = until
-	}
-
-	body := map[string]any{
-		"communication_disabled_until": val,
+	params := &discord.ModifyMemberParams{
+		CommunicationDisabledUntil: discord.NullIfEmpty(plan.Until.ValueString()),
 	}
 
-	if err := r.c.DoJSONWithReason(ctx, "PATCH", fmt.Sprintf("/guilds/%s/members/%s", serverID, userID), nil, body, nil, plan.Reason.ValueString()); err != nil {
+	if err := r.c.ModifyMember(ctx, serverID, userID, params, plan.Reason.ValueString()); err != nil {
 		diags.AddError("Discord API error", err.Error())
 		return
 	}
@@ -139,6 +157,10 @@ func (r *memberTimeoutResource) upsert(ctx context.Context, plan *memberTimeoutM
 }
 
 func (r *memberTimeoutResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := startResourceSpan(ctx, "discord_member_timeout", "read")
+	defer endResourceSpan(span, &resp.Diagnostics)
+	defer memberTimeoutIdentity.set(ctx, &resp.State, resp.Identity, &resp.Diagnostics)
+
 	var state memberTimeoutModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
@@ -168,8 +190,8 @@ func (r *memberTimeoutResource) readIntoState(ctx context.Context, state *member
 		serverID, userID = sid, uid
 	}
 
-	var out restGuildMember
-	if err := r.c.DoJSON(ctx, "GET", fmt.Sprintf("/guilds/%s/members/%s", serverID, userID), nil, nil, &out); err != nil {
+	out, err := r.c.GetMember(ctx, serverID, userID)
+	if err != nil {
 		if discord.IsDiscordHTTPStatus(err, 404) {
 			state.ID = types.StringNull()
 			return
@@ -185,6 +207,9 @@ func (r *memberTimeoutResource) readIntoState(ctx context.Context, state *member
 }
 
 func (r *memberTimeoutResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := startResourceSpan(ctx, "discord_member_timeout", "delete")
+	defer endResourceSpan(span, &resp.Diagnostics)
+
 	var state memberTimeoutModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
@@ -194,10 +219,8 @@ func (r *memberTimeoutResource) Delete(ctx context.Context, req resource.DeleteR
 	serverID := state.ServerID.ValueString()
 	userID := state.UserID.ValueString()
 
-	body := map[string]any{
-		"communication_disabled_until": nil,
-	}
-	if err := r.c.DoJSONWithReason(ctx, "PATCH", fmt.Sprintf("/guilds/%s/members/%s", serverID, userID), nil, body, nil, state.Reason.ValueString()); err != nil {
+	params := &discord.ModifyMemberParams{CommunicationDisabledUntil: discord.Null[string]()}
+	if err := r.c.ModifyMember(ctx, serverID, userID, params, ""); err != nil {
 		if discord.IsDiscordHTTPStatus(err, 404) {
 			resp.State.RemoveResource(ctx)
 			return
@@ -209,7 +232,11 @@ func (r *memberTimeoutResource) Delete(ctx context.Context, req resource.DeleteR
 }
 
 func (r *memberTimeoutResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	serverID, userID, err := fwutil.ParseTwoIDs(req.ID)
+	id := memberTimeoutIdentity.importID(ctx, req, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	serverID, userID, err := fwutil.ParseTwoIDs(id)
 	if err != nil {
 		resp.Diagnostics.AddError("Invalid import ID", err.Error())
 		return
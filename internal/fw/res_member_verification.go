@@ -3,7 +3,6 @@ package fw
 import (
 	"context"
 	"encoding/json"
-	"fmt"
 
 	"github.com/45ck/terraform-provider-discord/discord"
 	"github.com/45ck/terraform-provider-discord/internal/fw/planmod"
@@ -24,7 +23,8 @@ func NewMemberVerificationResource() resource.Resource {
 }
 
 type memberVerificationResource struct {
-	c *discord.RestClient
+	c               *discord.RestClient
+	defaultServerID string
 }
 
 type memberVerificationModel struct {
@@ -42,11 +42,15 @@ func (r *memberVerificationResource) Metadata(_ context.Context, req resource.Me
 
 func (r *memberVerificationResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Version: 1,
 		Attributes: map[string]schema.Attribute{
-			"id": schema.StringAttribute{Computed: true},
+			"id": schema.StringAttribute{Computed: true, PlanModifiers: stringUseState},
 			"server_id": schema.StringAttribute{
-				Required: true,
+				Optional:    true,
+				Computed:    true,
+				Description: serverIDDescription,
 				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
 					stringplanmodifier.RequiresReplace(),
 				},
 				Validators: []validator.String{
@@ -65,18 +69,23 @@ func (r *memberVerificationResource) Schema(_ context.Context, _ resource.Schema
 				Computed:    true,
 				Description: "Normalized JSON returned by Discord.",
 			},
-			"reason": schema.StringAttribute{
-				Optional:  true,
-				Sensitive: true,
-				PlanModifiers: []planmodifier.String{
-					planmod.IgnoreChangesString(),
-				},
-				Description: "Optional audit log reason (X-Audit-Log-Reason). This value is not readable.",
-			},
+			"reason": reasonAttribute(),
 		},
 	}
 }
 
+var memberVerificationIdentity = resourceIdentity{
+	{"server_id", path.Root("server_id")},
+}
+
+func (r *memberVerificationResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = memberVerificationIdentity.schema()
+}
+
+func (r *memberVerificationResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return writeOnlyStateUpgraders("reason")
+}
+
 func (r *memberVerificationResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	c, diags := getContextFromProviderData(req.ProviderData)
 	resp.Diagnostics.Append(diags...)
@@ -84,24 +93,34 @@ func (r *memberVerificationResource) Configure(ctx context.Context, req resource
 		return
 	}
 	r.c = c.Rest
+	r.defaultServerID = c.Config.DefaultServerID
+}
+
+func (r *memberVerificationResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	planServerID(ctx, r.defaultServerID, true, req, resp)
 }
 
 func (r *memberVerificationResource) upsert(ctx context.Context, plan *memberVerificationModel, diags discordFrameworkDiagnostics) {
-	var payload any
-	if err := json.Unmarshal([]byte(plan.PayloadJSON.ValueString()), &payload); err != nil {
-		diags.AddError("Invalid JSON", err.Error())
+	payload := json.RawMessage(plan.PayloadJSON.ValueString())
+	if !json.Valid(payload) {
+		diags.AddError("Invalid JSON", "payload_json is not valid JSON")
 		return
 	}
 
-	if err := r.c.DoJSONWithReason(ctx, "PUT", fmt.Sprintf("/guilds/%s/member-verification", plan.ServerID.ValueString()), nil, payload, nil, plan.Reason.ValueString()); err != nil {
+	if _, err := r.c.ModifyGuildMemberVerification(ctx, plan.ServerID.ValueString(), payload, plan.Reason.ValueString()); err != nil {
 		diags.AddError("Discord API error", err.Error())
 		return
 	}
 }
 
 func (r *memberVerificationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := startResourceSpan(ctx, "discord_member_verification", "create")
+	defer endResourceSpan(span, &resp.Diagnostics)
+	defer memberVerificationIdentity.set(ctx, &resp.State, resp.Identity, &resp.Diagnostics)
+
 	var plan memberVerificationModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("reason"), &plan.Reason)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -117,6 +136,10 @@ func (r *memberVerificationResource) Create(ctx context.Context, req resource.Cr
 }
 
 func (r *memberVerificationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := startResourceSpan(ctx, "discord_member_verification", "read")
+	defer endResourceSpan(span, &resp.Diagnostics)
+	defer memberVerificationIdentity.set(ctx, &resp.State, resp.Identity, &resp.Diagnostics)
+
 	var state memberVerificationModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
@@ -144,8 +167,8 @@ func (r *memberVerificationResource) readIntoState(ctx context.Context, state *m
 		return
 	}
 
-	var out any
-	if err := r.c.DoJSON(ctx, "GET", fmt.Sprintf("/guilds/%s/member-verification", serverID), nil, nil, &out); err != nil {
+	out, err := r.c.GetGuildMemberVerification(ctx, serverID)
+	if err != nil {
 		if discord.IsDiscordHTTPStatus(err, 404) {
 			state.ID = types.StringNull()
 			return
@@ -154,12 +177,7 @@ func (r *memberVerificationResource) readIntoState(ctx context.Context, state *m
 		return
 	}
 
-	b, err := json.Marshal(out)
-	if err != nil {
-		diags.AddError("JSON error", err.Error())
-		return
-	}
-	norm, err := discord.NormalizeJSON(string(b))
+	norm, err := discord.NormalizeJSON(string(out))
 	if err != nil {
 		diags.AddError("JSON error", err.Error())
 		return
@@ -171,8 +189,13 @@ func (r *memberVerificationResource) readIntoState(ctx context.Context, state *m
 }
 
 func (r *memberVerificationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := startResourceSpan(ctx, "discord_member_verification", "update")
+	defer endResourceSpan(span, &resp.Diagnostics)
+	defer memberVerificationIdentity.set(ctx, &resp.State, resp.Identity, &resp.Diagnostics)
+
 	var plan memberVerificationModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("reason"), &plan.Reason)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -188,14 +211,16 @@ func (r *memberVerificationResource) Update(ctx context.Context, req resource.Up
 }
 
 func (r *memberVerificationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := startResourceSpan(ctx, "discord_member_verification", "delete")
+	defer endResourceSpan(span, &resp.Diagnostics)
+
 	var state memberVerificationModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	body := map[string]any{"enabled": false}
-	if err := r.c.DoJSONWithReason(ctx, "PUT", fmt.Sprintf("/guilds/%s/member-verification", state.ServerID.ValueString()), nil, body, nil, state.Reason.ValueString()); err != nil {
+	if _, err := r.c.ModifyGuildMemberVerification(ctx, state.ServerID.ValueString(), json.RawMessage(`{"enabled":false}`), ""); err != nil {
 		if discord.IsDiscordHTTPStatus(err, 404) {
 			resp.State.RemoveResource(ctx)
 			return
@@ -207,6 +232,10 @@ func (r *memberVerificationResource) Delete(ctx context.Context, req resource.De
 }
 
 func (r *memberVerificationResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("server_id"), req, resp)
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	id := memberVerificationIdentity.importID(ctx, req, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("server_id"), id)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
 }
@@ -3,15 +3,16 @@ package fw
 import (
 	"context"
 	"fmt"
-	"net/url"
 	"strings"
 
 	"github.com/45ck/terraform-provider-discord/discord"
+	"github.com/45ck/terraform-provider-discord/internal/fw/fwutil"
 	"github.com/45ck/terraform-provider-discord/internal/fw/planmod"
 	"github.com/45ck/terraform-provider-discord/internal/fw/validate"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
@@ -104,98 +105,6 @@ type messageModel struct {
 	Type types.Int64 `tfsdk:"type"`
 }
 
-type restMessageAuthor struct {
-	ID string `json:"id"`
-}
-
-type restEmbed struct {
-	Title       string `json:"title,omitempty"`
-	Description string `json:"description,omitempty"`
-	URL         string `json:"url,omitempty"`
-	Timestamp   string `json:"timestamp,omitempty"`
-	Color       int    `json:"color,omitempty"`
-
-	Footer    *restEmbedFooter    `json:"footer,omitempty"`
-	Image     *restEmbedImage     `json:"image,omitempty"`
-	Thumbnail *restEmbedThumbnail `json:"thumbnail,omitempty"`
-	Video     *restEmbedVideo     `json:"video,omitempty"`
-	Provider  *restEmbedProvider  `json:"provider,omitempty"`
-	Author    *restEmbedAuthor    `json:"author,omitempty"`
-	Fields    []restEmbedField    `json:"fields,omitempty"`
-}
-
-type restEmbedFooter struct {
-	Text    string `json:"text,omitempty"`
-	IconURL string `json:"icon_url,omitempty"`
-}
-
-type restEmbedImage struct {
-	URL      string `json:"url,omitempty"`
-	ProxyURL string `json:"proxy_url,omitempty"`
-	Height   int    `json:"height,omitempty"`
-	Width    int    `json:"width,omitempty"`
-}
-
-type restEmbedThumbnail struct {
-	URL      string `json:"url,omitempty"`
-	ProxyURL string `json:"proxy_url,omitempty"`
-	Height   int    `json:"height,omitempty"`
-	Width    int    `json:"width,omitempty"`
-}
-
-type restEmbedVideo struct {
-	URL    string `json:"url,omitempty"`
-	Height int    `json:"height,omitempty"`
-	Width  int    `json:"width,omitempty"`
-}
-
-type restEmbedProvider struct {
-	Name string `json:"name,omitempty"`
-	URL  string `json:"url,omitempty"`
-}
-
-type restEmbedAuthor struct {
-	Name         string `json:"name,omitempty"`
-	URL          string `json:"url,omitempty"`
-	IconURL      string `json:"icon_url,omitempty"`
-	ProxyIconURL string `json:"proxy_icon_url,omitempty"`
-}
-
-type restEmbedField struct {
-	Name   string `json:"name,omitempty"`
-	Value  string `json:"value,omitempty"`
-	Inline bool   `json:"inline,omitempty"`
-}
-
-type restMessage struct {
-	ID              string            `json:"id"`
-	ChannelID       string            `json:"channel_id"`
-	Content         string            `json:"content"`
-	Tts             bool              `json:"tts"`
-	Pinned          bool              `json:"pinned"`
-	Type            int               `json:"type"`
-	Timestamp       string            `json:"timestamp"`
-	EditedTimestamp string            `json:"edited_timestamp"`
-	Author          restMessageAuthor `json:"author"`
-	Embeds          []restEmbed       `json:"embeds"`
-}
-
-type restChannelGuild struct {
-	ID      string `json:"id"`
-	GuildID string `json:"guild_id"`
-}
-
-type restMessageCreate struct {
-	Content string      `json:"content,omitempty"`
-	Tts     bool        `json:"tts,omitempty"`
-	Embeds  []restEmbed `json:"embeds,omitempty"`
-}
-
-type restMessageEdit struct {
-	Content *string     `json:"content,omitempty"`
-	Embeds  []restEmbed `json:"embeds,omitempty"`
-}
-
 func (r *messageResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_message"
 }
@@ -203,7 +112,7 @@ func (r *messageResource) Metadata(_ context.Context, req resource.MetadataReque
 func (r *messageResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
-			"id": schema.StringAttribute{Computed: true},
+			"id": schema.StringAttribute{Computed: true, PlanModifiers: stringUseState},
 
 			"channel_id": schema.StringAttribute{
 				Required: true,
@@ -228,6 +137,8 @@ func (r *messageResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 
 			"tts": schema.BoolAttribute{
 				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
 			},
 			"embed": schema.SingleNestedAttribute{
 				Optional: true,
@@ -254,8 +165,8 @@ func (r *messageResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 						Attributes: map[string]schema.Attribute{
 							"url":       schema.StringAttribute{Required: true},
 							"proxy_url": schema.StringAttribute{Computed: true},
-							"height":    schema.Int64Attribute{Optional: true},
-							"width":     schema.Int64Attribute{Optional: true},
+							"height":    schema.Int64Attribute{Optional: true, Computed: true},
+							"width":     schema.Int64Attribute{Optional: true, Computed: true},
 						},
 					},
 					"thumbnail": schema.SingleNestedAttribute{
@@ -263,16 +174,16 @@ func (r *messageResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 						Attributes: map[string]schema.Attribute{
 							"url":       schema.StringAttribute{Required: true},
 							"proxy_url": schema.StringAttribute{Computed: true},
-							"height":    schema.Int64Attribute{Optional: true},
-							"width":     schema.Int64Attribute{Optional: true},
+							"height":    schema.Int64Attribute{Optional: true, Computed: true},
+							"width":     schema.Int64Attribute{Optional: true, Computed: true},
 						},
 					},
 					"video": schema.SingleNestedAttribute{
 						Optional: true,
 						Attributes: map[string]schema.Attribute{
 							"url":    schema.StringAttribute{Required: true},
-							"height": schema.Int64Attribute{Optional: true},
-							"width":  schema.Int64Attribute{Optional: true},
+							"height": schema.Int64Attribute{Optional: true, Computed: true},
+							"width":  schema.Int64Attribute{Optional: true, Computed: true},
 						},
 					},
 					"provider": schema.SingleNestedAttribute{
@@ -305,12 +216,23 @@ func (r *messageResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 			},
 			"pinned": schema.BoolAttribute{
 				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
 			},
 			"type": schema.Int64Attribute{Computed: true},
 		},
 	}
 }
 
+var messageIdentity = resourceIdentity{
+	{"channel_id", path.Root("channel_id")},
+	{"message_id", path.Root("id")},
+}
+
+func (r *messageResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = messageIdentity.schema()
+}
+
 func (r *messageResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	c, diags := getContextFromProviderData(req.ProviderData)
 	resp.Diagnostics.Append(diags...)
@@ -320,11 +242,11 @@ func (r *messageResource) Configure(ctx context.Context, req resource.ConfigureR
 	r.c = c.Rest
 }
 
-func embedToRest(m *messageEmbedModel) restEmbed {
+func expandEmbed(m *messageEmbedModel) discord.Embed {
 	if m == nil {
-		return restEmbed{}
+		return discord.Embed{}
 	}
-	e := restEmbed{
+	e := discord.Embed{
 		Title:       m.Title.ValueString(),
 		Description: m.Description.ValueString(),
 		URL:         m.URL.ValueString(),
@@ -332,49 +254,49 @@ func embedToRest(m *messageEmbedModel) restEmbed {
 		Color:       int(m.Color.ValueInt64()),
 	}
 	if m.Footer != nil {
-		e.Footer = &restEmbedFooter{
+		e.Footer = &discord.EmbedFooter{
 			Text:    m.Footer.Text.ValueString(),
 			IconURL: m.Footer.IconURL.ValueString(),
 		}
 	}
 	if m.Image != nil {
-		e.Image = &restEmbedImage{
+		e.Image = &discord.EmbedImage{
 			URL:    m.Image.URL.ValueString(),
 			Height: int(m.Image.Height.ValueInt64()),
 			Width:  int(m.Image.Width.ValueInt64()),
 		}
 	}
 	if m.Thumbnail != nil {
-		e.Thumbnail = &restEmbedThumbnail{
+		e.Thumbnail = &discord.EmbedThumbnail{
 			URL:    m.Thumbnail.URL.ValueString(),
 			Height: int(m.Thumbnail.Height.ValueInt64()),
 			Width:  int(m.Thumbnail.Width.ValueInt64()),
 		}
 	}
 	if m.Video != nil {
-		e.Video = &restEmbedVideo{
+		e.Video = &discord.EmbedVideo{
 			URL:    m.Video.URL.ValueString(),
 			Height: int(m.Video.Height.ValueInt64()),
 			Width:  int(m.Video.Width.ValueInt64()),
 		}
 	}
 	if m.Provider != nil {
-		e.Provider = &restEmbedProvider{
+		e.Provider = &discord.EmbedProvider{
 			Name: m.Provider.Name.ValueString(),
 			URL:  m.Provider.URL.ValueString(),
 		}
 	}
 	if m.Author != nil {
-		e.Author = &restEmbedAuthor{
+		e.Author = &discord.EmbedAuthor{
 			Name:    m.Author.Name.ValueString(),
 			URL:     m.Author.URL.ValueString(),
 			IconURL: m.Author.IconURL.ValueString(),
 		}
 	}
 	if m.Fields != nil {
-		fields := make([]restEmbedField, 0, len(m.Fields))
+		fields := make([]discord.EmbedField, 0, len(m.Fields))
 		for _, f := range m.Fields {
-			fields = append(fields, restEmbedField{
+			fields = append(fields, discord.EmbedField{
 				Name:   f.Name.ValueString(),
 				Value:  f.Value.ValueString(),
 				Inline: !f.Inline.IsNull() && f.Inline.ValueBool(),
@@ -385,21 +307,30 @@ func embedToRest(m *messageEmbedModel) restEmbed {
 	return e
 }
 
-func restToEmbed(in *restEmbed) *messageEmbedModel {
+// flattenEmbed converts an embed as Discord returns it. Fields that are unset in
+// prior stay unset when Discord echoes their zero value.
+func flattenEmbed(in *discord.Embed, prior *messageEmbedModel) *messageEmbedModel {
 	if in == nil {
 		return nil
 	}
+	if prior == nil {
+		prior = &messageEmbedModel{}
+	}
 	out := &messageEmbedModel{
-		Title:       types.StringValue(in.Title),
-		Description: types.StringValue(in.Description),
-		URL:         types.StringValue(in.URL),
-		Timestamp:   types.StringValue(in.Timestamp),
-		Color:       types.Int64Value(int64(in.Color)),
+		Title:       fwutil.KeepNullString(prior.Title, in.Title),
+		Description: fwutil.KeepNullString(prior.Description, in.Description),
+		URL:         fwutil.KeepNullString(prior.URL, in.URL),
+		Timestamp:   fwutil.KeepNullString(prior.Timestamp, in.Timestamp),
+		Color:       fwutil.KeepNullInt64(prior.Color, int64(in.Color)),
 	}
 	if in.Footer != nil {
+		pf := prior.Footer
+		if pf == nil {
+			pf = &messageEmbedFooterModel{}
+		}
 		out.Footer = &messageEmbedFooterModel{
 			Text:    types.StringValue(in.Footer.Text),
-			IconURL: types.StringValue(in.Footer.IconURL),
+			IconURL: fwutil.KeepNullString(pf.IconURL, in.Footer.IconURL),
 		}
 	}
 	if in.Image != nil {
@@ -426,26 +357,38 @@ func restToEmbed(in *restEmbed) *messageEmbedModel {
 		}
 	}
 	if in.Provider != nil {
+		pp := prior.Provider
+		if pp == nil {
+			pp = &messageEmbedProviderModel{}
+		}
 		out.Provider = &messageEmbedProviderModel{
-			Name: types.StringValue(in.Provider.Name),
-			URL:  types.StringValue(in.Provider.URL),
+			Name: fwutil.KeepNullString(pp.Name, in.Provider.Name),
+			URL:  fwutil.KeepNullString(pp.URL, in.Provider.URL),
 		}
 	}
 	if in.Author != nil {
+		pa := prior.Author
+		if pa == nil {
+			pa = &messageEmbedAuthorModel{}
+		}
 		out.Author = &messageEmbedAuthorModel{
-			Name:         types.StringValue(in.Author.Name),
-			URL:          types.StringValue(in.Author.URL),
-			IconURL:      types.StringValue(in.Author.IconURL),
+			Name:         fwutil.KeepNullString(pa.Name, in.Author.Name),
+			URL:          fwutil.KeepNullString(pa.URL, in.Author.URL),
+			IconURL:      fwutil.KeepNullString(pa.IconURL, in.Author.IconURL),
 			ProxyIconURL: types.StringValue(in.Author.ProxyIconURL),
 		}
 	}
 	if in.Fields != nil {
 		fields := make([]messageEmbedFieldModel, 0, len(in.Fields))
-		for _, f := range in.Fields {
+		for i, f := range in.Fields {
+			var pf messageEmbedFieldModel
+			if i < len(prior.Fields) {
+				pf = prior.Fields[i]
+			}
 			fields = append(fields, messageEmbedFieldModel{
 				Name:   types.StringValue(f.Name),
-				Value:  types.StringValue(f.Value),
-				Inline: types.BoolValue(f.Inline),
+				Value:  fwutil.KeepNullString(pf.Value, f.Value),
+				Inline: fwutil.KeepNullBool(pf.Inline, f.Inline),
 			})
 		}
 		out.Fields = fields
@@ -454,6 +397,10 @@ func restToEmbed(in *restEmbed) *messageEmbedModel {
 }
 
 func (r *messageResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := startResourceSpan(ctx, "discord_message", "create")
+	defer endResourceSpan(span, &resp.Diagnostics)
+	defer messageIdentity.set(ctx, &resp.State, resp.Identity, &resp.Diagnostics)
+
 	var plan messageModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
@@ -470,71 +417,88 @@ func (r *messageResource) Create(ctx context.Context, req resource.CreateRequest
 		return
 	}
 
-	body := restMessageCreate{
+	params := &discord.CreateMessageParams{
 		Content: content,
-		Tts:     !plan.TTS.IsNull() && plan.TTS.ValueBool(),
+		TTS:     plan.TTS.ValueBool(),
+		// Lets the REST client retry the create without posting the message twice.
+		Nonce:        discord.NewNonce(),
+		EnforceNonce: true,
 	}
 	if plan.Embed != nil {
-		body.Embeds = []restEmbed{embedToRest(plan.Embed)}
+		params.Embeds = []discord.Embed{expandEmbed(plan.Embed)}
 	}
 
-	var msg restMessage
-	if err := r.c.DoJSON(ctx, "POST", "/channels/"+channelID+"/messages", nil, body, &msg); err != nil {
+	msg, err := r.c.CreateMessage(ctx, channelID, params)
+	if err != nil {
 		resp.Diagnostics.AddError("Discord API error", err.Error())
 		return
 	}
 
 	plan.ID = types.StringValue(msg.ID)
-	plan.Type = types.Int64Value(int64(msg.Type))
-	plan.Timestamp = types.StringValue(msg.Timestamp)
-	plan.Author = types.StringValue(msg.Author.ID)
-	if len(msg.Embeds) > 0 {
-		plan.Embed = restToEmbed(&msg.Embeds[0])
-	} else {
-		plan.Embed = nil
-	}
-
-	r.setMessageServerID(ctx, &plan, channelID)
 
-	if !plan.Pinned.IsNull() && plan.Pinned.ValueBool() {
-		if err := r.c.DoJSON(ctx, "PUT", fmt.Sprintf("/channels/%s/pins/%s", channelID, msg.ID), url.Values{}, nil, nil); err != nil {
+	if plan.Pinned.ValueBool() {
+		if err := r.c.PinMessage(ctx, channelID, msg.ID, ""); err != nil {
 			resp.Diagnostics.AddError("Discord API error", err.Error())
 			return
 		}
 	}
 
+	readAfterCreate(ctx, &resp.Diagnostics, "Message", msg.ID, func(ctx context.Context) bool {
+		plan.ID = types.StringValue(msg.ID)
+		r.readIntoState(ctx, &plan, &resp.Diagnostics)
+		return !plan.ID.IsNull()
+	})
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 }
 
 func (r *messageResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := startResourceSpan(ctx, "discord_message", "read")
+	defer endResourceSpan(span, &resp.Diagnostics)
+	defer messageIdentity.set(ctx, &resp.State, resp.Identity, &resp.Diagnostics)
+
 	var state messageModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	r.readIntoState(ctx, &state, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if state.ID.IsNull() {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *messageResource) readIntoState(ctx context.Context, state *messageModel, diags discordFrameworkDiagnostics) {
 	channelID := state.ChannelID.ValueString()
 	messageID := state.ID.ValueString()
 
-	var msg restMessage
-	if err := r.c.DoJSON(ctx, "GET", fmt.Sprintf("/channels/%s/messages/%s", channelID, messageID), nil, nil, &msg); err != nil {
+	msg, err := r.c.GetMessage(ctx, channelID, messageID)
+	if err != nil {
 		if discord.IsDiscordHTTPStatus(err, 404) {
-			resp.State.RemoveResource(ctx)
+			state.ID = types.StringNull()
 			return
 		}
-		resp.Diagnostics.AddError("Discord API error", err.Error())
+		diags.AddError("Discord API error", err.Error())
 		return
 	}
 
 	state.Type = types.Int64Value(int64(msg.Type))
-	state.TTS = types.BoolValue(msg.Tts)
+	state.TTS = types.BoolValue(msg.TTS)
 	state.Timestamp = types.StringValue(msg.Timestamp)
 	state.Author = types.StringValue(msg.Author.ID)
-	state.Content = types.StringValue(msg.Content)
+	state.Content = fwutil.KeepNullString(state.Content, msg.Content)
 	state.Pinned = types.BoolValue(msg.Pinned)
 
 	if len(msg.Embeds) > 0 {
-		state.Embed = restToEmbed(&msg.Embeds[0])
+		state.Embed = flattenEmbed(&msg.Embeds[0], state.Embed)
 	} else {
 		state.Embed = nil
 	}
@@ -545,12 +509,15 @@ func (r *messageResource) Read(ctx context.Context, req resource.ReadRequest, re
 		state.EditedTimestamp = types.StringValue(msg.EditedTimestamp)
 	}
 
-	r.setMessageServerID(ctx, &state, channelID)
-
-	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	state.ServerID = types.StringNull()
+	r.setMessageServerID(ctx, state, channelID)
 }
 
 func (r *messageResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := startResourceSpan(ctx, "discord_message", "update")
+	defer endResourceSpan(span, &resp.Diagnostics)
+	defer messageIdentity.set(ctx, &resp.State, resp.Identity, &resp.Diagnostics)
+
 	var plan messageModel
 	var state messageModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
@@ -562,7 +529,7 @@ func (r *messageResource) Update(ctx context.Context, req resource.UpdateRequest
 	channelID := state.ChannelID.ValueString()
 	messageID := state.ID.ValueString()
 
-	edit := restMessageEdit{}
+	edit := &discord.EditMessageParams{}
 	anyEdit := false
 
 	if !plan.Content.Equal(state.Content) {
@@ -577,39 +544,26 @@ func (r *messageResource) Update(ctx context.Context, req resource.UpdateRequest
 		// Conservative: if embed presence changed, or title changed, send embed set/clear.
 		// This avoids implementing deep equality across embed fields.
 		if plan.Embed != nil {
-			edit.Embeds = []restEmbed{embedToRest(plan.Embed)}
-		} else {
-			edit.Embeds = []restEmbed{}
+			edit.Embeds = &[]discord.Embed{expandEmbed(plan.Embed)}
 		}
 		anyEdit = true
 	}
 
 	if anyEdit {
-		var msg restMessage
-		if err := r.c.DoJSON(ctx, "PATCH", fmt.Sprintf("/channels/%s/messages/%s", channelID, messageID), nil, edit, &msg); err != nil {
+		if _, err := r.c.EditMessage(ctx, channelID, messageID, edit); err != nil {
 			resp.Diagnostics.AddError("Discord API error", err.Error())
 			return
 		}
-		if len(msg.Embeds) > 0 {
-			plan.Embed = restToEmbed(&msg.Embeds[0])
-		} else {
-			plan.Embed = nil
-		}
-		if msg.EditedTimestamp == "" {
-			plan.EditedTimestamp = types.StringNull()
-		} else {
-			plan.EditedTimestamp = types.StringValue(msg.EditedTimestamp)
-		}
 	}
 
 	if !plan.Pinned.Equal(state.Pinned) {
-		if !plan.Pinned.IsNull() && plan.Pinned.ValueBool() {
-			if err := r.c.DoJSON(ctx, "PUT", fmt.Sprintf("/channels/%s/pins/%s", channelID, messageID), nil, nil, nil); err != nil {
+		if plan.Pinned.ValueBool() {
+			if err := r.c.PinMessage(ctx, channelID, messageID, ""); err != nil {
 				resp.Diagnostics.AddError("Discord API error", err.Error())
 				return
 			}
 		} else {
-			if err := r.c.DoJSON(ctx, "DELETE", fmt.Sprintf("/channels/%s/pins/%s", channelID, messageID), nil, nil, nil); err != nil {
+			if err := r.c.UnpinMessage(ctx, channelID, messageID, ""); err != nil {
 				resp.Diagnostics.AddError("Discord API error", err.Error())
 				return
 			}
@@ -618,11 +572,17 @@ func (r *messageResource) Update(ctx context.Context, req resource.UpdateRequest
 
 	plan.ID = state.ID
 	plan.ChannelID = state.ChannelID
-	r.setMessageServerID(ctx, &plan, channelID)
+	r.readIntoState(ctx, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 }
 
 func (r *messageResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := startResourceSpan(ctx, "discord_message", "delete")
+	defer endResourceSpan(span, &resp.Diagnostics)
+
 	var state messageModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
@@ -632,7 +592,7 @@ func (r *messageResource) Delete(ctx context.Context, req resource.DeleteRequest
 	channelID := state.ChannelID.ValueString()
 	messageID := state.ID.ValueString()
 
-	if err := r.c.DoJSON(ctx, "DELETE", fmt.Sprintf("/channels/%s/messages/%s", channelID, messageID), nil, nil, nil); err != nil {
+	if err := r.c.DeleteMessage(ctx, channelID, messageID, ""); err != nil {
 		if discord.IsDiscordHTTPStatus(err, 404) {
 			resp.State.RemoveResource(ctx)
 			return
@@ -645,9 +605,13 @@ func (r *messageResource) Delete(ctx context.Context, req resource.DeleteRequest
 }
 
 func (r *messageResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	id := messageIdentity.importID(ctx, req, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	// Import format: message_id, but channel_id is required for API operations, so require composite.
 	// Accept channel_id:message_id.
-	ch, mid, err := parseTwoIDs(req.ID)
+	ch, mid, err := parseTwoIDs(id)
 	if err != nil {
 		resp.Diagnostics.AddError("Invalid import ID", "Expected channel_id:message_id")
 		return
@@ -665,8 +629,8 @@ func parseTwoIDs(id string) (string, string, error) {
 }
 
 func (r *messageResource) setMessageServerID(ctx context.Context, state *messageModel, channelID string) {
-	var ch restChannelGuild
-	if err := r.c.DoJSON(ctx, "GET", "/channels/"+channelID, nil, nil, &ch); err != nil {
+	ch, err := r.c.GetChannel(ctx, channelID)
+	if err != nil {
 		return
 	}
 	if ch.GuildID != "" {
@@ -0,0 +1,57 @@
+package fw
+
+import (
+	"context"
+	"testing"
+
+	"github.com/45ck/terraform-provider-discord/discord"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestMessageResource_FakeFaults(t *testing.T) {
+	e := newFakeEnv(t)
+	// The 502 is retried: message creates carry an enforced nonce, so the retry
+	// returns the message the first attempt posted.
+	e.maxRetries = 1
+	e.faults(t, mishaps("/channels/*/messages", "/channels/*/messages/*")...)
+	listMessages := func(ctx context.Context, c *discord.RestClient) ([]string, error) {
+		channels, err := c.ListGuildChannels(ctx, e.guildID)
+		if err != nil {
+			return nil, err
+		}
+		var ids []string
+		for _, ch := range channels {
+			var msgs []discord.Message
+			if err := c.DoJSON(ctx, "GET", "/channels/"+ch.ID+"/messages", nil, nil, &msgs); err != nil {
+				return nil, err
+			}
+			for _, m := range msgs {
+				ids = append(ids, m.ID)
+			}
+		}
+		return ids, nil
+	}
+
+	e.test(t, resource.TestCase{
+		Steps: []resource.TestStep{
+			{
+				Config: e.config(`
+resource "discord_channel" "text" {
+  server_id = %q
+  type      = "text"
+  name      = "announcements"
+}
+
+resource "discord_message" "hello" {
+  channel_id = discord_channel.text.id
+  content    = "hello"
+}
+`, e.guildID),
+				Check: resource.ComposeTestCheckFunc(
+					e.checkSingle("discord_message.hello", listMessages),
+					resource.TestCheckResourceAttr("discord_message.hello", "content", "hello"),
+				),
+			},
+		},
+	})
+}
@@ -3,7 +3,6 @@ package fw
 import (
 	"context"
 	"encoding/json"
-	"fmt"
 
 	"github.com/45ck/terraform-provider-discord/discord"
 	"github.com/45ck/terraform-provider-discord/internal/fw/planmod"
@@ -23,7 +22,8 @@ func NewOnboardingResource() resource.Resource {
 }
 
 type onboardingResource struct {
-	c *discord.RestClient
+	c               *discord.RestClient
+	defaultServerID string
 }
 
 type onboardingModel struct {
@@ -41,11 +41,15 @@ func (r *onboardingResource) Metadata(_ context.Context, req resource.MetadataRe
 
 func (r *onboardingResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Version: 1,
 		Attributes: map[string]schema.Attribute{
-			"id": schema.StringAttribute{Computed: true},
+			"id": schema.StringAttribute{Computed: true, PlanModifiers: stringUseState},
 			"server_id": schema.StringAttribute{
-				Required: true,
+				Optional:    true,
+				Computed:    true,
+				Description: serverIDDescription,
 				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
 					stringplanmodifier.RequiresReplace(),
 				},
 				Validators: []validator.String{
@@ -64,18 +68,23 @@ func (r *onboardingResource) Schema(_ context.Context, _ resource.SchemaRequest,
 				Computed:    true,
 				Description: "Normalized JSON returned from GET /guilds/{guild.id}/onboarding",
 			},
-			"reason": schema.StringAttribute{
-				Optional:  true,
-				Sensitive: true,
-				PlanModifiers: []planmodifier.String{
-					planmod.IgnoreChangesString(),
-				},
-				Description: "Optional audit log reason (X-Audit-Log-Reason). This value is not readable.",
-			},
+			"reason": reasonAttribute(),
 		},
 	}
 }
 
+var onboardingIdentity = resourceIdentity{
+	{"server_id", path.Root("server_id")},
+}
+
+func (r *onboardingResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = onboardingIdentity.schema()
+}
+
+func (r *onboardingResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return writeOnlyStateUpgraders("reason")
+}
+
 func (r *onboardingResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	c, diags := getContextFromProviderData(req.ProviderData)
 	resp.Diagnostics.Append(diags...)
@@ -83,24 +92,34 @@ func (r *onboardingResource) Configure(ctx context.Context, req resource.Configu
 		return
 	}
 	r.c = c.Rest
+	r.defaultServerID = c.Config.DefaultServerID
+}
+
+func (r *onboardingResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	planServerID(ctx, r.defaultServerID, true, req, resp)
 }
 
 func (r *onboardingResource) upsert(ctx context.Context, plan *onboardingModel, diags discordFrameworkDiagnostics) {
-	var payload any
-	if err := json.Unmarshal([]byte(plan.PayloadJSON.ValueString()), &payload); err != nil {
-		diags.AddError("Invalid JSON", err.Error())
+	payload := json.RawMessage(plan.PayloadJSON.ValueString())
+	if !json.Valid(payload) {
+		diags.AddError("Invalid JSON", "payload_json is not valid JSON")
 		return
 	}
 
-	if err := r.c.DoJSONWithReason(ctx, "PUT", fmt.Sprintf("/guilds/%s/onboarding", plan.ServerID.ValueString()), nil, payload, nil, plan.Reason.ValueString()); err != nil {
+	if _, err := r.c.ModifyGuildOnboarding(ctx, plan.ServerID.ValueString(), payload, plan.Reason.ValueString()); err != nil {
 		diags.AddError("Discord API error", err.Error())
 		return
 	}
 }
 
 func (r *onboardingResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := startResourceSpan(ctx, "discord_onboarding", "create")
+	defer endResourceSpan(span, &resp.Diagnostics)
+	defer onboardingIdentity.set(ctx, &resp.State, resp.Identity, &resp.Diagnostics)
+
 	var plan onboardingModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("reason"), &plan.Reason)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -116,6 +135,10 @@ func (r *onboardingResource) Create(ctx context.Context, req resource.CreateRequ
 }
 
 func (r *onboardingResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := startResourceSpan(ctx, "discord_onboarding", "read")
+	defer endResourceSpan(span, &resp.Diagnostics)
+	defer onboardingIdentity.set(ctx, &resp.State, resp.Identity, &resp.Diagnostics)
+
 	var state onboardingModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
@@ -143,8 +166,8 @@ func (r *onboardingResource) readIntoState(ctx context.Context, state *onboardin
 		return
 	}
 
-	var out any
-	if err := r.c.DoJSON(ctx, "GET", fmt.Sprintf("/guilds/%s/onboarding", serverID), nil, nil, &out); err != nil {
+	out, err := r.c.GetGuildOnboarding(ctx, serverID)
+	if err != nil {
 		if discord.IsDiscordHTTPStatus(err, 404) {
 			state.ID = types.StringNull()
 			return
@@ -153,12 +176,7 @@ func (r *onboardingResource) readIntoState(ctx context.Context, state *onboardin
 		return
 	}
 
-	b, err := json.Marshal(out)
-	if err != nil {
-		diags.AddError("JSON error", err.Error())
-		return
-	}
-	norm, err := discord.NormalizeJSON(string(b))
+	norm, err := discord.NormalizeJSON(string(out))
 	if err != nil {
 		diags.AddError("JSON error", err.Error())
 		return
@@ -170,8 +188,13 @@ func (r *onboardingResource) readIntoState(ctx context.Context, state *onboardin
 }
 
 func (r *onboardingResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := startResourceSpan(ctx, "discord_onboarding", "update")
+	defer endResourceSpan(span, &resp.Diagnostics)
+	defer onboardingIdentity.set(ctx, &resp.State, resp.Identity, &resp.Diagnostics)
+
 	var plan onboardingModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("reason"), &plan.Reason)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -187,6 +210,9 @@ func (r *onboardingResource) Update(ctx context.Context, req resource.UpdateRequ
 }
 
 func (r *onboardingResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := startResourceSpan(ctx, "discord_onboarding", "delete")
+	defer endResourceSpan(span, &resp.Diagnostics)
+
 	var state onboardingModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
@@ -194,8 +220,7 @@ func (r *onboardingResource) Delete(ctx context.Context, req resource.DeleteRequ
 	}
 
 	// Best-effort disable. Users that want to "remove" onboarding should explicitly manage enabled=false.
-	body := map[string]any{"enabled": false}
-	if err := r.c.DoJSONWithReason(ctx, "PATCH", fmt.Sprintf("/guilds/%s/onboarding", state.ServerID.ValueString()), nil, body, nil, state.Reason.ValueString()); err != nil {
+	if err := r.c.DoJSONWithReason(ctx, "PATCH", "/guilds/"+state.ServerID.ValueString()+"/onboarding", nil, json.RawMessage(`{"enabled":false}`), nil, ""); err != nil {
 		if discord.IsDiscordHTTPStatus(err, 404) {
 			resp.State.RemoveResource(ctx)
 			return
@@ -207,6 +232,10 @@ func (r *onboardingResource) Delete(ctx context.Context, req resource.DeleteRequ
 }
 
 func (r *onboardingResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("server_id"), req, resp)
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	id := onboardingIdentity.importID(ctx, req, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("server_id"), id)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
 }
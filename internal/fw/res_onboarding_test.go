@@ -0,0 +1,66 @@
+package fw
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestOnboardingResource_Fake(t *testing.T) {
+	e := newFakeEnv(t)
+	channel := `
+resource "discord_channel" "start" {
+  server_id = %[1]q
+  type      = "text"
+  name      = "start-here"
+}
+`
+
+	e.test(t, resource.TestCase{
+		Steps: []resource.TestStep{
+			{
+				Config: e.config(channel+`
+resource "discord_onboarding" "flow" {
+  server_id = %[1]q
+  payload_json = jsonencode({
+    enabled             = true
+    mode                = 0
+    default_channel_ids = [discord_channel.start.id]
+    prompts = [{
+      title         = "What brings you here?"
+      single_select = true
+      required      = false
+      in_onboarding = true
+      type          = 0
+      options = [{
+        title       = "Games"
+        channel_ids = [discord_channel.start.id]
+        role_ids    = []
+      }]
+    }]
+  })
+}
+`, e.guildID),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("discord_onboarding.flow", "id", e.guildID),
+					// Discord assigns IDs to new prompts and options.
+					resource.TestMatchResourceAttr("discord_onboarding.flow", "state_json", regexp.MustCompile(`"prompts":\[\{[^]]*"id":"\d{17,20}"`)),
+				),
+			},
+			{
+				Config: e.config(channel+`
+resource "discord_onboarding" "flow" {
+  server_id = %[1]q
+  payload_json = jsonencode({
+    enabled             = false
+    default_channel_ids = [discord_channel.start.id]
+    prompts             = []
+  })
+}
+`, e.guildID),
+				Check: resource.TestMatchResourceAttr("discord_onboarding.flow", "state_json", regexp.MustCompile(`"enabled":false`)),
+			},
+		},
+	})
+}
@@ -8,11 +8,12 @@ import (
 
 	"github.com/45ck/terraform-provider-discord/discord"
 	"github.com/45ck/terraform-provider-discord/internal/fw/fwutil"
-	"github.com/45ck/terraform-provider-discord/internal/fw/planmod"
 	"github.com/45ck/terraform-provider-discord/internal/fw/validate"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
@@ -23,8 +24,12 @@ func NewRoleResource() resource.Resource {
 	return &roleResource{}
 }
 
+// roleFieldPaths maps Discord request fields to schema attributes for per-attribute errors.
+var roleFieldPaths = sameNameFieldPaths("name", "permissions", "color", "hoist", "mentionable")
+
 type roleResource struct {
-	c *discord.RestClient
+	c               *discord.RestClient
+	defaultServerID string
 }
 
 type roleResourceModel struct {
@@ -44,66 +49,36 @@ type roleResourceModel struct {
 	Managed     types.Bool  `tfsdk:"managed"`
 }
 
-type restRoleFull struct {
-	ID          string `json:"id"`
-	Name        string `json:"name"`
-	Color       int    `json:"color"`
-	Hoist       bool   `json:"hoist"`
-	Mentionable bool   `json:"mentionable"`
-	Managed     bool   `json:"managed"`
-	Position    int    `json:"position"`
-	Permissions string `json:"permissions"`
-}
-
-type restRoleUpdate struct {
-	Name        string `json:"name,omitempty"`
-	Permissions string `json:"permissions,omitempty"`
-	Color       int    `json:"color,omitempty"`
-	Hoist       bool   `json:"hoist,omitempty"`
-	Mentionable bool   `json:"mentionable,omitempty"`
-}
-
-type restRolePosition struct {
-	ID       string `json:"id"`
-	Position int    `json:"position"`
-}
-
-type restRole struct {
-	ID       string `json:"id"`
-	Position int    `json:"position"`
-}
-
 func (r *roleResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_role"
 }
 
 func (r *roleResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Version: 1,
 		Attributes: map[string]schema.Attribute{
-			"id": schema.StringAttribute{Computed: true},
+			"id": schema.StringAttribute{Computed: true, PlanModifiers: stringUseState},
 
 			"server_id": schema.StringAttribute{
-				Required: true,
+				Optional:    true,
+				Computed:    true,
+				Description: serverIDDescription,
 				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
 					stringplanmodifier.RequiresReplace(),
 				},
 				Validators: []validator.String{
 					validate.Snowflake(),
 				},
 			},
-			"name": schema.StringAttribute{Required: true},
-			"reason": schema.StringAttribute{
-				Optional:  true,
-				Sensitive: true,
-				PlanModifiers: []planmodifier.String{
-					planmod.IgnoreChangesString(),
-				},
-				Description: "Optional audit log reason (X-Audit-Log-Reason). This value is not readable.",
-			},
+			"name":   schema.StringAttribute{Required: true},
+			"reason": reasonAttribute(),
 
 			"permissions": schema.Int64Attribute{
 				Optional: true,
-				// Historically TypeInt with default 0; Int64 is safer.
+				// Mirrors permissions_bits64 when the value fits, so it is computed
+				// when only permissions_bits64 is configured.
+				Computed: true,
 			},
 			"permissions_bits64": schema.StringAttribute{
 				Optional:    true,
@@ -111,16 +86,31 @@ func (r *roleResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 				Description: "Permissions as 64-bit integer string (decimal or 0x...). Prefer this for newer high-bit permissions.",
 			},
 
-			"color":       schema.Int64Attribute{Optional: true},
-			"hoist":       schema.BoolAttribute{Optional: true},
-			"mentionable": schema.BoolAttribute{Optional: true},
-			"position":    schema.Int64Attribute{Optional: true},
+			// Unset color, hoist and mentionable are sent as 0/false.
+			"color":       schema.Int64Attribute{Optional: true, Computed: true, Default: int64default.StaticInt64(0)},
+			"hoist":       schema.BoolAttribute{Optional: true, Computed: true, Default: booldefault.StaticBool(false)},
+			"mentionable": schema.BoolAttribute{Optional: true, Computed: true, Default: booldefault.StaticBool(false)},
+			// Discord shifts positions as roles are created and deleted.
+			"position": schema.Int64Attribute{Optional: true, Computed: true},
 
 			"managed": schema.BoolAttribute{Computed: true},
 		},
 	}
 }
 
+var roleIdentity = resourceIdentity{
+	{"server_id", path.Root("server_id")},
+	{"role_id", path.Root("id")},
+}
+
+func (r *roleResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = roleIdentity.schema()
+}
+
+func (r *roleResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return writeOnlyStateUpgraders("reason")
+}
+
 func (r *roleResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	c, diags := getContextFromProviderData(req.ProviderData)
 	resp.Diagnostics.Append(diags...)
@@ -128,6 +118,11 @@ func (r *roleResource) Configure(ctx context.Context, req resource.ConfigureRequ
 		return
 	}
 	r.c = c.Rest
+	r.defaultServerID = c.Config.DefaultServerID
+}
+
+func (r *roleResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	planServerID(ctx, r.defaultServerID, true, req, resp)
 }
 
 func desiredPerms64FromModel(m roleResourceModel) (uint64, error) {
@@ -145,9 +140,26 @@ func desiredPerms64FromModel(m roleResourceModel) (uint64, error) {
 	return perms, nil
 }
 
-func fetchRoleByID(ctx context.Context, c *discord.RestClient, serverID, roleID string) (*restRoleFull, error) {
-	var roles []restRoleFull
-	if err := c.DoJSON(ctx, "GET", "/guilds/"+serverID+"/roles", nil, nil, &roles); err != nil {
+func roleParamsFromModel(m roleResourceModel, perms uint64) *discord.RoleParams {
+	p := &discord.RoleParams{
+		Name:        discord.Ptr(m.Name.ValueString()),
+		Permissions: discord.Ptr(strconv.FormatUint(perms, 10)),
+	}
+	if color := int(m.Color.ValueInt64()); color != 0 {
+		p.Color = &color
+	}
+	if m.Hoist.ValueBool() {
+		p.Hoist = discord.Ptr(true)
+	}
+	if m.Mentionable.ValueBool() {
+		p.Mentionable = discord.Ptr(true)
+	}
+	return p
+}
+
+func fetchRoleByID(ctx context.Context, c *discord.RestClient, serverID, roleID string) (*discord.Role, error) {
+	roles, err := c.GetGuildRoles(ctx, serverID)
+	if err != nil {
 		return nil, err
 	}
 	for i := range roles {
@@ -159,13 +171,13 @@ func fetchRoleByID(ctx context.Context, c *discord.RestClient, serverID, roleID
 }
 
 func swapRolePosition(ctx context.Context, c *discord.RestClient, serverID, roleID string, newPos int, reason string) error {
-	var roles []restRoleFull
-	if err := c.DoJSON(ctx, "GET", "/guilds/"+serverID+"/roles", nil, nil, &roles); err != nil {
+	roles, err := c.GetGuildRoles(ctx, serverID)
+	if err != nil {
 		return err
 	}
 
-	var current *restRoleFull
-	var occupant *restRoleFull
+	var current *discord.Role
+	var occupant *discord.Role
 	for i := range roles {
 		if roles[i].ID == roleID {
 			current = &roles[i]
@@ -184,17 +196,22 @@ func swapRolePosition(ctx context.Context, c *discord.RestClient, serverID, role
 		return nil
 	}
 
-	body := []restRolePosition{
-		{ID: occupant.ID, Position: current.Position},
-		{ID: roleID, Position: newPos},
+	positions := []discord.RolePosition{
+		{ID: occupant.ID, Position: discord.Ptr(current.Position)},
+		{ID: roleID, Position: discord.Ptr(newPos)},
 	}
-	var out []restRole
-	return c.DoJSONWithReason(ctx, "PATCH", "/guilds/"+serverID+"/roles", nil, body, &out, reason)
+	_, err = c.ModifyRolePositions(ctx, serverID, positions, reason)
+	return err
 }
 
 func (r *roleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := startResourceSpan(ctx, "discord_role", "create")
+	defer endResourceSpan(span, &resp.Diagnostics)
+	defer roleIdentity.set(ctx, &resp.State, resp.Identity, &resp.Diagnostics)
+
 	var plan roleResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("reason"), &plan.Reason)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -207,36 +224,69 @@ func (r *roleResource) Create(ctx context.Context, req resource.CreateRequest, r
 		return
 	}
 
-	create := restRoleUpdate{
+	// What a role created from this plan looks like, to recognise it after an
+	// ambiguous failure.
+	want := discord.Role{
 		Name:        plan.Name.ValueString(),
 		Permissions: strconv.FormatUint(perms, 10),
 		Color:       int(plan.Color.ValueInt64()),
-		Hoist:       !plan.Hoist.IsNull() && plan.Hoist.ValueBool(),
-		Mentionable: !plan.Mentionable.IsNull() && plan.Mentionable.ValueBool(),
+		Hoist:       plan.Hoist.ValueBool(),
+		Mentionable: plan.Mentionable.ValueBool(),
 	}
-
-	var role restRoleFull
-	if err := r.c.DoJSONWithReason(ctx, "POST", "/guilds/"+serverID+"/roles", nil, create, &role, plan.Reason.ValueString()); err != nil {
-		resp.Diagnostics.AddError("Discord API error", err.Error())
-		return
+	create := beginCreate("role", serverID, want.Name, want.Permissions, want.Color, want.Hoist, want.Mentionable)
+	defer create.end()
+	role, err := r.c.CreateRole(ctx, serverID, roleParamsFromModel(plan, perms), plan.Reason.ValueString())
+	if err != nil {
+		candidates := map[string]*discord.Role{}
+		id, err := create.recover(ctx, err, func(ctx context.Context) ([]string, error) {
+			roles, err := r.c.GetGuildRoles(ctx, serverID)
+			if err != nil {
+				return nil, err
+			}
+			var ids []string
+			for i := range roles {
+				ro := &roles[i]
+				if ro.Name == want.Name && strings.TrimSpace(ro.Permissions) == want.Permissions &&
+					ro.Color == want.Color && ro.Hoist == want.Hoist && ro.Mentionable == want.Mentionable {
+					candidates[ro.ID] = ro
+					ids = append(ids, ro.ID)
+				}
+			}
+			return ids, nil
+		})
+		if err != nil {
+			addDiscordAPIError(&resp.Diagnostics, err, roleFieldPaths)
+			return
+		}
+		role = candidates[id]
 	}
+	create.created(role.ID)
 
 	plan.ID = types.StringValue(role.ID)
 	plan.Managed = types.BoolValue(role.Managed)
 	plan.PermissionsBits64 = types.StringValue(strings.TrimSpace(role.Permissions))
 
-	if !plan.Position.IsNull() {
+	if !plan.Position.IsNull() && !plan.Position.IsUnknown() {
 		if err := swapRolePosition(ctx, r.c, serverID, role.ID, int(plan.Position.ValueInt64()), plan.Reason.ValueString()); err != nil {
-			resp.Diagnostics.AddError("Discord API error", err.Error())
+			addDiscordAPIError(&resp.Diagnostics, err, roleFieldPaths)
 			return
 		}
 	}
 
-	r.readIntoState(ctx, &plan, &resp.Diagnostics)
+	readAfterCreate(ctx, &resp.Diagnostics, "Role", role.ID, func(ctx context.Context) bool {
+		plan.ID = types.StringValue(role.ID)
+		r.readIntoState(ctx, &plan, &resp.Diagnostics)
+		return !plan.ID.IsNull()
+	})
+	plan.ID = types.StringValue(role.ID)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 }
 
 func (r *roleResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := startResourceSpan(ctx, "discord_role", "read")
+	defer endResourceSpan(span, &resp.Diagnostics)
+	defer roleIdentity.set(ctx, &resp.State, resp.Identity, &resp.Diagnostics)
+
 	var state roleResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
@@ -255,9 +305,14 @@ func (r *roleResource) Read(ctx context.Context, req resource.ReadRequest, resp
 }
 
 func (r *roleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := startResourceSpan(ctx, "discord_role", "update")
+	defer endResourceSpan(span, &resp.Diagnostics)
+	defer roleIdentity.set(ctx, &resp.State, resp.Identity, &resp.Diagnostics)
+
 	var plan roleResourceModel
 	var state roleResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("reason"), &plan.Reason)...)
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -268,7 +323,7 @@ func (r *roleResource) Update(ctx context.Context, req resource.UpdateRequest, r
 
 	if fwutil.ChangedInt64(plan.Position, state.Position) && !plan.Position.IsNull() {
 		if err := swapRolePosition(ctx, r.c, serverID, roleID, int(plan.Position.ValueInt64()), plan.Reason.ValueString()); err != nil {
-			resp.Diagnostics.AddError("Discord API error", err.Error())
+			addDiscordAPIError(&resp.Diagnostics, err, roleFieldPaths)
 			return
 		}
 	}
@@ -279,17 +334,8 @@ func (r *roleResource) Update(ctx context.Context, req resource.UpdateRequest, r
 		return
 	}
 
-	update := restRoleUpdate{
-		Name:        plan.Name.ValueString(),
-		Permissions: strconv.FormatUint(perms, 10),
-		Color:       int(plan.Color.ValueInt64()),
-		Hoist:       !plan.Hoist.IsNull() && plan.Hoist.ValueBool(),
-		Mentionable: !plan.Mentionable.IsNull() && plan.Mentionable.ValueBool(),
-	}
-
-	var out restRoleFull
-	if err := r.c.DoJSONWithReason(ctx, "PATCH", fmt.Sprintf("/guilds/%s/roles/%s", serverID, roleID), nil, update, &out, plan.Reason.ValueString()); err != nil {
-		resp.Diagnostics.AddError("Discord API error", err.Error())
+	if _, err := r.c.ModifyRole(ctx, serverID, roleID, roleParamsFromModel(plan, perms), plan.Reason.ValueString()); err != nil {
+		addDiscordAPIError(&resp.Diagnostics, err, roleFieldPaths)
 		return
 	}
 
@@ -300,6 +346,9 @@ func (r *roleResource) Update(ctx context.Context, req resource.UpdateRequest, r
 }
 
 func (r *roleResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := startResourceSpan(ctx, "discord_role", "delete")
+	defer endResourceSpan(span, &resp.Diagnostics)
+
 	var state roleResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
@@ -309,7 +358,7 @@ func (r *roleResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 	serverID := state.ServerID.ValueString()
 	roleID := state.ID.ValueString()
 
-	if err := r.c.DoJSONWithReason(ctx, "DELETE", fmt.Sprintf("/guilds/%s/roles/%s", serverID, roleID), nil, nil, nil, state.Reason.ValueString()); err != nil {
+	if err := r.c.DeleteRole(ctx, serverID, roleID, ""); err != nil {
 		if discord.IsDiscordHTTPStatus(err, 404) {
 			resp.State.RemoveResource(ctx)
 			return
@@ -321,8 +370,12 @@ func (r *roleResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 }
 
 func (r *roleResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	id := roleIdentity.importID(ctx, req, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	// Import format: server_id:role_id
-	serverID, roleID, err := fwutil.ParseTwoIDs(req.ID)
+	serverID, roleID, err := fwutil.ParseTwoIDs(id)
 	if err != nil {
 		resp.Diagnostics.AddError("Invalid import ID", err.Error())
 		return
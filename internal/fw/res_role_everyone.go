@@ -2,7 +2,6 @@ package fw
 
 import (
 	"context"
-	"fmt"
 	"strconv"
 	"strings"
 
@@ -22,7 +21,8 @@ func NewRoleEveryoneResource() resource.Resource {
 }
 
 type roleEveryoneResource struct {
-	c *discord.RestClient
+	c               *discord.RestClient
+	defaultServerID string
 }
 
 type roleEveryoneModel struct {
@@ -41,10 +41,13 @@ func (r *roleEveryoneResource) Metadata(_ context.Context, req resource.Metadata
 func (r *roleEveryoneResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
-			"id": schema.StringAttribute{Computed: true},
+			"id": schema.StringAttribute{Computed: true, PlanModifiers: stringUseState},
 			"server_id": schema.StringAttribute{
-				Required: true,
+				Optional:    true,
+				Computed:    true,
+				Description: serverIDDescription,
 				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
 					stringplanmodifier.RequiresReplace(),
 				},
 				Validators: []validator.String{
@@ -63,6 +66,14 @@ func (r *roleEveryoneResource) Schema(_ context.Context, _ resource.SchemaReques
 	}
 }
 
+var roleEveryoneIdentity = resourceIdentity{
+	{"server_id", path.Root("server_id")},
+}
+
+func (r *roleEveryoneResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = roleEveryoneIdentity.schema()
+}
+
 func (r *roleEveryoneResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	c, diags := getContextFromProviderData(req.ProviderData)
 	resp.Diagnostics.Append(diags...)
@@ -70,9 +81,18 @@ func (r *roleEveryoneResource) Configure(ctx context.Context, req resource.Confi
 		return
 	}
 	r.c = c.Rest
+	r.defaultServerID = c.Config.DefaultServerID
+}
+
+func (r *roleEveryoneResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	planServerID(ctx, r.defaultServerID, true, req, resp)
 }
 
 func (r *roleEveryoneResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := startResourceSpan(ctx, "discord_role_everyone", "create")
+	defer endResourceSpan(span, &resp.Diagnostics)
+	defer roleEveryoneIdentity.set(ctx, &resp.State, resp.Identity, &resp.Diagnostics)
+
 	var plan roleEveryoneModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
@@ -89,6 +109,10 @@ func (r *roleEveryoneResource) Create(ctx context.Context, req resource.CreateRe
 }
 
 func (r *roleEveryoneResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := startResourceSpan(ctx, "discord_role_everyone", "read")
+	defer endResourceSpan(span, &resp.Diagnostics)
+	defer roleEveryoneIdentity.set(ctx, &resp.State, resp.Identity, &resp.Diagnostics)
+
 	var state roleEveryoneModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
@@ -108,6 +132,10 @@ func (r *roleEveryoneResource) Read(ctx context.Context, req resource.ReadReques
 }
 
 func (r *roleEveryoneResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := startResourceSpan(ctx, "discord_role_everyone", "update")
+	defer endResourceSpan(span, &resp.Diagnostics)
+	defer roleEveryoneIdentity.set(ctx, &resp.State, resp.Identity, &resp.Diagnostics)
+
 	var plan roleEveryoneModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
@@ -130,12 +158,11 @@ func (r *roleEveryoneResource) Update(ctx context.Context, req resource.UpdateRe
 		perms = v
 	}
 
-	body := restRoleUpdate{
-		Permissions: strconv.FormatUint(perms, 10),
+	params := &discord.RoleParams{
+		Permissions: discord.Ptr(strconv.FormatUint(perms, 10)),
 	}
 
-	var out restRoleFull
-	if err := r.c.DoJSON(ctx, "PATCH", fmt.Sprintf("/guilds/%s/roles/%s", serverID, serverID), nil, body, &out); err != nil {
+	if _, err := r.c.ModifyRole(ctx, serverID, serverID, params, ""); err != nil {
 		resp.Diagnostics.AddError("Discord API error", err.Error())
 		return
 	}
@@ -146,14 +173,21 @@ func (r *roleEveryoneResource) Update(ctx context.Context, req resource.UpdateRe
 }
 
 func (r *roleEveryoneResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := startResourceSpan(ctx, "discord_role_everyone", "delete")
+	defer endResourceSpan(span, &resp.Diagnostics)
+
 	resp.Diagnostics.AddWarning("Deleting the everyone role is not allowed", "Destroying this resource removes it from state only.")
 	resp.State.RemoveResource(ctx)
 }
 
 func (r *roleEveryoneResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	id := roleEveryoneIdentity.importID(ctx, req, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	// Import format is just the server/guild ID (the @everyone role has the same ID as the guild).
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("server_id"), req.ID)...)
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("server_id"), id)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
 }
 
 func (r *roleEveryoneResource) readIntoState(ctx context.Context, state *roleEveryoneModel, diags discordFrameworkDiagnostics) {
@@ -5,7 +5,6 @@ import (
 	"fmt"
 
 	"github.com/45ck/terraform-provider-discord/discord"
-	"github.com/45ck/terraform-provider-discord/internal/fw/planmod"
 	"github.com/45ck/terraform-provider-discord/internal/fw/validate"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -21,7 +20,9 @@ func NewRoleOrderResource() resource.Resource {
 }
 
 type roleOrderResource struct {
-	c *discord.RestClient
+	c               *discord.RestClient
+	defaultServerID string
+	positions       *discord.PositionBatcher
 }
 
 type roleOrderItemModel struct {
@@ -37,27 +38,21 @@ type roleOrderModel struct {
 	Reason   types.String         `tfsdk:"reason"`
 }
 
-type restRoleOrderPosition struct {
-	ID       string `json:"id"`
-	Position int    `json:"position"`
-}
-
-type restRoleOrder struct {
-	ID       string `json:"id"`
-	Position int    `json:"position"`
-}
-
 func (r *roleOrderResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_role_order"
 }
 
 func (r *roleOrderResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Version: 1,
 		Attributes: map[string]schema.Attribute{
-			"id": schema.StringAttribute{Computed: true},
+			"id": schema.StringAttribute{Computed: true, PlanModifiers: stringUseState},
 			"server_id": schema.StringAttribute{
-				Required: true,
+				Optional:    true,
+				Computed:    true,
+				Description: serverIDDescription,
 				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
 					stringplanmodifier.RequiresReplace(),
 				},
 				Validators: []validator.String{
@@ -78,17 +73,23 @@ func (r *roleOrderResource) Schema(_ context.Context, _ resource.SchemaRequest,
 					},
 				},
 			},
-			"reason": schema.StringAttribute{
-				Optional:  true,
-				Sensitive: true,
-				PlanModifiers: []planmodifier.String{
-					planmod.IgnoreChangesString(),
-				},
-			},
+			"reason": reasonAttribute(),
 		},
 	}
 }
 
+var roleOrderIdentity = resourceIdentity{
+	{"server_id", path.Root("server_id")},
+}
+
+func (r *roleOrderResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = roleOrderIdentity.schema()
+}
+
+func (r *roleOrderResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return writeOnlyStateUpgraders("reason")
+}
+
 func (r *roleOrderResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	c, diags := getContextFromProviderData(req.ProviderData)
 	resp.Diagnostics.Append(diags...)
@@ -96,29 +97,38 @@ func (r *roleOrderResource) Configure(ctx context.Context, req resource.Configur
 		return
 	}
 	r.c = c.Rest
+	r.positions = c.Positions
+	r.defaultServerID = c.Config.DefaultServerID
+}
+
+func (r *roleOrderResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	planServerID(ctx, r.defaultServerID, true, req, resp)
 }
 
-func expandRolePositions(items []roleOrderItemModel) []restRoleOrderPosition {
-	out := make([]restRoleOrderPosition, 0, len(items))
+func expandRolePositions(items []roleOrderItemModel) []discord.RolePosition {
+	out := make([]discord.RolePosition, 0, len(items))
 	for _, it := range items {
-		out = append(out, restRoleOrderPosition{
+		out = append(out, discord.RolePosition{
 			ID:       it.RoleID.ValueString(),
-			Position: int(it.Position.ValueInt64()),
+			Position: discord.Ptr(int(it.Position.ValueInt64())),
 		})
 	}
 	return out
 }
 
 func (r *roleOrderResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := startResourceSpan(ctx, "discord_role_order", "create")
+	defer endResourceSpan(span, &resp.Diagnostics)
+	defer roleOrderIdentity.set(ctx, &resp.State, resp.Identity, &resp.Diagnostics)
+
 	var plan roleOrderModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("reason"), &plan.Reason)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	body := expandRolePositions(plan.Role)
-	var out []restRoleOrder
-	if err := r.c.DoJSONWithReason(ctx, "PATCH", fmt.Sprintf("/guilds/%s/roles", plan.ServerID.ValueString()), nil, body, &out, plan.Reason.ValueString()); err != nil {
+	if err := r.positions.ModifyRolePositions(ctx, r.c, plan.ServerID.ValueString(), expandRolePositions(plan.Role), plan.Reason.ValueString()); err != nil {
 		resp.Diagnostics.AddError("Discord API error", err.Error())
 		return
 	}
@@ -129,15 +139,18 @@ func (r *roleOrderResource) Create(ctx context.Context, req resource.CreateReque
 }
 
 func (r *roleOrderResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := startResourceSpan(ctx, "discord_role_order", "update")
+	defer endResourceSpan(span, &resp.Diagnostics)
+	defer roleOrderIdentity.set(ctx, &resp.State, resp.Identity, &resp.Diagnostics)
+
 	var plan roleOrderModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("reason"), &plan.Reason)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	body := expandRolePositions(plan.Role)
-	var out []restRoleOrder
-	if err := r.c.DoJSONWithReason(ctx, "PATCH", fmt.Sprintf("/guilds/%s/roles", plan.ServerID.ValueString()), nil, body, &out, plan.Reason.ValueString()); err != nil {
+	if err := r.positions.ModifyRolePositions(ctx, r.c, plan.ServerID.ValueString(), expandRolePositions(plan.Role), plan.Reason.ValueString()); err != nil {
 		resp.Diagnostics.AddError("Discord API error", err.Error())
 		return
 	}
@@ -148,6 +161,10 @@ func (r *roleOrderResource) Update(ctx context.Context, req resource.UpdateReque
 }
 
 func (r *roleOrderResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := startResourceSpan(ctx, "discord_role_order", "read")
+	defer endResourceSpan(span, &resp.Diagnostics)
+	defer roleOrderIdentity.set(ctx, &resp.State, resp.Identity, &resp.Diagnostics)
+
 	var state roleOrderModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
@@ -171,8 +188,8 @@ func (r *roleOrderResource) readIntoState(ctx context.Context, state *roleOrderM
 		serverID = state.ServerID.ValueString()
 	}
 
-	var roles []restRoleOrder
-	if err := r.c.DoJSON(ctx, "GET", fmt.Sprintf("/guilds/%s/roles", serverID), nil, nil, &roles); err != nil {
+	roles, err := r.c.GetGuildRoles(ctx, serverID)
+	if err != nil {
 		if discord.IsDiscordHTTPStatus(err, 404) {
 			state.ID = types.StringNull()
 			return
@@ -207,11 +224,18 @@ func (r *roleOrderResource) readIntoState(ctx context.Context, state *roleOrderM
 }
 
 func (r *roleOrderResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := startResourceSpan(ctx, "discord_role_order", "delete")
+	defer endResourceSpan(span, &resp.Diagnostics)
+
 	resp.Diagnostics.AddWarning("discord_role_order does not revert ordering on destroy", "Destroying this resource removes it from state only.")
 	resp.State.RemoveResource(ctx)
 }
 
 func (r *roleOrderResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("server_id"), req, resp)
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	id := roleOrderIdentity.importID(ctx, req, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("server_id"), id)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
 }
@@ -0,0 +1,48 @@
+package fw
+
+import (
+	"context"
+	"testing"
+
+	"github.com/45ck/terraform-provider-discord/discord"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestRoleResource_FakeFaults(t *testing.T) {
+	e := newFakeEnv(t)
+	e.maxRetries = 1
+	// Roles are read from the guild's role list, which names no role ID, so the
+	// 404 targets the read after the lookup that recovers the role from the 502.
+	rules := mishaps("/guilds/*/roles", "/guilds/*/roles")
+	rules[2].Created = false
+	rules[2].Skip = 1
+	e.faults(t, rules...)
+	listRoles := func(ctx context.Context, c *discord.RestClient) ([]string, error) {
+		roles, err := c.GetGuildRoles(ctx, e.guildID)
+		var ids []string
+		for _, r := range roles {
+			if r.Name == "flaky" {
+				ids = append(ids, r.ID)
+			}
+		}
+		return ids, err
+	}
+
+	e.test(t, resource.TestCase{
+		Steps: []resource.TestStep{
+			{
+				Config: e.config(`
+resource "discord_role" "flaky" {
+  server_id   = %q
+  name        = "flaky"
+  permissions = 1024
+}
+`, e.guildID),
+				Check: resource.ComposeTestCheckFunc(
+					e.checkSingle("discord_role.flaky", listRoles),
+					resource.TestCheckResourceAttr("discord_role.flaky", "permissions_bits64", "1024"),
+				),
+			},
+		},
+	})
+}
@@ -2,13 +2,11 @@ package fw
 
 import (
 	"context"
-	"fmt"
 	"strings"
 	"time"
 
 	"github.com/45ck/terraform-provider-discord/discord"
 	"github.com/45ck/terraform-provider-discord/internal/fw/fwutil"
-	"github.com/45ck/terraform-provider-discord/internal/fw/planmod"
 	"github.com/45ck/terraform-provider-discord/internal/fw/validate"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
@@ -25,27 +23,20 @@ func NewScheduledEventResource() resource.Resource {
 	return &scheduledEventResource{}
 }
 
-type scheduledEventResource struct {
-	c *discord.RestClient
-}
+// scheduledEventFieldPaths maps Discord request fields to schema attributes for per-attribute errors.
+var scheduledEventFieldPaths = func() discordFieldPaths {
+	m := sameNameFieldPaths(
+		"name", "description", "scheduled_start_time", "scheduled_end_time", "privacy_level",
+		"entity_type", "channel_id", "status",
+	)
+	m["entity_metadata"] = path.Root("location")
+	m["image"] = path.Root("image_data_uri")
+	return m
+}()
 
-type restScheduledEvent struct {
-	ID                 string                      `json:"id"`
-	GuildID            string                      `json:"guild_id"`
-	ChannelID          string                      `json:"channel_id"`
-	Name               string                      `json:"name"`
-	Description        string                      `json:"description"`
-	ScheduledStartTime string                      `json:"scheduled_start_time"`
-	ScheduledEndTime   string                      `json:"scheduled_end_time"`
-	PrivacyLevel       int                         `json:"privacy_level"`
-	Status             int                         `json:"status"`
-	EntityType         int                         `json:"entity_type"`
-	EntityMetadata     *restScheduledEventMetadata `json:"entity_metadata"`
-	Image              string                      `json:"image"`
-}
-
-type restScheduledEventMetadata struct {
-	Location string `json:"location"`
+type scheduledEventResource struct {
+	c               *discord.RestClient
+	defaultServerID string
 }
 
 type scheduledEventModel struct {
@@ -75,11 +66,15 @@ func (r *scheduledEventResource) Metadata(_ context.Context, req resource.Metada
 
 func (r *scheduledEventResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Version: 1,
 		Attributes: map[string]schema.Attribute{
-			"id": schema.StringAttribute{Computed: true},
+			"id": schema.StringAttribute{Computed: true, PlanModifiers: stringUseState},
 			"server_id": schema.StringAttribute{
-				Required: true,
+				Optional:    true,
+				Computed:    true,
+				Description: serverIDDescription,
 				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
 					stringplanmodifier.RequiresReplace(),
 				},
 				Validators: []validator.String{
@@ -108,6 +103,7 @@ func (r *scheduledEventResource) Schema(_ context.Context, _ resource.SchemaRequ
 			},
 			"privacy_level": schema.Int64Attribute{
 				Optional: true,
+				Computed: true,
 				Default:  int64default.StaticInt64(2),
 			},
 			"entity_type": schema.Int64Attribute{
@@ -136,21 +132,29 @@ func (r *scheduledEventResource) Schema(_ context.Context, _ resource.SchemaRequ
 				Description: "Image hash returned by Discord for the current cover image.",
 			},
 			"status": schema.Int64Attribute{
-				Optional:    true,
-				Description: "Event status (set on update to start/end/cancel where supported).",
-			},
-			"reason": schema.StringAttribute{
-				Optional:  true,
-				Sensitive: true,
-				PlanModifiers: []planmodifier.String{
-					planmod.IgnoreChangesString(),
-				},
-				Description: "Optional audit log reason (X-Audit-Log-Reason). This value is not readable.",
+				Optional:      true,
+				Computed:      true,
+				PlanModifiers: int64UseState,
+				Description:   "Event status (set on update to start/end/cancel where supported).",
 			},
+			"reason": reasonAttribute(),
 		},
 	}
 }
 
+var scheduledEventIdentity = resourceIdentity{
+	{"server_id", path.Root("server_id")},
+	{"event_id", path.Root("id")},
+}
+
+func (r *scheduledEventResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = scheduledEventIdentity.schema()
+}
+
+func (r *scheduledEventResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return writeOnlyStateUpgraders("reason")
+}
+
 func (r *scheduledEventResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	c, diags := getContextFromProviderData(req.ProviderData)
 	resp.Diagnostics.Append(diags...)
@@ -158,6 +162,11 @@ func (r *scheduledEventResource) Configure(ctx context.Context, req resource.Con
 		return
 	}
 	r.c = c.Rest
+	r.defaultServerID = c.Config.DefaultServerID
+}
+
+func (r *scheduledEventResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	planServerID(ctx, r.defaultServerID, true, req, resp)
 }
 
 func (r *scheduledEventResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
@@ -235,44 +244,41 @@ func (r *scheduledEventResource) ValidateConfig(ctx context.Context, req resourc
 	}
 }
 
-func (r *scheduledEventResource) payload(plan *scheduledEventModel, includeStatus bool) map[string]any {
-	body := map[string]any{
-		"name":                 plan.Name.ValueString(),
-		"scheduled_start_time": plan.ScheduledStartTime.ValueString(),
-		"privacy_level":        int(plan.PrivacyLevel.ValueInt64()),
-		"entity_type":          int(plan.EntityType.ValueInt64()),
-	}
-	if v := plan.Description.ValueString(); v != "" {
-		body["description"] = v
-	}
-	if v := plan.ScheduledEndTime.ValueString(); v != "" {
-		body["scheduled_end_time"] = v
-	}
-	if v := plan.ChannelID.ValueString(); v != "" {
-		body["channel_id"] = v
+func (r *scheduledEventResource) payload(plan *scheduledEventModel, includeStatus bool) *discord.ScheduledEventParams {
+	params := &discord.ScheduledEventParams{
+		Name:               discord.Ptr(plan.Name.ValueString()),
+		ScheduledStartTime: discord.Ptr(plan.ScheduledStartTime.ValueString()),
+		PrivacyLevel:       discord.Ptr(int(plan.PrivacyLevel.ValueInt64())),
+		EntityType:         discord.Ptr(int(plan.EntityType.ValueInt64())),
+		Description:        fwutil.NonEmptyString(plan.Description),
+		ScheduledEndTime:   fwutil.NonEmptyString(plan.ScheduledEndTime),
+		ChannelID:          fwutil.NonEmptyString(plan.ChannelID),
+		Image:              fwutil.NonEmptyString(plan.ImageDataURI),
 	}
 	if v := plan.Location.ValueString(); v != "" {
-		body["entity_metadata"] = map[string]any{"location": v}
+		params.EntityMetadata = &discord.ScheduledEventEntityMetadata{Location: v}
 	}
-	if v := plan.ImageDataURI.ValueString(); v != "" {
-		body["image"] = v
+	if includeStatus {
+		params.Status = fwutil.OptionalInt(plan.Status)
 	}
-	if includeStatus && !plan.Status.IsNull() && !plan.Status.IsUnknown() {
-		body["status"] = int(plan.Status.ValueInt64())
-	}
-	return body
+	return params
 }
 
 func (r *scheduledEventResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := startResourceSpan(ctx, "discord_scheduled_event", "create")
+	defer endResourceSpan(span, &resp.Diagnostics)
+	defer scheduledEventIdentity.set(ctx, &resp.State, resp.Identity, &resp.Diagnostics)
+
 	var plan scheduledEventModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("reason"), &plan.Reason)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	var out restScheduledEvent
-	if err := r.c.DoJSONWithReason(ctx, "POST", fmt.Sprintf("/guilds/%s/scheduled-events", plan.ServerID.ValueString()), nil, r.payload(&plan, false), &out, plan.Reason.ValueString()); err != nil {
-		resp.Diagnostics.AddError("Discord API error", err.Error())
+	out, err := r.c.CreateScheduledEvent(ctx, plan.ServerID.ValueString(), r.payload(&plan, false), plan.Reason.ValueString())
+	if err != nil {
+		addDiscordAPIError(&resp.Diagnostics, err, scheduledEventFieldPaths)
 		return
 	}
 
@@ -282,6 +288,10 @@ func (r *scheduledEventResource) Create(ctx context.Context, req resource.Create
 }
 
 func (r *scheduledEventResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := startResourceSpan(ctx, "discord_scheduled_event", "read")
+	defer endResourceSpan(span, &resp.Diagnostics)
+	defer scheduledEventIdentity.set(ctx, &resp.State, resp.Identity, &resp.Diagnostics)
+
 	var state scheduledEventModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
@@ -307,8 +317,8 @@ func (r *scheduledEventResource) readIntoState(ctx context.Context, state *sched
 		return
 	}
 
-	var out restScheduledEvent
-	if err := r.c.DoJSON(ctx, "GET", fmt.Sprintf("/guilds/%s/scheduled-events/%s", serverID, eventID), nil, nil, &out); err != nil {
+	out, err := r.c.GetScheduledEvent(ctx, serverID, eventID)
+	if err != nil {
 		if discord.IsDiscordHTTPStatus(err, 404) {
 			state.ID = types.StringNull()
 			return
@@ -320,14 +330,14 @@ func (r *scheduledEventResource) readIntoState(ctx context.Context, state *sched
 	state.ID = types.StringValue(out.ID)
 	state.ServerID = types.StringValue(serverID)
 	state.Name = types.StringValue(out.Name)
-	state.Description = types.StringValue(out.Description)
+	state.Description = fwutil.KeepNullString(state.Description, out.Description)
 	state.ScheduledStartTime = types.StringValue(out.ScheduledStartTime)
-	state.ScheduledEndTime = types.StringValue(out.ScheduledEndTime)
+	state.ScheduledEndTime = fwutil.KeepNullString(state.ScheduledEndTime, out.ScheduledEndTime)
 	state.PrivacyLevel = types.Int64Value(int64(out.PrivacyLevel))
 	state.EntityType = types.Int64Value(int64(out.EntityType))
-	state.ChannelID = types.StringValue(out.ChannelID)
+	state.ChannelID = fwutil.KeepNullString(state.ChannelID, out.ChannelID)
 	if out.EntityMetadata != nil {
-		state.Location = types.StringValue(out.EntityMetadata.Location)
+		state.Location = fwutil.KeepNullString(state.Location, out.EntityMetadata.Location)
 	} else {
 		state.Location = types.StringNull()
 	}
@@ -336,15 +346,20 @@ func (r *scheduledEventResource) readIntoState(ctx context.Context, state *sched
 }
 
 func (r *scheduledEventResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := startResourceSpan(ctx, "discord_scheduled_event", "update")
+	defer endResourceSpan(span, &resp.Diagnostics)
+	defer scheduledEventIdentity.set(ctx, &resp.State, resp.Identity, &resp.Diagnostics)
+
 	var plan scheduledEventModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("reason"), &plan.Reason)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	var out restScheduledEvent
-	if err := r.c.DoJSONWithReason(ctx, "PATCH", fmt.Sprintf("/guilds/%s/scheduled-events/%s", plan.ServerID.ValueString(), plan.ID.ValueString()), nil, r.payload(&plan, true), &out, plan.Reason.ValueString()); err != nil {
-		resp.Diagnostics.AddError("Discord API error", err.Error())
+	out, err := r.c.ModifyScheduledEvent(ctx, plan.ServerID.ValueString(), plan.ID.ValueString(), r.payload(&plan, true), plan.Reason.ValueString())
+	if err != nil {
+		addDiscordAPIError(&resp.Diagnostics, err, scheduledEventFieldPaths)
 		return
 	}
 
@@ -354,13 +369,16 @@ func (r *scheduledEventResource) Update(ctx context.Context, req resource.Update
 }
 
 func (r *scheduledEventResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := startResourceSpan(ctx, "discord_scheduled_event", "delete")
+	defer endResourceSpan(span, &resp.Diagnostics)
+
 	var state scheduledEventModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	if err := r.c.DoJSONWithReason(ctx, "DELETE", fmt.Sprintf("/guilds/%s/scheduled-events/%s", state.ServerID.ValueString(), state.ID.ValueString()), nil, nil, nil, state.Reason.ValueString()); err != nil {
+	if err := r.c.DeleteScheduledEvent(ctx, state.ServerID.ValueString(), state.ID.ValueString(), ""); err != nil {
 		if discord.IsDiscordHTTPStatus(err, 404) {
 			resp.State.RemoveResource(ctx)
 			return
@@ -372,8 +390,12 @@ func (r *scheduledEventResource) Delete(ctx context.Context, req resource.Delete
 }
 
 func (r *scheduledEventResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	id := scheduledEventIdentity.importID(ctx, req, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	// Import format: server_id:event_id
-	serverID, eventID, err := fwutil.ParseTwoIDs(req.ID)
+	serverID, eventID, err := fwutil.ParseTwoIDs(id)
 	if err != nil {
 		resp.Diagnostics.AddError("Invalid import ID", "Expected server_id:event_id")
 		return
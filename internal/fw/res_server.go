@@ -2,10 +2,9 @@ package fw
 
 import (
 	"context"
-	"fmt"
 
 	"github.com/45ck/terraform-provider-discord/discord"
-	"github.com/45ck/terraform-provider-discord/internal/fw/planmod"
+	"github.com/45ck/terraform-provider-discord/internal/fw/fwutil"
 	"github.com/45ck/terraform-provider-discord/internal/fw/validate"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -24,7 +23,8 @@ func NewServerResource() resource.Resource {
 }
 
 type serverResource struct {
-	c *discord.RestClient
+	c               *discord.RestClient
+	defaultServerID string
 }
 
 type serverResourceModel struct {
@@ -57,14 +57,17 @@ func (r *serverResource) Metadata(_ context.Context, req resource.MetadataReques
 
 func (r *serverResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Version: 1,
 		Attributes: map[string]schema.Attribute{
-			"id": schema.StringAttribute{Computed: true},
+			"id": schema.StringAttribute{Computed: true, PlanModifiers: stringUseState},
 			"server_id": schema.StringAttribute{
-				Required: true,
+				Optional:    true,
+				Computed:    true,
+				Description: serverIDDescription,
 				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
 					stringplanmodifier.RequiresReplace(),
 				},
-				Description: "Guild (server) ID.",
 				Validators: []validator.String{
 					validate.Snowflake(),
 				},
@@ -74,16 +77,19 @@ func (r *serverResource) Schema(_ context.Context, _ resource.SchemaRequest, res
 			},
 			"default_message_notifications": schema.Int64Attribute{
 				Optional:    true,
+				Computed:    true,
 				Default:     int64default.StaticInt64(0),
 				Description: "0 = all messages, 1 = only mentions.",
 			},
 			"verification_level": schema.Int64Attribute{
 				Optional:    true,
+				Computed:    true,
 				Default:     int64default.StaticInt64(0),
 				Description: "0..4 depending on guild settings.",
 			},
 			"explicit_content_filter": schema.Int64Attribute{
 				Optional:    true,
+				Computed:    true,
 				Default:     int64default.StaticInt64(0),
 				Description: "0..2 depending on guild settings.",
 			},
@@ -93,6 +99,7 @@ func (r *serverResource) Schema(_ context.Context, _ resource.SchemaRequest, res
 			},
 			"afk_timeout": schema.Int64Attribute{
 				Optional:    true,
+				Computed:    true,
 				Default:     int64default.StaticInt64(300),
 				Description: "AFK timeout in seconds.",
 			},
@@ -114,21 +121,28 @@ func (r *serverResource) Schema(_ context.Context, _ resource.SchemaRequest, res
 				Computed: true,
 			},
 			"owner_id": schema.StringAttribute{
-				Optional:    true,
-				Description: "Guild owner ID (transfer). This is a privileged operation and often not permitted for bot tokens.",
-			},
-			"reason": schema.StringAttribute{
-				Optional:  true,
-				Sensitive: true,
-				PlanModifiers: []planmodifier.String{
-					planmod.IgnoreChangesString(),
-				},
-				Description: "Optional audit log reason (X-Audit-Log-Reason). This value is not readable.",
+				Optional:      true,
+				Computed:      true,
+				PlanModifiers: stringUseState,
+				Description:   "Guild owner ID (transfer). This is a privileged operation and often not permitted for bot tokens.",
 			},
+			"reason": reasonAttribute(),
 		},
 	}
 }
 
+var serverIdentity = resourceIdentity{
+	{"server_id", path.Root("server_id")},
+}
+
+func (r *serverResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = serverIdentity.schema()
+}
+
+func (r *serverResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return writeOnlyStateUpgraders("reason")
+}
+
 func (r *serverResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	c, diags := getContextFromProviderData(req.ProviderData)
 	resp.Diagnostics.Append(diags...)
@@ -136,12 +150,22 @@ func (r *serverResource) Configure(ctx context.Context, req resource.ConfigureRe
 		return
 	}
 	r.c = c.Rest
+	r.defaultServerID = c.Config.DefaultServerID
+}
+
+func (r *serverResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	planServerID(ctx, r.defaultServerID, true, req, resp)
 }
 
 func (r *serverResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := startResourceSpan(ctx, "discord_server", "create")
+	defer endResourceSpan(span, &resp.Diagnostics)
+	defer serverIdentity.set(ctx, &resp.State, resp.Identity, &resp.Diagnostics)
+
 	// Adopt + apply settings.
 	var plan serverResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("reason"), &plan.Reason)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -157,6 +181,10 @@ func (r *serverResource) Create(ctx context.Context, req resource.CreateRequest,
 }
 
 func (r *serverResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := startResourceSpan(ctx, "discord_server", "read")
+	defer endResourceSpan(span, &resp.Diagnostics)
+	defer serverIdentity.set(ctx, &resp.State, resp.Identity, &resp.Diagnostics)
+
 	var state serverResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
@@ -184,8 +212,8 @@ func (r *serverResource) readIntoState(ctx context.Context, state *serverResourc
 		return
 	}
 
-	var guild discordRestGuild
-	if err := r.c.DoJSON(ctx, "GET", "/guilds/"+serverID, nil, nil, &guild); err != nil {
+	guild, err := r.c.GetGuild(ctx, serverID)
+	if err != nil {
 		if discord.IsDiscordHTTPStatus(err, 404) {
 			state.ID = types.StringNull()
 			return
@@ -203,82 +231,50 @@ func (r *serverResource) readIntoState(ctx context.Context, state *serverResourc
 	state.AfkTimeout = types.Int64Value(int64(guild.AfkTimeout))
 	state.IconHash = types.StringValue(guild.Icon)
 	state.SplashHash = types.StringValue(guild.Splash)
-	if guild.AfkChannelID != "" {
-		state.AfkChannelID = types.StringValue(guild.AfkChannelID)
-	} else {
-		state.AfkChannelID = types.StringNull()
-	}
-	if guild.OwnerID != "" {
-		state.OwnerID = types.StringValue(guild.OwnerID)
-	} else {
-		state.OwnerID = types.StringNull()
-	}
+	state.AfkChannelID = fwutil.KeepNullString(state.AfkChannelID, guild.AfkChannelID)
+	state.OwnerID = fwutil.StringOrNull(guild.OwnerID)
 
 	// Preserve write-only fields in state (icon_data_uri/splash_data_uri) by not touching them here.
 }
 
 func (r *serverResource) apply(ctx context.Context, plan *serverResourceModel, diags discordFrameworkDiagnostics) {
-	body := map[string]any{
-		"name": plan.Name.ValueString(),
-	}
-
-	if !plan.VerificationLevel.IsNull() && !plan.VerificationLevel.IsUnknown() {
-		body["verification_level"] = int(plan.VerificationLevel.ValueInt64())
-	}
-	if !plan.DefaultMessageNotifications.IsNull() && !plan.DefaultMessageNotifications.IsUnknown() {
-		body["default_message_notifications"] = int(plan.DefaultMessageNotifications.ValueInt64())
-	}
-	if !plan.ExplicitContentFilter.IsNull() && !plan.ExplicitContentFilter.IsUnknown() {
-		body["explicit_content_filter"] = int(plan.ExplicitContentFilter.ValueInt64())
-	}
-	if !plan.AfkTimeout.IsNull() && !plan.AfkTimeout.IsUnknown() {
-		body["afk_timeout"] = int(plan.AfkTimeout.ValueInt64())
+	params := &discord.ModifyGuildParams{
+		Name:                        discord.Ptr(plan.Name.ValueString()),
+		VerificationLevel:           fwutil.OptionalInt(plan.VerificationLevel),
+		DefaultMessageNotifications: fwutil.OptionalInt(plan.DefaultMessageNotifications),
+		ExplicitContentFilter:       fwutil.OptionalInt(plan.ExplicitContentFilter),
+		AfkTimeout:                  fwutil.OptionalInt(plan.AfkTimeout),
 	}
 
 	// Empty string clears for IDs and write-only images (translated to JSON null).
-	if !plan.AfkChannelID.IsNull() && !plan.AfkChannelID.IsUnknown() {
-		v := plan.AfkChannelID.ValueString()
-		if v == "" {
-			body["afk_channel_id"] = nil
-		} else {
-			body["afk_channel_id"] = v
-		}
+	if v := fwutil.OptionalString(plan.AfkChannelID); v != nil {
+		params.AfkChannelID = discord.NullIfEmpty(*v)
 	}
-	if !plan.OwnerID.IsNull() && !plan.OwnerID.IsUnknown() {
-		v := plan.OwnerID.ValueString()
-		if v == "" {
-			body["owner_id"] = nil
-		} else {
-			body["owner_id"] = v
-		}
+	if v := fwutil.OptionalString(plan.OwnerID); v != nil {
+		params.OwnerID = discord.NullIfEmpty(*v)
 	}
-	if !plan.IconDataURI.IsNull() && !plan.IconDataURI.IsUnknown() {
-		v := plan.IconDataURI.ValueString()
-		if v == "" {
-			body["icon"] = nil
-		} else {
-			body["icon"] = v
-		}
+	if v := fwutil.OptionalString(plan.IconDataURI); v != nil {
+		params.Icon = discord.NullIfEmpty(*v)
 	}
-	if !plan.SplashDataURI.IsNull() && !plan.SplashDataURI.IsUnknown() {
-		v := plan.SplashDataURI.ValueString()
-		if v == "" {
-			body["splash"] = nil
-		} else {
-			body["splash"] = v
-		}
+	if v := fwutil.OptionalString(plan.SplashDataURI); v != nil {
+		params.Splash = discord.NullIfEmpty(*v)
 	}
 
-	if err := r.c.DoJSONWithReason(ctx, "PATCH", fmt.Sprintf("/guilds/%s", plan.ServerID.ValueString()), nil, body, nil, plan.Reason.ValueString()); err != nil {
+	if _, err := r.c.ModifyGuild(ctx, plan.ServerID.ValueString(), params, plan.Reason.ValueString()); err != nil {
 		diags.AddError("Discord API error", err.Error())
 		return
 	}
 }
 
 func (r *serverResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := startResourceSpan(ctx, "discord_server", "update")
+	defer endResourceSpan(span, &resp.Diagnostics)
+	defer serverIdentity.set(ctx, &resp.State, resp.Identity, &resp.Diagnostics)
+
 	var plan serverResourceModel
 	var prior serverResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("reason"), &plan.Reason)...)
 	resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -295,12 +291,19 @@ func (r *serverResource) Update(ctx context.Context, req resource.UpdateRequest,
 }
 
 func (r *serverResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := startResourceSpan(ctx, "discord_server", "delete")
+	defer endResourceSpan(span, &resp.Diagnostics)
+
 	resp.Diagnostics.AddWarning("discord_server does not delete the guild on destroy", "Destroying this resource removes it from state only.")
 	resp.State.RemoveResource(ctx)
 }
 
 func (r *serverResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	id := serverIdentity.importID(ctx, req, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	// Import ID is the server_id.
-	resource.ImportStatePassthroughID(ctx, path.Root("server_id"), req, resp)
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("server_id"), id)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
 }
@@ -3,12 +3,10 @@ package fw
 import (
 	"context"
 	"encoding/base64"
-	"fmt"
 	"os"
 
 	"github.com/45ck/terraform-provider-discord/discord"
 	"github.com/45ck/terraform-provider-discord/internal/fw/fwutil"
-	"github.com/45ck/terraform-provider-discord/internal/fw/planmod"
 	"github.com/45ck/terraform-provider-discord/internal/fw/validate"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -24,18 +22,16 @@ func NewSoundboardSoundResource() resource.Resource {
 	return &soundboardSoundResource{}
 }
 
-type soundboardSoundResource struct {
-	c *discord.RestClient
-}
+// soundboardSoundFieldPaths maps Discord request fields to schema attributes for per-attribute errors.
+var soundboardSoundFieldPaths = func() discordFieldPaths {
+	m := sameNameFieldPaths("name", "volume", "emoji_id", "emoji_name")
+	m["sound"] = path.Root("sound_file_path")
+	return m
+}()
 
-type restSoundboardSoundResource struct {
-	Name      string  `json:"name"`
-	SoundID   string  `json:"sound_id"`
-	Volume    float64 `json:"volume"`
-	EmojiID   string  `json:"emoji_id"`
-	EmojiName string  `json:"emoji_name"`
-	GuildID   string  `json:"guild_id"`
-	Available bool    `json:"available"`
+type soundboardSoundResource struct {
+	c               *discord.RestClient
+	defaultServerID string
 }
 
 type soundboardSoundResourceModel struct {
@@ -61,11 +57,15 @@ func (r *soundboardSoundResource) Metadata(_ context.Context, req resource.Metad
 
 func (r *soundboardSoundResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Version: 1,
 		Attributes: map[string]schema.Attribute{
-			"id": schema.StringAttribute{Computed: true},
+			"id": schema.StringAttribute{Computed: true, PlanModifiers: stringUseState},
 			"server_id": schema.StringAttribute{
-				Required: true,
+				Optional:    true,
+				Computed:    true,
+				Description: serverIDDescription,
 				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
 					stringplanmodifier.RequiresReplace(),
 				},
 				Validators: []validator.String{
@@ -77,6 +77,7 @@ func (r *soundboardSoundResource) Schema(_ context.Context, _ resource.SchemaReq
 			},
 			"volume": schema.Float64Attribute{
 				Optional: true,
+				Computed: true,
 				Default:  float64default.StaticFloat64(1.0),
 			},
 			"emoji_id": schema.StringAttribute{
@@ -97,18 +98,24 @@ func (r *soundboardSoundResource) Schema(_ context.Context, _ resource.SchemaReq
 			"available": schema.BoolAttribute{
 				Computed: true,
 			},
-			"reason": schema.StringAttribute{
-				Optional:  true,
-				Sensitive: true,
-				PlanModifiers: []planmodifier.String{
-					planmod.IgnoreChangesString(),
-				},
-				Description: "Optional audit log reason (X-Audit-Log-Reason). This value is not readable.",
-			},
+			"reason": reasonAttribute(),
 		},
 	}
 }
 
+var soundboardSoundIdentity = resourceIdentity{
+	{"server_id", path.Root("server_id")},
+	{"sound_id", path.Root("id")},
+}
+
+func (r *soundboardSoundResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = soundboardSoundIdentity.schema()
+}
+
+func (r *soundboardSoundResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return writeOnlyStateUpgraders("reason")
+}
+
 func (r *soundboardSoundResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	c, diags := getContextFromProviderData(req.ProviderData)
 	resp.Diagnostics.Append(diags...)
@@ -116,6 +123,11 @@ func (r *soundboardSoundResource) Configure(ctx context.Context, req resource.Co
 		return
 	}
 	r.c = c.Rest
+	r.defaultServerID = c.Config.DefaultServerID
+}
+
+func (r *soundboardSoundResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	planServerID(ctx, r.defaultServerID, true, req, resp)
 }
 
 func (r *soundboardSoundResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
@@ -135,8 +147,13 @@ func (r *soundboardSoundResource) ValidateConfig(ctx context.Context, req resour
 }
 
 func (r *soundboardSoundResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := startResourceSpan(ctx, "discord_soundboard_sound", "create")
+	defer endResourceSpan(span, &resp.Diagnostics)
+	defer soundboardSoundIdentity.set(ctx, &resp.State, resp.Identity, &resp.Diagnostics)
+
 	var plan soundboardSoundResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("reason"), &plan.Reason)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -148,21 +165,17 @@ func (r *soundboardSoundResource) Create(ctx context.Context, req resource.Creat
 	}
 	snd := base64.StdEncoding.EncodeToString(b)
 
-	body := map[string]any{
-		"name":   plan.Name.ValueString(),
-		"sound":  snd,
-		"volume": plan.Volume.ValueFloat64(),
-	}
-	if v := plan.EmojiID.ValueString(); v != "" {
-		body["emoji_id"] = v
-	}
-	if v := plan.EmojiName.ValueString(); v != "" {
-		body["emoji_name"] = v
+	params := &discord.CreateGuildSoundboardSoundParams{
+		Name:      plan.Name.ValueString(),
+		Sound:     snd,
+		Volume:    discord.Ptr(plan.Volume.ValueFloat64()),
+		EmojiID:   fwutil.NonEmptyString(plan.EmojiID),
+		EmojiName: fwutil.NonEmptyString(plan.EmojiName),
 	}
 
-	var out restSoundboardSoundResource
-	if err := r.c.DoJSONWithReason(ctx, "POST", fmt.Sprintf("/guilds/%s/soundboard-sounds", plan.ServerID.ValueString()), nil, body, &out, plan.Reason.ValueString()); err != nil {
-		resp.Diagnostics.AddError("Discord API error", err.Error())
+	out, err := r.c.CreateGuildSoundboardSound(ctx, plan.ServerID.ValueString(), params, plan.Reason.ValueString())
+	if err != nil {
+		addDiscordAPIError(&resp.Diagnostics, err, soundboardSoundFieldPaths)
 		return
 	}
 
@@ -172,6 +185,10 @@ func (r *soundboardSoundResource) Create(ctx context.Context, req resource.Creat
 }
 
 func (r *soundboardSoundResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := startResourceSpan(ctx, "discord_soundboard_sound", "read")
+	defer endResourceSpan(span, &resp.Diagnostics)
+	defer soundboardSoundIdentity.set(ctx, &resp.State, resp.Identity, &resp.Diagnostics)
+
 	var state soundboardSoundResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
@@ -197,8 +214,8 @@ func (r *soundboardSoundResource) readIntoState(ctx context.Context, state *soun
 		return
 	}
 
-	var out restSoundboardSoundResource
-	if err := r.c.DoJSON(ctx, "GET", fmt.Sprintf("/guilds/%s/soundboard-sounds/%s", serverID, soundID), nil, nil, &out); err != nil {
+	out, err := r.c.GetGuildSoundboardSound(ctx, serverID, soundID)
+	if err != nil {
 		if discord.IsDiscordHTTPStatus(err, 404) {
 			state.ID = types.StringNull()
 			return
@@ -209,48 +226,47 @@ func (r *soundboardSoundResource) readIntoState(ctx context.Context, state *soun
 
 	state.Name = types.StringValue(out.Name)
 	state.Volume = types.Float64Value(out.Volume)
-	state.EmojiID = types.StringValue(out.EmojiID)
-	state.EmojiName = types.StringValue(out.EmojiName)
+	state.EmojiID = fwutil.KeepNullString(state.EmojiID, out.EmojiID)
+	state.EmojiName = fwutil.KeepNullString(state.EmojiName, out.EmojiName)
 	state.Available = types.BoolValue(out.Available)
 }
 
 func (r *soundboardSoundResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := startResourceSpan(ctx, "discord_soundboard_sound", "update")
+	defer endResourceSpan(span, &resp.Diagnostics)
+	defer soundboardSoundIdentity.set(ctx, &resp.State, resp.Identity, &resp.Diagnostics)
+
 	var plan soundboardSoundResourceModel
 	var prior soundboardSoundResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("reason"), &plan.Reason)...)
 	resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	body := map[string]any{}
+	params := &discord.ModifyGuildSoundboardSoundParams{}
+	changed := false
 	if plan.Name.ValueString() != prior.Name.ValueString() {
-		body["name"] = plan.Name.ValueString()
+		params.Name = discord.Ptr(plan.Name.ValueString())
+		changed = true
 	}
 	if !plan.Volume.IsNull() && !prior.Volume.IsNull() && plan.Volume.ValueFloat64() != prior.Volume.ValueFloat64() {
-		body["volume"] = plan.Volume.ValueFloat64()
+		params.Volume = discord.Ptr(plan.Volume.ValueFloat64())
+		changed = true
 	}
 	if plan.EmojiID.ValueString() != prior.EmojiID.ValueString() {
-		v := plan.EmojiID.ValueString()
-		if v == "" {
-			body["emoji_id"] = nil
-		} else {
-			body["emoji_id"] = v
-		}
+		params.EmojiID = discord.NullIfEmpty(plan.EmojiID.ValueString())
+		changed = true
 	}
 	if plan.EmojiName.ValueString() != prior.EmojiName.ValueString() {
-		v := plan.EmojiName.ValueString()
-		if v == "" {
-			body["emoji_name"] = nil
-		} else {
-			body["emoji_name"] = v
-		}
+		params.EmojiName = discord.NullIfEmpty(plan.EmojiName.ValueString())
+		changed = true
 	}
 
-	if len(body) > 0 {
-		var out restSoundboardSoundResource
-		if err := r.c.DoJSONWithReason(ctx, "PATCH", fmt.Sprintf("/guilds/%s/soundboard-sounds/%s", plan.ServerID.ValueString(), plan.ID.ValueString()), nil, body, &out, plan.Reason.ValueString()); err != nil {
-			resp.Diagnostics.AddError("Discord API error", err.Error())
+	if changed {
+		if _, err := r.c.ModifyGuildSoundboardSound(ctx, plan.ServerID.ValueString(), plan.ID.ValueString(), params, plan.Reason.ValueString()); err != nil {
+			addDiscordAPIError(&resp.Diagnostics, err, soundboardSoundFieldPaths)
 			return
 		}
 	}
@@ -261,13 +277,16 @@ func (r *soundboardSoundResource) Update(ctx context.Context, req resource.Updat
 }
 
 func (r *soundboardSoundResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := startResourceSpan(ctx, "discord_soundboard_sound", "delete")
+	defer endResourceSpan(span, &resp.Diagnostics)
+
 	var state soundboardSoundResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	if err := r.c.DoJSONWithReason(ctx, "DELETE", fmt.Sprintf("/guilds/%s/soundboard-sounds/%s", state.ServerID.ValueString(), state.ID.ValueString()), nil, nil, nil, state.Reason.ValueString()); err != nil {
+	if err := r.c.DeleteGuildSoundboardSound(ctx, state.ServerID.ValueString(), state.ID.ValueString(), ""); err != nil {
 		if discord.IsDiscordHTTPStatus(err, 404) {
 			resp.State.RemoveResource(ctx)
 			return
@@ -279,8 +298,12 @@ func (r *soundboardSoundResource) Delete(ctx context.Context, req resource.Delet
 }
 
 func (r *soundboardSoundResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	id := soundboardSoundIdentity.importID(ctx, req, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	// Import format: server_id:sound_id
-	serverID, soundID, err := fwutil.ParseTwoIDs(req.ID)
+	serverID, soundID, err := fwutil.ParseTwoIDs(id)
 	if err != nil {
 		resp.Diagnostics.AddError("Invalid import ID", "Expected server_id:sound_id")
 		return
@@ -2,10 +2,9 @@ package fw
 
 import (
 	"context"
-	"fmt"
 
 	"github.com/45ck/terraform-provider-discord/discord"
-	"github.com/45ck/terraform-provider-discord/internal/fw/planmod"
+	"github.com/45ck/terraform-provider-discord/internal/fw/fwutil"
 	"github.com/45ck/terraform-provider-discord/internal/fw/validate"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -22,19 +21,13 @@ func NewStageInstanceResource() resource.Resource {
 	return &stageInstanceResource{}
 }
 
+// stageInstanceFieldPaths maps Discord request fields to schema attributes for per-attribute errors.
+var stageInstanceFieldPaths = sameNameFieldPaths("channel_id", "topic", "privacy_level", "send_start_notification")
+
 type stageInstanceResource struct {
 	c *discord.RestClient
 }
 
-type restStageInstance struct {
-	ID                    string `json:"id"`
-	ChannelID             string `json:"channel_id"`
-	GuildID               string `json:"guild_id"`
-	Topic                 string `json:"topic"`
-	PrivacyLevel          int    `json:"privacy_level"`
-	GuildScheduledEventID string `json:"guild_scheduled_event_id"`
-}
-
 type stageInstanceModel struct {
 	ID types.String `tfsdk:"id"`
 
@@ -57,8 +50,9 @@ func (r *stageInstanceResource) Metadata(_ context.Context, req resource.Metadat
 
 func (r *stageInstanceResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Version: 1,
 		Attributes: map[string]schema.Attribute{
-			"id": schema.StringAttribute{Computed: true},
+			"id": schema.StringAttribute{Computed: true, PlanModifiers: stringUseState},
 			"channel_id": schema.StringAttribute{
 				Required: true,
 				PlanModifiers: []planmodifier.String{
@@ -74,6 +68,7 @@ func (r *stageInstanceResource) Schema(_ context.Context, _ resource.SchemaReque
 			},
 			"privacy_level": schema.Int64Attribute{
 				Optional: true,
+				Computed: true,
 				Default:  int64default.StaticInt64(2),
 			},
 			"send_start_notification": schema.BoolAttribute{
@@ -94,18 +89,23 @@ func (r *stageInstanceResource) Schema(_ context.Context, _ resource.SchemaReque
 			"server_id": schema.StringAttribute{
 				Computed: true,
 			},
-			"reason": schema.StringAttribute{
-				Optional:  true,
-				Sensitive: true,
-				PlanModifiers: []planmodifier.String{
-					planmod.IgnoreChangesString(),
-				},
-				Description: "Optional audit log reason (X-Audit-Log-Reason). This value is not readable.",
-			},
+			"reason": reasonAttribute(),
 		},
 	}
 }
 
+var stageInstanceIdentity = resourceIdentity{
+	{"channel_id", path.Root("channel_id")},
+}
+
+func (r *stageInstanceResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = stageInstanceIdentity.schema()
+}
+
+func (r *stageInstanceResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return writeOnlyStateUpgraders("reason")
+}
+
 func (r *stageInstanceResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	c, diags := getContextFromProviderData(req.ProviderData)
 	resp.Diagnostics.Append(diags...)
@@ -116,27 +116,27 @@ func (r *stageInstanceResource) Configure(ctx context.Context, req resource.Conf
 }
 
 func (r *stageInstanceResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := startResourceSpan(ctx, "discord_stage_instance", "create")
+	defer endResourceSpan(span, &resp.Diagnostics)
+	defer stageInstanceIdentity.set(ctx, &resp.State, resp.Identity, &resp.Diagnostics)
+
 	var plan stageInstanceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("reason"), &plan.Reason)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	body := map[string]any{
-		"channel_id":    plan.ChannelID.ValueString(),
-		"topic":         plan.Topic.ValueString(),
-		"privacy_level": int(plan.PrivacyLevel.ValueInt64()),
-	}
-	if !plan.SendStartNotification.IsNull() && !plan.SendStartNotification.IsUnknown() {
-		body["send_start_notification"] = plan.SendStartNotification.ValueBool()
-	}
-	if v := plan.ScheduledEventID.ValueString(); v != "" {
-		body["guild_scheduled_event_id"] = v
+	params := &discord.CreateStageInstanceParams{
+		ChannelID:             plan.ChannelID.ValueString(),
+		Topic:                 plan.Topic.ValueString(),
+		PrivacyLevel:          fwutil.OptionalInt(plan.PrivacyLevel),
+		SendStartNotification: fwutil.OptionalBool(plan.SendStartNotification),
+		GuildScheduledEventID: fwutil.NonEmptyString(plan.ScheduledEventID),
 	}
 
-	var out restStageInstance
-	if err := r.c.DoJSONWithReason(ctx, "POST", "/stage-instances", nil, body, &out, plan.Reason.ValueString()); err != nil {
-		resp.Diagnostics.AddError("Discord API error", err.Error())
+	if _, err := r.c.CreateStageInstance(ctx, params, plan.Reason.ValueString()); err != nil {
+		addDiscordAPIError(&resp.Diagnostics, err, stageInstanceFieldPaths)
 		return
 	}
 
@@ -146,6 +146,10 @@ func (r *stageInstanceResource) Create(ctx context.Context, req resource.CreateR
 }
 
 func (r *stageInstanceResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := startResourceSpan(ctx, "discord_stage_instance", "read")
+	defer endResourceSpan(span, &resp.Diagnostics)
+	defer stageInstanceIdentity.set(ctx, &resp.State, resp.Identity, &resp.Diagnostics)
+
 	var state stageInstanceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
@@ -173,8 +177,8 @@ func (r *stageInstanceResource) readIntoState(ctx context.Context, state *stageI
 		return
 	}
 
-	var out restStageInstance
-	if err := r.c.DoJSON(ctx, "GET", fmt.Sprintf("/stage-instances/%s", channelID), nil, nil, &out); err != nil {
+	out, err := r.c.GetStageInstance(ctx, channelID)
+	if err != nil {
 		if discord.IsDiscordHTTPStatus(err, 404) {
 			state.ID = types.StringNull()
 			return
@@ -187,30 +191,38 @@ func (r *stageInstanceResource) readIntoState(ctx context.Context, state *stageI
 	state.ChannelID = types.StringValue(out.ChannelID)
 	state.Topic = types.StringValue(out.Topic)
 	state.PrivacyLevel = types.Int64Value(int64(out.PrivacyLevel))
-	state.ScheduledEventID = types.StringValue(out.GuildScheduledEventID)
+	state.ScheduledEventID = fwutil.KeepNullString(state.ScheduledEventID, out.GuildScheduledEventID)
 	state.ServerID = types.StringValue(out.GuildID)
 }
 
 func (r *stageInstanceResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := startResourceSpan(ctx, "discord_stage_instance", "update")
+	defer endResourceSpan(span, &resp.Diagnostics)
+	defer stageInstanceIdentity.set(ctx, &resp.State, resp.Identity, &resp.Diagnostics)
+
 	var plan stageInstanceModel
 	var prior stageInstanceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("reason"), &plan.Reason)...)
 	resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	body := map[string]any{}
+	params := &discord.ModifyStageInstanceParams{}
+	changed := false
 	if plan.Topic.ValueString() != prior.Topic.ValueString() {
-		body["topic"] = plan.Topic.ValueString()
+		params.Topic = discord.Ptr(plan.Topic.ValueString())
+		changed = true
 	}
 	if !plan.PrivacyLevel.IsNull() && !prior.PrivacyLevel.IsNull() && plan.PrivacyLevel.ValueInt64() != prior.PrivacyLevel.ValueInt64() {
-		body["privacy_level"] = int(plan.PrivacyLevel.ValueInt64())
+		params.PrivacyLevel = discord.Ptr(int(plan.PrivacyLevel.ValueInt64()))
+		changed = true
 	}
 
-	if len(body) > 0 {
-		if err := r.c.DoJSONWithReason(ctx, "PATCH", fmt.Sprintf("/stage-instances/%s", prior.ChannelID.ValueString()), nil, body, nil, plan.Reason.ValueString()); err != nil {
-			resp.Diagnostics.AddError("Discord API error", err.Error())
+	if changed {
+		if _, err := r.c.ModifyStageInstance(ctx, prior.ChannelID.ValueString(), params, plan.Reason.ValueString()); err != nil {
+			addDiscordAPIError(&resp.Diagnostics, err, stageInstanceFieldPaths)
 			return
 		}
 	}
@@ -221,6 +233,9 @@ func (r *stageInstanceResource) Update(ctx context.Context, req resource.UpdateR
 }
 
 func (r *stageInstanceResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := startResourceSpan(ctx, "discord_stage_instance", "delete")
+	defer endResourceSpan(span, &resp.Diagnostics)
+
 	var state stageInstanceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
@@ -232,7 +247,7 @@ func (r *stageInstanceResource) Delete(ctx context.Context, req resource.DeleteR
 		channelID = state.ID.ValueString()
 	}
 
-	if err := r.c.DoJSONWithReason(ctx, "DELETE", fmt.Sprintf("/stage-instances/%s", channelID), nil, nil, nil, state.Reason.ValueString()); err != nil {
+	if err := r.c.DeleteStageInstance(ctx, channelID, ""); err != nil {
 		if discord.IsDiscordHTTPStatus(err, 404) {
 			resp.State.RemoveResource(ctx)
 			return
@@ -244,7 +259,11 @@ func (r *stageInstanceResource) Delete(ctx context.Context, req resource.DeleteR
 }
 
 func (r *stageInstanceResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	id := stageInstanceIdentity.importID(ctx, req, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	// Import ID is the channel_id.
-	resource.ImportStatePassthroughID(ctx, path.Root("channel_id"), req, resp)
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("channel_id"), id)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
 }
@@ -2,13 +2,11 @@ package fw
 
 import (
 	"context"
-	"fmt"
 	"os"
 	"path/filepath"
 
 	"github.com/45ck/terraform-provider-discord/discord"
 	"github.com/45ck/terraform-provider-discord/internal/fw/fwutil"
-	"github.com/45ck/terraform-provider-discord/internal/fw/planmod"
 	"github.com/45ck/terraform-provider-discord/internal/fw/validate"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -23,16 +21,12 @@ func NewStickerResource() resource.Resource {
 	return &stickerResource{}
 }
 
-type stickerResource struct {
-	c *discord.RestClient
-}
+// stickerFieldPaths maps Discord request fields to schema attributes for per-attribute errors.
+var stickerFieldPaths = sameNameFieldPaths("name", "description", "tags")
 
-type restSticker struct {
-	ID          string `json:"id"`
-	Name        string `json:"name"`
-	Description string `json:"description"`
-	Tags        string `json:"tags"`
-	FormatType  int    `json:"format_type"`
+type stickerResource struct {
+	c               *discord.RestClient
+	defaultServerID string
 }
 
 type stickerResourceModel struct {
@@ -56,11 +50,15 @@ func (r *stickerResource) Metadata(_ context.Context, req resource.MetadataReque
 
 func (r *stickerResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Version: 1,
 		Attributes: map[string]schema.Attribute{
-			"id": schema.StringAttribute{Computed: true},
+			"id": schema.StringAttribute{Computed: true, PlanModifiers: stringUseState},
 			"server_id": schema.StringAttribute{
-				Required: true,
+				Optional:    true,
+				Computed:    true,
+				Description: serverIDDescription,
 				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
 					stringplanmodifier.RequiresReplace(),
 				},
 				Validators: []validator.String{
@@ -88,18 +86,24 @@ func (r *stickerResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 			"format_type": schema.Int64Attribute{
 				Computed: true,
 			},
-			"reason": schema.StringAttribute{
-				Optional:  true,
-				Sensitive: true,
-				PlanModifiers: []planmodifier.String{
-					planmod.IgnoreChangesString(),
-				},
-				Description: "Optional audit log reason (X-Audit-Log-Reason). This value is not readable.",
-			},
+			"reason": reasonAttribute(),
 		},
 	}
 }
 
+var stickerIdentity = resourceIdentity{
+	{"server_id", path.Root("server_id")},
+	{"sticker_id", path.Root("id")},
+}
+
+func (r *stickerResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = stickerIdentity.schema()
+}
+
+func (r *stickerResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return writeOnlyStateUpgraders("reason")
+}
+
 func (r *stickerResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	c, diags := getContextFromProviderData(req.ProviderData)
 	resp.Diagnostics.Append(diags...)
@@ -107,11 +111,21 @@ func (r *stickerResource) Configure(ctx context.Context, req resource.ConfigureR
 		return
 	}
 	r.c = c.Rest
+	r.defaultServerID = c.Config.DefaultServerID
+}
+
+func (r *stickerResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	planServerID(ctx, r.defaultServerID, true, req, resp)
 }
 
 func (r *stickerResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := startResourceSpan(ctx, "discord_sticker", "create")
+	defer endResourceSpan(span, &resp.Diagnostics)
+	defer stickerIdentity.set(ctx, &resp.State, resp.Identity, &resp.Diagnostics)
+
 	var plan stickerResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("reason"), &plan.Reason)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -123,15 +137,17 @@ func (r *stickerResource) Create(ctx context.Context, req resource.CreateRequest
 		return
 	}
 
-	fields := map[string]string{
-		"name":        plan.Name.ValueString(),
-		"description": plan.Description.ValueString(),
-		"tags":        plan.Tags.ValueString(),
+	params := &discord.CreateStickerParams{
+		Name:        plan.Name.ValueString(),
+		Description: plan.Description.ValueString(),
+		Tags:        plan.Tags.ValueString(),
+		FileName:    filepath.Base(p),
+		File:        b,
 	}
 
-	var out restSticker
-	if err := r.c.DoMultipartWithReason(ctx, "POST", fmt.Sprintf("/guilds/%s/stickers", plan.ServerID.ValueString()), nil, fields, "file", filepath.Base(p), b, &out, plan.Reason.ValueString()); err != nil {
-		resp.Diagnostics.AddError("Discord API error", err.Error())
+	out, err := r.c.CreateGuildSticker(ctx, plan.ServerID.ValueString(), params, plan.Reason.ValueString())
+	if err != nil {
+		addDiscordAPIError(&resp.Diagnostics, err, stickerFieldPaths)
 		return
 	}
 
@@ -141,6 +157,10 @@ func (r *stickerResource) Create(ctx context.Context, req resource.CreateRequest
 }
 
 func (r *stickerResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := startResourceSpan(ctx, "discord_sticker", "read")
+	defer endResourceSpan(span, &resp.Diagnostics)
+	defer stickerIdentity.set(ctx, &resp.State, resp.Identity, &resp.Diagnostics)
+
 	var state stickerResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
@@ -166,8 +186,8 @@ func (r *stickerResource) readIntoState(ctx context.Context, state *stickerResou
 		return
 	}
 
-	var out restSticker
-	if err := r.c.DoJSON(ctx, "GET", fmt.Sprintf("/guilds/%s/stickers/%s", serverID, stickerID), nil, nil, &out); err != nil {
+	out, err := r.c.GetGuildSticker(ctx, serverID, stickerID)
+	if err != nil {
 		if discord.IsDiscordHTTPStatus(err, 404) {
 			state.ID = types.StringNull()
 			return
@@ -177,35 +197,39 @@ func (r *stickerResource) readIntoState(ctx context.Context, state *stickerResou
 	}
 
 	state.Name = types.StringValue(out.Name)
-	state.Description = types.StringValue(out.Description)
+	state.Description = fwutil.KeepNullString(state.Description, out.Description)
 	state.Tags = types.StringValue(out.Tags)
 	state.FormatType = types.Int64Value(int64(out.FormatType))
 }
 
 func (r *stickerResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := startResourceSpan(ctx, "discord_sticker", "update")
+	defer endResourceSpan(span, &resp.Diagnostics)
+	defer stickerIdentity.set(ctx, &resp.State, resp.Identity, &resp.Diagnostics)
+
 	var plan stickerResourceModel
 	var prior stickerResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("reason"), &plan.Reason)...)
 	resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	body := map[string]any{}
+	params := &discord.ModifyStickerParams{}
 	if plan.Name.ValueString() != prior.Name.ValueString() {
-		body["name"] = plan.Name.ValueString()
+		params.Name = discord.Ptr(plan.Name.ValueString())
 	}
 	if plan.Description.ValueString() != prior.Description.ValueString() {
-		body["description"] = plan.Description.ValueString()
+		params.Description = discord.Ptr(plan.Description.ValueString())
 	}
 	if plan.Tags.ValueString() != prior.Tags.ValueString() {
-		body["tags"] = plan.Tags.ValueString()
+		params.Tags = discord.Ptr(plan.Tags.ValueString())
 	}
 
-	if len(body) > 0 {
-		var out restSticker
-		if err := r.c.DoJSONWithReason(ctx, "PATCH", fmt.Sprintf("/guilds/%s/stickers/%s", plan.ServerID.ValueString(), plan.ID.ValueString()), nil, body, &out, plan.Reason.ValueString()); err != nil {
-			resp.Diagnostics.AddError("Discord API error", err.Error())
+	if *params != (discord.ModifyStickerParams{}) {
+		if _, err := r.c.ModifyGuildSticker(ctx, plan.ServerID.ValueString(), plan.ID.ValueString(), params, plan.Reason.ValueString()); err != nil {
+			addDiscordAPIError(&resp.Diagnostics, err, stickerFieldPaths)
 			return
 		}
 	}
@@ -216,13 +240,16 @@ func (r *stickerResource) Update(ctx context.Context, req resource.UpdateRequest
 }
 
 func (r *stickerResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := startResourceSpan(ctx, "discord_sticker", "delete")
+	defer endResourceSpan(span, &resp.Diagnostics)
+
 	var state stickerResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	if err := r.c.DoJSONWithReason(ctx, "DELETE", fmt.Sprintf("/guilds/%s/stickers/%s", state.ServerID.ValueString(), state.ID.ValueString()), nil, nil, nil, state.Reason.ValueString()); err != nil {
+	if err := r.c.DeleteGuildSticker(ctx, state.ServerID.ValueString(), state.ID.ValueString(), ""); err != nil {
 		if discord.IsDiscordHTTPStatus(err, 404) {
 			resp.State.RemoveResource(ctx)
 			return
@@ -234,8 +261,12 @@ func (r *stickerResource) Delete(ctx context.Context, req resource.DeleteRequest
 }
 
 func (r *stickerResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	id := stickerIdentity.importID(ctx, req, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	// Import format: server_id:sticker_id
-	serverID, stickerID, err := fwutil.ParseTwoIDs(req.ID)
+	serverID, stickerID, err := fwutil.ParseTwoIDs(id)
 	if err != nil {
 		resp.Diagnostics.AddError("Invalid import ID", "Expected server_id:sticker_id")
 		return
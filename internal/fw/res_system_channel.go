@@ -2,10 +2,8 @@ package fw
 
 import (
 	"context"
-	"fmt"
 
 	"github.com/45ck/terraform-provider-discord/discord"
-	"github.com/45ck/terraform-provider-discord/internal/fw/planmod"
 	"github.com/45ck/terraform-provider-discord/internal/fw/validate"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -21,7 +19,8 @@ func NewSystemChannelResource() resource.Resource {
 }
 
 type systemChannelResource struct {
-	c *discord.RestClient
+	c               *discord.RestClient
+	defaultServerID string
 }
 
 type systemChannelResourceModel struct {
@@ -32,22 +31,21 @@ type systemChannelResourceModel struct {
 	Reason          types.String `tfsdk:"reason"`
 }
 
-type restGuildForSystemChannel struct {
-	ID              string `json:"id"`
-	SystemChannelID string `json:"system_channel_id"`
-}
-
 func (r *systemChannelResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_system_channel"
 }
 
 func (r *systemChannelResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Version: 1,
 		Attributes: map[string]schema.Attribute{
-			"id": schema.StringAttribute{Computed: true},
+			"id": schema.StringAttribute{Computed: true, PlanModifiers: stringUseState},
 			"server_id": schema.StringAttribute{
-				Required: true,
+				Optional:    true,
+				Computed:    true,
+				Description: serverIDDescription,
 				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
 					stringplanmodifier.RequiresReplace(),
 				},
 				Validators: []validator.String{
@@ -60,17 +58,23 @@ func (r *systemChannelResource) Schema(_ context.Context, _ resource.SchemaReque
 					validate.Snowflake(),
 				},
 			},
-			"reason": schema.StringAttribute{
-				Optional:  true,
-				Sensitive: true,
-				PlanModifiers: []planmodifier.String{
-					planmod.IgnoreChangesString(),
-				},
-			},
+			"reason": reasonAttribute(),
 		},
 	}
 }
 
+var systemChannelIdentity = resourceIdentity{
+	{"server_id", path.Root("server_id")},
+}
+
+func (r *systemChannelResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = systemChannelIdentity.schema()
+}
+
+func (r *systemChannelResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return writeOnlyStateUpgraders("reason")
+}
+
 func (r *systemChannelResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	c, diags := getContextFromProviderData(req.ProviderData)
 	resp.Diagnostics.Append(diags...)
@@ -78,19 +82,29 @@ func (r *systemChannelResource) Configure(ctx context.Context, req resource.Conf
 		return
 	}
 	r.c = c.Rest
+	r.defaultServerID = c.Config.DefaultServerID
+}
+
+func (r *systemChannelResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	planServerID(ctx, r.defaultServerID, true, req, resp)
 }
 
 func (r *systemChannelResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := startResourceSpan(ctx, "discord_system_channel", "create")
+	defer endResourceSpan(span, &resp.Diagnostics)
+	defer systemChannelIdentity.set(ctx, &resp.State, resp.Identity, &resp.Diagnostics)
+
 	var plan systemChannelResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("reason"), &plan.Reason)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	body := map[string]any{
-		"system_channel_id": plan.SystemChannelID.ValueString(),
+	params := &discord.ModifyGuildParams{
+		SystemChannelID: discord.NullIfEmpty(plan.SystemChannelID.ValueString()),
 	}
-	if err := r.c.DoJSONWithReason(ctx, "PATCH", fmt.Sprintf("/guilds/%s", plan.ServerID.ValueString()), nil, body, nil, plan.Reason.ValueString()); err != nil {
+	if _, err := r.c.ModifyGuild(ctx, plan.ServerID.ValueString(), params, plan.Reason.ValueString()); err != nil {
 		resp.Diagnostics.AddError("Discord API error", err.Error())
 		return
 	}
@@ -101,16 +115,21 @@ func (r *systemChannelResource) Create(ctx context.Context, req resource.CreateR
 }
 
 func (r *systemChannelResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := startResourceSpan(ctx, "discord_system_channel", "update")
+	defer endResourceSpan(span, &resp.Diagnostics)
+	defer systemChannelIdentity.set(ctx, &resp.State, resp.Identity, &resp.Diagnostics)
+
 	var plan systemChannelResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("reason"), &plan.Reason)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	body := map[string]any{
-		"system_channel_id": plan.SystemChannelID.ValueString(),
+	params := &discord.ModifyGuildParams{
+		SystemChannelID: discord.NullIfEmpty(plan.SystemChannelID.ValueString()),
 	}
-	if err := r.c.DoJSONWithReason(ctx, "PATCH", fmt.Sprintf("/guilds/%s", plan.ServerID.ValueString()), nil, body, nil, plan.Reason.ValueString()); err != nil {
+	if _, err := r.c.ModifyGuild(ctx, plan.ServerID.ValueString(), params, plan.Reason.ValueString()); err != nil {
 		resp.Diagnostics.AddError("Discord API error", err.Error())
 		return
 	}
@@ -121,6 +140,10 @@ func (r *systemChannelResource) Update(ctx context.Context, req resource.UpdateR
 }
 
 func (r *systemChannelResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := startResourceSpan(ctx, "discord_system_channel", "read")
+	defer endResourceSpan(span, &resp.Diagnostics)
+	defer systemChannelIdentity.set(ctx, &resp.State, resp.Identity, &resp.Diagnostics)
+
 	var state systemChannelResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
@@ -144,8 +167,8 @@ func (r *systemChannelResource) readIntoState(ctx context.Context, state *system
 		serverID = state.ServerID.ValueString()
 	}
 
-	var guild restGuildForSystemChannel
-	if err := r.c.DoJSON(ctx, "GET", "/guilds/"+serverID, nil, nil, &guild); err != nil {
+	guild, err := r.c.GetGuild(ctx, serverID)
+	if err != nil {
 		if discord.IsDiscordHTTPStatus(err, 404) {
 			state.ID = types.StringNull()
 			return
@@ -160,16 +183,17 @@ func (r *systemChannelResource) readIntoState(ctx context.Context, state *system
 }
 
 func (r *systemChannelResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := startResourceSpan(ctx, "discord_system_channel", "delete")
+	defer endResourceSpan(span, &resp.Diagnostics)
+
 	var state systemChannelResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	body := map[string]any{
-		"system_channel_id": nil,
-	}
-	if err := r.c.DoJSONWithReason(ctx, "PATCH", fmt.Sprintf("/guilds/%s", state.ServerID.ValueString()), nil, body, nil, state.Reason.ValueString()); err != nil {
+	params := &discord.ModifyGuildParams{SystemChannelID: discord.Null[string]()}
+	if _, err := r.c.ModifyGuild(ctx, state.ServerID.ValueString(), params, ""); err != nil {
 		if discord.IsDiscordHTTPStatus(err, 404) {
 			resp.State.RemoveResource(ctx)
 			return
@@ -182,6 +206,10 @@ func (r *systemChannelResource) Delete(ctx context.Context, req resource.DeleteR
 }
 
 func (r *systemChannelResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("server_id"), req, resp)
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	id := systemChannelIdentity.importID(ctx, req, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("server_id"), id)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
 }
@@ -0,0 +1,67 @@
+package fw
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestSystemChannelResource_Fake(t *testing.T) {
+	e := newFakeEnv(t)
+	channels := fmt.Sprintf(`
+resource "discord_channel" "a" {
+  server_id = %[1]q
+  type      = "text"
+  name      = "welcome"
+}
+
+resource "discord_channel" "b" {
+  server_id = %[1]q
+  type      = "text"
+  name      = "joins"
+}
+`, e.guildID)
+
+	e.test(t, resource.TestCase{
+		// Destroying the resource unsets the guild's system channel.
+		CheckDestroy: func(*terraform.State) error {
+			g, err := e.client().GetGuild(context.Background(), e.guildID)
+			if err != nil {
+				return err
+			}
+			if g.SystemChannelID != "" {
+				return fmt.Errorf("system channel is still %s", g.SystemChannelID)
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: e.config(`%s
+resource "discord_system_channel" "s" {
+  server_id         = %q
+  system_channel_id = discord_channel.a.id
+}
+`, channels, e.guildID),
+				Check: resource.TestCheckResourceAttrPair("discord_system_channel.s", "system_channel_id", "discord_channel.a", "id"),
+			},
+			{
+				Config: e.config(`%s
+resource "discord_system_channel" "s" {
+  server_id         = %q
+  system_channel_id = discord_channel.b.id
+}
+`, channels, e.guildID),
+				Check: resource.TestCheckResourceAttrPair("discord_system_channel.s", "system_channel_id", "discord_channel.b", "id"),
+			},
+			{
+				ResourceName:      "discord_system_channel.s",
+				ImportState:       true,
+				ImportStateId:     e.guildID,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/45ck/terraform-provider-discord/discord"
+	"github.com/45ck/terraform-provider-discord/internal/fw/fwutil"
 	"github.com/45ck/terraform-provider-discord/internal/fw/planmod"
 	"github.com/45ck/terraform-provider-discord/internal/fw/validate"
 	"github.com/hashicorp/terraform-plugin-framework/path"
@@ -23,28 +24,13 @@ func NewThreadResource() resource.Resource {
 	return &threadResource{}
 }
 
+// threadFieldPaths maps Discord request fields to schema attributes for per-attribute errors.
+var threadFieldPaths = sameNameFieldPaths("name", "auto_archive_duration", "invitable", "rate_limit_per_user", "archived", "locked", "applied_tags")
+
 type threadResource struct {
 	c *discord.RestClient
 }
 
-type restThreadMetadata struct {
-	Archived       bool `json:"archived"`
-	AutoArchiveDur int  `json:"auto_archive_duration"`
-	Locked         bool `json:"locked"`
-	Invitable      bool `json:"invitable"`
-}
-
-type restThreadChannel struct {
-	ID             string              `json:"id"`
-	GuildID        string              `json:"guild_id"`
-	ParentID       string              `json:"parent_id"`
-	Name           string              `json:"name"`
-	Type           uint                `json:"type"`
-	RateLimit      int                 `json:"rate_limit_per_user"`
-	ThreadMetadata *restThreadMetadata `json:"thread_metadata"`
-	AppliedTags    []string            `json:"applied_tags"`
-}
-
 type threadModel struct {
 	ID types.String `tfsdk:"id"`
 
@@ -75,8 +61,9 @@ func (r *threadResource) Metadata(_ context.Context, req resource.MetadataReques
 
 func (r *threadResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Version: 1,
 		Attributes: map[string]schema.Attribute{
-			"id": schema.StringAttribute{Computed: true},
+			"id": schema.StringAttribute{Computed: true, PlanModifiers: stringUseState},
 			"channel_id": schema.StringAttribute{
 				Required: true,
 				PlanModifiers: []planmodifier.String{
@@ -99,6 +86,7 @@ func (r *threadResource) Schema(_ context.Context, _ resource.SchemaRequest, res
 			},
 			"type": schema.StringAttribute{
 				Optional:    true,
+				Computed:    true,
 				Default:     stringdefault.StaticString("public_thread"),
 				Description: "Thread type: public_thread, private_thread, announcement_thread.",
 				Validators: []validator.String{
@@ -109,24 +97,34 @@ func (r *threadResource) Schema(_ context.Context, _ resource.SchemaRequest, res
 				Required: true,
 			},
 			"auto_archive_duration": schema.Int64Attribute{
-				Optional:    true,
-				Description: "Minutes: 60, 1440, 4320, 10080.",
+				Optional:      true,
+				Computed:      true,
+				PlanModifiers: int64UseState,
+				Description:   "Minutes: 60, 1440, 4320, 10080.",
 			},
 			"invitable": schema.BoolAttribute{
-				Optional:    true,
-				Description: "Private thread invite permission.",
+				Optional:      true,
+				Computed:      true,
+				PlanModifiers: boolUseState,
+				Description:   "Private thread invite permission.",
 			},
 			"rate_limit_per_user": schema.Int64Attribute{
-				Optional:    true,
-				Description: "Thread slowmode in seconds.",
+				Optional:      true,
+				Computed:      true,
+				PlanModifiers: int64UseState,
+				Description:   "Thread slowmode in seconds.",
 			},
 			"archived": schema.BoolAttribute{
-				Optional:    true,
-				Description: "Whether the thread is archived.",
+				Optional:      true,
+				Computed:      true,
+				PlanModifiers: boolUseState,
+				Description:   "Whether the thread is archived.",
 			},
 			"locked": schema.BoolAttribute{
-				Optional:    true,
-				Description: "Whether the thread is locked.",
+				Optional:      true,
+				Computed:      true,
+				PlanModifiers: boolUseState,
+				Description:   "Whether the thread is locked.",
 			},
 			"applied_tags": schema.SetAttribute{
 				Optional:    true,
@@ -221,18 +219,23 @@ func (r *threadResource) Schema(_ context.Context, _ resource.SchemaRequest, res
 					},
 				},
 			},
-			"reason": schema.StringAttribute{
-				Optional:  true,
-				Sensitive: true,
-				PlanModifiers: []planmodifier.String{
-					planmod.IgnoreChangesString(),
-				},
-				Description: "Optional audit log reason (X-Audit-Log-Reason). This value is not readable.",
-			},
+			"reason": reasonAttribute(),
 		},
 	}
 }
 
+var threadIdentity = resourceIdentity{
+	{"thread_id", path.Root("id")},
+}
+
+func (r *threadResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = threadIdentity.schema()
+}
+
+func (r *threadResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return writeOnlyStateUpgraders("reason")
+}
+
 func (r *threadResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	c, diags := getContextFromProviderData(req.ProviderData)
 	resp.Diagnostics.Append(diags...)
@@ -243,8 +246,13 @@ func (r *threadResource) Configure(ctx context.Context, req resource.ConfigureRe
 }
 
 func (r *threadResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := startResourceSpan(ctx, "discord_thread", "create")
+	defer endResourceSpan(span, &resp.Diagnostics)
+	defer threadIdentity.set(ctx, &resp.State, resp.Identity, &resp.Diagnostics)
+
 	var plan threadModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("reason"), &plan.Reason)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -262,18 +270,12 @@ func (r *threadResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
-	body := map[string]any{
-		"name": plan.Name.ValueString(),
-		"type": int(typ),
-	}
-	if !plan.AutoArchiveDuration.IsNull() && !plan.AutoArchiveDuration.IsUnknown() {
-		body["auto_archive_duration"] = int(plan.AutoArchiveDuration.ValueInt64())
-	}
-	if !plan.Invitable.IsNull() && !plan.Invitable.IsUnknown() {
-		body["invitable"] = plan.Invitable.ValueBool()
-	}
-	if !plan.RateLimitPerUser.IsNull() && !plan.RateLimitPerUser.IsUnknown() {
-		body["rate_limit_per_user"] = int(plan.RateLimitPerUser.ValueInt64())
+	params := &discord.StartThreadParams{
+		Name:                plan.Name.ValueString(),
+		Type:                &typ,
+		AutoArchiveDuration: fwutil.OptionalInt(plan.AutoArchiveDuration),
+		Invitable:           fwutil.OptionalBool(plan.Invitable),
+		RateLimitPerUser:    fwutil.OptionalInt(plan.RateLimitPerUser),
 	}
 	if !plan.AppliedTags.IsNull() && !plan.AppliedTags.IsUnknown() {
 		tags := []string{}
@@ -281,33 +283,31 @@ func (r *threadResource) Create(ctx context.Context, req resource.CreateRequest,
 		if resp.Diagnostics.HasError() {
 			return
 		}
-		body["applied_tags"] = tags
+		params.AppliedTags = tags
 	}
 
 	// Optional initial message (forum/media).
-	if !plan.Content.IsNull() && !plan.Content.IsUnknown() && plan.Content.ValueString() != "" {
-		msg := map[string]any{"content": plan.Content.ValueString()}
+	content := fwutil.NonEmptyString(plan.Content)
+	if content != nil || plan.Embed != nil {
+		params.Message = &discord.ForumThreadMessageParams{}
+		if content != nil {
+			params.Message.Content = *content
+		}
 		if plan.Embed != nil {
-			msg["embeds"] = []restEmbed{embedToRest(plan.Embed)}
+			params.Message.Embeds = []discord.Embed{expandEmbed(plan.Embed)}
 		}
-		body["message"] = msg
-	} else if plan.Embed != nil {
-		body["message"] = map[string]any{"embeds": []restEmbed{embedToRest(plan.Embed)}}
 	}
 
-	var out restThreadChannel
+	var out *discord.Channel
+	var err error
 	if !plan.MessageID.IsNull() && !plan.MessageID.IsUnknown() && plan.MessageID.ValueString() != "" {
-		path := fmt.Sprintf("/channels/%s/messages/%s/threads", parentID, plan.MessageID.ValueString())
-		if err := r.c.DoJSONWithReason(ctx, "POST", path, nil, body, &out, plan.Reason.ValueString()); err != nil {
-			resp.Diagnostics.AddError("Discord API error", err.Error())
-			return
-		}
+		out, err = r.c.StartThreadFromMessage(ctx, parentID, plan.MessageID.ValueString(), params, plan.Reason.ValueString())
 	} else {
-		path := fmt.Sprintf("/channels/%s/threads", parentID)
-		if err := r.c.DoJSONWithReason(ctx, "POST", path, nil, body, &out, plan.Reason.ValueString()); err != nil {
-			resp.Diagnostics.AddError("Discord API error", err.Error())
-			return
-		}
+		out, err = r.c.StartThread(ctx, parentID, params, plan.Reason.ValueString())
+	}
+	if err != nil {
+		addDiscordAPIError(&resp.Diagnostics, err, threadFieldPaths)
+		return
 	}
 
 	plan.ID = types.StringValue(out.ID)
@@ -316,6 +316,10 @@ func (r *threadResource) Create(ctx context.Context, req resource.CreateRequest,
 }
 
 func (r *threadResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := startResourceSpan(ctx, "discord_thread", "read")
+	defer endResourceSpan(span, &resp.Diagnostics)
+	defer threadIdentity.set(ctx, &resp.State, resp.Identity, &resp.Diagnostics)
+
 	var state threadModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
@@ -334,8 +338,8 @@ func (r *threadResource) Read(ctx context.Context, req resource.ReadRequest, res
 }
 
 func (r *threadResource) readIntoState(ctx context.Context, state *threadModel, diags discordFrameworkDiagnostics) {
-	var out restThreadChannel
-	if err := r.c.DoJSON(ctx, "GET", fmt.Sprintf("/channels/%s", state.ID.ValueString()), nil, nil, &out); err != nil {
+	out, err := r.c.GetChannel(ctx, state.ID.ValueString())
+	if err != nil {
 		if discord.IsDiscordHTTPStatus(err, 404) {
 			state.ID = types.StringNull()
 			return
@@ -352,16 +356,16 @@ func (r *threadResource) readIntoState(ctx context.Context, state *threadModel,
 	state.ServerID = types.StringValue(out.GuildID)
 	state.ChannelID = types.StringValue(out.ParentID)
 	state.Name = types.StringValue(out.Name)
-	state.RateLimitPerUser = types.Int64Value(int64(out.RateLimit))
+	state.RateLimitPerUser = types.Int64Value(int64(out.RateLimitPerUser))
 
 	if out.ThreadMetadata != nil {
 		state.Archived = types.BoolValue(out.ThreadMetadata.Archived)
 		state.Locked = types.BoolValue(out.ThreadMetadata.Locked)
 		state.Invitable = types.BoolValue(out.ThreadMetadata.Invitable)
-		state.AutoArchiveDuration = types.Int64Value(int64(out.ThreadMetadata.AutoArchiveDur))
+		state.AutoArchiveDuration = types.Int64Value(int64(out.ThreadMetadata.AutoArchiveDuration))
 	}
 
-	if out.AppliedTags != nil {
+	if len(out.AppliedTags) > 0 || !state.AppliedTags.IsNull() {
 		tags, tdiags := types.SetValueFrom(ctx, types.StringType, out.AppliedTags)
 		if tdiags.HasError() {
 			diags.AddError("State error", "failed to set applied_tags")
@@ -374,33 +378,45 @@ func (r *threadResource) readIntoState(ctx context.Context, state *threadModel,
 }
 
 func (r *threadResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := startResourceSpan(ctx, "discord_thread", "update")
+	defer endResourceSpan(span, &resp.Diagnostics)
+	defer threadIdentity.set(ctx, &resp.State, resp.Identity, &resp.Diagnostics)
+
 	var plan threadModel
 	var prior threadModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("reason"), &plan.Reason)...)
 	resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	body := map[string]any{}
+	params := &discord.ModifyChannelParams{}
+	changed := false
 
 	if plan.Name.ValueString() != prior.Name.ValueString() {
-		body["name"] = plan.Name.ValueString()
+		params.Name = discord.Ptr(plan.Name.ValueString())
+		changed = true
 	}
 	if !plan.RateLimitPerUser.IsNull() && !prior.RateLimitPerUser.IsNull() && plan.RateLimitPerUser.ValueInt64() != prior.RateLimitPerUser.ValueInt64() {
-		body["rate_limit_per_user"] = int(plan.RateLimitPerUser.ValueInt64())
+		params.RateLimitPerUser = discord.Ptr(int(plan.RateLimitPerUser.ValueInt64()))
+		changed = true
 	}
 	if !plan.Archived.IsNull() && !prior.Archived.IsNull() && plan.Archived.ValueBool() != prior.Archived.ValueBool() {
-		body["archived"] = plan.Archived.ValueBool()
+		params.Archived = discord.Ptr(plan.Archived.ValueBool())
+		changed = true
 	}
 	if !plan.Locked.IsNull() && !prior.Locked.IsNull() && plan.Locked.ValueBool() != prior.Locked.ValueBool() {
-		body["locked"] = plan.Locked.ValueBool()
+		params.Locked = discord.Ptr(plan.Locked.ValueBool())
+		changed = true
 	}
 	if !plan.AutoArchiveDuration.IsNull() && !prior.AutoArchiveDuration.IsNull() && plan.AutoArchiveDuration.ValueInt64() != prior.AutoArchiveDuration.ValueInt64() {
-		body["auto_archive_duration"] = int(plan.AutoArchiveDuration.ValueInt64())
+		params.AutoArchiveDuration = discord.Ptr(int(plan.AutoArchiveDuration.ValueInt64()))
+		changed = true
 	}
 	if !plan.Invitable.IsNull() && !prior.Invitable.IsNull() && plan.Invitable.ValueBool() != prior.Invitable.ValueBool() {
-		body["invitable"] = plan.Invitable.ValueBool()
+		params.Invitable = discord.Ptr(plan.Invitable.ValueBool())
+		changed = true
 	}
 	if !plan.AppliedTags.IsNull() && !plan.AppliedTags.IsUnknown() {
 		tags := []string{}
@@ -408,12 +424,13 @@ func (r *threadResource) Update(ctx context.Context, req resource.UpdateRequest,
 		if resp.Diagnostics.HasError() {
 			return
 		}
-		body["applied_tags"] = tags
+		params.AppliedTags = &tags
+		changed = true
 	}
 
-	if len(body) > 0 {
-		if err := r.c.DoJSONWithReason(ctx, "PATCH", fmt.Sprintf("/channels/%s", prior.ID.ValueString()), nil, body, nil, plan.Reason.ValueString()); err != nil {
-			resp.Diagnostics.AddError("Discord API error", err.Error())
+	if changed {
+		if _, err := r.c.ModifyChannel(ctx, prior.ID.ValueString(), params, plan.Reason.ValueString()); err != nil {
+			addDiscordAPIError(&resp.Diagnostics, err, threadFieldPaths)
 			return
 		}
 	}
@@ -424,13 +441,16 @@ func (r *threadResource) Update(ctx context.Context, req resource.UpdateRequest,
 }
 
 func (r *threadResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := startResourceSpan(ctx, "discord_thread", "delete")
+	defer endResourceSpan(span, &resp.Diagnostics)
+
 	var state threadModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	if err := r.c.DoJSONWithReason(ctx, "DELETE", fmt.Sprintf("/channels/%s", state.ID.ValueString()), nil, nil, nil, state.Reason.ValueString()); err != nil {
+	if err := r.c.DeleteChannel(ctx, state.ID.ValueString(), ""); err != nil {
 		if discord.IsDiscordHTTPStatus(err, 404) {
 			resp.State.RemoveResource(ctx)
 			return
@@ -442,5 +462,9 @@ func (r *threadResource) Delete(ctx context.Context, req resource.DeleteRequest,
 }
 
 func (r *threadResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	id := threadIdentity.importID(ctx, req, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
 }
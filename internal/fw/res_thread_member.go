@@ -6,7 +6,6 @@ import (
 
 	"github.com/45ck/terraform-provider-discord/discord"
 	"github.com/45ck/terraform-provider-discord/internal/fw/fwutil"
-	"github.com/45ck/terraform-provider-discord/internal/fw/planmod"
 	"github.com/45ck/terraform-provider-discord/internal/fw/validate"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -25,13 +24,6 @@ type threadMemberResource struct {
 	c *discord.RestClient
 }
 
-type restThreadMemberResource struct {
-	ID            string `json:"id"`
-	UserID        string `json:"user_id"`
-	JoinTimestamp string `json:"join_timestamp"`
-	Flags         int    `json:"flags"`
-}
-
 type threadMemberResourceModel struct {
 	ID types.String `tfsdk:"id"`
 
@@ -50,8 +42,9 @@ func (r *threadMemberResource) Metadata(_ context.Context, req resource.Metadata
 
 func (r *threadMemberResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Version: 1,
 		Attributes: map[string]schema.Attribute{
-			"id": schema.StringAttribute{Computed: true},
+			"id": schema.StringAttribute{Computed: true, PlanModifiers: stringUseState},
 			"thread_id": schema.StringAttribute{
 				Required: true,
 				PlanModifiers: []planmodifier.String{
@@ -72,20 +65,26 @@ func (r *threadMemberResource) Schema(_ context.Context, _ resource.SchemaReques
 					validate.SnowflakeOrAtMe(),
 				},
 			},
-			"reason": schema.StringAttribute{
-				Optional:  true,
-				Sensitive: true,
-				PlanModifiers: []planmodifier.String{
-					planmod.IgnoreChangesString(),
-				},
-				Description: "Optional audit log reason (X-Audit-Log-Reason). This value is not readable.",
-			},
+			"reason":         reasonAttribute(),
 			"join_timestamp": schema.StringAttribute{Computed: true},
 			"flags":          schema.Int64Attribute{Computed: true},
 		},
 	}
 }
 
+var threadMemberIdentity = resourceIdentity{
+	{"thread_id", path.Root("thread_id")},
+	{"user_id", path.Root("user_id")},
+}
+
+func (r *threadMemberResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = threadMemberIdentity.schema()
+}
+
+func (r *threadMemberResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return writeOnlyStateUpgraders("reason")
+}
+
 func (r *threadMemberResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	c, diags := getContextFromProviderData(req.ProviderData)
 	resp.Diagnostics.Append(diags...)
@@ -95,16 +94,14 @@ func (r *threadMemberResource) Configure(ctx context.Context, req resource.Confi
 	r.c = c.Rest
 }
 
-func threadMemberPath(threadID, userID string) string {
-	if userID == "@me" {
-		return fmt.Sprintf("/channels/%s/thread-members/@me", threadID)
-	}
-	return fmt.Sprintf("/channels/%s/thread-members/%s", threadID, userID)
-}
-
 func (r *threadMemberResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := startResourceSpan(ctx, "discord_thread_member", "create")
+	defer endResourceSpan(span, &resp.Diagnostics)
+	defer threadMemberIdentity.set(ctx, &resp.State, resp.Identity, &resp.Diagnostics)
+
 	var plan threadMemberResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("reason"), &plan.Reason)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -113,7 +110,7 @@ func (r *threadMemberResource) Create(ctx context.Context, req resource.CreateRe
 	userID := plan.UserID.ValueString()
 
 	// PUT add thread member. API usually returns 204 for @me and 204/200 for others.
-	if err := r.c.DoJSONWithReason(ctx, "PUT", threadMemberPath(threadID, userID), nil, nil, nil, plan.Reason.ValueString()); err != nil {
+	if err := r.c.AddThreadMember(ctx, threadID, userID, plan.Reason.ValueString()); err != nil {
 		resp.Diagnostics.AddError("Discord API error", err.Error())
 		return
 	}
@@ -124,6 +121,10 @@ func (r *threadMemberResource) Create(ctx context.Context, req resource.CreateRe
 }
 
 func (r *threadMemberResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := startResourceSpan(ctx, "discord_thread_member", "read")
+	defer endResourceSpan(span, &resp.Diagnostics)
+	defer threadMemberIdentity.set(ctx, &resp.State, resp.Identity, &resp.Diagnostics)
+
 	var state threadMemberResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
@@ -153,8 +154,8 @@ func (r *threadMemberResource) readIntoState(ctx context.Context, state *threadM
 		threadID, userID = tid, uid
 	}
 
-	var out restThreadMemberResource
-	if err := r.c.DoJSON(ctx, "GET", threadMemberPath(threadID, userID), nil, nil, &out); err != nil {
+	out, err := r.c.GetThreadMember(ctx, threadID, userID)
+	if err != nil {
 		if discord.IsDiscordHTTPStatus(err, 404) {
 			state.ID = types.StringNull()
 			return
@@ -171,10 +172,28 @@ func (r *threadMemberResource) readIntoState(ctx context.Context, state *threadM
 }
 
 func (r *threadMemberResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	resp.Diagnostics.AddError("Unsupported operation", "discord_thread_member does not support updates (replace on change)")
+	ctx, span := startResourceSpan(ctx, "discord_thread_member", "update")
+	defer endResourceSpan(span, &resp.Diagnostics)
+	defer threadMemberIdentity.set(ctx, &resp.State, resp.Identity, &resp.Diagnostics)
+
+	// Everything but reason forces replacement.
+	var plan, state threadMemberResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("reason"), &plan.Reason)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	plan.ID = state.ID
+	plan.JoinTimestamp = state.JoinTimestamp
+	plan.Flags = state.Flags
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 }
 
 func (r *threadMemberResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := startResourceSpan(ctx, "discord_thread_member", "delete")
+	defer endResourceSpan(span, &resp.Diagnostics)
+
 	var state threadMemberResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
@@ -184,7 +203,7 @@ func (r *threadMemberResource) Delete(ctx context.Context, req resource.DeleteRe
 	threadID := state.ThreadID.ValueString()
 	userID := state.UserID.ValueString()
 
-	if err := r.c.DoJSONWithReason(ctx, "DELETE", threadMemberPath(threadID, userID), nil, nil, nil, state.Reason.ValueString()); err != nil {
+	if err := r.c.RemoveThreadMember(ctx, threadID, userID, ""); err != nil {
 		if discord.IsDiscordHTTPStatus(err, 404) {
 			resp.State.RemoveResource(ctx)
 			return
@@ -196,8 +215,12 @@ func (r *threadMemberResource) Delete(ctx context.Context, req resource.DeleteRe
 }
 
 func (r *threadMemberResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	id := threadMemberIdentity.importID(ctx, req, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	// Import format: thread_id:user_id
-	threadID, userID, err := fwutil.ParseTwoIDs(req.ID)
+	threadID, userID, err := fwutil.ParseTwoIDs(id)
 	if err != nil {
 		resp.Diagnostics.AddError("Invalid import ID", "Expected thread_id:user_id")
 		return
@@ -2,16 +2,13 @@ package fw
 
 import (
 	"context"
-	"fmt"
 	"strings"
 
 	"github.com/45ck/terraform-provider-discord/discord"
-	"github.com/45ck/terraform-provider-discord/internal/fw/planmod"
 	"github.com/45ck/terraform-provider-discord/internal/fw/validate"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
-	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
@@ -20,18 +17,15 @@ func NewWebhookResource() resource.Resource {
 	return &webhookResource{}
 }
 
-type webhookResource struct {
-	c *discord.RestClient
+// webhookFieldPaths maps Discord request fields to schema attributes for per-attribute errors.
+var webhookFieldPaths = discordFieldPaths{
+	"name":       path.Root("name"),
+	"channel_id": path.Root("channel_id"),
+	"avatar":     path.Root("avatar_data_uri"),
 }
 
-type restWebhook struct {
-	ID        string `json:"id"`
-	Type      int    `json:"type"`
-	GuildID   string `json:"guild_id"`
-	ChannelID string `json:"channel_id"`
-	Name      string `json:"name"`
-	Token     string `json:"token"`
-	URL       string `json:"url"`
+type webhookResource struct {
+	c *discord.RestClient
 }
 
 type webhookModel struct {
@@ -54,8 +48,9 @@ func (r *webhookResource) Metadata(_ context.Context, req resource.MetadataReque
 
 func (r *webhookResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Version: 1,
 		Attributes: map[string]schema.Attribute{
-			"id": schema.StringAttribute{Computed: true},
+			"id": schema.StringAttribute{Computed: true, PlanModifiers: stringUseState},
 			"channel_id": schema.StringAttribute{
 				Required: true,
 				Validators: []validator.String{
@@ -72,27 +67,35 @@ func (r *webhookResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 				Description: "data: URI for the webhook avatar. Use an empty string to clear.",
 			},
 			"token": schema.StringAttribute{
-				Computed:  true,
-				Sensitive: true,
+				Computed:      true,
+				Sensitive:     true,
+				PlanModifiers: stringUseState,
 			},
 			"url": schema.StringAttribute{
-				Computed: true,
+				Computed:      true,
+				PlanModifiers: stringUseState,
 			},
 			"guild_id": schema.StringAttribute{
-				Computed: true,
-			},
-			"reason": schema.StringAttribute{
-				Optional:  true,
-				Sensitive: true,
-				PlanModifiers: []planmodifier.String{
-					planmod.IgnoreChangesString(),
-				},
-				Description: "Optional audit log reason (X-Audit-Log-Reason). This value is not readable.",
+				Computed:      true,
+				PlanModifiers: stringUseState,
 			},
+			"reason": reasonAttribute(),
 		},
 	}
 }
 
+var webhookIdentity = resourceIdentity{
+	{"webhook_id", path.Root("id")},
+}
+
+func (r *webhookResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = webhookIdentity.schema()
+}
+
+func (r *webhookResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return writeOnlyStateUpgraders("reason")
+}
+
 func (r *webhookResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	c, diags := getContextFromProviderData(req.ProviderData)
 	resp.Diagnostics.Append(diags...)
@@ -103,31 +106,63 @@ func (r *webhookResource) Configure(ctx context.Context, req resource.ConfigureR
 }
 
 func (r *webhookResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := startResourceSpan(ctx, "discord_webhook", "create")
+	defer endResourceSpan(span, &resp.Diagnostics)
+	defer webhookIdentity.set(ctx, &resp.State, resp.Identity, &resp.Diagnostics)
+
 	var plan webhookModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("reason"), &plan.Reason)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	body := map[string]any{
-		"name": plan.Name.ValueString(),
+	params := &discord.CreateWebhookParams{
+		Name: plan.Name.ValueString(),
 	}
 	if !plan.AvatarDataURI.IsNull() && !plan.AvatarDataURI.IsUnknown() && strings.TrimSpace(plan.AvatarDataURI.ValueString()) != "" {
-		body["avatar"] = plan.AvatarDataURI.ValueString()
+		params.Avatar = discord.Ptr(plan.AvatarDataURI.ValueString())
 	}
 
-	var out restWebhook
-	if err := r.c.DoJSONWithReason(ctx, "POST", fmt.Sprintf("/channels/%s/webhooks", plan.ChannelID.ValueString()), nil, body, &out, plan.Reason.ValueString()); err != nil {
-		resp.Diagnostics.AddError("Discord API error", err.Error())
-		return
+	create := beginCreate("webhook", plan.ChannelID.ValueString(), params.Name)
+	defer create.end()
+	out, err := r.c.CreateWebhook(ctx, plan.ChannelID.ValueString(), params, plan.Reason.ValueString())
+	if err != nil {
+		id, err := create.recover(ctx, err, func(ctx context.Context) ([]string, error) {
+			hooks, err := r.c.GetChannelWebhooks(ctx, plan.ChannelID.ValueString())
+			if err != nil {
+				return nil, err
+			}
+			var ids []string
+			for _, h := range hooks {
+				if h.Name == params.Name {
+					ids = append(ids, h.ID)
+				}
+			}
+			return ids, nil
+		})
+		if err != nil {
+			addDiscordAPIError(&resp.Diagnostics, err, webhookFieldPaths)
+			return
+		}
+		out = &discord.Webhook{ID: id}
 	}
+	create.created(out.ID)
 
+	readAfterCreate(ctx, &resp.Diagnostics, "Webhook", out.ID, func(ctx context.Context) bool {
+		plan.ID = types.StringValue(out.ID)
+		r.readIntoState(ctx, &plan, &resp.Diagnostics)
+		return !plan.ID.IsNull()
+	})
 	plan.ID = types.StringValue(out.ID)
-	r.readIntoState(ctx, &plan, &resp.Diagnostics)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 }
 
 func (r *webhookResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := startResourceSpan(ctx, "discord_webhook", "read")
+	defer endResourceSpan(span, &resp.Diagnostics)
+	defer webhookIdentity.set(ctx, &resp.State, resp.Identity, &resp.Diagnostics)
+
 	var state webhookModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
@@ -146,8 +181,8 @@ func (r *webhookResource) Read(ctx context.Context, req resource.ReadRequest, re
 }
 
 func (r *webhookResource) readIntoState(ctx context.Context, state *webhookModel, diags discordFrameworkDiagnostics) {
-	var out restWebhook
-	if err := r.c.DoJSON(ctx, "GET", fmt.Sprintf("/webhooks/%s", state.ID.ValueString()), nil, nil, &out); err != nil {
+	out, err := r.c.GetWebhook(ctx, state.ID.ValueString())
+	if err != nil {
 		if discord.IsDiscordHTTPStatus(err, 404) {
 			state.ID = types.StringNull()
 			return
@@ -171,34 +206,33 @@ func (r *webhookResource) readIntoState(ctx context.Context, state *webhookModel
 }
 
 func (r *webhookResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := startResourceSpan(ctx, "discord_webhook", "update")
+	defer endResourceSpan(span, &resp.Diagnostics)
+	defer webhookIdentity.set(ctx, &resp.State, resp.Identity, &resp.Diagnostics)
+
 	var plan webhookModel
 	var prior webhookModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("reason"), &plan.Reason)...)
 	resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	body := map[string]any{
-		"name": plan.Name.ValueString(),
+	params := &discord.ModifyWebhookParams{
+		Name: discord.Ptr(plan.Name.ValueString()),
 	}
 
 	if plan.ChannelID.ValueString() != prior.ChannelID.ValueString() {
-		body["channel_id"] = plan.ChannelID.ValueString()
+		params.ChannelID = discord.Ptr(plan.ChannelID.ValueString())
 	}
 
 	if !plan.AvatarDataURI.IsNull() && !plan.AvatarDataURI.IsUnknown() {
-		v := strings.TrimSpace(plan.AvatarDataURI.ValueString())
-		if v == "" {
-			body["avatar"] = nil
-		} else {
-			body["avatar"] = v
-		}
+		params.Avatar = discord.NullIfEmpty(strings.TrimSpace(plan.AvatarDataURI.ValueString()))
 	}
 
-	var out restWebhook
-	if err := r.c.DoJSONWithReason(ctx, "PATCH", fmt.Sprintf("/webhooks/%s", prior.ID.ValueString()), nil, body, &out, plan.Reason.ValueString()); err != nil {
-		resp.Diagnostics.AddError("Discord API error", err.Error())
+	if _, err := r.c.ModifyWebhook(ctx, prior.ID.ValueString(), params, plan.Reason.ValueString()); err != nil {
+		addDiscordAPIError(&resp.Diagnostics, err, webhookFieldPaths)
 		return
 	}
 
@@ -208,13 +242,16 @@ func (r *webhookResource) Update(ctx context.Context, req resource.UpdateRequest
 }
 
 func (r *webhookResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := startResourceSpan(ctx, "discord_webhook", "delete")
+	defer endResourceSpan(span, &resp.Diagnostics)
+
 	var state webhookModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	if err := r.c.DoJSONWithReason(ctx, "DELETE", fmt.Sprintf("/webhooks/%s", state.ID.ValueString()), nil, nil, nil, state.Reason.ValueString()); err != nil {
+	if err := r.c.DeleteWebhook(ctx, state.ID.ValueString(), ""); err != nil {
 		if discord.IsDiscordHTTPStatus(err, 404) {
 			resp.State.RemoveResource(ctx)
 			return
@@ -226,5 +263,9 @@ func (r *webhookResource) Delete(ctx context.Context, req resource.DeleteRequest
 }
 
 func (r *webhookResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	id := webhookIdentity.importID(ctx, req, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
 }
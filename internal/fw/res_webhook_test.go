@@ -0,0 +1,55 @@
+package fw
+
+import (
+	"context"
+	"testing"
+
+	"github.com/45ck/terraform-provider-discord/discord"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestWebhookResource_FakeFaults(t *testing.T) {
+	e := newFakeEnv(t)
+	e.maxRetries = 1
+	e.faults(t, mishaps("/channels/*/webhooks", "/webhooks/*")...)
+	listWebhooks := func(ctx context.Context, c *discord.RestClient) ([]string, error) {
+		channels, err := c.ListGuildChannels(ctx, e.guildID)
+		if err != nil {
+			return nil, err
+		}
+		var ids []string
+		for _, ch := range channels {
+			hooks, err := c.GetChannelWebhooks(ctx, ch.ID)
+			if err != nil {
+				return nil, err
+			}
+			for _, h := range hooks {
+				ids = append(ids, h.ID)
+			}
+		}
+		return ids, nil
+	}
+
+	e.test(t, resource.TestCase{
+		Steps: []resource.TestStep{
+			{
+				Config: e.config(`
+resource "discord_channel" "a" {
+  server_id = %q
+  type      = "text"
+  name      = "a"
+}
+
+resource "discord_webhook" "hook" {
+  channel_id = discord_channel.a.id
+  name       = "deploys"
+}
+`, e.guildID),
+				Check: resource.ComposeTestCheckFunc(
+					e.checkSingle("discord_webhook.hook", listWebhooks),
+					resource.TestCheckResourceAttrSet("discord_webhook.hook", "token"),
+				),
+			},
+		},
+	})
+}
@@ -2,9 +2,9 @@ package fw
 
 import (
 	"context"
-	"fmt"
 
 	"github.com/45ck/terraform-provider-discord/discord"
+	"github.com/45ck/terraform-provider-discord/internal/fw/fwutil"
 	"github.com/45ck/terraform-provider-discord/internal/fw/validate"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -20,7 +20,8 @@ func NewWelcomeScreenResource() resource.Resource {
 }
 
 type welcomeScreenResource struct {
-	c *discord.RestClient
+	c               *discord.RestClient
+	defaultServerID string
 }
 
 type welcomeScreenChannelModel struct {
@@ -39,20 +40,6 @@ type welcomeScreenModel struct {
 	Channel     []welcomeScreenChannelModel `tfsdk:"channel"`
 }
 
-type restWelcomeScreen struct {
-	Description     string               `json:"description"`
-	WelcomeChannels []restWelcomeChannel `json:"welcome_channels"`
-	Enabled         bool                 `json:"enabled"`
-	GuildID         string               `json:"guild_id"`
-}
-
-type restWelcomeChannel struct {
-	ChannelID   string `json:"channel_id"`
-	Description string `json:"description"`
-	EmojiID     string `json:"emoji_id,omitempty"`
-	EmojiName   string `json:"emoji_name,omitempty"`
-}
-
 func (r *welcomeScreenResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_welcome_screen"
 }
@@ -60,10 +47,13 @@ func (r *welcomeScreenResource) Metadata(_ context.Context, req resource.Metadat
 func (r *welcomeScreenResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
-			"id": schema.StringAttribute{Computed: true},
+			"id": schema.StringAttribute{Computed: true, PlanModifiers: stringUseState},
 			"server_id": schema.StringAttribute{
-				Required: true,
+				Optional:    true,
+				Computed:    true,
+				Description: serverIDDescription,
 				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
 					stringplanmodifier.RequiresReplace(),
 				},
 				Validators: []validator.String{
@@ -101,6 +91,14 @@ func (r *welcomeScreenResource) Schema(_ context.Context, _ resource.SchemaReque
 	}
 }
 
+var welcomeScreenIdentity = resourceIdentity{
+	{"server_id", path.Root("server_id")},
+}
+
+func (r *welcomeScreenResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = welcomeScreenIdentity.schema()
+}
+
 func (r *welcomeScreenResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	c, diags := getContextFromProviderData(req.ProviderData)
 	resp.Diagnostics.Append(diags...)
@@ -108,6 +106,11 @@ func (r *welcomeScreenResource) Configure(ctx context.Context, req resource.Conf
 		return
 	}
 	r.c = c.Rest
+	r.defaultServerID = c.Config.DefaultServerID
+}
+
+func (r *welcomeScreenResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	planServerID(ctx, r.defaultServerID, true, req, resp)
 }
 
 func (r *welcomeScreenResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
@@ -130,38 +133,37 @@ func (r *welcomeScreenResource) ValidateConfig(ctx context.Context, req resource
 	}
 }
 
-func expandWelcomeChannels(v []welcomeScreenChannelModel) []restWelcomeChannel {
-	out := make([]restWelcomeChannel, 0, len(v))
+func expandWelcomeChannels(v []welcomeScreenChannelModel) []discord.WelcomeScreenChannel {
+	out := make([]discord.WelcomeScreenChannel, 0, len(v))
 	for _, raw := range v {
-		ch := restWelcomeChannel{
+		out = append(out, discord.WelcomeScreenChannel{
 			ChannelID:   raw.ChannelID.ValueString(),
 			Description: raw.Description.ValueString(),
-		}
-		if s := raw.EmojiID.ValueString(); s != "" {
-			ch.EmojiID = s
-		}
-		if s := raw.EmojiName.ValueString(); s != "" {
-			ch.EmojiName = s
-		}
-		out = append(out, ch)
+			EmojiID:     raw.EmojiID.ValueString(),
+			EmojiName:   raw.EmojiName.ValueString(),
+		})
 	}
 	return out
 }
 
-func flattenWelcomeChannels(v []restWelcomeChannel) []welcomeScreenChannelModel {
+func flattenWelcomeChannels(v []discord.WelcomeScreenChannel) []welcomeScreenChannelModel {
 	out := make([]welcomeScreenChannelModel, 0, len(v))
 	for _, ch := range v {
 		out = append(out, welcomeScreenChannelModel{
 			ChannelID:   types.StringValue(ch.ChannelID),
 			Description: types.StringValue(ch.Description),
-			EmojiID:     types.StringValue(ch.EmojiID),
-			EmojiName:   types.StringValue(ch.EmojiName),
+			EmojiID:     fwutil.StringOrNull(ch.EmojiID),
+			EmojiName:   fwutil.StringOrNull(ch.EmojiName),
 		})
 	}
 	return out
 }
 
 func (r *welcomeScreenResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := startResourceSpan(ctx, "discord_welcome_screen", "create")
+	defer endResourceSpan(span, &resp.Diagnostics)
+	defer welcomeScreenIdentity.set(ctx, &resp.State, resp.Identity, &resp.Diagnostics)
+
 	var plan welcomeScreenModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
@@ -175,6 +177,10 @@ func (r *welcomeScreenResource) Create(ctx context.Context, req resource.CreateR
 }
 
 func (r *welcomeScreenResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := startResourceSpan(ctx, "discord_welcome_screen", "update")
+	defer endResourceSpan(span, &resp.Diagnostics)
+	defer welcomeScreenIdentity.set(ctx, &resp.State, resp.Identity, &resp.Diagnostics)
+
 	var plan welcomeScreenModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
@@ -190,18 +196,14 @@ func (r *welcomeScreenResource) Update(ctx context.Context, req resource.UpdateR
 func (r *welcomeScreenResource) upsert(ctx context.Context, plan *welcomeScreenModel, diags discordFrameworkDiagnostics) {
 	serverID := plan.ServerID.ValueString()
 
-	body := map[string]any{
-		"enabled":     !plan.Enabled.IsNull() && plan.Enabled.ValueBool(),
-		"description": plan.Description.ValueString(),
-	}
-	if plan.Channel != nil {
-		body["welcome_channels"] = expandWelcomeChannels(plan.Channel)
-	} else {
-		body["welcome_channels"] = []restWelcomeChannel{}
+	channels := expandWelcomeChannels(plan.Channel)
+	params := &discord.ModifyGuildWelcomeScreenParams{
+		Enabled:         discord.Ptr(!plan.Enabled.IsNull() && plan.Enabled.ValueBool()),
+		Description:     discord.Ptr(plan.Description.ValueString()),
+		WelcomeChannels: &channels,
 	}
 
-	var out restWelcomeScreen
-	if err := r.c.DoJSON(ctx, "PATCH", fmt.Sprintf("/guilds/%s/welcome-screen", serverID), nil, body, &out); err != nil {
+	if _, err := r.c.ModifyGuildWelcomeScreen(ctx, serverID, params, ""); err != nil {
 		diags.AddError("Discord API error", err.Error())
 		return
 	}
@@ -211,6 +213,10 @@ func (r *welcomeScreenResource) upsert(ctx context.Context, plan *welcomeScreenM
 }
 
 func (r *welcomeScreenResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := startResourceSpan(ctx, "discord_welcome_screen", "read")
+	defer endResourceSpan(span, &resp.Diagnostics)
+	defer welcomeScreenIdentity.set(ctx, &resp.State, resp.Identity, &resp.Diagnostics)
+
 	var state welcomeScreenModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
@@ -234,8 +240,8 @@ func (r *welcomeScreenResource) readIntoState(ctx context.Context, state *welcom
 		serverID = state.ServerID.ValueString()
 	}
 
-	var out restWelcomeScreen
-	if err := r.c.DoJSON(ctx, "GET", fmt.Sprintf("/guilds/%s/welcome-screen", serverID), nil, nil, &out); err != nil {
+	out, err := r.c.GetGuildWelcomeScreen(ctx, serverID)
+	if err != nil {
 		if discord.IsDiscordHTTPStatus(err, 404) {
 			state.ID = types.StringNull()
 			return
@@ -246,12 +252,17 @@ func (r *welcomeScreenResource) readIntoState(ctx context.Context, state *welcom
 
 	state.ID = types.StringValue(serverID)
 	state.ServerID = types.StringValue(serverID)
-	state.Enabled = types.BoolValue(out.Enabled)
-	state.Description = types.StringValue(out.Description)
-	state.Channel = flattenWelcomeChannels(out.WelcomeChannels)
+	state.Enabled = fwutil.KeepNullBool(state.Enabled, out.Enabled)
+	state.Description = fwutil.KeepNullString(state.Description, out.Description)
+	if len(out.WelcomeChannels) > 0 || state.Channel != nil {
+		state.Channel = flattenWelcomeChannels(out.WelcomeChannels)
+	}
 }
 
 func (r *welcomeScreenResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := startResourceSpan(ctx, "discord_welcome_screen", "delete")
+	defer endResourceSpan(span, &resp.Diagnostics)
+
 	var state welcomeScreenModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
@@ -259,12 +270,12 @@ func (r *welcomeScreenResource) Delete(ctx context.Context, req resource.DeleteR
 	}
 
 	serverID := state.ID.ValueString()
-	body := map[string]any{
-		"enabled":          false,
-		"description":      "",
-		"welcome_channels": []restWelcomeChannel{},
+	params := &discord.ModifyGuildWelcomeScreenParams{
+		Enabled:         discord.Ptr(false),
+		Description:     discord.Ptr(""),
+		WelcomeChannels: &[]discord.WelcomeScreenChannel{},
 	}
-	if err := r.c.DoJSON(ctx, "PATCH", fmt.Sprintf("/guilds/%s/welcome-screen", serverID), nil, body, nil); err != nil {
+	if _, err := r.c.ModifyGuildWelcomeScreen(ctx, serverID, params, ""); err != nil {
 		if discord.IsDiscordHTTPStatus(err, 404) {
 			resp.State.RemoveResource(ctx)
 			return
@@ -277,6 +288,10 @@ func (r *welcomeScreenResource) Delete(ctx context.Context, req resource.DeleteR
 }
 
 func (r *welcomeScreenResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("server_id"), req, resp)
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	id := welcomeScreenIdentity.importID(ctx, req, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("server_id"), id)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
 }
@@ -2,10 +2,9 @@ package fw
 
 import (
 	"context"
-	"fmt"
 
 	"github.com/45ck/terraform-provider-discord/discord"
-	"github.com/45ck/terraform-provider-discord/internal/fw/planmod"
+	"github.com/45ck/terraform-provider-discord/internal/fw/fwutil"
 	"github.com/45ck/terraform-provider-discord/internal/fw/validate"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -21,7 +20,8 @@ func NewWidgetSettingsResource() resource.Resource {
 }
 
 type widgetSettingsResource struct {
-	c *discord.RestClient
+	c               *discord.RestClient
+	defaultServerID string
 }
 
 type widgetSettingsModel struct {
@@ -33,22 +33,21 @@ type widgetSettingsModel struct {
 	Reason    types.String `tfsdk:"reason"`
 }
 
-type restWidgetSettings struct {
-	Enabled   bool   `json:"enabled"`
-	ChannelID string `json:"channel_id"`
-}
-
 func (r *widgetSettingsResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_widget_settings"
 }
 
 func (r *widgetSettingsResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Version: 1,
 		Attributes: map[string]schema.Attribute{
-			"id": schema.StringAttribute{Computed: true},
+			"id": schema.StringAttribute{Computed: true, PlanModifiers: stringUseState},
 			"server_id": schema.StringAttribute{
-				Required: true,
+				Optional:    true,
+				Computed:    true,
+				Description: serverIDDescription,
 				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
 					stringplanmodifier.RequiresReplace(),
 				},
 				Validators: []validator.String{
@@ -66,18 +65,23 @@ func (r *widgetSettingsResource) Schema(_ context.Context, _ resource.SchemaRequ
 				},
 				Description: "Widget channel ID. Required when enabled=true.",
 			},
-			"reason": schema.StringAttribute{
-				Optional:  true,
-				Sensitive: true,
-				PlanModifiers: []planmodifier.String{
-					planmod.IgnoreChangesString(),
-				},
-				Description: "Optional audit log reason (X-Audit-Log-Reason). This value is not readable.",
-			},
+			"reason": reasonAttribute(),
 		},
 	}
 }
 
+var widgetSettingsIdentity = resourceIdentity{
+	{"server_id", path.Root("server_id")},
+}
+
+func (r *widgetSettingsResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = widgetSettingsIdentity.schema()
+}
+
+func (r *widgetSettingsResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return writeOnlyStateUpgraders("reason")
+}
+
 func (r *widgetSettingsResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	c, diags := getContextFromProviderData(req.ProviderData)
 	resp.Diagnostics.Append(diags...)
@@ -85,6 +89,11 @@ func (r *widgetSettingsResource) Configure(ctx context.Context, req resource.Con
 		return
 	}
 	r.c = c.Rest
+	r.defaultServerID = c.Config.DefaultServerID
+}
+
+func (r *widgetSettingsResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	planServerID(ctx, r.defaultServerID, true, req, resp)
 }
 
 func (r *widgetSettingsResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
@@ -106,8 +115,13 @@ func (r *widgetSettingsResource) ValidateConfig(ctx context.Context, req resourc
 }
 
 func (r *widgetSettingsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := startResourceSpan(ctx, "discord_widget_settings", "create")
+	defer endResourceSpan(span, &resp.Diagnostics)
+	defer widgetSettingsIdentity.set(ctx, &resp.State, resp.Identity, &resp.Diagnostics)
+
 	var plan widgetSettingsModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("reason"), &plan.Reason)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -120,6 +134,10 @@ func (r *widgetSettingsResource) Create(ctx context.Context, req resource.Create
 }
 
 func (r *widgetSettingsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := startResourceSpan(ctx, "discord_widget_settings", "read")
+	defer endResourceSpan(span, &resp.Diagnostics)
+	defer widgetSettingsIdentity.set(ctx, &resp.State, resp.Identity, &resp.Diagnostics)
+
 	var state widgetSettingsModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
@@ -138,9 +156,14 @@ func (r *widgetSettingsResource) Read(ctx context.Context, req resource.ReadRequ
 }
 
 func (r *widgetSettingsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := startResourceSpan(ctx, "discord_widget_settings", "update")
+	defer endResourceSpan(span, &resp.Diagnostics)
+	defer widgetSettingsIdentity.set(ctx, &resp.State, resp.Identity, &resp.Diagnostics)
+
 	var plan widgetSettingsModel
 	var prior widgetSettingsModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("reason"), &plan.Reason)...)
 	resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -157,16 +180,16 @@ func (r *widgetSettingsResource) Update(ctx context.Context, req resource.Update
 func (r *widgetSettingsResource) upsert(ctx context.Context, plan *widgetSettingsModel, diags discordFrameworkDiagnostics) {
 	serverID := plan.ServerID.ValueString()
 
-	body := map[string]any{
-		"enabled": plan.Enabled.ValueBool(),
+	params := &discord.ModifyGuildWidgetParams{
+		Enabled: discord.Ptr(plan.Enabled.ValueBool()),
 	}
 	// Only set channel_id when explicitly known. This avoids "clearing" it when config omits it.
-	if !(plan.ChannelID.IsNull() || plan.ChannelID.IsUnknown()) && plan.ChannelID.ValueString() != "" {
-		body["channel_id"] = plan.ChannelID.ValueString()
+	if v := fwutil.NonEmptyString(plan.ChannelID); v != nil {
+		params.ChannelID = discord.Value(*v)
 	}
 
-	var out restWidgetSettings
-	if err := r.c.DoJSONWithReason(ctx, "PATCH", fmt.Sprintf("/guilds/%s/widget", serverID), nil, body, &out, plan.Reason.ValueString()); err != nil {
+	out, err := r.c.ModifyGuildWidget(ctx, serverID, params, plan.Reason.ValueString())
+	if err != nil {
 		diags.AddError("Discord API error", err.Error())
 		return
 	}
@@ -183,8 +206,8 @@ func (r *widgetSettingsResource) readIntoState(ctx context.Context, state *widge
 		serverID = state.ServerID.ValueString()
 	}
 
-	var out restWidgetSettings
-	if err := r.c.DoJSON(ctx, "GET", fmt.Sprintf("/guilds/%s/widget", serverID), nil, nil, &out); err != nil {
+	out, err := r.c.GetGuildWidgetSettings(ctx, serverID)
+	if err != nil {
 		if discord.IsDiscordHTTPStatus(err, 404) {
 			state.ID = types.StringNull()
 			return
@@ -200,6 +223,9 @@ func (r *widgetSettingsResource) readIntoState(ctx context.Context, state *widge
 }
 
 func (r *widgetSettingsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := startResourceSpan(ctx, "discord_widget_settings", "delete")
+	defer endResourceSpan(span, &resp.Diagnostics)
+
 	// No-op; avoid implicitly changing server settings on destroy.
 	resp.Diagnostics.AddWarning(
 		"discord_widget_settings does not revert widget settings on destroy",
@@ -209,7 +235,11 @@ func (r *widgetSettingsResource) Delete(ctx context.Context, req resource.Delete
 }
 
 func (r *widgetSettingsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	id := widgetSettingsIdentity.importID(ctx, req, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	// ID is the server/guild ID.
-	resource.ImportStatePassthroughID(ctx, path.Root("server_id"), req, resp)
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("server_id"), id)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
 }
@@ -0,0 +1,1443 @@
+package fw
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/45ck/terraform-provider-discord/discord"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+// testPNGDataURI is a 1x1 transparent PNG.
+const testPNGDataURI = "data:image/png;base64,iVBORw0KGgoAAAANSUhEUgAAAAEAAAABCAQAAAC1HAwCAAAAC0lEQVR42mNkYAAAAAYAAjCB0C8AAAAASUVORK5CYII="
+
+// fakeGetters fetch the Discord object behind a resource in state, by resource
+// type. The shared cases' CheckDestroy wants a 404 from each of them.
+var fakeGetters = map[string]func(ctx context.Context, c *discord.RestClient, rs *terraform.ResourceState) error{
+	"discord_automod_rule": func(ctx context.Context, c *discord.RestClient, rs *terraform.ResourceState) error {
+		_, err := c.GetAutoModRule(ctx, rs.Primary.Attributes["server_id"], rs.Primary.ID)
+		return err
+	},
+	"discord_ban": func(ctx context.Context, c *discord.RestClient, rs *terraform.ResourceState) error {
+		_, err := c.GetGuildBan(ctx, rs.Primary.Attributes["server_id"], rs.Primary.Attributes["user_id"])
+		return err
+	},
+	"discord_channel": func(ctx context.Context, c *discord.RestClient, rs *terraform.ResourceState) error {
+		_, err := c.GetChannel(ctx, rs.Primary.ID)
+		return err
+	},
+	"discord_emoji": func(ctx context.Context, c *discord.RestClient, rs *terraform.ResourceState) error {
+		_, err := c.GetGuildEmoji(ctx, rs.Primary.Attributes["server_id"], rs.Primary.ID)
+		return err
+	},
+	"discord_invite": func(ctx context.Context, c *discord.RestClient, rs *terraform.ResourceState) error {
+		_, err := c.GetInvite(ctx, rs.Primary.ID)
+		return err
+	},
+	"discord_message": func(ctx context.Context, c *discord.RestClient, rs *terraform.ResourceState) error {
+		_, err := c.GetMessage(ctx, rs.Primary.Attributes["channel_id"], rs.Primary.ID)
+		return err
+	},
+	"discord_role": func(ctx context.Context, c *discord.RestClient, rs *terraform.ResourceState) error {
+		_, err := fetchRoleByID(ctx, c, rs.Primary.Attributes["server_id"], rs.Primary.ID)
+		return err
+	},
+	"discord_scheduled_event": func(ctx context.Context, c *discord.RestClient, rs *terraform.ResourceState) error {
+		_, err := c.GetScheduledEvent(ctx, rs.Primary.Attributes["server_id"], rs.Primary.ID)
+		return err
+	},
+	"discord_soundboard_sound": func(ctx context.Context, c *discord.RestClient, rs *terraform.ResourceState) error {
+		_, err := c.GetGuildSoundboardSound(ctx, rs.Primary.Attributes["server_id"], rs.Primary.ID)
+		return err
+	},
+	"discord_stage_instance": func(ctx context.Context, c *discord.RestClient, rs *terraform.ResourceState) error {
+		_, err := c.GetStageInstance(ctx, rs.Primary.ID)
+		return err
+	},
+	"discord_sticker": func(ctx context.Context, c *discord.RestClient, rs *terraform.ResourceState) error {
+		_, err := c.GetGuildSticker(ctx, rs.Primary.Attributes["server_id"], rs.Primary.ID)
+		return err
+	},
+	"discord_thread": func(ctx context.Context, c *discord.RestClient, rs *terraform.ResourceState) error {
+		_, err := c.GetChannel(ctx, rs.Primary.ID)
+		return err
+	},
+	"discord_thread_member": func(ctx context.Context, c *discord.RestClient, rs *terraform.ResourceState) error {
+		_, err := c.GetThreadMember(ctx, rs.Primary.Attributes["thread_id"], rs.Primary.Attributes["user_id"])
+		return err
+	},
+	"discord_webhook": func(ctx context.Context, c *discord.RestClient, rs *terraform.ResourceState) error {
+		_, err := c.GetWebhook(ctx, rs.Primary.ID)
+		return err
+	},
+}
+
+// checkAllDestroyed is a CheckDestroy that runs checkDestroyed for every type
+// in fakeGetters.
+func (e *fakeEnv) checkAllDestroyed(s *terraform.State) error {
+	for typ, get := range fakeGetters {
+		if err := e.checkDestroyed(typ, get)(s); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// fakeResourceCases are the resource tests that need nothing from the fake
+// beyond a fresh guild. Tests with their own provider settings, faults or
+// CheckDestroy stay next to their resource.
+var fakeResourceCases = []struct {
+	name string
+	// steps returns the test steps; it may add members and files first.
+	steps func(t *testing.T, e *fakeEnv) []resource.TestStep
+}{
+	{
+		name: "automod_rule",
+		steps: func(t *testing.T, e *fakeEnv) []resource.TestStep {
+			return []resource.TestStep{
+				{
+					Config: e.config(`
+resource "discord_automod_rule" "words" {
+  server_id = %q
+  payload_json = jsonencode({
+    name             = "no bad words"
+    event_type       = 1
+    trigger_type     = 1
+    trigger_metadata = { keyword_filter = ["darn"] }
+    actions          = [{ type = 1 }]
+    enabled          = true
+  })
+}
+`, e.guildID),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttrPair("discord_automod_rule.words", "effective_id", "discord_automod_rule.words", "id"),
+						resource.TestMatchResourceAttr("discord_automod_rule.words", "state_json", regexp.MustCompile(`"keyword_filter":\["darn"\]`)),
+					),
+				},
+				{
+					Config: e.config(`
+resource "discord_automod_rule" "words" {
+  server_id = %q
+  payload_json = jsonencode({
+    name             = "no bad words"
+    event_type       = 1
+    trigger_type     = 1
+    trigger_metadata = { keyword_filter = ["darn", "heck"] }
+    actions          = [{ type = 1 }]
+    enabled          = false
+  })
+}
+`, e.guildID),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestMatchResourceAttr("discord_automod_rule.words", "state_json", regexp.MustCompile(`"keyword_filter":\["darn","heck"\]`)),
+						resource.TestMatchResourceAttr("discord_automod_rule.words", "state_json", regexp.MustCompile(`"enabled":false`)),
+					),
+				},
+				{
+					ResourceName:            "discord_automod_rule.words",
+					ImportState:             true,
+					ImportStateIdFunc:       serverScopedImportID("discord_automod_rule.words"),
+					ImportStateVerify:       true,
+					ImportStateVerifyIgnore: []string{"payload_json"},
+				},
+			}
+		},
+	},
+	{
+		name: "ban",
+		steps: func(t *testing.T, e *fakeEnv) []resource.TestStep {
+			userID := e.srv.AddMember(e.guildID, "spammer")
+
+			return []resource.TestStep{
+				{
+					Config: e.config(`
+resource "discord_ban" "spammer" {
+  server_id              = %q
+  user_id                = %q
+  delete_message_seconds = 3600
+  reason                 = "spam"
+}
+`, e.guildID, userID),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr("discord_ban.spammer", "id", e.guildID+":"+userID),
+						// The reason reaches Discord but not the state.
+						resource.TestCheckNoResourceAttr("discord_ban.spammer", "reason"),
+						func(*terraform.State) error {
+							ban, err := e.client().GetGuildBan(context.Background(), e.guildID, userID)
+							if err != nil {
+								return err
+							}
+							if ban.Reason != "spam" {
+								return fmt.Errorf("ban reason = %q, want %q", ban.Reason, "spam")
+							}
+							return nil
+						},
+					),
+				},
+				{
+					// reason is write-only, so a new one plans nothing.
+					Config: e.config(`
+resource "discord_ban" "spammer" {
+  server_id              = %q
+  user_id                = %q
+  delete_message_seconds = 3600
+  reason                 = "more spam"
+}
+`, e.guildID, userID),
+					PlanOnly: true,
+				},
+				{
+					ResourceName:            "discord_ban.spammer",
+					ImportState:             true,
+					ImportStateVerify:       true,
+					ImportStateVerifyIgnore: []string{"delete_message_seconds"},
+				},
+			}
+		},
+	},
+	{
+		name: "channel",
+		steps: func(t *testing.T, e *fakeEnv) []resource.TestStep {
+			return []resource.TestStep{
+				{
+					Config: e.config(`
+resource "discord_channel" "cat" {
+  server_id = %[1]q
+  type      = "category"
+  name      = "Category"
+}
+
+resource "discord_channel" "text" {
+  server_id = %[1]q
+  type      = "text"
+  name      = "general-chat"
+  parent_id = discord_channel.cat.id
+  topic     = "hello"
+  reason    = "created by test"
+}
+`, e.guildID),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttrSet("discord_channel.text", "id"),
+						resource.TestCheckResourceAttr("discord_channel.text", "type", "text"),
+						resource.TestCheckResourceAttr("discord_channel.text", "topic", "hello"),
+						resource.TestCheckResourceAttrPair("discord_channel.text", "parent_id", "discord_channel.cat", "id"),
+						resource.TestCheckResourceAttr("discord_channel.cat", "type", "category"),
+					),
+				},
+				{
+					Config: e.config(`
+resource "discord_channel" "cat" {
+  server_id = %[1]q
+  type      = "category"
+  name      = "Category"
+}
+
+resource "discord_channel" "text" {
+  server_id           = %[1]q
+  type                = "text"
+  name                = "renamed"
+  topic               = "updated"
+  nsfw                = true
+  rate_limit_per_user = 10
+}
+`, e.guildID),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr("discord_channel.text", "name", "renamed"),
+						resource.TestCheckResourceAttr("discord_channel.text", "topic", "updated"),
+						resource.TestCheckResourceAttr("discord_channel.text", "nsfw", "true"),
+						resource.TestCheckResourceAttr("discord_channel.text", "rate_limit_per_user", "10"),
+						resource.TestCheckNoResourceAttr("discord_channel.text", "parent_id"),
+					),
+				},
+				{
+					ResourceName:            "discord_channel.text",
+					ImportState:             true,
+					ImportStateVerify:       true,
+					ImportStateVerifyIgnore: []string{"reason"},
+				},
+			}
+		},
+	},
+	{
+		name: "channel forum",
+		steps: func(t *testing.T, e *fakeEnv) []resource.TestStep {
+			return []resource.TestStep{
+				{
+					Config: e.config(`
+resource "discord_channel" "forum" {
+  server_id = %q
+  type      = "forum"
+  name      = "help"
+
+  available_tag = [
+    { name = "bug" },
+    { name = "question", moderated = true },
+  ]
+  default_reaction_emoji = { emoji_name = "👍" }
+  default_sort_order     = 1
+}
+`, e.guildID),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr("discord_channel.forum", "available_tag.#", "2"),
+						resource.TestCheckResourceAttrSet("discord_channel.forum", "available_tag.0.id"),
+						resource.TestCheckResourceAttr("discord_channel.forum", "available_tag.1.moderated", "true"),
+						resource.TestCheckResourceAttr("discord_channel.forum", "default_reaction_emoji.emoji_name", "👍"),
+					),
+				},
+			}
+		},
+	},
+	{
+		name: "channel validation error",
+		steps: func(t *testing.T, e *fakeEnv) []resource.TestStep {
+			return []resource.TestStep{
+				{
+					Config: e.config(`
+resource "discord_channel" "text" {
+  server_id = %q
+  type      = "text"
+  name      = "this-name-is-far-too-long-for-discord-this-name-is-far-too-long-for-discord-this-name-is-far-too-long"
+}
+`, e.guildID),
+					ExpectError: regexp.MustCompile(`Must be between 1 and 100 in\s+length`),
+				},
+			}
+		},
+	},
+	{
+		name: "channel_order",
+		steps: func(t *testing.T, e *fakeEnv) []resource.TestStep {
+			channels := fmt.Sprintf(`
+resource "discord_channel" "cat" {
+  server_id = %[1]q
+  type      = "category"
+  name      = "cat"
+}
+
+resource "discord_channel" "a" {
+  server_id = %[1]q
+  type      = "text"
+  name      = "a"
+}
+
+resource "discord_channel" "b" {
+  server_id = %[1]q
+  type      = "text"
+  name      = "b"
+  parent_id = discord_channel.cat.id
+}
+`, e.guildID)
+
+			return []resource.TestStep{
+				{
+					Config: e.config(`%s
+resource "discord_channel_order" "order" {
+  server_id = %q
+  channel = [
+    { channel_id = discord_channel.b.id, position = 1 },
+    { channel_id = discord_channel.a.id, position = 2 },
+  ]
+}
+`, channels, e.guildID),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr("discord_channel_order.order", "channel.0.position", "1"),
+						resource.TestCheckResourceAttr("discord_channel_order.order", "channel.1.position", "2"),
+						resource.TestCheckResourceAttrPair("discord_channel_order.order", "channel.0.parent_id", "discord_channel.cat", "id"),
+						resource.TestCheckNoResourceAttr("discord_channel_order.order", "channel.1.parent_id"),
+					),
+				},
+				{
+					Config: e.config(`%s
+resource "discord_channel_order" "order" {
+  server_id = %q
+  channel = [
+    { channel_id = discord_channel.b.id, position = 2, parent_id = discord_channel.cat.id, lock_permissions = true },
+    { channel_id = discord_channel.a.id, position = 1 },
+  ]
+}
+`, channels, e.guildID),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr("discord_channel_order.order", "channel.0.position", "2"),
+						resource.TestCheckResourceAttr("discord_channel_order.order", "channel.0.lock_permissions", "true"),
+						resource.TestCheckResourceAttr("discord_channel_order.order", "channel.1.position", "1"),
+					),
+				},
+			}
+		},
+	},
+	{
+		name: "emoji",
+		steps: func(t *testing.T, e *fakeEnv) []resource.TestStep {
+			role := fmt.Sprintf(`
+resource "discord_role" "vip" {
+  server_id = %q
+  name      = "vip"
+}
+`, e.guildID)
+
+			return []resource.TestStep{
+				{
+					Config: e.config(`%s
+resource "discord_emoji" "party" {
+  server_id      = %q
+  name           = "party"
+  image_data_uri = %q
+}
+`, role, e.guildID, testPNGDataURI),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr("discord_emoji.party", "effective_name", "party"),
+						resource.TestCheckResourceAttr("discord_emoji.party", "animated", "false"),
+						resource.TestCheckNoResourceAttr("discord_emoji.party", "roles"),
+					),
+				},
+				{
+					Config: e.config(`%s
+resource "discord_emoji" "party" {
+  server_id      = %q
+  name           = "party_time"
+  image_data_uri = %q
+  roles          = [discord_role.vip.id]
+}
+`, role, e.guildID, testPNGDataURI),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr("discord_emoji.party", "name", "party_time"),
+						resource.TestCheckResourceAttr("discord_emoji.party", "roles.#", "1"),
+						resource.TestCheckTypeSetElemAttrPair("discord_emoji.party", "roles.*", "discord_role.vip", "id"),
+					),
+				},
+				{
+					ResourceName:            "discord_emoji.party",
+					ImportState:             true,
+					ImportStateIdFunc:       serverScopedImportID("discord_emoji.party"),
+					ImportStateVerify:       true,
+					ImportStateVerifyIgnore: []string{"image_data_uri"},
+				},
+			}
+		},
+	},
+	{
+		name: "emoji validation error",
+		steps: func(t *testing.T, e *fakeEnv) []resource.TestStep {
+			return []resource.TestStep{
+				{
+					Config: e.config(`
+resource "discord_emoji" "bad" {
+  server_id      = %q
+  name           = "bad"
+  image_data_uri = "not-an-image"
+}
+`, e.guildID),
+					ExpectError: regexp.MustCompile(`Invalid image data`),
+				},
+			}
+		},
+	},
+	{
+		name: "guild_settings",
+		steps: func(t *testing.T, e *fakeEnv) []resource.TestStep {
+			return []resource.TestStep{
+				{
+					Config: e.config(`
+resource "discord_guild_settings" "g" {
+  server_id    = %q
+  payload_json = jsonencode({ description = "first" })
+}
+`, e.guildID),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr("discord_guild_settings.g", "id", e.guildID),
+						resource.TestMatchResourceAttr("discord_guild_settings.g", "state_json", regexp.MustCompile(`"description":"first"`)),
+					),
+				},
+				{
+					Config: e.config(`
+resource "discord_guild_settings" "g" {
+  server_id    = %q
+  payload_json = jsonencode({ description = "second", afk_timeout = 60 })
+}
+`, e.guildID),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestMatchResourceAttr("discord_guild_settings.g", "state_json", regexp.MustCompile(`"afk_timeout":60`)),
+						resource.TestMatchResourceAttr("discord_guild_settings.g", "state_json", regexp.MustCompile(`"description":"second"`)),
+					),
+				},
+			}
+		},
+	},
+	{
+		name: "guild_settings validation error",
+		steps: func(t *testing.T, e *fakeEnv) []resource.TestStep {
+			return []resource.TestStep{
+				{
+					Config: e.config(`
+resource "discord_guild_settings" "g" {
+  server_id    = %q
+  payload_json = jsonencode({ afk_timeout = 42 })
+}
+`, e.guildID),
+					ExpectError: regexp.MustCompile(`afk_timeout`),
+				},
+			}
+		},
+	},
+	{
+		name: "guild_template",
+		steps: func(t *testing.T, e *fakeEnv) []resource.TestStep {
+			return []resource.TestStep{
+				{
+					Config: e.config(`
+resource "discord_guild_template" "base" {
+  server_id = %q
+  name      = "base"
+}
+`, e.guildID),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttrSet("discord_guild_template.base", "id"),
+						resource.TestCheckNoResourceAttr("discord_guild_template.base", "description"),
+						resource.TestCheckResourceAttr("discord_guild_template.base", "usage_count", "0"),
+						resource.TestCheckResourceAttrSet("discord_guild_template.base", "creator_id"),
+					),
+				},
+				{
+					Config: e.config(`
+resource "discord_guild_template" "base" {
+  server_id   = %[1]q
+  name        = "base v2"
+  description = "starting point"
+}
+
+resource "discord_guild_template_sync" "base" {
+  server_id     = %[1]q
+  template_code = discord_guild_template.base.id
+  sync_nonce    = "1"
+}
+`, e.guildID),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr("discord_guild_template.base", "name", "base v2"),
+						resource.TestCheckResourceAttr("discord_guild_template.base", "description", "starting point"),
+						resource.TestCheckResourceAttr("discord_guild_template_sync.base", "is_dirty", "false"),
+						resource.TestCheckResourceAttrSet("discord_guild_template_sync.base", "updated_at"),
+					),
+				},
+				{
+					Config: e.config(`
+resource "discord_guild_template" "base" {
+  server_id   = %[1]q
+  name        = "base v2"
+  description = "starting point"
+}
+
+resource "discord_guild_template_sync" "base" {
+  server_id     = %[1]q
+  template_code = discord_guild_template.base.id
+  sync_nonce    = "2"
+}
+`, e.guildID),
+					Check: resource.TestCheckResourceAttr("discord_guild_template_sync.base", "sync_nonce", "2"),
+				},
+				{
+					ResourceName:      "discord_guild_template.base",
+					ImportState:       true,
+					ImportStateIdFunc: serverScopedImportID("discord_guild_template.base"),
+					ImportStateVerify: true,
+				},
+			}
+		},
+	},
+	{
+		name: "guild_template one per guild",
+		steps: func(t *testing.T, e *fakeEnv) []resource.TestStep {
+			return []resource.TestStep{
+				{
+					Config: e.config(`
+resource "discord_guild_template" "a" {
+  server_id = %[1]q
+  name      = "a"
+}
+
+resource "discord_guild_template" "b" {
+  server_id  = %[1]q
+  name       = "b"
+  depends_on = [discord_guild_template.a]
+}
+`, e.guildID),
+					ExpectError: regexp.MustCompile(`Guild already has a template`),
+				},
+			}
+		},
+	},
+	{
+		name: "invite",
+		steps: func(t *testing.T, e *fakeEnv) []resource.TestStep {
+			return []resource.TestStep{
+				{
+					Config: e.config(`
+resource "discord_channel" "lobby" {
+  server_id = %q
+  type      = "text"
+  name      = "lobby"
+}
+
+resource "discord_invite" "lobby" {
+  channel_id = discord_channel.lobby.id
+  max_age    = 3600
+  max_uses   = 10
+}
+`, e.guildID),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestMatchResourceAttr("discord_invite.lobby", "code", regexp.MustCompile(`^[A-Za-z0-9]{8}$`)),
+						resource.TestCheckResourceAttrPair("discord_invite.lobby", "id", "discord_invite.lobby", "code"),
+					),
+				},
+				{
+					// Invites cannot be edited, so a change replaces the invite.
+					Config: e.config(`
+resource "discord_channel" "lobby" {
+  server_id = %q
+  type      = "text"
+  name      = "lobby"
+}
+
+resource "discord_invite" "lobby" {
+  channel_id = discord_channel.lobby.id
+  max_age    = 0
+  temporary  = true
+}
+`, e.guildID),
+					Check: resource.TestCheckResourceAttr("discord_invite.lobby", "temporary", "true"),
+				},
+				{
+					ResourceName:            "discord_invite.lobby",
+					ImportState:             true,
+					ImportStateVerify:       true,
+					ImportStateVerifyIgnore: []string{"channel_id", "max_age", "temporary"},
+				},
+			}
+		},
+	},
+	{
+		name: "member_nickname",
+		steps: func(t *testing.T, e *fakeEnv) []resource.TestStep {
+			userID := e.srv.AddMember(e.guildID, "alice")
+
+			return []resource.TestStep{
+				{
+					Config: e.config(`
+resource "discord_member_nickname" "alice" {
+  server_id = %q
+  user_id   = %q
+  nick      = "Al"
+}
+`, e.guildID, userID),
+					Check: resource.TestCheckResourceAttr("discord_member_nickname.alice", "nick", "Al"),
+				},
+				{
+					Config: e.config(`
+resource "discord_member_nickname" "alice" {
+  server_id = %q
+  user_id   = %q
+  nick      = ""
+}
+`, e.guildID, userID),
+					Check: resource.TestCheckResourceAttr("discord_member_nickname.alice", "nick", ""),
+				},
+				{
+					ResourceName:      "discord_member_nickname.alice",
+					ImportState:       true,
+					ImportStateVerify: true,
+				},
+			}
+		},
+	},
+	{
+		name: "member_roles",
+		steps: func(t *testing.T, e *fakeEnv) []resource.TestStep {
+			userID := e.srv.AddMember(e.guildID, "alice")
+			roles := `
+resource "discord_role" "a" {
+  server_id = %[1]q
+  name      = "a"
+}
+
+resource "discord_role" "b" {
+  server_id = %[1]q
+  name      = "b"
+}
+`
+
+			return []resource.TestStep{
+				{
+					Config: e.config(roles+`
+resource "discord_member_roles" "alice" {
+  server_id = %[1]q
+  user_id   = %[2]q
+  role = [
+    { role_id = discord_role.a.id },
+    { role_id = discord_role.b.id },
+  ]
+}
+`, e.guildID, userID),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr("discord_member_roles.alice", "role.#", "2"),
+						resource.TestCheckTypeSetElemNestedAttrs("discord_member_roles.alice", "role.*", map[string]string{"has_role": "true"}),
+					),
+				},
+				{
+					// has_role = false takes the role away without forgetting it.
+					Config: e.config(roles+`
+resource "discord_member_roles" "alice" {
+  server_id = %[1]q
+  user_id   = %[2]q
+  role = [
+    { role_id = discord_role.a.id },
+    { role_id = discord_role.b.id, has_role = false },
+  ]
+}
+`, e.guildID, userID),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckTypeSetElemNestedAttrs("discord_member_roles.alice", "role.*", map[string]string{"has_role": "true"}),
+						resource.TestCheckTypeSetElemNestedAttrs("discord_member_roles.alice", "role.*", map[string]string{"has_role": "false"}),
+					),
+				},
+			}
+		},
+	},
+	{
+		name: "member_timeout too long",
+		steps: func(t *testing.T, e *fakeEnv) []resource.TestStep {
+			userID := e.srv.AddMember(e.guildID, "alice")
+			until := time.Now().Add(60 * 24 * time.Hour).UTC().Format(time.RFC3339)
+
+			return []resource.TestStep{
+				{
+					Config: e.config(`
+resource "discord_member_timeout" "alice" {
+  server_id = %q
+  user_id   = %q
+  until     = %q
+}
+`, e.guildID, userID, until),
+					ExpectError: regexp.MustCompile(`at most 28 days`),
+				},
+			}
+		},
+	},
+	{
+		name: "member_verification",
+		steps: func(t *testing.T, e *fakeEnv) []resource.TestStep {
+			return []resource.TestStep{
+				{
+					Config: e.config(`
+resource "discord_member_verification" "gate" {
+  server_id = %q
+  payload_json = jsonencode({
+    enabled     = true
+    description = "Please read the rules"
+    form_fields = [{
+      field_type = "TERMS"
+      label      = "Read and agree to the server rules"
+      values     = ["Be nice"]
+      required   = true
+    }]
+  })
+}
+`, e.guildID),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr("discord_member_verification.gate", "id", e.guildID),
+						resource.TestMatchResourceAttr("discord_member_verification.gate", "state_json", regexp.MustCompile(`"label":"Read and agree to the server rules"`)),
+					),
+				},
+				{
+					Config: e.config(`
+resource "discord_member_verification" "gate" {
+  server_id = %q
+  payload_json = jsonencode({
+    enabled     = true
+    description = "Rules changed"
+    form_fields = []
+  })
+}
+`, e.guildID),
+					Check: resource.TestMatchResourceAttr("discord_member_verification.gate", "state_json", regexp.MustCompile(`"description":"Rules changed"`)),
+				},
+			}
+		},
+	},
+	{
+		name: "member_verification validation error",
+		steps: func(t *testing.T, e *fakeEnv) []resource.TestStep {
+			return []resource.TestStep{
+				{
+					Config: e.config(`
+resource "discord_member_verification" "gate" {
+  server_id = %q
+  payload_json = jsonencode({
+    form_fields = [{ field_type = "TEXT_INPUT", label = "Why?" }]
+  })
+}
+`, e.guildID),
+					ExpectError: regexp.MustCompile(`TERMS`),
+				},
+			}
+		},
+	},
+	{
+		name: "message",
+		steps: func(t *testing.T, e *fakeEnv) []resource.TestStep {
+			channel := fmt.Sprintf(`
+resource "discord_channel" "text" {
+  server_id = %q
+  type      = "text"
+  name      = "announcements"
+}
+`, e.guildID)
+
+			return []resource.TestStep{
+				{
+					Config: e.config(`%s
+resource "discord_message" "hello" {
+  channel_id = discord_channel.text.id
+  content    = "hello"
+  pinned     = true
+
+  embed = {
+    title     = "Welcome"
+    image     = { url = "https://example.com/a.png" }
+    fields = [
+      { name = "rules", value = "be nice", inline = true },
+    ]
+  }
+}
+`, channel),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr("discord_message.hello", "server_id", e.guildID),
+						resource.TestCheckResourceAttr("discord_message.hello", "author", e.srv.BotUserID()),
+						resource.TestCheckResourceAttr("discord_message.hello", "pinned", "true"),
+						resource.TestCheckResourceAttrSet("discord_message.hello", "embed.image.proxy_url"),
+						resource.TestCheckNoResourceAttr("discord_message.hello", "edited_timestamp"),
+					),
+				},
+				{
+					ResourceName: "discord_message.hello",
+					ImportState:  true,
+					ImportStateIdFunc: func(s *terraform.State) (string, error) {
+						rs := s.RootModule().Resources["discord_message.hello"]
+						return rs.Primary.Attributes["channel_id"] + ":" + rs.Primary.ID, nil
+					},
+					ImportStateVerify: true,
+				},
+			}
+		},
+	},
+	{
+		name: "role",
+		steps: func(t *testing.T, e *fakeEnv) []resource.TestStep {
+			return []resource.TestStep{
+				{
+					Config: e.config(`
+resource "discord_role" "mod" {
+  server_id   = %q
+  name        = "moderator"
+  permissions = 8192
+  color       = 16711680
+  hoist       = true
+}
+`, e.guildID),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr("discord_role.mod", "permissions_bits64", "8192"),
+						resource.TestCheckResourceAttr("discord_role.mod", "color", "16711680"),
+						resource.TestCheckResourceAttr("discord_role.mod", "hoist", "true"),
+						resource.TestCheckResourceAttr("discord_role.mod", "mentionable", "false"),
+						resource.TestCheckResourceAttr("discord_role.mod", "managed", "false"),
+						resource.TestCheckResourceAttrSet("discord_role.mod", "position"),
+					),
+				},
+				{
+					ResourceName:      "discord_role.mod",
+					ImportState:       true,
+					ImportStateIdFunc: serverScopedImportID("discord_role.mod"),
+					ImportStateVerify: true,
+				},
+			}
+		},
+	},
+	{
+		name: "role position",
+		steps: func(t *testing.T, e *fakeEnv) []resource.TestStep {
+			return []resource.TestStep{
+				{
+					Config: e.config(`
+resource "discord_role" "a" {
+  server_id = %[1]q
+  name      = "a"
+}
+
+resource "discord_role" "b" {
+  server_id  = %[1]q
+  name       = "b"
+  position   = 2
+  depends_on = [discord_role.a]
+}
+`, e.guildID),
+					Check: resource.TestCheckResourceAttr("discord_role.b", "position", "2"),
+				},
+			}
+		},
+	},
+	{
+		name: "role validation error",
+		steps: func(t *testing.T, e *fakeEnv) []resource.TestStep {
+			return []resource.TestStep{
+				{
+					Config: e.config(`
+resource "discord_role" "bad" {
+  server_id = %q
+  name      = "bad"
+  color     = 99999999
+}
+`, e.guildID),
+					ExpectError: regexp.MustCompile(`color`),
+				},
+			}
+		},
+	},
+	{
+		name: "role_order",
+		steps: func(t *testing.T, e *fakeEnv) []resource.TestStep {
+			roles := fmt.Sprintf(`
+resource "discord_role" "a" {
+  server_id = %[1]q
+  name      = "a"
+}
+
+resource "discord_role" "b" {
+  server_id = %[1]q
+  name      = "b"
+}
+`, e.guildID)
+
+			return []resource.TestStep{
+				{
+					Config: e.config(`%s
+resource "discord_role_order" "order" {
+  server_id = %q
+  role = [
+    { role_id = discord_role.a.id, position = 2 },
+    { role_id = discord_role.b.id, position = 1 },
+  ]
+}
+`, roles, e.guildID),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr("discord_role_order.order", "role.0.position", "2"),
+						resource.TestCheckResourceAttr("discord_role_order.order", "role.1.position", "1"),
+					),
+				},
+				{
+					Config: e.config(`%s
+resource "discord_role_order" "order" {
+  server_id = %q
+  role = [
+    { role_id = discord_role.a.id, position = 1 },
+    { role_id = discord_role.b.id, position = 2 },
+  ]
+}
+`, roles, e.guildID),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr("discord_role_order.order", "role.0.position", "1"),
+						resource.TestCheckResourceAttr("discord_role_order.order", "role.1.position", "2"),
+					),
+				},
+			}
+		},
+	},
+	{
+		name: "role_order several",
+		steps: func(t *testing.T, e *fakeEnv) []resource.TestStep {
+			// Terraform applies the two orders concurrently, so their updates are merged
+			// into one request; both read back the final positions.
+
+			return []resource.TestStep{
+				{
+					Config: e.config(`
+resource "discord_role" "a" {
+  server_id = %[1]q
+  name      = "a"
+}
+
+resource "discord_role" "b" {
+  server_id = %[1]q
+  name      = "b"
+}
+
+resource "discord_role" "c" {
+  server_id = %[1]q
+  name      = "c"
+}
+
+resource "discord_role" "d" {
+  server_id = %[1]q
+  name      = "d"
+}
+
+resource "discord_role_order" "top" {
+  server_id = %[1]q
+  role = [
+    { role_id = discord_role.a.id, position = 4 },
+    { role_id = discord_role.b.id, position = 3 },
+  ]
+}
+
+resource "discord_role_order" "bottom" {
+  server_id = %[1]q
+  role = [
+    { role_id = discord_role.c.id, position = 2 },
+    { role_id = discord_role.d.id, position = 1 },
+  ]
+}
+`, e.guildID),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr("discord_role_order.top", "role.0.position", "4"),
+						resource.TestCheckResourceAttr("discord_role_order.top", "role.1.position", "3"),
+						resource.TestCheckResourceAttr("discord_role_order.bottom", "role.0.position", "2"),
+						resource.TestCheckResourceAttr("discord_role_order.bottom", "role.1.position", "1"),
+					),
+				},
+			}
+		},
+	},
+	{
+		name: "scheduled_event start in past",
+		steps: func(t *testing.T, e *fakeEnv) []resource.TestStep {
+			start := time.Now().Add(-time.Hour).UTC()
+
+			return []resource.TestStep{
+				{
+					Config: e.config(`
+resource "discord_scheduled_event" "late" {
+  server_id            = %q
+  name                 = "Too late"
+  entity_type          = 3
+  location             = "Anywhere"
+  scheduled_start_time = %q
+  scheduled_end_time   = %q
+}
+`, e.guildID, start.Format(time.RFC3339), start.Add(time.Hour).Format(time.RFC3339)),
+					ExpectError: regexp.MustCompile(`Cannot schedule event in the past`),
+				},
+			}
+		},
+	},
+	{
+		name: "server",
+		steps: func(t *testing.T, e *fakeEnv) []resource.TestStep {
+			voice := fmt.Sprintf(`
+resource "discord_channel" "afk" {
+  server_id = %q
+  type      = "voice"
+  name      = "afk"
+}
+`, e.guildID)
+
+			return []resource.TestStep{
+				{
+					Config: e.config(`%s
+resource "discord_server" "s" {
+  server_id = %q
+  name      = "tf-test"
+}
+`, voice, e.guildID),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr("discord_server.s", "id", e.guildID),
+						resource.TestCheckResourceAttr("discord_server.s", "afk_timeout", "300"),
+						resource.TestCheckResourceAttrSet("discord_server.s", "owner_id"),
+						resource.TestCheckNoResourceAttr("discord_server.s", "afk_channel_id"),
+					),
+				},
+				{
+					Config: e.config(`%s
+resource "discord_server" "s" {
+  server_id          = %q
+  name               = "renamed"
+  verification_level = 2
+  afk_channel_id     = discord_channel.afk.id
+  afk_timeout        = 900
+  icon_data_uri      = %q
+}
+`, voice, e.guildID, testPNGDataURI),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr("discord_server.s", "name", "renamed"),
+						resource.TestCheckResourceAttr("discord_server.s", "verification_level", "2"),
+						resource.TestCheckResourceAttrPair("discord_server.s", "afk_channel_id", "discord_channel.afk", "id"),
+						resource.TestCheckResourceAttr("discord_server.s", "afk_timeout", "900"),
+						resource.TestCheckResourceAttrSet("discord_server.s", "icon_hash"),
+					),
+				},
+				{
+					// An empty string clears the AFK channel.
+					Config: e.config(`%s
+resource "discord_server" "s" {
+  server_id      = %q
+  name           = "renamed"
+  afk_channel_id = ""
+}
+`, voice, e.guildID),
+					Check: resource.TestCheckResourceAttr("discord_server.s", "afk_channel_id", ""),
+				},
+				{
+					ResourceName:            "discord_server.s",
+					ImportState:             true,
+					ImportStateId:           e.guildID,
+					ImportStateVerify:       true,
+					ImportStateVerifyIgnore: []string{"afk_channel_id"},
+				},
+			}
+		},
+	},
+	{
+		name: "stage_instance",
+		steps: func(t *testing.T, e *fakeEnv) []resource.TestStep {
+			stage := `
+resource "discord_channel" "stage" {
+  server_id = %q
+  type      = "stage"
+  name      = "Town Hall"
+}
+`
+
+			return []resource.TestStep{
+				{
+					Config: e.config(stage+`
+resource "discord_stage_instance" "live" {
+  channel_id = discord_channel.stage.id
+  topic      = "Q&A"
+}
+`, e.guildID),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttrPair("discord_stage_instance.live", "id", "discord_channel.stage", "id"),
+						resource.TestCheckResourceAttr("discord_stage_instance.live", "privacy_level", "2"),
+						resource.TestCheckResourceAttr("discord_stage_instance.live", "server_id", e.guildID),
+					),
+				},
+				{
+					Config: e.config(stage+`
+resource "discord_stage_instance" "live" {
+  channel_id = discord_channel.stage.id
+  topic      = "AMA"
+}
+`, e.guildID),
+					Check: resource.TestCheckResourceAttr("discord_stage_instance.live", "topic", "AMA"),
+				},
+				{
+					ResourceName:      "discord_stage_instance.live",
+					ImportState:       true,
+					ImportStateVerify: true,
+				},
+			}
+		},
+	},
+	{
+		name: "stage_instance not stage",
+		steps: func(t *testing.T, e *fakeEnv) []resource.TestStep {
+			return []resource.TestStep{
+				{
+					Config: e.config(`
+resource "discord_channel" "voice" {
+  server_id = %q
+  type      = "voice"
+  name      = "Voice"
+}
+
+resource "discord_stage_instance" "live" {
+  channel_id = discord_channel.voice.id
+  topic      = "Q&A"
+}
+`, e.guildID),
+					ExpectError: regexp.MustCompile(`code 50024`),
+				},
+			}
+		},
+	},
+	{
+		name: "sticker",
+		steps: func(t *testing.T, e *fakeEnv) []resource.TestStep {
+			png, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(testPNGDataURI, "data:image/png;base64,"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			file := filepath.Join(t.TempDir(), "wave.png")
+			if err := os.WriteFile(file, png, 0o600); err != nil {
+				t.Fatal(err)
+			}
+
+			return []resource.TestStep{
+				{
+					Config: e.config(`
+resource "discord_sticker" "wave" {
+  server_id = %q
+  name      = "wave"
+  tags      = "wave"
+  file_path = %q
+}
+`, e.guildID, file),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr("discord_sticker.wave", "format_type", "1"),
+						resource.TestCheckNoResourceAttr("discord_sticker.wave", "description"),
+					),
+				},
+				{
+					Config: e.config(`
+resource "discord_sticker" "wave" {
+  server_id   = %q
+  name        = "wave_hello"
+  description = "waves hello"
+  tags        = "wave,hello"
+  file_path   = %q
+}
+`, e.guildID, file),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr("discord_sticker.wave", "name", "wave_hello"),
+						resource.TestCheckResourceAttr("discord_sticker.wave", "description", "waves hello"),
+						resource.TestCheckResourceAttr("discord_sticker.wave", "tags", "wave,hello"),
+					),
+				},
+				{
+					ResourceName:            "discord_sticker.wave",
+					ImportState:             true,
+					ImportStateIdFunc:       serverScopedImportID("discord_sticker.wave"),
+					ImportStateVerify:       true,
+					ImportStateVerifyIgnore: []string{"file_path"},
+				},
+			}
+		},
+	},
+	{
+		name: "thread",
+		steps: func(t *testing.T, e *fakeEnv) []resource.TestStep {
+			channel := fmt.Sprintf(`
+resource "discord_channel" "text" {
+  server_id = %q
+  type      = "text"
+  name      = "general"
+}
+`, e.guildID)
+
+			return []resource.TestStep{
+				{
+					Config: e.config(`%s
+resource "discord_thread" "t" {
+  channel_id = discord_channel.text.id
+  name       = "planning"
+}
+`, channel),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr("discord_thread.t", "type", "public_thread"),
+						resource.TestCheckResourceAttr("discord_thread.t", "server_id", e.guildID),
+						resource.TestCheckResourceAttr("discord_thread.t", "archived", "false"),
+						resource.TestCheckResourceAttrSet("discord_thread.t", "auto_archive_duration"),
+					),
+				},
+				{
+					Config: e.config(`%s
+resource "discord_thread" "t" {
+  channel_id            = discord_channel.text.id
+  name                  = "planning-2"
+  auto_archive_duration = 60
+  rate_limit_per_user   = 5
+  locked                = true
+}
+`, channel),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr("discord_thread.t", "name", "planning-2"),
+						resource.TestCheckResourceAttr("discord_thread.t", "auto_archive_duration", "60"),
+						resource.TestCheckResourceAttr("discord_thread.t", "rate_limit_per_user", "5"),
+						resource.TestCheckResourceAttr("discord_thread.t", "locked", "true"),
+					),
+				},
+				{
+					ResourceName:      "discord_thread.t",
+					ImportState:       true,
+					ImportStateVerify: true,
+				},
+			}
+		},
+	},
+	{
+		name: "thread_member",
+		steps: func(t *testing.T, e *fakeEnv) []resource.TestStep {
+			userID := e.srv.AddMember(e.guildID, "alice")
+
+			return []resource.TestStep{
+				{
+					Config: e.config(`
+resource "discord_channel" "text" {
+  server_id = %q
+  type      = "text"
+  name      = "general"
+}
+
+resource "discord_thread" "t" {
+  channel_id = discord_channel.text.id
+  name       = "planning"
+}
+
+resource "discord_thread_member" "alice" {
+  thread_id = discord_thread.t.id
+  user_id   = %q
+}
+`, e.guildID, userID),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttrSet("discord_thread_member.alice", "join_timestamp"),
+						resource.TestCheckResourceAttr("discord_thread_member.alice", "flags", "0"),
+					),
+				},
+				{
+					ResourceName:      "discord_thread_member.alice",
+					ImportState:       true,
+					ImportStateVerify: true,
+				},
+			}
+		},
+	},
+	{
+		name: "webhook",
+		steps: func(t *testing.T, e *fakeEnv) []resource.TestStep {
+			channels := fmt.Sprintf(`
+resource "discord_channel" "a" {
+  server_id = %[1]q
+  type      = "text"
+  name      = "a"
+}
+
+resource "discord_channel" "b" {
+  server_id = %[1]q
+  type      = "text"
+  name      = "b"
+}
+`, e.guildID)
+
+			return []resource.TestStep{
+				{
+					Config: e.config(`%s
+resource "discord_webhook" "hook" {
+  channel_id = discord_channel.a.id
+  name       = "deploys"
+}
+`, channels),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttrSet("discord_webhook.hook", "token"),
+						resource.TestCheckResourceAttr("discord_webhook.hook", "guild_id", e.guildID),
+						resource.TestMatchResourceAttr("discord_webhook.hook", "url", regexp.MustCompile(`/webhooks/\d+/`)),
+					),
+				},
+				{
+					// Moving the webhook to another channel keeps its ID and token.
+					Config: e.config(`%s
+resource "discord_webhook" "hook" {
+  channel_id = discord_channel.b.id
+  name       = "deploys-2"
+}
+`, channels),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttrPair("discord_webhook.hook", "channel_id", "discord_channel.b", "id"),
+						resource.TestCheckResourceAttr("discord_webhook.hook", "name", "deploys-2"),
+					),
+				},
+				{
+					ResourceName:      "discord_webhook.hook",
+					ImportState:       true,
+					ImportStateVerify: true,
+				},
+			}
+		},
+	},
+	{
+		name: "welcome_screen validation error",
+		steps: func(t *testing.T, e *fakeEnv) []resource.TestStep {
+			return []resource.TestStep{
+				{
+					Config: e.config(`
+resource "discord_channel" "voice" {
+  server_id = %[1]q
+  type      = "voice"
+  name      = "Voice"
+}
+
+resource "discord_welcome_screen" "welcome" {
+  server_id = %[1]q
+  channel = [
+    { channel_id = discord_channel.voice.id, description = "Hang out" },
+  ]
+}
+`, e.guildID),
+					ExpectError: regexp.MustCompile(`Invalid channel`),
+				},
+			}
+		},
+	},
+	{
+		name: "widget_settings",
+		steps: func(t *testing.T, e *fakeEnv) []resource.TestStep {
+			channel := `
+resource "discord_channel" "widget" {
+  server_id = %[1]q
+  type      = "text"
+  name      = "widget"
+}
+`
+
+			return []resource.TestStep{
+				{
+					Config: e.config(channel+`
+resource "discord_widget_settings" "widget" {
+  server_id  = %[1]q
+  enabled    = true
+  channel_id = discord_channel.widget.id
+}
+`, e.guildID),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr("discord_widget_settings.widget", "id", e.guildID),
+						resource.TestCheckResourceAttr("discord_widget_settings.widget", "enabled", "true"),
+						resource.TestCheckResourceAttrPair("discord_widget_settings.widget", "channel_id", "discord_channel.widget", "id"),
+					),
+				},
+				{
+					// Disabling without channel_id leaves the channel as it was.
+					Config: e.config(channel+`
+resource "discord_widget_settings" "widget" {
+  server_id = %[1]q
+  enabled   = false
+}
+`, e.guildID),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr("discord_widget_settings.widget", "enabled", "false"),
+						resource.TestCheckResourceAttrPair("discord_widget_settings.widget", "channel_id", "discord_channel.widget", "id"),
+					),
+				},
+				{
+					ResourceName:      "discord_widget_settings.widget",
+					ImportState:       true,
+					ImportStateVerify: true,
+				},
+			}
+		},
+	},
+}
+
+func TestResources_Fake(t *testing.T) {
+	for _, tc := range fakeResourceCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := newFakeEnv(t)
+			e.test(t, resource.TestCase{
+				CheckDestroy: e.checkAllDestroyed,
+				Steps:        tc.steps(t, e),
+			})
+		})
+	}
+}
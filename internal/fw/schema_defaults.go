@@ -0,0 +1,17 @@
+package fw
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+)
+
+// Plan modifiers for Optional+Computed attributes that Discord fills in with a
+// default when the configuration leaves them out. The default does not change on
+// its own, so the prior state is a better plan than "(known after apply)".
+var (
+	stringUseState = []planmodifier.String{stringplanmodifier.UseStateForUnknown()}
+	int64UseState  = []planmodifier.Int64{int64planmodifier.UseStateForUnknown()}
+	boolUseState   = []planmodifier.Bool{boolplanmodifier.UseStateForUnknown()}
+)
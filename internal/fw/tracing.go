@@ -0,0 +1,41 @@
+package fw
+
+import (
+	"context"
+
+	"github.com/45ck/terraform-provider-discord/discord"
+	"github.com/45ck/terraform-provider-discord/internal/telemetry"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var tracer = otel.Tracer("github.com/45ck/terraform-provider-discord/internal/fw")
+
+// startResourceSpan starts the span for one resource CRUD call, e.g. "discord_role.create".
+// The Discord HTTP attempts made with the returned context become its children,
+// and their default audit log reason names the resource type and operation.
+func startResourceSpan(ctx context.Context, typeName, operation string) (context.Context, trace.Span) {
+	ctx = discord.WithOperation(ctx, typeName, operation)
+	return tracer.Start(telemetry.WithEnvParent(ctx), typeName+"."+operation, trace.WithAttributes(
+		attribute.String("terraform.resource.type", typeName),
+		attribute.String("terraform.operation", operation),
+	))
+}
+
+// endResourceSpan marks the span failed when the call produced error diagnostics and ends it.
+func endResourceSpan(span trace.Span, diags *diag.Diagnostics) {
+	if diags != nil && diags.HasError() {
+		for _, d := range diags.Errors() {
+			span.RecordError(diagError{d})
+		}
+		span.SetStatus(codes.Error, diags.Errors()[0].Summary())
+	}
+	span.End()
+}
+
+type diagError struct{ d diag.Diagnostic }
+
+func (e diagError) Error() string { return e.d.Summary() + ": " + e.d.Detail() }
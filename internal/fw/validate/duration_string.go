@@ -0,0 +1,47 @@
+package validate
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+// Duration validates that a string is a non-negative Go duration (e.g. "30s", "2m").
+// Empty string is allowed.
+func Duration() validator.String {
+	return durationStringValidator{}
+}
+
+type durationStringValidator struct{}
+
+func (v durationStringValidator) Description(_ context.Context) string {
+	return "Value must be a duration such as \"30s\" or \"2m\"."
+}
+
+func (v durationStringValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v durationStringValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	raw := strings.TrimSpace(req.ConfigValue.ValueString())
+	if raw == "" {
+		return
+	}
+
+	if d, err := time.ParseDuration(raw); err == nil && d >= 0 {
+		return
+	}
+
+	resp.Diagnostics.AddAttributeError(
+		req.Path,
+		"Invalid duration",
+		fmt.Sprintf("Expected a non-negative duration such as \"30s\" or \"2m\", got %q", raw),
+	)
+}
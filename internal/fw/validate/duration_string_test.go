@@ -0,0 +1,45 @@
+package validate
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestDurationValidator(t *testing.T) {
+	cases := []struct {
+		name    string
+		val     types.String
+		wantErr bool
+	}{
+		{"null", types.StringNull(), false},
+		{"unknown", types.StringUnknown(), false},
+		{"empty", types.StringValue(""), false},
+		{"seconds", types.StringValue("30s"), false},
+		{"compound", types.StringValue("1m30s"), false},
+		{"negative", types.StringValue("-5s"), true},
+		{"noUnit", types.StringValue("30"), true},
+	}
+
+	v := Duration()
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := validator.StringRequest{
+				Path:        path.Root("x"),
+				ConfigValue: tc.val,
+			}
+			resp := validator.StringResponse{Diagnostics: diag.Diagnostics{}}
+			v.ValidateString(context.Background(), req, &resp)
+			if tc.wantErr && !resp.Diagnostics.HasError() {
+				t.Fatalf("expected error, got none")
+			}
+			if !tc.wantErr && resp.Diagnostics.HasError() {
+				t.Fatalf("expected no error, got: %v", resp.Diagnostics)
+			}
+		})
+	}
+}
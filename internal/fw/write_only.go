@@ -0,0 +1,48 @@
+package fw
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+)
+
+// reasonAttribute is the audit log reason every resource takes. It is
+// write-only: Terraform passes it to create and update but never stores it, so
+// it neither shows up in state nor causes a diff. Destroy has no configuration
+// to read it from, so deletes use the provider's default_audit_log_reason.
+func reasonAttribute() schema.StringAttribute {
+	return schema.StringAttribute{
+		Optional:    true,
+		WriteOnly:   true,
+		Description: "Optional audit log reason (X-Audit-Log-Reason) for creates and updates. Write-only: it is not stored in state.",
+	}
+}
+
+// writeOnlyStateUpgraders upgrades version 0 state, from before attrs were
+// write-only, by dropping the values it stored for them. Nothing else about
+// the schema changed, so the rest of the state is kept as is.
+func writeOnlyStateUpgraders(attrs ...string) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: {
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				var state map[string]json.RawMessage
+				if err := json.Unmarshal(req.RawState.JSON, &state); err != nil {
+					resp.Diagnostics.AddError("Unable to upgrade state", err.Error())
+					return
+				}
+				for _, a := range attrs {
+					state[a] = json.RawMessage("null")
+				}
+				b, err := json.Marshal(state)
+				if err != nil {
+					resp.Diagnostics.AddError("Unable to upgrade state", err.Error())
+					return
+				}
+				resp.DynamicValue = &tfprotov6.DynamicValue{JSON: b}
+			},
+		},
+	}
+}
@@ -0,0 +1,77 @@
+package fw
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// TestWriteOnlyStateUpgrade checks that state from before reason and the
+// api_resource bodies were write-only loses them and keeps everything else.
+func TestWriteOnlyStateUpgrade(t *testing.T) {
+	ctx := context.Background()
+	p, schemas := newProtocolServer(t, nil)
+
+	tests := []struct {
+		typ   string
+		state string
+		keep  map[string]tftypes.Value
+	}{
+		{
+			typ:   "discord_role",
+			state: `{"id":"222","server_id":"111","name":"mod","reason":"secret","permissions":8}`,
+			keep: map[string]tftypes.Value{
+				"id":          tftypes.NewValue(tftypes.String, "222"),
+				"name":        tftypes.NewValue(tftypes.String, "mod"),
+				"permissions": tftypes.NewValue(tftypes.Number, 8),
+			},
+		},
+		{
+			typ:   "discord_api_resource",
+			state: `{"id":"42","read_path":"/x/{id}","create_body_json":"{\"a\":1}","update_body_json":"{\"b\":2}","delete_body_json":"{}","reason":"secret"}`,
+			keep: map[string]tftypes.Value{
+				"id":               tftypes.NewValue(tftypes.String, "42"),
+				"read_path":        tftypes.NewValue(tftypes.String, "/x/{id}"),
+				"delete_body_json": tftypes.NewValue(tftypes.String, "{}"),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.typ, func(t *testing.T) {
+			if v := schemas.ResourceSchemas[tt.typ].Version; v != 1 {
+				t.Fatalf("schema version = %d, want 1", v)
+			}
+			resp, err := p.UpgradeResourceState(ctx, &tfprotov6.UpgradeResourceStateRequest{
+				TypeName: tt.typ,
+				Version:  0,
+				RawState: &tfprotov6.RawState{JSON: []byte(tt.state)},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(resp.Diagnostics) > 0 {
+				t.Fatalf("diagnostics: %v", resp.Diagnostics[0])
+			}
+			v, err := resp.UpgradedState.Unmarshal(schemas.ResourceSchemas[tt.typ].ValueType())
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got map[string]tftypes.Value
+			if err := v.As(&got); err != nil {
+				t.Fatal(err)
+			}
+			for _, a := range []string{"reason", "create_body_json", "update_body_json"} {
+				if got[a].IsKnown() && !got[a].IsNull() {
+					t.Errorf("%s = %s after upgrade, want null", a, got[a])
+				}
+			}
+			for k, want := range tt.keep {
+				if !got[k].Equal(want) {
+					t.Errorf("%s = %s after upgrade, want %s", k, got[k], want)
+				}
+			}
+		})
+	}
+}
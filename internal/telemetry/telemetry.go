@@ -0,0 +1,101 @@
+// Package telemetry wires optional OpenTelemetry tracing for the provider process.
+//
+// Tracing is configured entirely through the standard OTEL_* environment variables.
+// Without an OTLP endpoint the global tracer provider stays the OpenTelemetry no-op
+// implementation, so spans created by the provider cost next to nothing.
+package telemetry
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ServiceName is the default OpenTelemetry service.name; OTEL_SERVICE_NAME overrides it.
+const ServiceName = "terraform-provider-discord"
+
+// Enabled reports whether the environment asks for OTLP trace export.
+func Enabled() bool {
+	if strings.EqualFold(os.Getenv("OTEL_SDK_DISABLED"), "true") {
+		return false
+	}
+	if strings.EqualFold(os.Getenv("OTEL_TRACES_EXPORTER"), "none") {
+		return false
+	}
+	return os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT") != "" || os.Getenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT") != ""
+}
+
+// Setup installs a global OTLP tracer provider when Enabled and returns a shutdown
+// function that flushes pending spans. When tracing is not enabled it is a no-op.
+func Setup(ctx context.Context, version string) (func(context.Context) error, error) {
+	noop := func(context.Context) error { return nil }
+	if !Enabled() {
+		return noop, nil
+	}
+
+	exp, err := newExporter(ctx)
+	if err != nil {
+		return noop, err
+	}
+
+	// Explicit attributes first so OTEL_SERVICE_NAME / OTEL_RESOURCE_ATTRIBUTES win.
+	res, err := resource.Merge(
+		resource.NewSchemaless(
+			attribute.String("service.name", ServiceName),
+			attribute.String("service.version", version),
+		),
+		resource.Environment(),
+	)
+	if err != nil {
+		return noop, errors.Join(err, exp.Shutdown(ctx))
+	}
+
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exp),
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(tp)
+
+	return tp.Shutdown, nil
+}
+
+// newExporter picks the OTLP transport from OTEL_EXPORTER_OTLP_(TRACES_)PROTOCOL.
+// The exporters read the endpoint, headers, TLS and timeout settings from the environment.
+func newExporter(ctx context.Context) (sdktrace.SpanExporter, error) {
+	protocol := os.Getenv("OTEL_EXPORTER_OTLP_TRACES_PROTOCOL")
+	if protocol == "" {
+		protocol = os.Getenv("OTEL_EXPORTER_OTLP_PROTOCOL")
+	}
+	if protocol == "grpc" {
+		return otlptracegrpc.New(ctx)
+	}
+	return otlptracehttp.New(ctx)
+}
+
+// WithEnvParent returns ctx parented on the TRACEPARENT/TRACESTATE environment
+// variables when ctx carries no span yet, so provider spans join a trace started by
+// the CI job that runs Terraform.
+func WithEnvParent(ctx context.Context) context.Context {
+	if trace.SpanContextFromContext(ctx).IsValid() {
+		return ctx
+	}
+	tp := os.Getenv("TRACEPARENT")
+	if tp == "" {
+		return ctx
+	}
+	carrier := propagation.MapCarrier{"traceparent": tp}
+	if ts := os.Getenv("TRACESTATE"); ts != "" {
+		carrier["tracestate"] = ts
+	}
+	return propagation.TraceContext{}.Extract(ctx, carrier)
+}
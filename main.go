@@ -6,6 +6,7 @@ import (
 
 	"github.com/45ck/terraform-provider-discord/discord"
 	"github.com/45ck/terraform-provider-discord/internal/fw"
+	"github.com/45ck/terraform-provider-discord/internal/telemetry"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 )
 
@@ -14,10 +15,21 @@ func main() {
 
 	discord.SetBuildVersion(version)
 
+	// Tracing is opt-in through the OTEL_EXPORTER_OTLP_* environment variables.
+	shutdownTracing, err := telemetry.Setup(ctx, version)
+	if err != nil {
+		log.Printf("[WARN] OpenTelemetry tracing disabled: %v", err)
+	}
+
 	// Address should match the Terraform Registry source address users configure in required_providers.
 	// It is also used for Terraform CLI dev overrides and debugging.
 	const address = "registry.terraform.io/45ck/discord"
-	if err := providerserver.Serve(ctx, fw.New(version), providerserver.ServeOpts{Address: address}); err != nil {
-		log.Fatalf("failed to serve provider: %v", err)
+	serveErr := providerserver.Serve(ctx, fw.New(version), providerserver.ServeOpts{Address: address})
+
+	if err := shutdownTracing(ctx); err != nil {
+		log.Printf("[WARN] failed to flush OpenTelemetry spans: %v", err)
+	}
+	if serveErr != nil {
+		log.Fatalf("failed to serve provider: %v", serveErr)
 	}
 }